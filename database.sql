@@ -16,26 +16,306 @@ CREATE TABLE estates (
     width INTEGER NOT NULL CHECK (width >= 1 AND width <= 50000),
     length INTEGER NOT NULL CHECK (length >= 1 AND length <= 50000),
     tree_count INTEGER NOT NULL CHECK (tree_count >= 0 AND tree_count <= 1000),
-    tree_max_height SMALLINT NOT NULL CHECK (tree_max_height >= 0 AND tree_max_height <= 30),
-    tree_min_height SMALLINT NOT NULL CHECK (tree_min_height >= 0 AND tree_min_height <= 30),
-    tree_median_height SMALLINT NOT NULL CHECK (tree_median_height >= 0 AND tree_median_height <= 30),
+    tree_max_height SMALLINT NOT NULL CHECK (tree_max_height >= 0 AND tree_max_height <= 150),
+    tree_min_height SMALLINT NOT NULL CHECK (tree_min_height >= 0 AND tree_min_height <= 150),
+    tree_median_height SMALLINT NOT NULL CHECK (tree_median_height >= 0 AND tree_median_height <= 150),
     total_distance INTEGER NOT NULL,
+    -- the anchor is the outer corner of plot (1,1), the estate is not geo-referenced while it is null.
+    -- bearing is the compass bearing of the y axis in degrees, the x axis points 90 degrees clockwise from it.
+    anchor_latitude DOUBLE PRECISION CHECK (anchor_latitude >= -90 AND anchor_latitude <= 90),
+    anchor_longitude DOUBLE PRECISION CHECK (anchor_longitude >= -180 AND anchor_longitude <= 180),
+    bearing DOUBLE PRECISION NOT NULL DEFAULT 0 CHECK (bearing >= 0 AND bearing < 360),
+    plot_size DOUBLE PRECISION NOT NULL DEFAULT 10 CHECK (plot_size > 0),
+    -- flight settings of the drone in meters: the height kept above the trees, the lowest altitude flown over any plot
+    -- and the altitude the drone takes off from and lands at. plot_size is the horizontal distance to cross a plot.
+    clearance SMALLINT NOT NULL DEFAULT 1 CHECK (clearance >= 0 AND clearance <= 100),
+    min_cruise_altitude SMALLINT NOT NULL DEFAULT 0 CHECK (min_cruise_altitude >= 0 AND min_cruise_altitude <= 500),
+    start_end_altitude SMALLINT NOT NULL DEFAULT 0 CHECK (start_end_altitude >= 0 AND start_end_altitude <= 500),
+    -- the order the drone crosses the plots in, plots.order_number follows it.
+    traversal_pattern VARCHAR(20) NOT NULL DEFAULT 'row_serpentine' CHECK (traversal_pattern IN ('row_serpentine', 'column_serpentine', 'spiral')),
+    traversal_corner VARCHAR(11) NOT NULL DEFAULT 'x_min_y_min' CHECK (traversal_corner IN ('x_min_y_min', 'x_max_y_min', 'x_min_y_max', 'x_max_y_max')),
+    -- the number of plots under an obstacle, the obstacles are only loaded when there is any.
+    blocked_plots INTEGER NOT NULL DEFAULT 0 CHECK (blocked_plots >= 0 AND blocked_plots <= 10000),
+    -- the plots of an estate that is not a rectangle, run-length encoded by row. empty for a rectangle.
+    shape_mask BYTEA NOT NULL DEFAULT '',
+    -- the height in meters of the highest cell of the elevation grid above the lowest one, the grid is only loaded
+    -- when it is not 0.
+    relief INTEGER NOT NULL DEFAULT 0 CHECK (relief >= 0 AND relief <= 10000),
+    -- whether the drone leaves the dead trees out of the flight model and flies over their plots as empty plots.
+    exclude_dead_trees BOOLEAN NOT NULL DEFAULT FALSE,
     created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 );
 
 
+-- a tree species of the catalog. max_height is the typical max height in meters and caps the height of its trees,
+-- growth_rate is in meters per year and harvest_cycle is the months between two harvests.
+CREATE TABLE species (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    max_height SMALLINT NOT NULL CHECK (max_height >= 1 AND max_height <= 150),
+    growth_rate DOUBLE PRECISION CHECK (growth_rate >= 0),
+    harvest_cycle SMALLINT CHECK (harvest_cycle >= 1 AND harvest_cycle <= 1200),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_species_name ON species (name);
+
+-- the height of a tree is capped by the max height of its species, or 30 meters without species. health_status is
+-- the status of the latest inspection of the tree, null while it was never inspected.
 CREATE TABLE plots (
      id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
      x INTEGER NOT NULL CHECK (x >= 1 AND x <= 50000),
      y INTEGER NOT NULL CHECK (y >= 1 AND y <= 50000),
      estate_id UUID NOT NULL,
      order_number INTEGER NOT NULL,
-     tree_height SMALLINT NOT NULL CHECK (tree_height >= 1 AND tree_height <= 30),
+     tree_height SMALLINT NOT NULL CHECK (tree_height >= 1 AND tree_height <= 150),
+     species_id UUID,
      distance INTEGER NOT NULL,
+     health_status VARCHAR(8) CHECK (health_status IN ('healthy', 'stressed', 'diseased', 'dead')),
      created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
-     FOREIGN KEY (estate_id) REFERENCES estates(id)
+     FOREIGN KEY (estate_id) REFERENCES estates(id),
+     FOREIGN KEY (species_id) REFERENCES species(id)
 );
 
 CREATE INDEX idx_estate_id ON plots (estate_id);
 CREATE INDEX idx_estate_id_order_number ON plots (estate_id, order_number);
-CREATE INDEX idx_x_y ON plots (x, y);
\ No newline at end of file
+CREATE INDEX idx_x_y ON plots (x, y);
+
+-- every height measurement of a tree is kept here, plots.tree_height only holds the latest one for the flight model.
+-- estate_id is duplicated from plots so the stats of an estate at a given time can be computed without joining plots.
+-- the measurements of a removed tree are kept for the stats before its removal, plot_id has no foreign key and the
+-- tree is copied from its plot when it is removed: its coordinates, species and health status, and removed_at.
+CREATE TABLE tree_measurements (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plot_id UUID NOT NULL,
+    estate_id UUID NOT NULL,
+    height SMALLINT NOT NULL CHECK (height >= 1 AND height <= 150),
+    measured_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    x INTEGER CHECK (x >= 1 AND x <= 50000),
+    y INTEGER CHECK (y >= 1 AND y <= 50000),
+    species_id UUID,
+    health_status VARCHAR(8) CHECK (health_status IN ('healthy', 'stressed', 'diseased', 'dead')),
+    removed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    FOREIGN KEY (species_id) REFERENCES species(id),
+    CHECK ((removed_at IS NULL) = (x IS NULL) AND (removed_at IS NULL) = (y IS NULL))
+);
+
+CREATE INDEX idx_tree_measurements_plot_id_measured_at ON tree_measurements (plot_id, measured_at);
+CREATE INDEX idx_tree_measurements_estate_id_measured_at ON tree_measurements (estate_id, measured_at);
+
+-- a drone model the flight time and energy of a drone plan are estimated with. speeds are in meters per second,
+-- power draws in watts and the usable battery capacity in watt-hours.
+CREATE TABLE drone_profiles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(100) NOT NULL,
+    horizontal_speed DOUBLE PRECISION NOT NULL CHECK (horizontal_speed > 0),
+    climb_speed DOUBLE PRECISION NOT NULL CHECK (climb_speed > 0),
+    descent_speed DOUBLE PRECISION NOT NULL CHECK (descent_speed > 0),
+    horizontal_power DOUBLE PRECISION NOT NULL CHECK (horizontal_power > 0),
+    climb_power DOUBLE PRECISION NOT NULL CHECK (climb_power > 0),
+    descent_power DOUBLE PRECISION NOT NULL CHECK (descent_power > 0),
+    battery_capacity DOUBLE PRECISION NOT NULL CHECK (battery_capacity > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- a pad on a plot of an estate the drone takes off from, lands on and recharges at between sorties.
+CREATE TABLE charging_pads (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    estate_id UUID NOT NULL,
+    x INTEGER NOT NULL CHECK (x >= 1 AND x <= 50000),
+    y INTEGER NOT NULL CHECK (y >= 1 AND y <= 50000),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    UNIQUE (estate_id, x, y)
+);
+
+-- an obstacle over plots of an estate, the drone climbs over it when it has a height and detours around it when it
+-- is a no-fly zone. shape tells whether it was given as a rectangle, a single area, or as a list of plots.
+CREATE TABLE obstacles (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    estate_id UUID NOT NULL,
+    shape VARCHAR(9) NOT NULL CHECK (shape IN ('rectangle', 'plots')),
+    height SMALLINT CHECK (height >= 1 AND height <= 300),
+    no_fly BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    CHECK ((height IS NULL) = no_fly)
+);
+
+CREATE INDEX idx_obstacles_estate_id ON obstacles (estate_id);
+
+-- a rectangle of plots covered by an obstacle, a plot of a list is a rectangle of one plot.
+CREATE TABLE obstacle_areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    obstacle_id UUID NOT NULL,
+    min_x INTEGER NOT NULL CHECK (min_x >= 1 AND min_x <= 50000),
+    min_y INTEGER NOT NULL CHECK (min_y >= 1 AND min_y <= 50000),
+    max_x INTEGER NOT NULL CHECK (max_x >= min_x AND max_x <= 50000),
+    max_y INTEGER NOT NULL CHECK (max_y >= min_y AND max_y <= 50000),
+    FOREIGN KEY (obstacle_id) REFERENCES obstacles(id)
+);
+
+CREATE INDEX idx_obstacle_areas_obstacle_id ON obstacle_areas (obstacle_id);
+
+-- the elevation grid of an estate in meters, the elevations of its square cells as little endian float32 row by row
+-- from y 1 up. the lower left corner of the grid is the origin of the estate, a new grid replaces the previous one.
+CREATE TABLE estate_elevations (
+    estate_id UUID PRIMARY KEY,
+    columns INTEGER NOT NULL CHECK (columns >= 1),
+    rows INTEGER NOT NULL CHECK (rows >= 1),
+    cell_size DOUBLE PRECISION NOT NULL CHECK (cell_size > 0 AND cell_size <= 1000),
+    elevations BYTEA NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    CHECK (octet_length(elevations) = 4 * columns * rows)
+);
+
+-- an observation of a tree recorded by a drone pass. image_ref points to the image taken, e.g. its URL or object key,
+-- and height is the observed height, also recorded as a measurement of the tree when it is given. the inspections of a
+-- removed tree are kept, plot_id has no foreign key.
+CREATE TABLE tree_inspections (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plot_id UUID NOT NULL,
+    estate_id UUID NOT NULL,
+    health_status VARCHAR(8) NOT NULL CHECK (health_status IN ('healthy', 'stressed', 'diseased', 'dead')),
+    notes VARCHAR(2000),
+    image_ref VARCHAR(500),
+    height SMALLINT CHECK (height >= 1 AND height <= 150),
+    inspected_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id)
+);
+
+CREATE INDEX idx_tree_inspections_plot_id_inspected_at ON tree_inspections (plot_id, inspected_at);
+
+-- the telemetry of a real flight over an estate, compared with the drone plan of the max_distance it was flown with.
+CREATE TABLE flight_logs (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    estate_id UUID NOT NULL,
+    max_distance INTEGER CHECK (max_distance >= 1),
+    started_at TIMESTAMP NOT NULL,
+    ended_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    CHECK (ended_at >= started_at)
+);
+
+CREATE INDEX idx_flight_logs_estate_id ON flight_logs (estate_id);
+
+-- a sample of a flight log in the order it was recorded. x and y are the position in plots, whole at the plot centers,
+-- altitude is in meters above any fixed reference and sortie counts the takeoffs from 1.
+CREATE TABLE flight_log_samples (
+    flight_log_id UUID NOT NULL,
+    sequence INTEGER NOT NULL CHECK (sequence >= 1),
+    sortie INTEGER NOT NULL CHECK (sortie >= 1),
+    recorded_at TIMESTAMP NOT NULL,
+    x DOUBLE PRECISION NOT NULL,
+    y DOUBLE PRECISION NOT NULL,
+    altitude DOUBLE PRECISION NOT NULL,
+    battery DOUBLE PRECISION NOT NULL CHECK (battery >= 0 AND battery <= 100),
+    PRIMARY KEY (flight_log_id, sequence),
+    FOREIGN KEY (flight_log_id) REFERENCES flight_logs(id)
+);
+
+-- a harvest of a tree, quantity is in kilograms and grade is the quality grade the harvest was sorted in. the harvests
+-- of a removed tree are kept for the yield of its estate, plot_id is cleared and x and y keep the plot of the tree.
+CREATE TABLE tree_harvests (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    plot_id UUID,
+    estate_id UUID NOT NULL,
+    x INTEGER NOT NULL CHECK (x >= 1 AND x <= 50000),
+    y INTEGER NOT NULL CHECK (y >= 1 AND y <= 50000),
+    quantity DOUBLE PRECISION NOT NULL CHECK (quantity > 0 AND quantity <= 10000),
+    grade VARCHAR(20),
+    harvested_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (plot_id) REFERENCES plots(id) ON DELETE SET NULL,
+    FOREIGN KEY (estate_id) REFERENCES estates(id)
+);
+
+CREATE INDEX idx_tree_harvests_estate_id_harvested_at ON tree_harvests (estate_id, harvested_at);
+
+-- a work order on the trees of an estate. it targets a tree, a rectangle of plots or a list of plots kept in
+-- work_order_areas like the obstacles, a tree as the area of its plot. tree_id has no foreign key, a remove or replant
+-- order outlives its tree. status moves from open to assigned, in_progress and completed, an order not completed may
+-- be cancelled. height and species_id are the saplings of a replant order.
+CREATE TABLE work_orders (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    estate_id UUID NOT NULL,
+    kind VARCHAR(7) NOT NULL CHECK (kind IN ('prune', 'spray', 'replant', 'remove')),
+    status VARCHAR(11) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'assigned', 'in_progress', 'completed', 'cancelled')),
+    shape VARCHAR(9) NOT NULL CHECK (shape IN ('tree', 'rectangle', 'plots')),
+    tree_id UUID,
+    assignee VARCHAR(100),
+    notes VARCHAR(2000),
+    height SMALLINT CHECK (height >= 1 AND height <= 150),
+    species_id UUID,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    FOREIGN KEY (species_id) REFERENCES species(id),
+    CHECK ((tree_id IS NOT NULL) = (shape = 'tree')),
+    CHECK ((height IS NOT NULL) = (kind = 'replant')),
+    CHECK ((completed_at IS NOT NULL) = (status = 'completed'))
+);
+
+CREATE INDEX idx_work_orders_estate_id_status ON work_orders (estate_id, status);
+
+-- a rectangle of plots targeted by a work order, a plot of a list or the plot of a tree is a rectangle of one plot.
+CREATE TABLE work_order_areas (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    work_order_id UUID NOT NULL,
+    min_x INTEGER NOT NULL CHECK (min_x >= 1 AND min_x <= 50000),
+    min_y INTEGER NOT NULL CHECK (min_y >= 1 AND min_y <= 50000),
+    max_x INTEGER NOT NULL CHECK (max_x >= min_x AND max_x <= 50000),
+    max_y INTEGER NOT NULL CHECK (max_y >= min_y AND max_y <= 50000),
+    FOREIGN KEY (work_order_id) REFERENCES work_orders(id)
+);
+
+CREATE INDEX idx_work_order_areas_work_order_id ON work_order_areas (work_order_id);
+
+-- a soil probe on a plot of an estate. it is bound to the plot coordinates and not to the tree, it stays in the ground
+-- when the tree is removed or replanted. a plot holds at most one sensor of each kind.
+CREATE TABLE sensors (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    estate_id UUID NOT NULL,
+    kind VARCHAR(13) NOT NULL CHECK (kind IN ('soil_moisture', 'temperature')),
+    x INTEGER NOT NULL CHECK (x >= 1 AND x <= 50000),
+    y INTEGER NOT NULL CHECK (y >= 1 AND y <= 50000),
+    name VARCHAR(100),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (estate_id) REFERENCES estates(id),
+    UNIQUE (estate_id, x, y, kind)
+);
+
+-- a value recorded by a sensor, the soil moisture in percent or the temperature in degrees Celsius. the readings are
+-- partitioned by month of recorded_at, a reading sent twice is stored once. they reference neither the plots nor the
+-- estate, an insert of readings never waits on the rows locked by the tree mutations.
+CREATE TABLE sensor_readings (
+    sensor_id UUID NOT NULL,
+    recorded_at TIMESTAMP NOT NULL,
+    value DOUBLE PRECISION NOT NULL,
+    PRIMARY KEY (sensor_id, recorded_at),
+    FOREIGN KEY (sensor_id) REFERENCES sensors(id)
+) PARTITION BY RANGE (recorded_at);
+
+-- creates the partition of sensor_readings of the month of recorded_at unless it exists. the ingestion calls it for
+-- the months of a batch before storing the batch so no partition is created ahead of time, a partition created at
+-- the same time by another ingestion is taken as created.
+CREATE FUNCTION create_sensor_readings_partition(recorded_at TIMESTAMP) RETURNS VOID AS $$
+DECLARE
+    month TIMESTAMP := date_trunc('month', recorded_at);
+    partition_name TEXT := 'sensor_readings_' || to_char(month, 'YYYY_MM');
+BEGIN
+    IF to_regclass(partition_name) IS NOT NULL THEN
+        RETURN;
+    END IF;
+    EXECUTE format('CREATE TABLE %I PARTITION OF sensor_readings FOR VALUES FROM (%L) TO (%L)',
+        partition_name, month, month + INTERVAL '1 month');
+EXCEPTION
+    WHEN duplicate_table OR unique_violation THEN
+        NULL;
+END $$ LANGUAGE plpgsql;
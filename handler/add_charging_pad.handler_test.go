@@ -0,0 +1,107 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddChargingPad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockUUID := uuid.New()
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"x": 3, "y": 2}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddChargingPad(gomock.Any(), mockEstateID, generated.ChargingPadRequest{X: 3, Y: 2}).
+					Return(generated.ChargingPadResponse{Id: &mockUUID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"x": "first", "y": 2}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Invalid Parameters",
+			requestBody:    `{"x": 0, "y": 2}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'ChargingPadRequest.X' Error:Field validation for 'X' failed"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"x": 3, "y": 2}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddChargingPad(gomock.Any(), mockEstateID, generated.ChargingPadRequest{X: 3, Y: 2}).
+					Return(generated.ChargingPadResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddChargingPad(c, mockEstateID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.ChargingPadResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, generated.ChargingPadResponse{Id: &mockUUID}, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
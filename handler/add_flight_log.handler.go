@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"spgo/generated"
+)
+
+// maxFlightLogBytes bounds the size of an uploaded flight log, enough for its most samples with a few more columns.
+const maxFlightLogBytes = 16 << 20
+
+func (s *Server) AddFlightLog(ctx echo.Context, id openapi_types.UUID, params generated.AddFlightLogParams) error {
+	log, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxFlightLogBytes+1))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if len(log) > maxFlightLogBytes {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "flight log is too large"})
+	}
+
+	resp, httpStatus, err := s.Service.AddFlightLog(ctx.Request().Context(), id, log, params)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusCreated, resp)
+}
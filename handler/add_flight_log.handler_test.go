@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddFlightLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	mockLogID := uuid.New()
+	maxDistance := 400
+	mockLog := []byte("timestamp,x,y,altitude,battery\n1790000000,1,1,0,100\n")
+	mockResponse := generated.FlightLogReport{
+		Id:          &mockLogID,
+		MaxDistance: &maxDistance,
+		Distance:    ptrInt(0),
+		Sorties:     &[]generated.FlightLogSortie{{Sortie: ptrInt(1), Distance: ptrInt(0)}},
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    []byte
+		params         generated.AddFlightLogParams
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: mockLog,
+			params:      generated.AddFlightLogParams{MaxDistance: &maxDistance},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddFlightLog(gomock.Any(), mockUUID, mockLog, generated.AddFlightLogParams{MaxDistance: &maxDistance}).
+					Return(mockResponse, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Log Too Large",
+			requestBody:    bytes.Repeat([]byte("1,"), 8<<20+1),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  ptr("flight log is too large"),
+		},
+		{
+			name:        "Invalid Log",
+			requestBody: []byte("timestamp,x\n"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddFlightLog(gomock.Any(), mockUUID, gomock.Any(), gomock.Any()).
+					Return(generated.FlightLogReport{}, http.StatusBadRequest, errors.New("flight log has no altitude column"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("flight log has no altitude column"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: mockLog,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddFlightLog(gomock.Any(), mockUUID, gomock.Any(), gomock.Any()).
+					Return(generated.FlightLogReport{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, "text/csv")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddFlightLog(c, mockUUID, tc.params)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.FlightLogReport
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddHarvest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockUUID := uuid.New()
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockResponse   generated.HarvestResponse
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name:         "Valid Request",
+			requestBody:  `{"x": 4, "y": 2, "quantity": 24.5, "grade": "A"}`,
+			mockResponse: generated.HarvestResponse{Id: &mockUUID, TreeId: &mockTreeID},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddHarvest(gomock.Any(), mockEstateID, gomock.Any()).Return(generated.HarvestResponse{Id: &mockUUID, TreeId: &mockTreeID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"x": "4"}`,
+			expectedError:  ptr("Invalid request"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "X Out Of Bounds",
+			requestBody:    `{"x": 0, "y": 2, "quantity": 24.5}`,
+			expectedError:  ptr("Key: 'HarvestRequest.X' Error:Field validation for 'X' failed"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Plot Without Tree",
+			requestBody:   `{"x": 4, "y": 3, "quantity": 24.5}`,
+			expectedError: ptr("plot with coordinate x and y holds no tree"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddHarvest(gomock.Any(), mockEstateID, gomock.Any()).Return(generated.HarvestResponse{}, http.StatusBadRequest, errors.New("plot with coordinate x and y holds no tree"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Estate Not Found",
+			requestBody:   `{"x": 4, "y": 2, "quantity": 24.5}`,
+			expectedError: ptr("estate not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddHarvest(gomock.Any(), mockEstateID, gomock.Any()).Return(generated.HarvestResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddHarvest(c, mockEstateID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.HarvestResponse
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, tc.mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,109 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddObstacle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockUUID := uuid.New()
+	noFly := true
+	mockRequest := generated.ObstacleRequest{Rectangle: &generated.PlotRectangle{MinX: 2, MinY: 1, MaxX: 3, MaxY: 2}, NoFly: &noFly}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"rectangle": {"min_x": 2, "min_y": 1, "max_x": 3, "max_y": 2}, "no_fly": true}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddObstacle(gomock.Any(), mockEstateID, mockRequest).
+					Return(generated.ObstacleResponse{Id: &mockUUID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"rectangle": "first", "no_fly": true}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Invalid Parameters",
+			requestBody:    `{"rectangle": {"min_x": 0, "min_y": 1, "max_x": 3, "max_y": 2}, "no_fly": true}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'ObstacleRequest.Rectangle.MinX' Error:Field validation for 'MinX' failed"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"rectangle": {"min_x": 2, "min_y": 1, "max_x": 3, "max_y": 2}, "no_fly": true}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddObstacle(gomock.Any(), mockEstateID, mockRequest).
+					Return(generated.ObstacleResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddObstacle(c, mockEstateID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.ObstacleResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, generated.ObstacleResponse{Id: &mockUUID}, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
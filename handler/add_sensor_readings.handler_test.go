@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddSensorReadings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockSensorID := uuid.MustParse("5b6f3a52-0c3d-4c43-9a43-8f2f1d0b8e21")
+	value := 31.5
+	mockRequest := generated.SensorReadingBatch{Readings: []generated.SensorReading{
+		{SensorId: mockSensorID, RecordedAt: time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), Value: &value},
+	}}
+	mockResponse := generated.SensorReadingBatchResponse{Stored: ptrInt(1), Duplicates: ptrInt(0)}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"readings": [{"sensor_id": "5b6f3a52-0c3d-4c43-9a43-8f2f1d0b8e21", "recorded_at": "2026-10-01T08:00:00Z", "value": 31.5}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddSensorReadings(gomock.Any(), mockEstateID, mockRequest).
+					Return(mockResponse, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"readings": {"sensor_id": "5b6f3a52-0c3d-4c43-9a43-8f2f1d0b8e21"}}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Missing Readings",
+			requestBody:    `{}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'SensorReadingBatch.Readings' Error:Field validation for 'Readings' failed"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"readings": [{"sensor_id": "5b6f3a52-0c3d-4c43-9a43-8f2f1d0b8e21", "recorded_at": "2026-10-01T08:00:00Z", "value": 31.5}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddSensorReadings(gomock.Any(), mockEstateID, mockRequest).
+					Return(generated.SensorReadingBatchResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddSensorReadings(c, mockEstateID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.SensorReadingBatchResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddTreeInspection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockUUID := uuid.New()
+	mockMeasurementID := uuid.New()
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockResponse   generated.TreeInspectionResponse
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name:         "Valid Request",
+			requestBody:  `{"health_status": "stressed", "notes": "yellowing fronds", "image_ref": "s3://inspections/plot-4-2.jpg", "height": 12}`,
+			mockResponse: generated.TreeInspectionResponse{Id: &mockUUID, MeasurementId: &mockMeasurementID},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeInspection(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeInspectionResponse{Id: &mockUUID, MeasurementId: &mockMeasurementID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"health_status": 1}`,
+			expectedError:  ptr("Invalid request"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing Health Status",
+			requestBody:    `{"notes": "yellowing fronds"}`,
+			expectedError:  ptr("Key: 'TreeInspectionRequest.HealthStatus' Error:Field validation for 'HealthStatus' failed"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Unknown Health Status",
+			requestBody:   `{"health_status": "sick"}`,
+			expectedError: ptr("health_status must be one of healthy, stressed, diseased, dead"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeInspection(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeInspectionResponse{}, http.StatusBadRequest, errors.New("health_status must be one of healthy, stressed, diseased, dead"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Tree Not Found",
+			requestBody:   `{"health_status": "dead"}`,
+			expectedError: ptr("tree not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeInspection(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeInspectionResponse{}, http.StatusNotFound, errors.New("tree not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddTreeInspection(c, mockEstateID, mockTreeID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.TreeInspectionResponse
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, tc.mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
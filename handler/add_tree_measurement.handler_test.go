@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestAddTreeMeasurement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockUUID := uuid.New()
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockResponse   generated.TreeMeasurementResponse
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name:         "Valid Request",
+			requestBody:  `{"height": 12, "measured_at": "2024-01-01T00:00:00Z"}`,
+			mockResponse: generated.TreeMeasurementResponse{Id: &mockUUID},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeMeasurement(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeMeasurementResponse{Id: &mockUUID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"height": "invalid"}`,
+			expectedError:  ptr("Invalid request"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Height Below One",
+			requestBody:    `{"height": 0}`,
+			expectedError:  ptr("Key: 'TreeMeasurementRequest.Height' Error:Field validation for 'Height' failed"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Height Exceeds The Species",
+			requestBody:   `{"height": 31}`,
+			expectedError: ptr("height must be between 1 and 30 for a tree without species"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeMeasurement(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeMeasurementResponse{}, http.StatusBadRequest, errors.New("height must be between 1 and 30 for a tree without species"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "Tree Not Found",
+			requestBody:   `{"height": 12}`,
+			expectedError: ptr("tree not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().AddTreeMeasurement(gomock.Any(), mockEstateID, mockTreeID, gomock.Any()).Return(generated.TreeMeasurementResponse{}, http.StatusNotFound, errors.New("tree not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.AddTreeMeasurement(c, mockEstateID, mockTreeID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.TreeMeasurementResponse
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, tc.mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
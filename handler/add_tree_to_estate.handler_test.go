@@ -87,13 +87,17 @@ func TestAddTreeToEstate(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "Height Exceeds",
-			id:             openapi_types.UUID(mockUUID),
-			requestBody:    `{"height": 100000}`,
-			mockResponse:   generated.TreeResponse{},
-			mockError:      nil,
-			expectedError:  ptr("Key: 'TreeRequest.Height' Error:Field validation for 'Height' failed"),
-			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			name:          "Height Exceeds The Species",
+			id:            openapi_types.UUID(mockUUID),
+			requestBody:   `{"x": 1, "y": 2, "height": 45, "species_id": "123e4567-e89b-12d3-a456-426614174000"}`,
+			mockResponse:  generated.TreeResponse{},
+			mockError:     nil,
+			expectedError: ptr("height must be between 1 and 20, the max height of the species"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				speciesId := uuid.MustParse("123e4567-e89b-12d3-a456-426614174000")
+				mockService.EXPECT().AddTreeToEstate(gomock.Any(), generated.TreeRequest{X: 1, Y: 2, Height: 45, SpeciesId: &speciesId}, mockUUID).
+					Return(generated.TreeResponse{}, http.StatusBadRequest, errors.New("height must be between 1 and 20, the max height of the species"))
+			},
 			expectedStatus: http.StatusBadRequest,
 		},
 	}
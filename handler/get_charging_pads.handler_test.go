@@ -0,0 +1,86 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetChargingPads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockPadID := uuid.New()
+	mockResponse := generated.ChargingPadList{Pads: &[]generated.ChargingPad{{Id: &mockPadID, X: ptrInt(1), Y: ptrInt(1)}}}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name: "Valid Request",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).
+					Return(generated.ChargingPadList{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetChargingPads(c, mockEstateID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.ChargingPadList
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
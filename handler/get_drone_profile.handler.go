@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetDroneProfile(ctx echo.Context, id openapi_types.UUID) error {
+	resp, httpStatus, err := s.Service.GetDroneProfile(ctx.Request().Context(), id)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
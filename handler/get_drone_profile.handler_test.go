@@ -0,0 +1,87 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetDroneProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	name := "quadcopter"
+	speed := 10.0
+	mockResponse := generated.DroneProfile{Id: &mockUUID, Name: &name, HorizontalSpeed: &speed}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name: "Valid Request",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetDroneProfile(gomock.Any(), mockUUID).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Profile Not Found",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetDroneProfile(gomock.Any(), mockUUID).
+					Return(generated.DroneProfile{}, http.StatusNotFound, errors.New("drone profile not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("drone profile not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetDroneProfile(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.DroneProfile
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
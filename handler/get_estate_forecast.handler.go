@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"spgo/generated"
+)
+
+func (s *Server) GetEstateForecast(ctx echo.Context, id openapi_types.UUID, params generated.GetEstateForecastParams) error {
+	resp, httpStatus, err := s.Service.GetEstateForecast(ctx.Request().Context(), id, params)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,105 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetEstateForecast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	at := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	params := generated.GetEstateForecastParams{At: at}
+	mockResponse := generated.EstateForecast{
+		At:              &at,
+		Count:           ptrInt(3),
+		Max:             ptrInt(13),
+		Min:             ptrInt(5),
+		Median:          ptrInt(12),
+		Distance:        ptrInt(68),
+		CurrentDistance: ptrInt(62),
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name: "Valid Request",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateForecast(gomock.Any(), mockUUID, params).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "At In The Past",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateForecast(gomock.Any(), mockUUID, params).
+					Return(generated.EstateForecast{}, http.StatusBadRequest, errors.New("at cannot be in the past"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("at cannot be in the past"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateForecast(gomock.Any(), mockUUID, params).
+					Return(generated.EstateForecast{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetEstateForecast(c, mockUUID, params)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.EstateForecast
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
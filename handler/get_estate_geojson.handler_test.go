@@ -0,0 +1,132 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetEstateGeoJson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	featureType := "Feature"
+	collectionType := "FeatureCollection"
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		call           func(server *handler.Server, c echo.Context) error
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedType   string
+		expectedError  *string
+	}{
+		{
+			name: "Boundary",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateBoundaryGeoJson(c, mockUUID)
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateBoundaryGeoJson(gomock.Any(), mockUUID).
+					Return(generated.EstateBoundaryFeature{Type: &featureType}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   featureType,
+		},
+		{
+			name: "Trees",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateTreesGeoJson(c, mockUUID)
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateTreesGeoJson(gomock.Any(), mockUUID).
+					Return(generated.TreeFeatureCollection{Type: &collectionType}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   collectionType,
+		},
+		{
+			name: "Drone Path",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateDronePathGeoJson(c, mockUUID)
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateDronePathGeoJson(gomock.Any(), mockUUID).
+					Return(generated.DronePathFeature{Type: &featureType}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+			expectedType:   featureType,
+		},
+		{
+			name: "Not Geo-Referenced",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateBoundaryGeoJson(c, mockUUID)
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateBoundaryGeoJson(gomock.Any(), mockUUID).
+					Return(generated.EstateBoundaryFeature{}, http.StatusConflict, errors.New("estate is not geo-referenced"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  ptr("estate is not geo-referenced"),
+		},
+		{
+			name: "Estate Not Found",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateTreesGeoJson(c, mockUUID)
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateTreesGeoJson(gomock.Any(), mockUUID).
+					Return(generated.TreeFeatureCollection{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tc.call(server, c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			var resp map[string]interface{}
+			err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+			assert.NoError(t, err2)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, "application/geo+json", rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tc.expectedType, resp["type"])
+			} else {
+				assert.Equal(t, echo.MIMEApplicationJSONCharsetUTF8, rec.Header().Get(echo.HeaderContentType))
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -11,9 +11,9 @@ import (
 
 func (s *Server) GetEstateIdDronePlan(ctx echo.Context, id openapi_types.UUID, params generated.GetEstateIdDronePlanParams) error {
 
-	resp, err := s.Service.GetEstateDronePlan(ctx.Request().Context(), id, params.MaxDistance)
+	resp, httpStatus, err := s.Service.GetEstateDronePlan(ctx.Request().Context(), id, params)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
 	}
 
 	return ctx.JSON(http.StatusOK, resp)
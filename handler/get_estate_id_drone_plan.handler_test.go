@@ -54,7 +54,7 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 			mockError:     nil,
 			expectedError: nil,
 			prepareMock: func(mockService *service.MockServiceInterface) {
-				mockService.EXPECT().GetEstateDronePlan(gomock.Any(), mockUUID, ptrInt(100)).Return(generated.DronePlanResponse{
+				mockService.EXPECT().GetEstateDronePlan(gomock.Any(), mockUUID, generated.GetEstateIdDronePlanParams{MaxDistance: ptrInt(100)}).Return(generated.DronePlanResponse{
 					Distance: ptrInt(100),
 					Rest: &struct {
 						X *int `json:"x,omitempty"`
@@ -63,7 +63,7 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 						X: ptrInt(10),
 						Y: ptrInt(20),
 					},
-				}, nil)
+				}, http.StatusOK, nil)
 			},
 			expectedStatus: http.StatusOK,
 		},
@@ -75,10 +75,23 @@ func TestGetEstateIdDronePlan(t *testing.T) {
 			mockError:     nil,
 			expectedError: ptr("service error"),
 			prepareMock: func(mockService *service.MockServiceInterface) {
-				mockService.EXPECT().GetEstateDronePlan(gomock.Any(), mockUUID, ptrInt(100)).Return(generated.DronePlanResponse{}, errors.New("service error"))
+				mockService.EXPECT().GetEstateDronePlan(gomock.Any(), mockUUID, generated.GetEstateIdDronePlanParams{MaxDistance: ptrInt(100)}).Return(generated.DronePlanResponse{}, http.StatusInternalServerError, errors.New("service error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:          "Invalid Flight Mode",
+			id:            mockUUID,
+			maxDistance:   ptrInt(100),
+			mockResponse:  generated.DronePlanResponse{},
+			mockError:     nil,
+			expectedError: ptr("flight_mode must be one of"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateDronePlan(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.DronePlanResponse{}, http.StatusBadRequest, errors.New("flight_mode must be one of terrain_following, fixed_estate_max or fixed_row_max"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
 	}
 
 	for _, tc := range tests {
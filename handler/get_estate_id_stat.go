@@ -9,10 +9,44 @@ import (
 	"spgo/generated"
 )
 
-func (s *Server) GetEstateIdStats(ctx echo.Context, id openapi_types.UUID) error {
+func (s *Server) GetEstateIdStats(ctx echo.Context, id openapi_types.UUID, params generated.GetEstateIdStatsParams) error {
 	var resp generated.EstateStatsResponse
+	var err error
 
-	resp, err := s.Service.GetEstateStats(ctx.Request().Context(), id)
+	extended := (params.Extended != nil && *params.Extended) || params.Percentiles != nil
+
+	if params.GroupBy != nil || params.MinX != nil || params.MinY != nil || params.MaxX != nil || params.MaxY != nil {
+		if extended {
+			return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "extended and percentiles cannot be combined with group_by or a region"})
+		}
+
+		resp, httpStatus, err := s.Service.GetEstateRegionStats(ctx.Request().Context(), id, params)
+		if err != nil {
+			return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+		}
+
+		return ctx.JSON(http.StatusOK, resp)
+	}
+
+	if extended {
+		var percentiles []float64
+		if params.Percentiles != nil {
+			percentiles = *params.Percentiles
+		}
+
+		resp, httpStatus, err := s.Service.GetEstateExtendedStats(ctx.Request().Context(), id, params.AsOf, percentiles)
+		if err != nil {
+			return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+		}
+
+		return ctx.JSON(http.StatusOK, resp)
+	}
+
+	if params.AsOf != nil {
+		resp, err = s.Service.GetEstateStatsAsOf(ctx.Request().Context(), id, *params.AsOf)
+	} else {
+		resp, err = s.Service.GetEstateStats(ctx.Request().Context(), id)
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
 	}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -24,12 +25,17 @@ func TestGetEstateIdStats(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockUUID := uuid.New()
+	mockAsOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
 
 	e := echo.New()
 
 	tests := []struct {
 		name           string
 		id             openapi_types.UUID
+		asOf           *time.Time
+		extended       *bool
+		percentiles    *[]float64
+		groupBy        *generated.GetEstateIdStatsParamsGroupBy
 		mockResponse   generated.EstateStatsResponse
 		mockError      error
 		expectedError  *string
@@ -57,6 +63,115 @@ func TestGetEstateIdStats(t *testing.T) {
 			},
 			expectedStatus: http.StatusOK,
 		},
+		{
+			name: "Valid Request As Of",
+			id:   mockUUID,
+			asOf: &mockAsOf,
+			mockResponse: generated.EstateStatsResponse{
+				Count:  ptrInt(2),
+				Max:    ptrInt(12),
+				Median: ptrInt(10),
+				Min:    ptrInt(8),
+			},
+			mockError:     nil,
+			expectedError: nil,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateStatsAsOf(gomock.Any(), mockUUID, mockAsOf).Return(generated.EstateStatsResponse{
+					Count:  ptrInt(2),
+					Max:    ptrInt(12),
+					Median: ptrInt(10),
+					Min:    ptrInt(8),
+				}, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Service Error As Of",
+			id:            mockUUID,
+			asOf:          &mockAsOf,
+			mockResponse:  generated.EstateStatsResponse{},
+			mockError:     nil,
+			expectedError: ptr("service error"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateStatsAsOf(gomock.Any(), mockUUID, mockAsOf).Return(generated.EstateStatsResponse{}, errors.New("service error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "Valid Request Extended",
+			id:          mockUUID,
+			extended:    &[]bool{true}[0],
+			percentiles: &[]float64{10, 90},
+			mockResponse: generated.EstateStatsResponse{
+				Count:       ptrInt(2),
+				MedianExact: &[]float64{10.5}[0],
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateExtendedStats(gomock.Any(), mockUUID, nil, []float64{10, 90}).Return(generated.EstateStatsResponse{
+					Count:       ptrInt(2),
+					MedianExact: &[]float64{10.5}[0],
+				}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Invalid Percentiles",
+			id:            mockUUID,
+			percentiles:   &[]float64{120},
+			mockResponse:  generated.EstateStatsResponse{},
+			expectedError: ptr("percentiles must be between 0 and 100"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateExtendedStats(gomock.Any(), mockUUID, nil, []float64{120}).Return(generated.EstateStatsResponse{}, http.StatusBadRequest, errors.New("percentiles must be between 0 and 100"))
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:    "Valid Request Group By Row",
+			id:      mockUUID,
+			groupBy: &[]generated.GetEstateIdStatsParamsGroupBy{generated.GetEstateIdStatsParamsGroupByRow}[0],
+			mockResponse: generated.EstateStatsResponse{
+				Count:     ptrInt(1),
+				Occupancy: &[]float64{0.5}[0],
+				Groups:    &[]generated.GroupStats{{Count: ptrInt(1), MinY: ptrInt(2), MaxY: ptrInt(2)}},
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateRegionStats(gomock.Any(), mockUUID, gomock.Any()).Return(generated.EstateStatsResponse{
+					Count:     ptrInt(1),
+					Occupancy: &[]float64{0.5}[0],
+					Groups:    &[]generated.GroupStats{{Count: ptrInt(1), MinY: ptrInt(2), MaxY: ptrInt(2)}},
+				}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Region Service Error",
+			id:            mockUUID,
+			groupBy:       &[]generated.GetEstateIdStatsParamsGroupBy{generated.GetEstateIdStatsParamsGroupByGrid}[0],
+			mockResponse:  generated.EstateStatsResponse{},
+			expectedError: ptr("estate not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateRegionStats(gomock.Any(), mockUUID, gomock.Any()).Return(generated.EstateStatsResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Extended With Group By",
+			id:             mockUUID,
+			extended:       &[]bool{true}[0],
+			groupBy:        &[]generated.GetEstateIdStatsParamsGroupBy{generated.GetEstateIdStatsParamsGroupByRow}[0],
+			expectedError:  ptr("cannot be combined with group_by"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Percentiles With Group By",
+			id:             mockUUID,
+			percentiles:    &[]float64{50},
+			groupBy:        &[]generated.GetEstateIdStatsParamsGroupBy{generated.GetEstateIdStatsParamsGroupByGrid}[0],
+			expectedError:  ptr("cannot be combined with group_by"),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:          "Service Error",
 			id:            mockUUID,
@@ -84,7 +199,7 @@ func TestGetEstateIdStats(t *testing.T) {
 			c.SetParamNames("id")
 			c.SetParamValues(tc.id.String())
 
-			err := server.GetEstateIdStats(c, tc.id)
+			err := server.GetEstateIdStats(c, tc.id, generated.GetEstateIdStatsParams{AsOf: tc.asOf, Extended: tc.extended, Percentiles: tc.percentiles, GroupBy: tc.groupBy})
 
 			assert.Equal(t, tc.expectedStatus, rec.Code)
 
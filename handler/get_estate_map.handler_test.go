@@ -0,0 +1,150 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetEstateMap(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+
+	e := echo.New()
+
+	tests := []struct {
+		name                string
+		call                func(server *handler.Server, c echo.Context) error
+		prepareMock         func(mockService *service.MockServiceInterface)
+		expectedStatus      int
+		expectedContentType string
+		expectedBody        string
+		expectedError       *string
+	}{
+		{
+			name: "PNG",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapPng(c, mockUUID, generated.GetEstateMapPngParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapPng(gomock.Any(), mockUUID, gomock.Any()).Return([]byte("\x89PNG"), http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "image/png",
+			expectedBody:        "\x89PNG",
+		},
+		{
+			name: "PNG Estate Not Found",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapPng(c, mockUUID, generated.GetEstateMapPngParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapPng(gomock.Any(), mockUUID, gomock.Any()).Return(nil, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+		{
+			name: "ASCII",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapAscii(c, mockUUID, generated.GetEstateMapAsciiParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapAscii(gomock.Any(), mockUUID, gomock.Any()).Return([]byte("-9999 4\n"), http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMETextPlainCharsetUTF8,
+			expectedBody:        "-9999 4\n",
+		},
+		{
+			name: "ASCII Invalid Size",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapAscii(c, mockUUID, generated.GetEstateMapAsciiParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapAscii(gomock.Any(), mockUUID, gomock.Any()).Return(nil, http.StatusBadRequest, errors.New("max_size must be at least 1"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("max_size must be at least 1"),
+		},
+		{
+			name: "KML",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapKml(c, mockUUID, generated.GetEstateMapKmlParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapKml(gomock.Any(), mockUUID, gomock.Any()).Return([]byte("<kml/>"), http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/vnd.google-earth.kml+xml",
+			expectedBody:        "<kml/>",
+		},
+		{
+			name: "KMZ",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapKmz(c, mockUUID, generated.GetEstateMapKmzParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapKmz(gomock.Any(), mockUUID, gomock.Any()).Return([]byte("PK"), http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/vnd.google-earth.kmz",
+			expectedBody:        "PK",
+		},
+		{
+			name: "KMZ Not Geo-Referenced",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMapKmz(c, mockUUID, generated.GetEstateMapKmzParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMapKmz(gomock.Any(), mockUUID, gomock.Any()).Return(nil, http.StatusConflict, errors.New("estate is not geo-referenced"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  ptr("estate is not geo-referenced"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tc.call(server, c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"spgo/generated"
+)
+
+func (s *Server) GetEstateMapAscii(ctx echo.Context, id openapi_types.UUID, params generated.GetEstateMapAsciiParams) error {
+	resp, httpStatus, err := s.Service.GetEstateMapAscii(ctx.Request().Context(), id, params)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, resp)
+}
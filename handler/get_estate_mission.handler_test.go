@@ -0,0 +1,124 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetEstateMission(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+
+	e := echo.New()
+
+	tests := []struct {
+		name                string
+		call                func(server *handler.Server, c echo.Context) error
+		prepareMock         func(mockService *service.MockServiceInterface)
+		expectedStatus      int
+		expectedContentType string
+		expectedFileName    string
+		expectedBody        string
+		expectedError       *string
+	}{
+		{
+			name: "Plan",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMissionPlan(c, mockUUID, generated.GetEstateMissionPlanParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMissionPlan(gomock.Any(), mockUUID, gomock.Any()).
+					Return(service.MissionFile{Content: []byte(`{"fileType":"Plan"}`), Sortie: 2, SortieCount: 3}, http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMEApplicationJSON,
+			expectedFileName:    "estate-" + mockUUID.String() + "-sortie-2.plan",
+			expectedBody:        `{"fileType":"Plan"}`,
+		},
+		{
+			name: "Waypoints",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMissionWaypoints(c, mockUUID, generated.GetEstateMissionWaypointsParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMissionWaypoints(gomock.Any(), mockUUID, gomock.Any()).
+					Return(service.MissionFile{Content: []byte("QGC WPL 110\n"), Sortie: 1, SortieCount: 3}, http.StatusOK, nil)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: echo.MIMETextPlainCharsetUTF8,
+			expectedFileName:    "estate-" + mockUUID.String() + "-sortie-1.waypoints",
+			expectedBody:        "QGC WPL 110\n",
+		},
+		{
+			name: "Not Geo-Referenced",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMissionPlan(c, mockUUID, generated.GetEstateMissionPlanParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMissionPlan(gomock.Any(), mockUUID, gomock.Any()).
+					Return(service.MissionFile{}, http.StatusConflict, errors.New("estate is not geo-referenced"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  ptr("estate is not geo-referenced"),
+		},
+		{
+			name: "Sortie Out Of Range",
+			call: func(server *handler.Server, c echo.Context) error {
+				return server.GetEstateMissionWaypoints(c, mockUUID, generated.GetEstateMissionWaypointsParams{})
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetEstateMissionWaypoints(gomock.Any(), mockUUID, gomock.Any()).
+					Return(service.MissionFile{}, http.StatusBadRequest, errors.New("sortie must be at most 3"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("sortie must be at most 3"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := tc.call(server, c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				assert.Equal(t, tc.expectedContentType, rec.Header().Get(echo.HeaderContentType))
+				assert.Equal(t, "3", rec.Header().Get("X-Sortie-Count"))
+				assert.Equal(t, `attachment; filename="`+tc.expectedFileName+`"`, rec.Header().Get(echo.HeaderContentDisposition))
+				assert.Equal(t, tc.expectedBody, rec.Body.String())
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
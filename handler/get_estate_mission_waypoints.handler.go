@@ -0,0 +1,23 @@
+package handler
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"spgo/generated"
+)
+
+func (s *Server) GetEstateMissionWaypoints(ctx echo.Context, id openapi_types.UUID, params generated.GetEstateMissionWaypointsParams) error {
+	resp, httpStatus, err := s.Service.GetEstateMissionWaypoints(ctx.Request().Context(), id, params)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	ctx.Response().Header().Set(headerSortieCount, strconv.Itoa(resp.SortieCount))
+	ctx.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=\"estate-%s-sortie-%d.waypoints\"", id, resp.Sortie))
+	return ctx.Blob(http.StatusOK, echo.MIMETextPlainCharsetUTF8, resp.Content)
+}
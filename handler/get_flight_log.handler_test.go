@@ -0,0 +1,91 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetFlightLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockLogID := uuid.New()
+	mockResponse := generated.FlightLogReport{
+		Id:                &mockLogID,
+		Distance:          ptrInt(24),
+		PlannedDistance:   ptrInt(22),
+		DistanceDeviation: ptrInt(2),
+		Sorties:           &[]generated.FlightLogSortie{{Sortie: ptrInt(1), Distance: ptrInt(24), LandingDeviation: ptrInt(0)}},
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "Valid Request",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetFlightLog(gomock.Any(), mockEstateID, mockLogID).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Flight Log Not Found",
+			expectedError: ptr("flight log not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetFlightLog(gomock.Any(), mockEstateID, mockLogID).Return(generated.FlightLogReport{}, http.StatusNotFound, errors.New("flight log not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetFlightLog(c, mockEstateID, mockLogID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.FlightLogReport
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
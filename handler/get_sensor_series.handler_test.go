@@ -0,0 +1,92 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetSensorSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	temperature, day := generated.Temperature, generated.SensorBucketSizeDay
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * 24 * time.Hour)
+	mean := 24.25
+	mockParams := generated.GetSensorSeriesParams{Kind: temperature, Bucket: &day, X: ptrInt(3), Y: ptrInt(2), From: &from, To: &to}
+	mockResponse := generated.SensorSeries{Kind: &temperature, Bucket: &day, From: &from, To: &to, Buckets: &[]generated.SensorBucket{
+		{Start: &from, Readings: ptrInt(24), Mean: &mean},
+	}}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "Valid Request",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetSensorSeries(gomock.Any(), mockEstateID, mockParams).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Estate Not Found",
+			expectedError: ptr("estate not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetSensorSeries(gomock.Any(), mockEstateID, mockParams).Return(generated.SensorSeries{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetSensorSeries(c, mockEstateID, mockParams)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.SensorSeries
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,17 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+)
+
+func (s *Server) GetTreeGrowth(ctx echo.Context, id openapi_types.UUID, treeId openapi_types.UUID) error {
+	resp, httpStatus, err := s.Service.GetTreeGrowth(ctx.Request().Context(), id, treeId)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetTreeGrowth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	growthRate := 1.5
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		mockResponse   generated.TreeGrowthResponse
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "Valid Request",
+			mockResponse: generated.TreeGrowthResponse{
+				Id:         &mockTreeID,
+				Height:     ptrInt(12),
+				GrowthRate: &growthRate,
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetTreeGrowth(gomock.Any(), mockEstateID, mockTreeID).Return(generated.TreeGrowthResponse{
+					Id:         &mockTreeID,
+					Height:     ptrInt(12),
+					GrowthRate: &growthRate,
+				}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Tree Not Found",
+			expectedError: ptr("tree not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetTreeGrowth(gomock.Any(), mockEstateID, mockTreeID).Return(generated.TreeGrowthResponse{}, http.StatusNotFound, errors.New("tree not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetTreeGrowth(c, mockEstateID, mockTreeID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.TreeGrowthResponse
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, tc.mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestGetTreeInspections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockInspectionID := uuid.New()
+	dead := generated.Dead
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		mockResponse   generated.TreeInspectionList
+		expectedError  *string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+	}{
+		{
+			name: "Valid Request",
+			mockResponse: generated.TreeInspectionList{
+				Id:           &mockTreeID,
+				HealthStatus: &dead,
+				Inspections:  &[]generated.TreeInspection{{Id: &mockInspectionID, HealthStatus: &dead, Height: ptrInt(12)}},
+			},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetTreeInspections(gomock.Any(), mockEstateID, mockTreeID).Return(generated.TreeInspectionList{
+					Id:           &mockTreeID,
+					HealthStatus: &dead,
+					Inspections:  &[]generated.TreeInspection{{Id: &mockInspectionID, HealthStatus: &dead, Height: ptrInt(12)}},
+				}, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:          "Tree Not Found",
+			expectedError: ptr("tree not found"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().GetTreeInspections(gomock.Any(), mockEstateID, mockTreeID).Return(generated.TreeInspectionList{}, http.StatusNotFound, errors.New("tree not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.GetTreeInspections(c, mockEstateID, mockTreeID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.TreeInspectionList
+				err1 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err1)
+				assert.Equal(t, tc.mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
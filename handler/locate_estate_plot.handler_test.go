@@ -0,0 +1,95 @@
+package handler_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestLocateEstatePlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	mockParams := generated.LocateEstatePlotParams{Latitude: -6.2, Longitude: 106.8}
+	mockResponse := generated.PlotLocation{X: ptrInt(4), Y: ptrInt(2)}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name: "Plot Found",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().LocateEstatePlot(gomock.Any(), mockUUID, mockParams).Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Outside The Estate",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().LocateEstatePlot(gomock.Any(), mockUUID, mockParams).
+					Return(generated.PlotLocation{}, http.StatusBadRequest, errors.New("position is outside the estate"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("position is outside the estate"),
+		},
+		{
+			name: "Not Geo-Referenced",
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().LocateEstatePlot(gomock.Any(), mockUUID, mockParams).
+					Return(generated.PlotLocation{}, http.StatusConflict, errors.New("estate is not geo-referenced"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  ptr("estate is not geo-referenced"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.LocateEstatePlot(c, mockUUID, mockParams)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.PlotLocation
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
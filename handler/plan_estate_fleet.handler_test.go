@@ -0,0 +1,125 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestPlanEstateFleet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	terrainFollowing := generated.TerrainFollowing
+	mockResponse := generated.FleetPlan{
+		FlightMode: &terrainFollowing,
+		Makespan:   ptrInt(40),
+		Drones: &[]generated.FleetAssignment{
+			{Drone: ptrInt(1), Start: &generated.PlotPosition{X: ptrInt(1), Y: ptrInt(1)}, End: &generated.PlotPosition{X: ptrInt(5), Y: ptrInt(1)}, Plots: ptrInt(5), Distance: ptrInt(40), Landings: ptrInt(1)},
+			{Drone: ptrInt(2), Start: &generated.PlotPosition{X: ptrInt(6), Y: ptrInt(1)}, End: &generated.PlotPosition{X: ptrInt(10), Y: ptrInt(1)}, Plots: ptrInt(5), Distance: ptrInt(40), Landings: ptrInt(1)},
+		},
+	}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"drones": [{"max_distance": 1000}, {"max_distance": 1000}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PlanEstateFleet(gomock.Any(), mockUUID, generated.FleetRequest{Drones: []generated.FleetDrone{{MaxDistance: 1000}, {MaxDistance: 1000}}}).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"drones": "invalid"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Missing Drones",
+			requestBody:    `{}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'FleetRequest.Drones' Error:Field validation for 'Drones' failed on the 'required' tag"),
+		},
+		{
+			name:        "Invalid Max Distance",
+			requestBody: `{"drones": [{"max_distance": -5}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PlanEstateFleet(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.FleetPlan{}, http.StatusBadRequest, errors.New("max_distance of drone 1 must be at least 1"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("max_distance of drone 1 must be at least 1"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"drones": [{"max_distance": 1000}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PlanEstateFleet(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.FleetPlan{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.PlanEstateFleet(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.FleetPlan
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
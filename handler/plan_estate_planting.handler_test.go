@@ -0,0 +1,113 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestPlanEstatePlanting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	commit := true
+	mockRequest := generated.PlantingRequest{Count: 1, Height: 4, Commit: &commit}
+	mockResponse := generated.PlantingPlan{
+		Plots:         &[]generated.PlantingSuggestion{{Id: &mockUUID, X: ptrInt(1), Y: ptrInt(1), AddedDistance: ptrInt(10)}},
+		AddedDistance: ptrInt(10),
+		Distance:      ptrInt(82),
+		Committed:     &commit,
+	}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Saplings Planted",
+			requestBody: `{"count": 1, "height": 4, "commit": true}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PlanEstatePlanting(gomock.Any(), mockUUID, mockRequest).Return(mockResponse, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"count": "many"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Too Many Saplings",
+			requestBody:    `{"count": 1001, "height": 4}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'PlantingRequest.Count' Error:Field validation for 'Count' failed"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"count": 1, "height": 4, "commit": true}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PlanEstatePlanting(gomock.Any(), mockUUID, mockRequest).
+					Return(generated.PlantingPlan{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.PlanEstatePlanting(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.PlantingPlan
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
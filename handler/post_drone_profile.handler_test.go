@@ -0,0 +1,118 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestPostDroneProfileHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	validBody := `{"name": "quadcopter", "horizontal_speed": 10, "climb_speed": 2, "descent_speed": 5,
+		"horizontal_power": 100, "climb_power": 360, "descent_power": 36, "battery_capacity": 50}`
+	mockRequest := generated.DroneProfileRequest{
+		Name:            "quadcopter",
+		HorizontalSpeed: 10,
+		ClimbSpeed:      2,
+		DescentSpeed:    5,
+		HorizontalPower: 100,
+		ClimbPower:      360,
+		DescentPower:    36,
+		BatteryCapacity: 50,
+	}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: validBody,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PostDroneProfile(gomock.Any(), mockRequest).
+					Return(generated.DroneProfileResponse{Id: &mockUUID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"name": "quadcopter", "climb_speed": "fast"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Missing Battery Capacity",
+			requestBody:    `{"name": "quadcopter", "horizontal_speed": 10, "climb_speed": 2, "descent_speed": 5, "horizontal_power": 100, "climb_power": 360, "descent_power": 36}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'DroneProfileRequest.BatteryCapacity' Error:Field validation for 'BatteryCapacity' failed"),
+		},
+		{
+			name:        "Service Error",
+			requestBody: validBody,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PostDroneProfile(gomock.Any(), mockRequest).
+					Return(generated.DroneProfileResponse{}, http.StatusBadRequest, errors.New("climb_power must be greater than 0"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("climb_power must be greater than 0"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.PostDroneProfile(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.DroneProfileResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, generated.DroneProfileResponse{Id: &mockUUID}, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -20,9 +20,9 @@ func (s *Server) PostEstate(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
 	}
 
-	resp, err := s.Service.PostEstate(ctx.Request().Context(), req)
+	resp, httpStatus, err := s.Service.PostEstate(ctx.Request().Context(), req)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
 	}
 
 	return ctx.JSON(http.StatusCreated, resp)
@@ -55,7 +55,7 @@ func TestPostEstateHandler(t *testing.T) {
 			mockError:     nil,
 			expectedError: nil,
 			prepareMock: func(mockService *service.MockServiceInterface) {
-				mockService.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(generated.EstateResponse{Id: &mockUUID}, nil)
+				mockService.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(generated.EstateResponse{Id: &mockUUID}, http.StatusCreated, nil)
 			},
 			expectedStatus: http.StatusCreated,
 		},
@@ -84,10 +84,19 @@ func TestPostEstateHandler(t *testing.T) {
 			mockError:     nil,
 			expectedError: nil,
 			prepareMock: func(mockService *service.MockServiceInterface) {
-				mockService.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(generated.EstateResponse{}, errors.New("service error"))
+				mockService.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(generated.EstateResponse{}, http.StatusInternalServerError, errors.New("service error"))
 			},
 			expectedStatus: http.StatusInternalServerError,
 		},
+		{
+			name:        "Invalid Shape",
+			requestBody: `{"width": 5, "length": 10, "boundary": [{"x": 1, "y": 1}, {"x": 11, "y": 1}, {"x": 1, "y": 5}]}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(generated.EstateResponse{}, http.StatusBadRequest, errors.New("boundary vertex (11,1) is out of range"))
+			},
+			expectedError:  ptr("boundary vertex (11,1) is out of range"),
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name:           "Length Exceeds",
 			requestBody:    `{"width": 1, "length": 100000}`,
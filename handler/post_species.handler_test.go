@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestPostSpeciesHandler(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	growthRate := 0.5
+	validBody := `{"name": "oak", "max_height": 40, "growth_rate": 0.5, "harvest_cycle": 120}`
+	mockRequest := generated.SpeciesRequest{
+		Name:         "oak",
+		MaxHeight:    40,
+		GrowthRate:   &growthRate,
+		HarvestCycle: ptrInt(120),
+	}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: validBody,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PostSpecies(gomock.Any(), mockRequest).
+					Return(generated.SpeciesResponse{Id: &mockUUID}, http.StatusCreated, nil)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"name": "oak", "max_height": "tall"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Max Height Too Tall",
+			requestBody:    `{"name": "sequoia", "max_height": 151}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'SpeciesRequest.MaxHeight' Error:Field validation for 'MaxHeight' failed"),
+		},
+		{
+			name:        "Service Error",
+			requestBody: validBody,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().PostSpecies(gomock.Any(), mockRequest).
+					Return(generated.SpeciesResponse{}, http.StatusBadRequest, errors.New("harvest_cycle must be between 1 and 1200 months"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("harvest_cycle must be between 1 and 1200 months"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPost, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.PostSpecies(c)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusCreated {
+				var resp generated.SpeciesResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, generated.SpeciesResponse{Id: &mockUUID}, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -4,6 +4,11 @@ import (
 	"spgo/service"
 )
 
+const (
+	mimeGeoJSON       = "application/geo+json"
+	headerSortieCount = "X-Sortie-Count"
+)
+
 type Server struct {
 	Service service.ServiceInterface
 }
@@ -0,0 +1,31 @@
+package handler
+
+import (
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	openapi_types "github.com/oapi-codegen/runtime/types"
+
+	"spgo/generated"
+)
+
+// maxElevationGridBytes bounds the size of an uploaded elevation grid, enough for its most cells written in full.
+const maxElevationGridBytes = 32 << 20
+
+func (s *Server) SetEstateElevation(ctx echo.Context, id openapi_types.UUID, params generated.SetEstateElevationParams) error {
+	grid, err := io.ReadAll(io.LimitReader(ctx.Request().Body, maxElevationGridBytes+1))
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+	if len(grid) > maxElevationGridBytes {
+		return ctx.JSON(http.StatusRequestEntityTooLarge, map[string]string{"error": "elevation grid is too large"})
+	}
+
+	resp, httpStatus, err := s.Service.SetEstateElevation(ctx.Request().Context(), id, grid, params)
+	if err != nil {
+		return ctx.JSON(httpStatus, map[string]string{"error": err.Error()})
+	}
+
+	return ctx.JSON(http.StatusOK, resp)
+}
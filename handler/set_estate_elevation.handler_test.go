@@ -0,0 +1,117 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestSetEstateElevation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	lowest, highest := 120.0, 130.0
+	cellSize := 5.0
+	mockResponse := generated.ElevationResponse{
+		Lowest:   &lowest,
+		Highest:  &highest,
+		Relief:   ptrInt(10),
+		Distance: ptrInt(60),
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    []byte
+		params         generated.SetEstateElevationParams
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: []byte("130,120,130\n"),
+			params:      generated.SetEstateElevationParams{CellSize: &cellSize},
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateElevation(gomock.Any(), mockUUID, []byte("130,120,130\n"), generated.SetEstateElevationParams{CellSize: &cellSize}).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Grid Too Large",
+			requestBody:    bytes.Repeat([]byte("1,"), 16<<20+1),
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusRequestEntityTooLarge,
+			expectedError:  ptr("elevation grid is too large"),
+		},
+		{
+			name:        "Invalid Grid",
+			requestBody: []byte("130,x\n"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateElevation(gomock.Any(), mockUUID, gomock.Any(), gomock.Any()).
+					Return(generated.ElevationResponse{}, http.StatusBadRequest, errors.New(`elevation grid value "x" is not a number`))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr(`elevation grid value "x" is not a number`),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: []byte("0\n"),
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateElevation(gomock.Any(), mockUUID, gomock.Any(), gomock.Any()).
+					Return(generated.ElevationResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, "text/csv")
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.SetEstateElevation(c, mockUUID, tc.params)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.ElevationResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
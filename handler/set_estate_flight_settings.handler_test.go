@@ -0,0 +1,115 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestSetEstateFlightSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	plotSize := 10.0
+	mockResponse := generated.FlightSettingsResponse{
+		PlotSize:          &plotSize,
+		Clearance:         ptrInt(2),
+		MinCruiseAltitude: ptrInt(5),
+		StartEndAltitude:  ptrInt(0),
+		Distance:          ptrInt(120),
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"clearance": 2, "min_cruise_altitude": 5}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateFlightSettings(gomock.Any(), mockUUID, generated.FlightSettings{Clearance: ptrInt(2), MinCruiseAltitude: ptrInt(5)}).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"clearance": "invalid"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:        "Clearance Out Of Range",
+			requestBody: `{"clearance": 101}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateFlightSettings(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.FlightSettingsResponse{}, http.StatusBadRequest, errors.New("clearance must be between 0 and 100"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("clearance must be between 0 and 100"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateFlightSettings(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.FlightSettingsResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.SetEstateFlightSettings(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.FlightSettingsResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
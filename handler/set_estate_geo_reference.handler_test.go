@@ -0,0 +1,112 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestSetEstateGeoReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	latitude := -6.2
+	longitude := 106.8
+	bearing := 0.0
+	plotSize := 10.0
+	mockResponse := generated.GeoReference{Latitude: &latitude, Longitude: &longitude, Bearing: &bearing, PlotSize: &plotSize}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"latitude": -6.2, "longitude": 106.8}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateGeoReference(gomock.Any(), mockUUID, generated.GeoReference{Latitude: &latitude, Longitude: &longitude}).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"latitude": "invalid"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:        "Missing Anchor",
+			requestBody: `{"bearing": 15}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateGeoReference(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.GeoReference{}, http.StatusBadRequest, errors.New("latitude and longitude are required"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("latitude and longitude are required"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{"latitude": -6.2, "longitude": 106.8}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateGeoReference(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.GeoReference{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.SetEstateGeoReference(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.GeoReference
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
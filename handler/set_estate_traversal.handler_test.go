@@ -0,0 +1,114 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestSetEstateTraversal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	spiral := generated.Spiral
+	xMaxYMin := generated.XMaxYMin
+	mockResponse := generated.TraversalResponse{
+		Pattern:     &spiral,
+		StartCorner: &xMaxYMin,
+		Distance:    ptrInt(120),
+	}
+
+	e := echo.New()
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"pattern": "spiral", "start_corner": "x_max_y_min"}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateTraversal(gomock.Any(), mockUUID, generated.Traversal{Pattern: &spiral, StartCorner: &xMaxYMin}).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"pattern": 1}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:        "Invalid Pattern",
+			requestBody: `{"pattern": "zigzag"}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateTraversal(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.TraversalResponse{}, http.StatusBadRequest, errors.New("traversal_pattern must be one of row_serpentine, column_serpentine or spiral"))
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("traversal_pattern must be one of row_serpentine, column_serpentine or spiral"),
+		},
+		{
+			name:        "Estate Not Found",
+			requestBody: `{}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetEstateTraversal(gomock.Any(), mockUUID, gomock.Any()).
+					Return(generated.TraversalResponse{}, http.StatusNotFound, errors.New("estate not found"))
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedError:  ptr("estate not found"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewBufferString(tc.requestBody))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.SetEstateTraversal(c, mockUUID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.TraversalResponse
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package handler_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/handler"
+	"spgo/service"
+)
+
+func TestSetWorkOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockOrderID := uuid.New()
+	mockTreeID := uuid.New()
+	remove, completed := generated.Remove, generated.Completed
+	mockRequest := generated.WorkOrderStatusRequest{Status: generated.Completed}
+	mockResponse := generated.WorkOrder{Id: &mockOrderID, Kind: &remove, Status: &completed, RemovedTreeIds: &[]uuid.UUID{mockTreeID}}
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	tests := []struct {
+		name           string
+		requestBody    string
+		prepareMock    func(mockService *service.MockServiceInterface)
+		expectedStatus int
+		expectedError  *string
+	}{
+		{
+			name:        "Valid Request",
+			requestBody: `{"status": "completed"}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetWorkOrderStatus(gomock.Any(), mockEstateID, mockOrderID, mockRequest).
+					Return(mockResponse, http.StatusOK, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid JSON",
+			requestBody:    `{"status": 3}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Invalid request"),
+		},
+		{
+			name:           "Missing Status",
+			requestBody:    `{"assignee": "Budi"}`,
+			prepareMock:    func(mockService *service.MockServiceInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedError:  ptr("Key: 'WorkOrderStatusRequest.Status' Error:Field validation for 'Status' failed"),
+		},
+		{
+			name:        "Final Status",
+			requestBody: `{"status": "completed"}`,
+			prepareMock: func(mockService *service.MockServiceInterface) {
+				mockService.EXPECT().SetWorkOrderStatus(gomock.Any(), mockEstateID, mockOrderID, mockRequest).
+					Return(generated.WorkOrder{}, http.StatusConflict, errors.New("work order cannot move from cancelled to completed"))
+			},
+			expectedStatus: http.StatusConflict,
+			expectedError:  ptr("work order cannot move from cancelled to completed"),
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			mockService := service.NewMockServiceInterface(ctrl)
+
+			tc.prepareMock(mockService)
+
+			server := handler.NewServer(handler.NewServerOptions{Service: mockService})
+
+			req := httptest.NewRequest(http.MethodPut, "/", bytes.NewReader([]byte(tc.requestBody)))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			c := e.NewContext(req, rec)
+
+			err := server.SetWorkOrderStatus(c, mockEstateID, mockOrderID)
+			assert.NoError(t, err)
+
+			assert.Equal(t, tc.expectedStatus, rec.Code)
+
+			if tc.expectedStatus == http.StatusOK {
+				var resp generated.WorkOrder
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				assert.Equal(t, mockResponse, resp)
+			} else {
+				var resp map[string]string
+				err2 := json.Unmarshal(rec.Body.Bytes(), &resp)
+				assert.NoError(t, err2)
+				if tc.expectedError != nil {
+					assert.Contains(t, resp["error"], *tc.expectedError)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// DeletePlots removes trees of an estate at removedAt. Their measurements and inspections are kept, the tree is
+// copied on its measurements so the stats before its removal still count it, and their harvests are kept without tree.
+func (r *Repository) DeletePlots(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID, removedAt time.Time) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query := `
+        UPDATE tree_measurements m
+        SET x = p.x, y = p.y, species_id = p.species_id, health_status = p.health_status, removed_at = ?
+        FROM plots p
+        WHERE p.id = m.plot_id AND p.estate_id = ? AND p.id IN ?`
+	if err := tx.WithContext(ctx).Exec(query, removedAt, estateId, ids).Error; err != nil {
+		return err
+	}
+
+	return tx.WithContext(ctx).
+		Where("estate_id = ? AND id IN ?", estateId, ids).
+		Delete(&PlotEntity{}).Error
+}
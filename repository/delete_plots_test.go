@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_DeletePlots(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockEstateID = uuid.New()
+		mockIDs      = []uuid.UUID{uuid.New(), uuid.New()}
+		mockTime     = time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+		removeQuery = `UPDATE tree_measurements m SET x = p.x, y = p.y, species_id = p.species_id, health_status = p.health_status, removed_at = $1 FROM plots p WHERE p.id = m.plot_id AND p.estate_id = $2 AND p.id IN ($3,$4)`
+		query       = `DELETE FROM "plots" WHERE estate_id = $1 AND id IN ($2,$3)`
+	)
+
+	tests := []struct {
+		name        string
+		ids         []uuid.UUID
+		expectedErr error
+		prepareMock func()
+	}{
+		{
+			name:        "Successful Delete",
+			ids:         mockIDs,
+			expectedErr: nil,
+			prepareMock: func() {
+				mock.ExpectExec(regexp.QuoteMeta(removeQuery)).
+					WithArgs(mockTime, mockEstateID, mockIDs[0], mockIDs[1]).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(mockEstateID, mockIDs[0], mockIDs[1]).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "No Tree",
+			ids:         nil,
+			expectedErr: nil,
+			prepareMock: func() {},
+		},
+		{
+			name:        "Measurements Error",
+			ids:         mockIDs,
+			expectedErr: sql.ErrConnDone,
+			prepareMock: func() {
+				mock.ExpectExec(regexp.QuoteMeta(removeQuery)).
+					WithArgs(mockTime, mockEstateID, mockIDs[0], mockIDs[1]).
+					WillReturnError(sql.ErrConnDone)
+			},
+		},
+		{
+			name:        "Delete Error",
+			ids:         mockIDs,
+			expectedErr: sql.ErrConnDone,
+			prepareMock: func() {
+				mock.ExpectExec(regexp.QuoteMeta(removeQuery)).
+					WithArgs(mockTime, mockEstateID, mockIDs[0], mockIDs[1]).
+					WillReturnResult(sqlmock.NewResult(0, 3))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(mockEstateID, mockIDs[0], mockIDs[1]).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			err = repo.DeletePlots(context.Background(), mockEstateID, tt.ids, mockTime)
+			assert.Equal(t, tt.expectedErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
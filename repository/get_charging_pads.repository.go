@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetChargingPads(ctx context.Context, estateId uuid.UUID) ([]ChargingPadEntity, error) {
+	var pads []ChargingPadEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("estate_id = ?", estateId).
+		Order("created_at asc").
+		Find(&pads).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return pads, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetDroneProfile(ctx context.Context, id uuid.UUID) (DroneProfileEntity, error) {
+	var profile DroneProfileEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	if err := tx.WithContext(ctx).Where("id = ?", id).First(&profile).Error; err != nil {
+		return DroneProfileEntity{}, err
+	}
+	return profile, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetEstateElevation(ctx context.Context, estateId uuid.UUID) (EstateElevationEntity, error) {
+	var elevation EstateElevationEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	if err := tx.WithContext(ctx).Where("estate_id = ?", estateId).First(&elevation).Error; err != nil {
+		return EstateElevationEntity{}, err
+	}
+	return elevation, nil
+}
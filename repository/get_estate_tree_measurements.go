@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetEstateTreeMeasurements(ctx context.Context, estateId uuid.UUID) ([]TreeMeasurementEntity, error) {
+	var measurements []TreeMeasurementEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("estate_id = ?", estateId).
+		Order("plot_id asc, measured_at asc").
+		Find(&measurements).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
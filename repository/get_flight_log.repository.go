@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetFlightLog(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (FlightLogEntity, error) {
+	var flightLog FlightLogEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Preload("Samples", func(db *gorm.DB) *gorm.DB {
+			return db.Order("sequence asc")
+		}).
+		Where("estate_id = ? AND id = ?", estateId, id).
+		First(&flightLog).Error
+
+	if err != nil {
+		return FlightLogEntity{}, err
+	}
+	return flightLog, nil
+}
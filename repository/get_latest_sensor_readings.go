@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetLatestSensorReadings returns the last reading of every sensor of an estate of kind, of any kind when it is nil, in
+the order of their plots. The sensors never read are left out. The last reading is looked up per sensor so only the
+newest partition holding its readings is read.
+*/
+func (r *Repository) GetLatestSensorReadings(ctx context.Context, estateID uuid.UUID, kind *string) ([]LatestSensorReading, error) {
+	var readings []LatestSensorReading
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query := `
+        SELECT s.id AS sensor_id, s.kind, s.x, s.y, r.value, r.recorded_at
+        FROM sensors s
+        CROSS JOIN LATERAL (
+            SELECT value, recorded_at
+            FROM sensor_readings
+            WHERE sensor_id = s.id
+            ORDER BY recorded_at DESC
+            LIMIT 1
+        ) r
+        WHERE s.estate_id = ?`
+	args := []interface{}{estateID}
+
+	if kind != nil {
+		query += ` AND s.kind = ?`
+		args = append(args, *kind)
+	}
+	query += `
+        ORDER BY s.x, s.y, s.kind`
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&readings).Error; err != nil {
+		return nil, err
+	}
+	return readings, nil
+}
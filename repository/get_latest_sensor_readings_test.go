@@ -0,0 +1,61 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetLatestSensorReadings(t *testing.T) {
+	mockEstateID := uuid.New()
+	mockSensorID := uuid.New()
+	recordedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	kind := "temperature"
+
+	tests := []struct {
+		name  string
+		kind  *string
+		query string
+		args  []driver.Value
+	}{
+		{
+			name:  "Every Kind",
+			query: `CROSS JOIN LATERAL \(\s+SELECT value, recorded_at\s+FROM sensor_readings\s+WHERE sensor_id = s\.id\s+ORDER BY recorded_at DESC\s+LIMIT 1\s+\) r\s+WHERE s\.estate_id = \$1\s+ORDER BY s\.x, s\.y, s\.kind`,
+			args:  []driver.Value{mockEstateID},
+		},
+		{
+			name:  "Temperature Only",
+			kind:  &kind,
+			query: `WHERE s\.estate_id = \$1 AND s\.kind = \$2\s+ORDER BY s\.x, s\.y, s\.kind`,
+			args:  []driver.Value{mockEstateID, kind},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"sensor_id", "kind", "x", "y", "value", "recorded_at"}).
+					AddRow(mockSensorID, kind, 3, 2, 24.5, recordedAt))
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			readings, err := repo.GetLatestSensorReadings(context.Background(), mockEstateID, tt.kind)
+			require.NoError(t, err)
+			assert.Equal(t, []LatestSensorReading{{SensorId: mockSensorID, Kind: kind, X: 3, Y: 2, Value: 24.5, RecordedAt: recordedAt}}, readings)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
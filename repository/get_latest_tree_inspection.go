@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetLatestTreeInspection(ctx context.Context, plotId uuid.UUID) (*TreeInspectionEntity, error) {
+	var inspection *TreeInspectionEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("plot_id = ?", plotId).
+		Order("inspected_at desc").
+		First(&inspection).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return inspection, nil
+}
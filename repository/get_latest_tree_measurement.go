@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetLatestTreeMeasurement(ctx context.Context, plotId uuid.UUID) (*TreeMeasurementEntity, error) {
+	var measurement *TreeMeasurementEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("plot_id = ?", plotId).
+		Order("measured_at desc").
+		First(&measurement).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return measurement, nil
+}
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetObstacles(ctx context.Context, estateId uuid.UUID) ([]ObstacleEntity, error) {
+	var obstacles []ObstacleEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Preload("Areas").
+		Where("estate_id = ?", estateId).
+		Order("created_at asc").
+		Find(&obstacles).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return obstacles, nil
+}
@@ -0,0 +1,20 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetPlotByID(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (*PlotEntity, error) {
+	var plot *PlotEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	if err := tx.WithContext(ctx).Where("estate_id = ? and id = ?", estateId, id).First(&plot).Error; err != nil {
+		return nil, err
+	}
+	return plot, nil
+}
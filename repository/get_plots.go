@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetPlots(ctx context.Context, estateId uuid.UUID) ([]PlotEntity, error) {
+	var plots []PlotEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("estate_id = ?", estateId).
+		Order("order_number asc").
+		Find(&plots).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return plots, nil
+}
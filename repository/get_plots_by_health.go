@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetPlotsByHealth returns the trees of an estate by order number with one of the health statuses, and the trees never
+inspected when uninspected is set.
+*/
+func (r *Repository) GetPlotsByHealth(ctx context.Context, estateId uuid.UUID, statuses []string, uninspected bool) ([]PlotEntity, error) {
+	var plots []PlotEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query := tx.WithContext(ctx).Where("estate_id = ?", estateId)
+	switch {
+	case len(statuses) > 0 && uninspected:
+		query = query.Where("health_status IN ? OR health_status IS NULL", statuses)
+	case len(statuses) > 0:
+		query = query.Where("health_status IN ?", statuses)
+	case uninspected:
+		query = query.Where("health_status IS NULL")
+	}
+
+	err := query.
+		Order("order_number asc").
+		Find(&plots).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return plots, nil
+}
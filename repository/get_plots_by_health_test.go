@@ -0,0 +1,72 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetPlotsByHealth(t *testing.T) {
+	mockEstateID := uuid.New()
+
+	tests := []struct {
+		name        string
+		statuses    []string
+		uninspected bool
+		query       string
+		args        []driver.Value
+	}{
+		{
+			name:     "Statuses",
+			statuses: []string{"diseased", "dead"},
+			query:    `SELECT * FROM "plots" WHERE estate_id = $1 AND health_status IN ($2,$3) ORDER BY order_number asc`,
+			args:     []driver.Value{mockEstateID, "diseased", "dead"},
+		},
+		{
+			name:        "Statuses And Uninspected",
+			statuses:    []string{"healthy"},
+			uninspected: true,
+			query:       `SELECT * FROM "plots" WHERE estate_id = $1 AND (health_status IN ($2) OR health_status IS NULL) ORDER BY order_number asc`,
+			args:        []driver.Value{mockEstateID, "healthy"},
+		},
+		{
+			name:        "Uninspected",
+			uninspected: true,
+			query:       `SELECT * FROM "plots" WHERE estate_id = $1 AND health_status IS NULL ORDER BY order_number asc`,
+			args:        []driver.Value{mockEstateID},
+		},
+		{
+			name:  "All",
+			query: `SELECT * FROM "plots" WHERE estate_id = $1 ORDER BY order_number asc`,
+			args:  []driver.Value{mockEstateID},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "x", "y", "health_status"}).AddRow(uuid.New(), 1, 1, "dead"))
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			plots, err := repo.GetPlotsByHealth(context.Background(), mockEstateID, tt.statuses, tt.uninspected)
+			require.NoError(t, err)
+			assert.Len(t, plots, 1)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
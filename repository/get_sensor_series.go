@@ -0,0 +1,42 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// sensorBuckets are the date_trunc units the readings of a series may be aggregated in. Like the yield periods the
+// unit is written in the query so the expression grouped by is the selected one.
+var sensorBuckets = map[string]string{
+	"hour": "date_trunc('hour', r.recorded_at)",
+	"day":  "date_trunc('day', r.recorded_at)",
+	"week": "date_trunc('week', r.recorded_at)",
+}
+
+// GetSensorSeries aggregates the readings of the sensors of an estate selected by the filter per bucket, one of hour,
+// day and week, in the order of the buckets. The buckets without readings are left out.
+func (r *Repository) GetSensorSeries(ctx context.Context, estateID uuid.UUID, bucket string, filter SensorFilter) ([]SensorBucket, error) {
+	var buckets []SensorBucket
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	start := sensorBuckets[bucket]
+	from, args := sensorReadingsOf(estateID, filter)
+	query := `
+        SELECT
+            ` + start + ` AS start,
+            COUNT(*) AS readings,
+            MIN(r.value) AS min,
+            MAX(r.value) AS max,
+            AVG(r.value) AS mean` + from + `
+        GROUP BY ` + start + `
+        ORDER BY ` + start
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&buckets).Error; err != nil {
+		return nil, err
+	}
+	return buckets, nil
+}
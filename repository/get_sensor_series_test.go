@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetSensorSeries(t *testing.T) {
+	mockEstateID := uuid.New()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	kind := "temperature"
+	x, y := 3, 2
+
+	tests := []struct {
+		name   string
+		bucket string
+		filter SensorFilter
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "Hourly Over The Estate",
+			bucket: "hour",
+			filter: SensorFilter{Kind: &kind, From: from, To: to},
+			query: `SELECT\s+date_trunc\('hour', r\.recorded_at\) AS start,.*` +
+				`WHERE s\.estate_id = \$1 AND r\.recorded_at >= \$2 AND r\.recorded_at < \$3 AND s\.kind = \$4\s+` +
+				`GROUP BY date_trunc\('hour', r\.recorded_at\)\s+ORDER BY date_trunc\('hour', r\.recorded_at\)$`,
+			args: []driver.Value{mockEstateID, from, to, kind},
+		},
+		{
+			name:   "Daily On A Plot",
+			bucket: "day",
+			filter: SensorFilter{Kind: &kind, X: &x, Y: &y, From: from, To: to},
+			query: `SELECT\s+date_trunc\('day', r\.recorded_at\) AS start,.*` +
+				`AND s\.kind = \$4 AND s\.x = \$5 AND s\.y = \$6\s+GROUP BY date_trunc\('day', r\.recorded_at\)`,
+			args: []driver.Value{mockEstateID, from, to, kind, x, y},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"start", "readings", "min", "max", "mean"}).
+					AddRow(from, 24, 18.5, 31.0, 24.25))
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			buckets, err := repo.GetSensorSeries(context.Background(), mockEstateID, tt.bucket, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, []SensorBucket{{Start: from, Readings: 24, Min: 18.5, Max: 31, Mean: 24.25}}, buckets)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
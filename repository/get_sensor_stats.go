@@ -0,0 +1,54 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// sensorReadingsOf selects the readings of the sensors of an estate matched by the filter, the range of recorded_at
+// limits the partitions read.
+func sensorReadingsOf(estateID uuid.UUID, filter SensorFilter) (string, []interface{}) {
+	query := `
+        FROM sensor_readings r
+        JOIN sensors s ON s.id = r.sensor_id
+        WHERE s.estate_id = ? AND r.recorded_at >= ? AND r.recorded_at < ?`
+	args := []interface{}{estateID, filter.From, filter.To}
+
+	if filter.Kind != nil {
+		query += ` AND s.kind = ?`
+		args = append(args, *filter.Kind)
+	}
+	if filter.X != nil && filter.Y != nil {
+		query += ` AND s.x = ? AND s.y = ?`
+		args = append(args, *filter.X, *filter.Y)
+	}
+	return query, args
+}
+
+// GetSensorStats aggregates the readings of the sensors of an estate selected by the filter per kind, the kinds
+// without readings are left out.
+func (r *Repository) GetSensorStats(ctx context.Context, estateID uuid.UUID, filter SensorFilter) ([]SensorStats, error) {
+	var stats []SensorStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	from, args := sensorReadingsOf(estateID, filter)
+	query := `
+        SELECT
+            s.kind,
+            COUNT(DISTINCT r.sensor_id) AS sensors,
+            COUNT(*) AS readings,
+            MIN(r.value) AS min,
+            MAX(r.value) AS max,
+            AVG(r.value) AS mean` + from + `
+        GROUP BY s.kind
+        ORDER BY s.kind`
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetSensorStats(t *testing.T) {
+	mockEstateID := uuid.New()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(24 * time.Hour)
+	kind := "soil_moisture"
+
+	tests := []struct {
+		name   string
+		filter SensorFilter
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "Every Kind",
+			filter: SensorFilter{From: from, To: to},
+			query: `COUNT\(DISTINCT r\.sensor_id\) AS sensors,.*FROM sensor_readings r\s+JOIN sensors s ON s\.id = r\.sensor_id\s+` +
+				`WHERE s\.estate_id = \$1 AND r\.recorded_at >= \$2 AND r\.recorded_at < \$3\s+GROUP BY s\.kind\s+ORDER BY s\.kind`,
+			args: []driver.Value{mockEstateID, from, to},
+		},
+		{
+			name:   "Soil Moisture Only",
+			filter: SensorFilter{Kind: &kind, From: from, To: to},
+			query:  `WHERE s\.estate_id = \$1 AND r\.recorded_at >= \$2 AND r\.recorded_at < \$3 AND s\.kind = \$4\s+GROUP BY s\.kind`,
+			args:   []driver.Value{mockEstateID, from, to, kind},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"kind", "sensors", "readings", "min", "max", "mean"}).
+					AddRow(kind, 2, 48, 18.5, 42.0, 30.25))
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			stats, err := repo.GetSensorStats(context.Background(), mockEstateID, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, []SensorStats{{Kind: kind, Sensors: 2, Readings: 48, Min: 18.5, Max: 42, Mean: 30.25}}, stats)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
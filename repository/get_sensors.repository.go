@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetSensors(ctx context.Context, estateId uuid.UUID) ([]SensorEntity, error) {
+	var sensors []SensorEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("estate_id = ?", estateId).
+		Order("created_at asc").
+		Find(&sensors).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
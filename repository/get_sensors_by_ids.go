@@ -0,0 +1,28 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// GetSensorsByIds returns the sensors of an estate among ids, the ids of other estates or of no sensor are left out.
+func (r *Repository) GetSensorsByIds(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID) ([]SensorEntity, error) {
+	var sensors []SensorEntity
+	if len(ids) == 0 {
+		return sensors, nil
+	}
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("estate_id = ? AND id IN ?", estateId, ids).
+		Find(&sensors).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return sensors, nil
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetSensorsByIds(t *testing.T) {
+	mockEstateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "sensors" WHERE estate_id = $1 AND id IN ($2,$3)`)).
+		WithArgs(mockEstateID, firstID, secondID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "kind", "x", "y"}).
+			AddRow(firstID, mockEstateID, "temperature", 3, 2))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	sensors, err := repo.GetSensorsByIds(context.Background(), mockEstateID, []uuid.UUID{firstID, secondID})
+	require.NoError(t, err)
+	assert.Equal(t, []SensorEntity{{ID: firstID, EstateId: mockEstateID, Kind: "temperature", X: 3, Y: 2}}, sensors)
+
+	// without ids there is nothing to look up
+	sensors, err = repo.GetSensorsByIds(context.Background(), mockEstateID, nil)
+	require.NoError(t, err)
+	assert.Empty(t, sensors)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetSpecies(ctx context.Context, id uuid.UUID) (SpeciesEntity, error) {
+	var species SpeciesEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	if err := tx.WithContext(ctx).Where("id = ?", id).First(&species).Error; err != nil {
+		return SpeciesEntity{}, err
+	}
+	return species, nil
+}
+
+// GetSpeciesCatalog returns every species of the catalog by name.
+func (r *Repository) GetSpeciesCatalog(ctx context.Context) ([]SpeciesEntity, error) {
+	var catalog []SpeciesEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	if err := tx.WithContext(ctx).Order("name, id").Find(&catalog).Error; err != nil {
+		return nil, err
+	}
+	return catalog, nil
+}
@@ -0,0 +1,80 @@
+package repository
+
+import (
+	"context"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetTreeHeightDistribution computes the extended tree height stats of an estate in the database: the summary with the
+mean and population standard deviation, the interpolated height at every requested fraction (between 0 and 1) and the
+tree count per 1 meter bucket, for the trees selected by the filter.
+*/
+func (r *Repository) GetTreeHeightDistribution(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, fractions []float64) (TreeHeightDistribution, error) {
+	var distribution TreeHeightDistribution
+
+	tx := util.GetTxFromContext(ctx, r.Db).WithContext(ctx)
+	heights, args := treeHeightsQuery(estateID, filter)
+
+	summaryQuery := heights + `
+        SELECT
+            COUNT(*) AS count,
+            COALESCE(MIN(height), 0) AS min,
+            COALESCE(MAX(height), 0) AS max,
+            COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY height), 0) AS median,
+            COALESCE(AVG(height), 0) AS mean,
+            COALESCE(stddev_pop(height), 0) AS std_dev
+        FROM TreeHeights
+    `
+	if err := tx.Raw(summaryQuery, args...).Scan(&distribution).Error; err != nil {
+		return TreeHeightDistribution{}, err
+	}
+
+	if distribution.Count == 0 {
+		return distribution, nil
+	}
+
+	if len(fractions) > 0 {
+		values := make([]string, len(fractions))
+		percentileArgs := append([]interface{}{}, args...)
+		for i, fraction := range fractions {
+			values[i] = "(?::float8)"
+			percentileArgs = append(percentileArgs, fraction)
+		}
+
+		percentileQuery := heights + `
+        SELECT
+            f.fraction,
+            percentile_cont(f.fraction) WITHIN GROUP (ORDER BY h.height) AS height
+        FROM TreeHeights h
+        CROSS JOIN (VALUES ` + strings.Join(values, ", ") + `) AS f(fraction)
+        GROUP BY f.fraction
+        ORDER BY f.fraction
+    `
+		if err := tx.Raw(percentileQuery, percentileArgs...).Scan(&distribution.Percentiles).Error; err != nil {
+			return TreeHeightDistribution{}, err
+		}
+	}
+
+	// buckets are 1 meter wide between the lowest and the highest tree, the upper bound is exclusive
+	// so the highest tree gets a bucket of its own instead of falling in the overflow bucket
+	lowest, highest := distribution.Min, distribution.Max+1
+	histogramQuery := heights + `
+        SELECT
+            ?::int + width_bucket(height::float8, ?::float8, ?::float8, ?::int) - 1 AS min_height,
+            COUNT(*) AS count
+        FROM TreeHeights
+        GROUP BY min_height
+        ORDER BY min_height
+    `
+	histogramArgs := append(append([]interface{}{}, args...), lowest, lowest, highest, highest-lowest)
+	if err := tx.Raw(histogramQuery, histogramArgs...).Scan(&distribution.Histogram).Error; err != nil {
+		return TreeHeightDistribution{}, err
+	}
+
+	return distribution, nil
+}
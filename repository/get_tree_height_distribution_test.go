@@ -0,0 +1,73 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetTreeHeightDistribution(t *testing.T) {
+	mockEstateID := uuid.New()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`stddev_pop\(height\)`).
+		WithArgs(mockEstateID).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "min", "max", "median", "mean", "std_dev"}).
+			AddRow(4, 3, 6, 4.5, 4.5, 1.118))
+	mock.ExpectQuery(`percentile_cont\(f.fraction\)`).
+		WithArgs(mockEstateID, 0.1, 0.9).
+		WillReturnRows(sqlmock.NewRows([]string{"fraction", "height"}).
+			AddRow(0.1, 3.3).
+			AddRow(0.9, 5.7))
+	mock.ExpectQuery(`width_bucket`).
+		WithArgs(mockEstateID, 3, 3, 7, 4).
+		WillReturnRows(sqlmock.NewRows([]string{"min_height", "count"}).
+			AddRow(3, 1).
+			AddRow(4, 1).
+			AddRow(5, 1).
+			AddRow(6, 1))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	distribution, err := repo.GetTreeHeightDistribution(context.Background(), mockEstateID, TreeHeightFilter{}, []float64{0.1, 0.9})
+	require.NoError(t, err)
+
+	assert.Equal(t, TreeHeightStats{Count: 4, Min: 3, Max: 6, Median: 4.5}, distribution.TreeHeightStats)
+	assert.Equal(t, 4.5, distribution.Mean)
+	assert.Equal(t, 1.118, distribution.StdDev)
+	assert.Equal(t, []TreeHeightPercentile{{Fraction: 0.1, Height: 3.3}, {Fraction: 0.9, Height: 5.7}}, distribution.Percentiles)
+	assert.Equal(t, []TreeHeightBucket{{MinHeight: 3, Count: 1}, {MinHeight: 4, Count: 1}, {MinHeight: 5, Count: 1}, {MinHeight: 6, Count: 1}}, distribution.Histogram)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRepository_GetTreeHeightDistribution_NoTrees(t *testing.T) {
+	mockEstateID := uuid.New()
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`stddev_pop\(height\)`).
+		WithArgs(mockEstateID).
+		WillReturnRows(sqlmock.NewRows([]string{"count", "min", "max", "median", "mean", "std_dev"}).
+			AddRow(0, 0, 0, 0, 0, 0))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	distribution, err := repo.GetTreeHeightDistribution(context.Background(), mockEstateID, TreeHeightFilter{}, []float64{0.5})
+	require.NoError(t, err)
+
+	assert.Equal(t, TreeHeightDistribution{}, distribution)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// treeHeightsQuery returns a CTE named TreeHeights with the plot coordinates, species, health status and one height per
+// tree of the estate.
+// Without filter.AsOf it reads the current heights from plots, otherwise every tree's latest measurement at or
+// before that time, a tree removed since is taken from its measurements. filter.Region limits the trees to the plots
+// inside the rectangle.
+func treeHeightsQuery(estateID uuid.UUID, filter TreeHeightFilter) (string, []interface{}) {
+	var query string
+	var args []interface{}
+
+	x, y := "x", "y"
+	if filter.AsOf == nil {
+		query = `
+        WITH TreeHeights AS (
+            SELECT x, y, tree_height AS height, species_id, health_status
+            FROM plots
+            WHERE estate_id = ?`
+		args = []interface{}{estateID}
+	} else {
+		x, y = "COALESCE(p.x, m.x)", "COALESCE(p.y, m.y)"
+		query = `
+        WITH TreeHeights AS (
+            SELECT DISTINCT ON (m.plot_id)
+                ` + x + ` AS x, ` + y + ` AS y, m.height,
+                COALESCE(p.species_id, m.species_id) AS species_id,
+                COALESCE(p.health_status, m.health_status) AS health_status
+            FROM tree_measurements m
+            LEFT JOIN plots p ON p.id = m.plot_id
+            WHERE m.estate_id = ? AND m.measured_at <= ? AND (m.removed_at IS NULL OR m.removed_at > ?)`
+		args = []interface{}{estateID, *filter.AsOf, *filter.AsOf}
+	}
+
+	if filter.Region != nil {
+		query += ` AND ` + x + ` BETWEEN ? AND ? AND ` + y + ` BETWEEN ? AND ?`
+		args = append(args, filter.Region.MinX, filter.Region.MaxX, filter.Region.MinY, filter.Region.MaxY)
+	}
+
+	if filter.AsOf != nil {
+		query += `
+            ORDER BY m.plot_id, m.measured_at DESC`
+	}
+
+	return query + `
+        )`, args
+}
+
+// GetTreeHeightStats aggregates the current tree heights of an estate.
+func (r *Repository) GetTreeHeightStats(ctx context.Context, estateID uuid.UUID) (TreeHeightStats, error) {
+	return r.GetFilteredTreeHeightStats(ctx, estateID, TreeHeightFilter{})
+}
+
+// GetTreeHeightStatsAsOf aggregates the tree heights of an estate as they were at asOf,
+// taking for every tree its latest measurement at or before that time.
+func (r *Repository) GetTreeHeightStatsAsOf(ctx context.Context, estateID uuid.UUID, asOf time.Time) (TreeHeightStats, error) {
+	return r.GetFilteredTreeHeightStats(ctx, estateID, TreeHeightFilter{AsOf: &asOf})
+}
+
+// GetFilteredTreeHeightStats aggregates the tree heights of an estate selected by the filter.
+func (r *Repository) GetFilteredTreeHeightStats(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) (TreeHeightStats, error) {
+	var stats TreeHeightStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query, args := treeHeightsQuery(estateID, filter)
+	query += `
+        SELECT
+            COUNT(*) AS count,
+            COALESCE(MIN(height), 0) AS min,
+            COALESCE(MAX(height), 0) AS max,
+            COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY height), 0) AS median
+        FROM TreeHeights
+    `
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return TreeHeightStats{}, err
+	}
+	return stats, nil
+}
@@ -0,0 +1,38 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetTreeHeightStatsByHealth aggregates the tree heights selected by the filter per health status of the trees, from
+healthy to dead. The trees never inspected are aggregated together last. With filter.AsOf the trees keep their current
+health status.
+*/
+func (r *Repository) GetTreeHeightStatsByHealth(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]HealthTreeHeightStats, error) {
+	var stats []HealthTreeHeightStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query, args := treeHeightsQuery(estateID, filter)
+	query += `
+        SELECT
+            health_status,
+            COUNT(*) AS count,
+            MIN(height) AS min,
+            MAX(height) AS max,
+            percentile_cont(0.5) WITHIN GROUP (ORDER BY height) AS median
+        FROM TreeHeights
+        GROUP BY health_status
+        ORDER BY array_position(ARRAY['healthy', 'stressed', 'diseased', 'dead']::varchar[], health_status) NULLS LAST
+    `
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
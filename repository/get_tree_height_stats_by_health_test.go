@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetTreeHeightStatsByHealth(t *testing.T) {
+	mockEstateID := uuid.New()
+	status := "dead"
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`health_status\s+FROM plots.*GROUP BY health_status`).
+		WithArgs(mockEstateID).
+		WillReturnRows(sqlmock.NewRows([]string{"health_status", "count", "min", "max", "median"}).
+			AddRow(status, 2, 6, 18, 12).
+			AddRow(nil, 1, 4, 4, 4))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	stats, err := repo.GetTreeHeightStatsByHealth(context.Background(), mockEstateID, TreeHeightFilter{})
+	require.NoError(t, err)
+
+	assert.Equal(t, []HealthTreeHeightStats{
+		{HealthStatus: &status, TreeHeightStats: TreeHeightStats{Count: 2, Min: 6, Max: 18, Median: 12}},
+		{TreeHeightStats: TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4}},
+	}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
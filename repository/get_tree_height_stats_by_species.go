@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetTreeHeightStatsBySpecies aggregates the tree heights selected by the filter per species, by species name. The
+trees without species are aggregated together last.
+*/
+func (r *Repository) GetTreeHeightStatsBySpecies(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]SpeciesTreeHeightStats, error) {
+	var stats []SpeciesTreeHeightStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query, args := treeHeightsQuery(estateID, filter)
+	query += `
+        SELECT
+            h.species_id,
+            s.name,
+            COUNT(*) AS count,
+            MIN(h.height) AS min,
+            MAX(h.height) AS max,
+            percentile_cont(0.5) WITHIN GROUP (ORDER BY h.height) AS median
+        FROM TreeHeights h
+        LEFT JOIN species s ON s.id = h.species_id
+        GROUP BY h.species_id, s.name
+        ORDER BY s.name NULLS LAST, h.species_id
+    `
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
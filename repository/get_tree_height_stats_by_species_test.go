@@ -0,0 +1,43 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetTreeHeightStatsBySpecies(t *testing.T) {
+	mockEstateID := uuid.New()
+	mockSpeciesID := uuid.New()
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	name := "Oil palm"
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`p\.species_id.*m\.measured_at <= \$2.*LEFT JOIN species s ON s\.id = h\.species_id`).
+		WithArgs(mockEstateID, asOf, asOf).
+		WillReturnRows(sqlmock.NewRows([]string{"species_id", "name", "count", "min", "max", "median"}).
+			AddRow(mockSpeciesID, name, 2, 6, 18, 12).
+			AddRow(nil, nil, 1, 4, 4, 4))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	stats, err := repo.GetTreeHeightStatsBySpecies(context.Background(), mockEstateID, TreeHeightFilter{AsOf: &asOf})
+	require.NoError(t, err)
+
+	assert.Equal(t, []SpeciesTreeHeightStats{
+		{SpeciesId: &mockSpeciesID, Name: &name, TreeHeightStats: TreeHeightStats{Count: 2, Min: 6, Max: 18, Median: 12}},
+		{TreeHeightStats: TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4}},
+	}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+/*
+GetTreeHeightStatsByTile aggregates the tree heights selected by the filter per tile of tileWidth x tileLength plots.
+Tiles are aligned on plot (1,1), so tile (0,0) covers x 1..tileWidth and y 1..tileLength. Only tiles holding at least
+one tree are returned, ordered by row then column.
+*/
+func (r *Repository) GetTreeHeightStatsByTile(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, tileWidth int, tileLength int) ([]TileTreeHeightStats, error) {
+	var stats []TileTreeHeightStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query, args := treeHeightsQuery(estateID, filter)
+	query += `
+        SELECT
+            (x - 1) / ? AS tile_x,
+            (y - 1) / ? AS tile_y,
+            COUNT(*) AS count,
+            MIN(height) AS min,
+            MAX(height) AS max,
+            percentile_cont(0.5) WITHIN GROUP (ORDER BY height) AS median,
+            AVG(height) AS mean
+        FROM TreeHeights
+        GROUP BY tile_x, tile_y
+        ORDER BY tile_y, tile_x
+    `
+	args = append(args, tileWidth, tileLength)
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
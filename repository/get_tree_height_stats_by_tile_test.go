@@ -0,0 +1,40 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetTreeHeightStatsByTile(t *testing.T) {
+	mockEstateID := uuid.New()
+	region := PlotRegion{MinX: 1, MinY: 1, MaxX: 20, MaxY: 30}
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+	require.NoError(t, err)
+
+	mock.ExpectQuery(`x BETWEEN \$2 AND \$3 AND y BETWEEN \$4 AND \$5.*\(x - 1\) / \$6 AS tile_x`).
+		WithArgs(mockEstateID, 1, 20, 1, 30, 10, 10).
+		WillReturnRows(sqlmock.NewRows([]string{"tile_x", "tile_y", "count", "min", "max", "median", "mean"}).
+			AddRow(0, 0, 2, 3, 7, 5, 5).
+			AddRow(1, 2, 1, 4, 4, 4, 4))
+
+	repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+	stats, err := repo.GetTreeHeightStatsByTile(context.Background(), mockEstateID, TreeHeightFilter{Region: &region}, 10, 10)
+	require.NoError(t, err)
+
+	assert.Equal(t, []TileTreeHeightStats{
+		{TileX: 0, TileY: 0, TreeHeightStats: TreeHeightStats{Count: 2, Min: 3, Max: 7, Median: 5}, Mean: 5},
+		{TileX: 1, TileY: 2, TreeHeightStats: TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4}, Mean: 4},
+	}, stats)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetFilteredTreeHeightStats(t *testing.T) {
+	mockEstateID := uuid.New()
+	asOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	columns := []string{"count", "min", "max", "median"}
+
+	tests := []struct {
+		name          string
+		filter        TreeHeightFilter
+		prepareMock   func(mock sqlmock.Sqlmock)
+		expectedStats TreeHeightStats
+		expectedErr   error
+	}{
+		{
+			name:   "Current Heights",
+			filter: TreeHeightFilter{},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`SELECT x, y, tree_height AS height, species_id, health_status\s+FROM plots\s+WHERE estate_id = \$1\s+\)`).
+					WithArgs(mockEstateID).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(2, 5, 10, 7.5))
+			},
+			expectedStats: TreeHeightStats{Count: 2, Min: 5, Max: 10, Median: 7.5},
+		},
+		{
+			// a tree removed after asOf is read from its measurements, its plot is gone
+			name:   "As Of Counts The Trees Removed Since",
+			filter: TreeHeightFilter{AsOf: &asOf},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM tree_measurements m\s+LEFT JOIN plots p ON p\.id = m\.plot_id\s+`+
+					`WHERE m\.estate_id = \$1 AND m\.measured_at <= \$2 AND \(m\.removed_at IS NULL OR m\.removed_at > \$3\)`).
+					WithArgs(mockEstateID, asOf, asOf).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(3, 4, 10, 6))
+			},
+			expectedStats: TreeHeightStats{Count: 3, Min: 4, Max: 10, Median: 6},
+		},
+		{
+			name:   "As Of Region Uses The Coordinates Of The Removed Trees",
+			filter: TreeHeightFilter{AsOf: &asOf, Region: &PlotRegion{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`COALESCE\(p\.x, m\.x\) BETWEEN \$4 AND \$5 AND COALESCE\(p\.y, m\.y\) BETWEEN \$6 AND \$7`).
+					WithArgs(mockEstateID, asOf, asOf, 1, 2, 1, 3).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(1, 4, 4, 4))
+			},
+			expectedStats: TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4},
+		},
+		{
+			name:   "Query Error",
+			filter: TreeHeightFilter{AsOf: &asOf},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery(`FROM tree_measurements m`).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			tt.prepareMock(mock)
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			stats, err := repo.GetFilteredTreeHeightStats(context.Background(), mockEstateID, tt.filter)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedStats, stats)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
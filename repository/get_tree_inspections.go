@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetTreeInspections(ctx context.Context, plotId uuid.UUID) ([]TreeInspectionEntity, error) {
+	var inspections []TreeInspectionEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("plot_id = ?", plotId).
+		Order("inspected_at asc").
+		Find(&inspections).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return inspections, nil
+}
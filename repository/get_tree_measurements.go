@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetTreeMeasurements(ctx context.Context, plotId uuid.UUID) ([]TreeMeasurementEntity, error) {
+	var measurements []TreeMeasurementEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Where("plot_id = ?", plotId).
+		Order("measured_at asc").
+		Find(&measurements).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return measurements, nil
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) GetWorkOrder(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (WorkOrderEntity, error) {
+	var workOrder WorkOrderEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	err := tx.WithContext(ctx).
+		Preload("Areas").
+		Where("estate_id = ? AND id = ?", estateId, id).
+		First(&workOrder).Error
+
+	if err != nil {
+		return WorkOrderEntity{}, err
+	}
+	return workOrder, nil
+}
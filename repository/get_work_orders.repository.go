@@ -0,0 +1,36 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// GetWorkOrders returns the work orders of an estate selected by the filter, oldest first.
+func (r *Repository) GetWorkOrders(ctx context.Context, estateId uuid.UUID, filter WorkOrderFilter) ([]WorkOrderEntity, error) {
+	var workOrders []WorkOrderEntity
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	query := tx.WithContext(ctx).Preload("Areas").Where("estate_id = ?", estateId)
+	if len(filter.Statuses) > 0 {
+		query = query.Where("status IN ?", filter.Statuses)
+	}
+	if filter.Kind != nil {
+		query = query.Where("kind = ?", *filter.Kind)
+	}
+	if filter.Assignee != nil {
+		query = query.Where("assignee = ?", *filter.Assignee)
+	}
+
+	err := query.
+		Order("created_at asc").
+		Find(&workOrders).Error
+
+	if err != nil {
+		return nil, err
+	}
+	return workOrders, nil
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetWorkOrders(t *testing.T) {
+	mockEstateID := uuid.New()
+	mockOrderID := uuid.New()
+	kind := "remove"
+	assignee := "Budi"
+
+	tests := []struct {
+		name   string
+		filter WorkOrderFilter
+		query  string
+		args   []driver.Value
+	}{
+		{
+			name:   "Open Orders",
+			filter: WorkOrderFilter{Statuses: []string{"open", "assigned"}},
+			query:  `SELECT * FROM "work_orders" WHERE estate_id = $1 AND status IN ($2,$3) ORDER BY created_at asc`,
+			args:   []driver.Value{mockEstateID, "open", "assigned"},
+		},
+		{
+			name:   "Kind And Assignee",
+			filter: WorkOrderFilter{Kind: &kind, Assignee: &assignee},
+			query:  `SELECT * FROM "work_orders" WHERE estate_id = $1 AND kind = $2 AND assignee = $3 ORDER BY created_at asc`,
+			args:   []driver.Value{mockEstateID, kind, assignee},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(regexp.QuoteMeta(tt.query)).
+				WithArgs(tt.args...).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "estate_id", "kind", "status", "shape"}).
+					AddRow(mockOrderID, mockEstateID, "remove", "open", "plots"))
+			mock.ExpectQuery(regexp.QuoteMeta(`SELECT * FROM "work_order_areas" WHERE "work_order_areas"."work_order_id" = $1`)).
+				WithArgs(mockOrderID).
+				WillReturnRows(sqlmock.NewRows([]string{"id", "work_order_id", "min_x", "min_y", "max_x", "max_y"}).
+					AddRow(uuid.New(), mockOrderID, 2, 3, 2, 3))
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			orders, err := repo.GetWorkOrders(context.Background(), mockEstateID, tt.filter)
+			require.NoError(t, err)
+			require.Len(t, orders, 1)
+			assert.Equal(t, mockOrderID, orders[0].ID)
+			assert.Equal(t, []WorkOrderAreaEntity{{ID: orders[0].Areas[0].ID, WorkOrderId: mockOrderID, MinX: 2, MinY: 3, MaxX: 2, MaxY: 3}}, orders[0].Areas)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
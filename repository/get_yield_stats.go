@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+// yieldGrouping is what GetYieldStats selects, groups and orders the harvests of a grouping by.
+type yieldGrouping struct {
+	columns []string
+	groupBy []string
+	orderBy []string
+}
+
+var yieldGroupings = map[string]yieldGrouping{
+	"estate":  {},
+	"tree":    {columns: []string{"t.plot_id AS tree_id", "t.x", "t.y"}, groupBy: []string{"t.plot_id", "t.x", "t.y"}, orderBy: []string{"t.x", "t.y", "t.plot_id NULLS LAST"}},
+	"row":     {columns: []string{"t.y"}, groupBy: []string{"t.y"}, orderBy: []string{"t.y"}},
+	"species": {columns: []string{"t.species_id", "s.name"}, groupBy: []string{"t.species_id", "s.name"}, orderBy: []string{"s.name NULLS LAST", "t.species_id"}},
+}
+
+// yieldPeriods are the date_trunc units the harvests may be split by. The unit is written in the query, a parameter
+// would make the expression grouped by differ from the selected one.
+var yieldPeriods = map[string]string{
+	"day":   "date_trunc('day', h.harvested_at)",
+	"week":  "date_trunc('week', h.harvested_at)",
+	"month": "date_trunc('month', h.harvested_at)",
+	"year":  "date_trunc('year', h.harvested_at)",
+}
+
+/*
+GetYieldStats aggregates the harvests of an estate selected by the filter per group, in the order of the groups and
+then of the periods. The harvests are first summed per tree so every tree harvested counts its current height once
+in TreeHeight. The harvests of the removed trees have no tree left, they only count in Harvests and Quantity. They
+stay in the row of the plot of their tree, and make a group without tree for that plot and a group without species
+of their own. The groups without harvests are left out.
+*/
+func (r *Repository) GetYieldStats(ctx context.Context, estateID uuid.UUID, filter YieldFilter) ([]YieldStats, error) {
+	var stats []YieldStats
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	grouping := yieldGroupings[filter.GroupBy]
+	columns, groupBy, orderBy := grouping.columns, grouping.groupBy, grouping.orderBy
+	treeGroupBy := "h.plot_id, h.x, h.y, p.species_id"
+	period := ""
+	if filter.Period != nil {
+		period = `
+                ` + yieldPeriods[*filter.Period] + ` AS period,`
+		treeGroupBy += ", " + yieldPeriods[*filter.Period]
+		columns = append(slices.Clip(columns), "t.period")
+		groupBy = append(slices.Clip(groupBy), "t.period")
+		orderBy = append(slices.Clip(orderBy), "t.period")
+	}
+
+	query := `
+        WITH TreeYields AS (
+            SELECT
+                h.plot_id, h.x, h.y, p.species_id,` + period + `
+                COUNT(*) AS harvests,
+                SUM(h.quantity) AS quantity,
+                MAX(p.tree_height) AS tree_height
+            FROM tree_harvests h
+            LEFT JOIN plots p ON p.id = h.plot_id
+            WHERE h.estate_id = ?`
+	args := []interface{}{estateID}
+
+	if filter.From != nil {
+		query += ` AND h.harvested_at >= ?`
+		args = append(args, *filter.From)
+	}
+	if filter.To != nil {
+		query += ` AND h.harvested_at < ?`
+		args = append(args, *filter.To)
+	}
+	if filter.Grade != nil {
+		query += ` AND h.grade = ?`
+		args = append(args, *filter.Grade)
+	}
+
+	query += `
+            GROUP BY ` + treeGroupBy + `
+        )
+        SELECT`
+	for _, column := range columns {
+		query += `
+            ` + column + `,`
+	}
+	query += `
+            SUM(t.harvests) AS harvests,
+            COUNT(t.plot_id) AS trees,
+            SUM(t.quantity) AS quantity,
+            COALESCE(SUM(t.quantity) FILTER (WHERE t.plot_id IS NOT NULL), 0) AS standing_quantity,
+            COALESCE(SUM(t.tree_height), 0) AS tree_height
+        FROM TreeYields t`
+	if filter.GroupBy == "species" {
+		query += `
+        LEFT JOIN species s ON s.id = t.species_id`
+	}
+	if len(groupBy) > 0 {
+		query += `
+        GROUP BY ` + strings.Join(groupBy, ", ")
+	}
+	query += `
+        HAVING COUNT(*) > 0`
+	if len(orderBy) > 0 {
+		query += `
+        ORDER BY ` + strings.Join(orderBy, ", ")
+	}
+
+	if err := tx.WithContext(ctx).Raw(query, args...).Scan(&stats).Error; err != nil {
+		return nil, err
+	}
+	return stats, nil
+}
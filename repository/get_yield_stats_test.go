@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql/driver"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_GetYieldStats(t *testing.T) {
+	mockEstateID := uuid.New()
+	mockSpeciesID := uuid.New()
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	month := "month"
+	grade := "A"
+	name := "Oil palm"
+
+	tests := []struct {
+		name        string
+		filter      YieldFilter
+		query       string
+		args        []driver.Value
+		rows        *sqlmock.Rows
+		expectedRes []YieldStats
+	}{
+		{
+			name:   "Whole Estate",
+			filter: YieldFilter{GroupBy: "estate"},
+			query:  `LEFT JOIN plots p ON p\.id = h\.plot_id.*GROUP BY h\.plot_id, h\.x, h\.y, p\.species_id\s+\)\s+SELECT\s+SUM\(t\.harvests\).*FROM TreeYields t\s+HAVING COUNT\(\*\) > 0\s*$`,
+			args:   []driver.Value{mockEstateID},
+			rows: sqlmock.NewRows([]string{"harvests", "trees", "quantity", "standing_quantity", "tree_height"}).
+				AddRow(3, 2, 61.5, 50.0, 24),
+			expectedRes: []YieldStats{{Harvests: 3, Trees: 2, Quantity: 61.5, StandingQuantity: 50, TreeHeight: 24}},
+		},
+		{
+			// the harvests of a removed tree keep the row of its plot, its plot is gone
+			name:   "Row Keeps The Harvests Of The Removed Trees",
+			filter: YieldFilter{GroupBy: "row"},
+			query: `SELECT\s+h\.plot_id, h\.x, h\.y, p\.species_id,.*` +
+				`SELECT\s+t\.y,.*FROM TreeYields t\s+GROUP BY t\.y\s+HAVING COUNT\(\*\) > 0\s+ORDER BY t\.y\s*$`,
+			args: []driver.Value{mockEstateID},
+			rows: sqlmock.NewRows([]string{"y", "harvests", "trees", "quantity", "standing_quantity", "tree_height"}).
+				AddRow(2, 3, 1, 45.0, 30.0, 10),
+			expectedRes: []YieldStats{{Y: &[]int{2}[0], Harvests: 3, Trees: 1, Quantity: 45, StandingQuantity: 30, TreeHeight: 10}},
+		},
+		{
+			name:   "Species Per Month",
+			filter: YieldFilter{GroupBy: "species", Period: &month, From: &from, Grade: &grade},
+			query: `date_trunc\('month', h\.harvested_at\) AS period,.*WHERE h\.estate_id = \$1 AND h\.harvested_at >= \$2 AND h\.grade = \$3\s+` +
+				`GROUP BY h\.plot_id, h\.x, h\.y, p\.species_id, date_trunc\('month', h\.harvested_at\).*` +
+				`t\.species_id,\s+s\.name,\s+t\.period,.*LEFT JOIN species s ON s\.id = t\.species_id\s+` +
+				`GROUP BY t\.species_id, s\.name, t\.period\s+HAVING COUNT\(\*\) > 0\s+ORDER BY s\.name NULLS LAST, t\.species_id, t\.period`,
+			args: []driver.Value{mockEstateID, from, grade},
+			rows: sqlmock.NewRows([]string{"species_id", "name", "period", "harvests", "trees", "quantity", "standing_quantity", "tree_height"}).
+				AddRow(mockSpeciesID, name, from, 2, 1, 40.0, 40.0, 12),
+			expectedRes: []YieldStats{{SpeciesId: &mockSpeciesID, Name: &name, Period: &from, Harvests: 2, Trees: 1, Quantity: 40, StandingQuantity: 40, TreeHeight: 12}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mock.ExpectQuery(tt.query).WithArgs(tt.args...).WillReturnRows(tt.rows)
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			stats, err := repo.GetYieldStats(context.Background(), mockEstateID, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedRes, stats)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
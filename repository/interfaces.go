@@ -6,6 +6,7 @@ package repository
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -14,7 +15,9 @@ type RepositoryInterface interface {
 	PostEstate(ctx context.Context, entity EstateEntity) (*uuid.UUID, error)
 	GetEstate(ctx context.Context, id uuid.UUID) (EstateEntity, error)
 	PostPlot(ctx context.Context, entity PlotEntity) (*uuid.UUID, error)
+	PostPlots(ctx context.Context, entities []PlotEntity) ([]uuid.UUID, error)
 	SavePlot(ctx context.Context, entity PlotEntity) (*uuid.UUID, error)
+	DeletePlots(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID, removedAt time.Time) error
 	SaveEstate(ctx context.Context, entity EstateEntity) (*uuid.UUID, error)
 	GetPlotByXAndY(ctx context.Context, estateId uuid.UUID, x int, y int) (*uuid.UUID, error)
 	GetOccupiedPlotBehind(ctx context.Context, estateId uuid.UUID, currentOrderNumber int) (*PlotEntity, error)
@@ -23,4 +26,49 @@ type RepositoryInterface interface {
 	GetMedianTreeHeight(ctx context.Context, estateID uuid.UUID) (int, error)
 	GetPlotByOrderNumber(ctx context.Context, estateId uuid.UUID, orderNumber int) (*PlotEntity, error)
 	GetPlotByDistance(ctx context.Context, estateId uuid.UUID, distance int) (*PlotEntity, error)
+	GetPlotByID(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (*PlotEntity, error)
+	PostTreeMeasurement(ctx context.Context, entity TreeMeasurementEntity) (*uuid.UUID, error)
+	PostTreeMeasurements(ctx context.Context, entities []TreeMeasurementEntity) error
+	GetTreeMeasurements(ctx context.Context, plotId uuid.UUID) ([]TreeMeasurementEntity, error)
+	GetLatestTreeMeasurement(ctx context.Context, plotId uuid.UUID) (*TreeMeasurementEntity, error)
+	GetEstateTreeMeasurements(ctx context.Context, estateId uuid.UUID) ([]TreeMeasurementEntity, error)
+	PostTreeInspection(ctx context.Context, entity TreeInspectionEntity) (*uuid.UUID, error)
+	GetTreeInspections(ctx context.Context, plotId uuid.UUID) ([]TreeInspectionEntity, error)
+	GetLatestTreeInspection(ctx context.Context, plotId uuid.UUID) (*TreeInspectionEntity, error)
+	PostTreeHarvest(ctx context.Context, entity TreeHarvestEntity) (*uuid.UUID, error)
+	GetYieldStats(ctx context.Context, estateID uuid.UUID, filter YieldFilter) ([]YieldStats, error)
+	GetTreeHeightStats(ctx context.Context, estateID uuid.UUID) (TreeHeightStats, error)
+	GetTreeHeightStatsAsOf(ctx context.Context, estateID uuid.UUID, asOf time.Time) (TreeHeightStats, error)
+	GetFilteredTreeHeightStats(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) (TreeHeightStats, error)
+	GetTreeHeightDistribution(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, fractions []float64) (TreeHeightDistribution, error)
+	GetTreeHeightStatsByTile(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, tileWidth int, tileLength int) ([]TileTreeHeightStats, error)
+	GetTreeHeightStatsBySpecies(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]SpeciesTreeHeightStats, error)
+	GetTreeHeightStatsByHealth(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]HealthTreeHeightStats, error)
+	UpdateEstateGeoReference(ctx context.Context, entity EstateEntity) error
+	GetPlots(ctx context.Context, estateId uuid.UUID) ([]PlotEntity, error)
+	GetPlotsByHealth(ctx context.Context, estateId uuid.UUID, statuses []string, uninspected bool) ([]PlotEntity, error)
+	PostDroneProfile(ctx context.Context, entity DroneProfileEntity) (*uuid.UUID, error)
+	GetDroneProfile(ctx context.Context, id uuid.UUID) (DroneProfileEntity, error)
+	PostSpecies(ctx context.Context, entity SpeciesEntity) (*uuid.UUID, error)
+	GetSpecies(ctx context.Context, id uuid.UUID) (SpeciesEntity, error)
+	GetSpeciesCatalog(ctx context.Context) ([]SpeciesEntity, error)
+	PostChargingPad(ctx context.Context, entity ChargingPadEntity) (*uuid.UUID, error)
+	GetChargingPads(ctx context.Context, estateId uuid.UUID) ([]ChargingPadEntity, error)
+	PostObstacle(ctx context.Context, entity ObstacleEntity) (*uuid.UUID, error)
+	GetObstacles(ctx context.Context, estateId uuid.UUID) ([]ObstacleEntity, error)
+	SaveEstateElevation(ctx context.Context, entity EstateElevationEntity) error
+	GetEstateElevation(ctx context.Context, estateId uuid.UUID) (EstateElevationEntity, error)
+	PostFlightLog(ctx context.Context, entity FlightLogEntity) (*uuid.UUID, error)
+	GetFlightLog(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (FlightLogEntity, error)
+	PostWorkOrder(ctx context.Context, entity WorkOrderEntity) (*uuid.UUID, error)
+	GetWorkOrder(ctx context.Context, estateId uuid.UUID, id uuid.UUID) (WorkOrderEntity, error)
+	GetWorkOrders(ctx context.Context, estateId uuid.UUID, filter WorkOrderFilter) ([]WorkOrderEntity, error)
+	UpdateWorkOrderStatus(ctx context.Context, entity WorkOrderEntity, from string) error
+	PostSensor(ctx context.Context, entity SensorEntity) (*uuid.UUID, error)
+	GetSensors(ctx context.Context, estateId uuid.UUID) ([]SensorEntity, error)
+	GetSensorsByIds(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID) ([]SensorEntity, error)
+	PostSensorReadings(ctx context.Context, entities []SensorReadingEntity) (int, error)
+	GetLatestSensorReadings(ctx context.Context, estateID uuid.UUID, kind *string) ([]LatestSensorReading, error)
+	GetSensorStats(ctx context.Context, estateID uuid.UUID, filter SensorFilter) ([]SensorStats, error)
+	GetSensorSeries(ctx context.Context, estateID uuid.UUID, bucket string, filter SensorFilter) ([]SensorBucket, error)
 }
@@ -7,6 +7,7 @@ package repository
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
@@ -49,6 +50,50 @@ func (mr *MockRepositoryInterfaceMockRecorder) AdjustPlotForwardDistance(ctx, es
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AdjustPlotForwardDistance", reflect.TypeOf((*MockRepositoryInterface)(nil).AdjustPlotForwardDistance), ctx, estateId, currentOrderNumber, additionalDistanceGap)
 }
 
+// DeletePlots mocks base method.
+func (m *MockRepositoryInterface) DeletePlots(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID, removedAt time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePlots", ctx, estateId, ids, removedAt)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePlots indicates an expected call of DeletePlots.
+func (mr *MockRepositoryInterfaceMockRecorder) DeletePlots(ctx, estateId, ids, removedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePlots", reflect.TypeOf((*MockRepositoryInterface)(nil).DeletePlots), ctx, estateId, ids, removedAt)
+}
+
+// GetChargingPads mocks base method.
+func (m *MockRepositoryInterface) GetChargingPads(ctx context.Context, estateId uuid.UUID) ([]ChargingPadEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargingPads", ctx, estateId)
+	ret0, _ := ret[0].([]ChargingPadEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetChargingPads indicates an expected call of GetChargingPads.
+func (mr *MockRepositoryInterfaceMockRecorder) GetChargingPads(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargingPads", reflect.TypeOf((*MockRepositoryInterface)(nil).GetChargingPads), ctx, estateId)
+}
+
+// GetDroneProfile mocks base method.
+func (m *MockRepositoryInterface) GetDroneProfile(ctx context.Context, id uuid.UUID) (DroneProfileEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDroneProfile", ctx, id)
+	ret0, _ := ret[0].(DroneProfileEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDroneProfile indicates an expected call of GetDroneProfile.
+func (mr *MockRepositoryInterfaceMockRecorder) GetDroneProfile(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDroneProfile", reflect.TypeOf((*MockRepositoryInterface)(nil).GetDroneProfile), ctx, id)
+}
+
 // GetEstate mocks base method.
 func (m *MockRepositoryInterface) GetEstate(ctx context.Context, id uuid.UUID) (EstateEntity, error) {
 	m.ctrl.T.Helper()
@@ -64,6 +109,111 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetEstate(ctx, id interface{}) *g
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstate), ctx, id)
 }
 
+// GetEstateElevation mocks base method.
+func (m *MockRepositoryInterface) GetEstateElevation(ctx context.Context, estateId uuid.UUID) (EstateElevationEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateElevation", ctx, estateId)
+	ret0, _ := ret[0].(EstateElevationEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateElevation indicates an expected call of GetEstateElevation.
+func (mr *MockRepositoryInterfaceMockRecorder) GetEstateElevation(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateElevation", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateElevation), ctx, estateId)
+}
+
+// GetEstateTreeMeasurements mocks base method.
+func (m *MockRepositoryInterface) GetEstateTreeMeasurements(ctx context.Context, estateId uuid.UUID) ([]TreeMeasurementEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateTreeMeasurements", ctx, estateId)
+	ret0, _ := ret[0].([]TreeMeasurementEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateTreeMeasurements indicates an expected call of GetEstateTreeMeasurements.
+func (mr *MockRepositoryInterfaceMockRecorder) GetEstateTreeMeasurements(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateTreeMeasurements", reflect.TypeOf((*MockRepositoryInterface)(nil).GetEstateTreeMeasurements), ctx, estateId)
+}
+
+// GetFilteredTreeHeightStats mocks base method.
+func (m *MockRepositoryInterface) GetFilteredTreeHeightStats(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) (TreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilteredTreeHeightStats", ctx, estateID, filter)
+	ret0, _ := ret[0].(TreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilteredTreeHeightStats indicates an expected call of GetFilteredTreeHeightStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetFilteredTreeHeightStats(ctx, estateID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilteredTreeHeightStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetFilteredTreeHeightStats), ctx, estateID, filter)
+}
+
+// GetFlightLog mocks base method.
+func (m *MockRepositoryInterface) GetFlightLog(ctx context.Context, estateId, id uuid.UUID) (FlightLogEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightLog", ctx, estateId, id)
+	ret0, _ := ret[0].(FlightLogEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFlightLog indicates an expected call of GetFlightLog.
+func (mr *MockRepositoryInterfaceMockRecorder) GetFlightLog(ctx, estateId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightLog", reflect.TypeOf((*MockRepositoryInterface)(nil).GetFlightLog), ctx, estateId, id)
+}
+
+// GetLatestSensorReadings mocks base method.
+func (m *MockRepositoryInterface) GetLatestSensorReadings(ctx context.Context, estateID uuid.UUID, kind *string) ([]LatestSensorReading, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestSensorReadings", ctx, estateID, kind)
+	ret0, _ := ret[0].([]LatestSensorReading)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestSensorReadings indicates an expected call of GetLatestSensorReadings.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLatestSensorReadings(ctx, estateID, kind interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestSensorReadings", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLatestSensorReadings), ctx, estateID, kind)
+}
+
+// GetLatestTreeInspection mocks base method.
+func (m *MockRepositoryInterface) GetLatestTreeInspection(ctx context.Context, plotId uuid.UUID) (*TreeInspectionEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTreeInspection", ctx, plotId)
+	ret0, _ := ret[0].(*TreeInspectionEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTreeInspection indicates an expected call of GetLatestTreeInspection.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLatestTreeInspection(ctx, plotId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTreeInspection", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLatestTreeInspection), ctx, plotId)
+}
+
+// GetLatestTreeMeasurement mocks base method.
+func (m *MockRepositoryInterface) GetLatestTreeMeasurement(ctx context.Context, plotId uuid.UUID) (*TreeMeasurementEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLatestTreeMeasurement", ctx, plotId)
+	ret0, _ := ret[0].(*TreeMeasurementEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLatestTreeMeasurement indicates an expected call of GetLatestTreeMeasurement.
+func (mr *MockRepositoryInterfaceMockRecorder) GetLatestTreeMeasurement(ctx, plotId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLatestTreeMeasurement", reflect.TypeOf((*MockRepositoryInterface)(nil).GetLatestTreeMeasurement), ctx, plotId)
+}
+
 // GetMedianTreeHeight mocks base method.
 func (m *MockRepositoryInterface) GetMedianTreeHeight(ctx context.Context, estateID uuid.UUID) (int, error) {
 	m.ctrl.T.Helper()
@@ -79,6 +229,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetMedianTreeHeight(ctx, estateID
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetMedianTreeHeight", reflect.TypeOf((*MockRepositoryInterface)(nil).GetMedianTreeHeight), ctx, estateID)
 }
 
+// GetObstacles mocks base method.
+func (m *MockRepositoryInterface) GetObstacles(ctx context.Context, estateId uuid.UUID) ([]ObstacleEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObstacles", ctx, estateId)
+	ret0, _ := ret[0].([]ObstacleEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetObstacles indicates an expected call of GetObstacles.
+func (mr *MockRepositoryInterfaceMockRecorder) GetObstacles(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObstacles", reflect.TypeOf((*MockRepositoryInterface)(nil).GetObstacles), ctx, estateId)
+}
+
 // GetOccupiedPlotBehind mocks base method.
 func (m *MockRepositoryInterface) GetOccupiedPlotBehind(ctx context.Context, estateId uuid.UUID, currentOrderNumber int) (*PlotEntity, error) {
 	m.ctrl.T.Helper()
@@ -124,6 +289,21 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetPlotByDistance(ctx, estateId,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlotByDistance", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPlotByDistance), ctx, estateId, distance)
 }
 
+// GetPlotByID mocks base method.
+func (m *MockRepositoryInterface) GetPlotByID(ctx context.Context, estateId, id uuid.UUID) (*PlotEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlotByID", ctx, estateId, id)
+	ret0, _ := ret[0].(*PlotEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlotByID indicates an expected call of GetPlotByID.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPlotByID(ctx, estateId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlotByID", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPlotByID), ctx, estateId, id)
+}
+
 // GetPlotByOrderNumber mocks base method.
 func (m *MockRepositoryInterface) GetPlotByOrderNumber(ctx context.Context, estateId uuid.UUID, orderNumber int) (*PlotEntity, error) {
 	m.ctrl.T.Helper()
@@ -154,6 +334,321 @@ func (mr *MockRepositoryInterfaceMockRecorder) GetPlotByXAndY(ctx, estateId, x,
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlotByXAndY", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPlotByXAndY), ctx, estateId, x, y)
 }
 
+// GetPlots mocks base method.
+func (m *MockRepositoryInterface) GetPlots(ctx context.Context, estateId uuid.UUID) ([]PlotEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlots", ctx, estateId)
+	ret0, _ := ret[0].([]PlotEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlots indicates an expected call of GetPlots.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPlots(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlots", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPlots), ctx, estateId)
+}
+
+// GetPlotsByHealth mocks base method.
+func (m *MockRepositoryInterface) GetPlotsByHealth(ctx context.Context, estateId uuid.UUID, statuses []string, uninspected bool) ([]PlotEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPlotsByHealth", ctx, estateId, statuses, uninspected)
+	ret0, _ := ret[0].([]PlotEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPlotsByHealth indicates an expected call of GetPlotsByHealth.
+func (mr *MockRepositoryInterfaceMockRecorder) GetPlotsByHealth(ctx, estateId, statuses, uninspected interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlotsByHealth", reflect.TypeOf((*MockRepositoryInterface)(nil).GetPlotsByHealth), ctx, estateId, statuses, uninspected)
+}
+
+// GetSensorSeries mocks base method.
+func (m *MockRepositoryInterface) GetSensorSeries(ctx context.Context, estateID uuid.UUID, bucket string, filter SensorFilter) ([]SensorBucket, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorSeries", ctx, estateID, bucket, filter)
+	ret0, _ := ret[0].([]SensorBucket)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSensorSeries indicates an expected call of GetSensorSeries.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSensorSeries(ctx, estateID, bucket, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorSeries", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSensorSeries), ctx, estateID, bucket, filter)
+}
+
+// GetSensorStats mocks base method.
+func (m *MockRepositoryInterface) GetSensorStats(ctx context.Context, estateID uuid.UUID, filter SensorFilter) ([]SensorStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorStats", ctx, estateID, filter)
+	ret0, _ := ret[0].([]SensorStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSensorStats indicates an expected call of GetSensorStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSensorStats(ctx, estateID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSensorStats), ctx, estateID, filter)
+}
+
+// GetSensors mocks base method.
+func (m *MockRepositoryInterface) GetSensors(ctx context.Context, estateId uuid.UUID) ([]SensorEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensors", ctx, estateId)
+	ret0, _ := ret[0].([]SensorEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSensors indicates an expected call of GetSensors.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSensors(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensors", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSensors), ctx, estateId)
+}
+
+// GetSensorsByIds mocks base method.
+func (m *MockRepositoryInterface) GetSensorsByIds(ctx context.Context, estateId uuid.UUID, ids []uuid.UUID) ([]SensorEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorsByIds", ctx, estateId, ids)
+	ret0, _ := ret[0].([]SensorEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSensorsByIds indicates an expected call of GetSensorsByIds.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSensorsByIds(ctx, estateId, ids interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorsByIds", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSensorsByIds), ctx, estateId, ids)
+}
+
+// GetSpecies mocks base method.
+func (m *MockRepositoryInterface) GetSpecies(ctx context.Context, id uuid.UUID) (SpeciesEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpecies", ctx, id)
+	ret0, _ := ret[0].(SpeciesEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpecies indicates an expected call of GetSpecies.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSpecies(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpecies", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSpecies), ctx, id)
+}
+
+// GetSpeciesCatalog mocks base method.
+func (m *MockRepositoryInterface) GetSpeciesCatalog(ctx context.Context) ([]SpeciesEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpeciesCatalog", ctx)
+	ret0, _ := ret[0].([]SpeciesEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSpeciesCatalog indicates an expected call of GetSpeciesCatalog.
+func (mr *MockRepositoryInterfaceMockRecorder) GetSpeciesCatalog(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpeciesCatalog", reflect.TypeOf((*MockRepositoryInterface)(nil).GetSpeciesCatalog), ctx)
+}
+
+// GetTreeHeightDistribution mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightDistribution(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, fractions []float64) (TreeHeightDistribution, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightDistribution", ctx, estateID, filter, fractions)
+	ret0, _ := ret[0].(TreeHeightDistribution)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightDistribution indicates an expected call of GetTreeHeightDistribution.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightDistribution(ctx, estateID, filter, fractions interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightDistribution", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightDistribution), ctx, estateID, filter, fractions)
+}
+
+// GetTreeHeightStats mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightStats(ctx context.Context, estateID uuid.UUID) (TreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightStats", ctx, estateID)
+	ret0, _ := ret[0].(TreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightStats indicates an expected call of GetTreeHeightStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightStats(ctx, estateID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightStats), ctx, estateID)
+}
+
+// GetTreeHeightStatsAsOf mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightStatsAsOf(ctx context.Context, estateID uuid.UUID, asOf time.Time) (TreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightStatsAsOf", ctx, estateID, asOf)
+	ret0, _ := ret[0].(TreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightStatsAsOf indicates an expected call of GetTreeHeightStatsAsOf.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightStatsAsOf(ctx, estateID, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightStatsAsOf", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightStatsAsOf), ctx, estateID, asOf)
+}
+
+// GetTreeHeightStatsByHealth mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightStatsByHealth(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]HealthTreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightStatsByHealth", ctx, estateID, filter)
+	ret0, _ := ret[0].([]HealthTreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightStatsByHealth indicates an expected call of GetTreeHeightStatsByHealth.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightStatsByHealth(ctx, estateID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightStatsByHealth", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightStatsByHealth), ctx, estateID, filter)
+}
+
+// GetTreeHeightStatsBySpecies mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightStatsBySpecies(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter) ([]SpeciesTreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightStatsBySpecies", ctx, estateID, filter)
+	ret0, _ := ret[0].([]SpeciesTreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightStatsBySpecies indicates an expected call of GetTreeHeightStatsBySpecies.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightStatsBySpecies(ctx, estateID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightStatsBySpecies", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightStatsBySpecies), ctx, estateID, filter)
+}
+
+// GetTreeHeightStatsByTile mocks base method.
+func (m *MockRepositoryInterface) GetTreeHeightStatsByTile(ctx context.Context, estateID uuid.UUID, filter TreeHeightFilter, tileWidth, tileLength int) ([]TileTreeHeightStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeHeightStatsByTile", ctx, estateID, filter, tileWidth, tileLength)
+	ret0, _ := ret[0].([]TileTreeHeightStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeHeightStatsByTile indicates an expected call of GetTreeHeightStatsByTile.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeHeightStatsByTile(ctx, estateID, filter, tileWidth, tileLength interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeHeightStatsByTile", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeHeightStatsByTile), ctx, estateID, filter, tileWidth, tileLength)
+}
+
+// GetTreeInspections mocks base method.
+func (m *MockRepositoryInterface) GetTreeInspections(ctx context.Context, plotId uuid.UUID) ([]TreeInspectionEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeInspections", ctx, plotId)
+	ret0, _ := ret[0].([]TreeInspectionEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeInspections indicates an expected call of GetTreeInspections.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeInspections(ctx, plotId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeInspections", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeInspections), ctx, plotId)
+}
+
+// GetTreeMeasurements mocks base method.
+func (m *MockRepositoryInterface) GetTreeMeasurements(ctx context.Context, plotId uuid.UUID) ([]TreeMeasurementEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeMeasurements", ctx, plotId)
+	ret0, _ := ret[0].([]TreeMeasurementEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetTreeMeasurements indicates an expected call of GetTreeMeasurements.
+func (mr *MockRepositoryInterfaceMockRecorder) GetTreeMeasurements(ctx, plotId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeMeasurements", reflect.TypeOf((*MockRepositoryInterface)(nil).GetTreeMeasurements), ctx, plotId)
+}
+
+// GetWorkOrder mocks base method.
+func (m *MockRepositoryInterface) GetWorkOrder(ctx context.Context, estateId, id uuid.UUID) (WorkOrderEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkOrder", ctx, estateId, id)
+	ret0, _ := ret[0].(WorkOrderEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkOrder indicates an expected call of GetWorkOrder.
+func (mr *MockRepositoryInterfaceMockRecorder) GetWorkOrder(ctx, estateId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkOrder", reflect.TypeOf((*MockRepositoryInterface)(nil).GetWorkOrder), ctx, estateId, id)
+}
+
+// GetWorkOrders mocks base method.
+func (m *MockRepositoryInterface) GetWorkOrders(ctx context.Context, estateId uuid.UUID, filter WorkOrderFilter) ([]WorkOrderEntity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkOrders", ctx, estateId, filter)
+	ret0, _ := ret[0].([]WorkOrderEntity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetWorkOrders indicates an expected call of GetWorkOrders.
+func (mr *MockRepositoryInterfaceMockRecorder) GetWorkOrders(ctx, estateId, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkOrders", reflect.TypeOf((*MockRepositoryInterface)(nil).GetWorkOrders), ctx, estateId, filter)
+}
+
+// GetYieldStats mocks base method.
+func (m *MockRepositoryInterface) GetYieldStats(ctx context.Context, estateID uuid.UUID, filter YieldFilter) ([]YieldStats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetYieldStats", ctx, estateID, filter)
+	ret0, _ := ret[0].([]YieldStats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetYieldStats indicates an expected call of GetYieldStats.
+func (mr *MockRepositoryInterfaceMockRecorder) GetYieldStats(ctx, estateID, filter interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetYieldStats", reflect.TypeOf((*MockRepositoryInterface)(nil).GetYieldStats), ctx, estateID, filter)
+}
+
+// PostChargingPad mocks base method.
+func (m *MockRepositoryInterface) PostChargingPad(ctx context.Context, entity ChargingPadEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostChargingPad", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostChargingPad indicates an expected call of PostChargingPad.
+func (mr *MockRepositoryInterfaceMockRecorder) PostChargingPad(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostChargingPad", reflect.TypeOf((*MockRepositoryInterface)(nil).PostChargingPad), ctx, entity)
+}
+
+// PostDroneProfile mocks base method.
+func (m *MockRepositoryInterface) PostDroneProfile(ctx context.Context, entity DroneProfileEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostDroneProfile", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostDroneProfile indicates an expected call of PostDroneProfile.
+func (mr *MockRepositoryInterfaceMockRecorder) PostDroneProfile(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostDroneProfile", reflect.TypeOf((*MockRepositoryInterface)(nil).PostDroneProfile), ctx, entity)
+}
+
 // PostEstate mocks base method.
 func (m *MockRepositoryInterface) PostEstate(ctx context.Context, entity EstateEntity) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -169,6 +664,36 @@ func (mr *MockRepositoryInterfaceMockRecorder) PostEstate(ctx, entity interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).PostEstate), ctx, entity)
 }
 
+// PostFlightLog mocks base method.
+func (m *MockRepositoryInterface) PostFlightLog(ctx context.Context, entity FlightLogEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostFlightLog", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostFlightLog indicates an expected call of PostFlightLog.
+func (mr *MockRepositoryInterfaceMockRecorder) PostFlightLog(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostFlightLog", reflect.TypeOf((*MockRepositoryInterface)(nil).PostFlightLog), ctx, entity)
+}
+
+// PostObstacle mocks base method.
+func (m *MockRepositoryInterface) PostObstacle(ctx context.Context, entity ObstacleEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostObstacle", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostObstacle indicates an expected call of PostObstacle.
+func (mr *MockRepositoryInterfaceMockRecorder) PostObstacle(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostObstacle", reflect.TypeOf((*MockRepositoryInterface)(nil).PostObstacle), ctx, entity)
+}
+
 // PostPlot mocks base method.
 func (m *MockRepositoryInterface) PostPlot(ctx context.Context, entity PlotEntity) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -184,6 +709,140 @@ func (mr *MockRepositoryInterfaceMockRecorder) PostPlot(ctx, entity interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostPlot", reflect.TypeOf((*MockRepositoryInterface)(nil).PostPlot), ctx, entity)
 }
 
+// PostPlots mocks base method.
+func (m *MockRepositoryInterface) PostPlots(ctx context.Context, entities []PlotEntity) ([]uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostPlots", ctx, entities)
+	ret0, _ := ret[0].([]uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostPlots indicates an expected call of PostPlots.
+func (mr *MockRepositoryInterfaceMockRecorder) PostPlots(ctx, entities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostPlots", reflect.TypeOf((*MockRepositoryInterface)(nil).PostPlots), ctx, entities)
+}
+
+// PostSensor mocks base method.
+func (m *MockRepositoryInterface) PostSensor(ctx context.Context, entity SensorEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSensor", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostSensor indicates an expected call of PostSensor.
+func (mr *MockRepositoryInterfaceMockRecorder) PostSensor(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSensor", reflect.TypeOf((*MockRepositoryInterface)(nil).PostSensor), ctx, entity)
+}
+
+// PostSensorReadings mocks base method.
+func (m *MockRepositoryInterface) PostSensorReadings(ctx context.Context, entities []SensorReadingEntity) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSensorReadings", ctx, entities)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostSensorReadings indicates an expected call of PostSensorReadings.
+func (mr *MockRepositoryInterfaceMockRecorder) PostSensorReadings(ctx, entities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSensorReadings", reflect.TypeOf((*MockRepositoryInterface)(nil).PostSensorReadings), ctx, entities)
+}
+
+// PostSpecies mocks base method.
+func (m *MockRepositoryInterface) PostSpecies(ctx context.Context, entity SpeciesEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSpecies", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostSpecies indicates an expected call of PostSpecies.
+func (mr *MockRepositoryInterfaceMockRecorder) PostSpecies(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSpecies", reflect.TypeOf((*MockRepositoryInterface)(nil).PostSpecies), ctx, entity)
+}
+
+// PostTreeHarvest mocks base method.
+func (m *MockRepositoryInterface) PostTreeHarvest(ctx context.Context, entity TreeHarvestEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTreeHarvest", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTreeHarvest indicates an expected call of PostTreeHarvest.
+func (mr *MockRepositoryInterfaceMockRecorder) PostTreeHarvest(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTreeHarvest", reflect.TypeOf((*MockRepositoryInterface)(nil).PostTreeHarvest), ctx, entity)
+}
+
+// PostTreeInspection mocks base method.
+func (m *MockRepositoryInterface) PostTreeInspection(ctx context.Context, entity TreeInspectionEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTreeInspection", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTreeInspection indicates an expected call of PostTreeInspection.
+func (mr *MockRepositoryInterfaceMockRecorder) PostTreeInspection(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTreeInspection", reflect.TypeOf((*MockRepositoryInterface)(nil).PostTreeInspection), ctx, entity)
+}
+
+// PostTreeMeasurement mocks base method.
+func (m *MockRepositoryInterface) PostTreeMeasurement(ctx context.Context, entity TreeMeasurementEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTreeMeasurement", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostTreeMeasurement indicates an expected call of PostTreeMeasurement.
+func (mr *MockRepositoryInterfaceMockRecorder) PostTreeMeasurement(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTreeMeasurement", reflect.TypeOf((*MockRepositoryInterface)(nil).PostTreeMeasurement), ctx, entity)
+}
+
+// PostTreeMeasurements mocks base method.
+func (m *MockRepositoryInterface) PostTreeMeasurements(ctx context.Context, entities []TreeMeasurementEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostTreeMeasurements", ctx, entities)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// PostTreeMeasurements indicates an expected call of PostTreeMeasurements.
+func (mr *MockRepositoryInterfaceMockRecorder) PostTreeMeasurements(ctx, entities interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostTreeMeasurements", reflect.TypeOf((*MockRepositoryInterface)(nil).PostTreeMeasurements), ctx, entities)
+}
+
+// PostWorkOrder mocks base method.
+func (m *MockRepositoryInterface) PostWorkOrder(ctx context.Context, entity WorkOrderEntity) (*uuid.UUID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostWorkOrder", ctx, entity)
+	ret0, _ := ret[0].(*uuid.UUID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PostWorkOrder indicates an expected call of PostWorkOrder.
+func (mr *MockRepositoryInterfaceMockRecorder) PostWorkOrder(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostWorkOrder", reflect.TypeOf((*MockRepositoryInterface)(nil).PostWorkOrder), ctx, entity)
+}
+
 // SaveEstate mocks base method.
 func (m *MockRepositoryInterface) SaveEstate(ctx context.Context, entity EstateEntity) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -199,6 +858,20 @@ func (mr *MockRepositoryInterfaceMockRecorder) SaveEstate(ctx, entity interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEstate", reflect.TypeOf((*MockRepositoryInterface)(nil).SaveEstate), ctx, entity)
 }
 
+// SaveEstateElevation mocks base method.
+func (m *MockRepositoryInterface) SaveEstateElevation(ctx context.Context, entity EstateElevationEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveEstateElevation", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveEstateElevation indicates an expected call of SaveEstateElevation.
+func (mr *MockRepositoryInterfaceMockRecorder) SaveEstateElevation(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveEstateElevation", reflect.TypeOf((*MockRepositoryInterface)(nil).SaveEstateElevation), ctx, entity)
+}
+
 // SavePlot mocks base method.
 func (m *MockRepositoryInterface) SavePlot(ctx context.Context, entity PlotEntity) (*uuid.UUID, error) {
 	m.ctrl.T.Helper()
@@ -213,3 +886,31 @@ func (mr *MockRepositoryInterfaceMockRecorder) SavePlot(ctx, entity interface{})
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SavePlot", reflect.TypeOf((*MockRepositoryInterface)(nil).SavePlot), ctx, entity)
 }
+
+// UpdateEstateGeoReference mocks base method.
+func (m *MockRepositoryInterface) UpdateEstateGeoReference(ctx context.Context, entity EstateEntity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateEstateGeoReference", ctx, entity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateEstateGeoReference indicates an expected call of UpdateEstateGeoReference.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateEstateGeoReference(ctx, entity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateEstateGeoReference", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateEstateGeoReference), ctx, entity)
+}
+
+// UpdateWorkOrderStatus mocks base method.
+func (m *MockRepositoryInterface) UpdateWorkOrderStatus(ctx context.Context, entity WorkOrderEntity, from string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateWorkOrderStatus", ctx, entity, from)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateWorkOrderStatus indicates an expected call of UpdateWorkOrderStatus.
+func (mr *MockRepositoryInterfaceMockRecorder) UpdateWorkOrderStatus(ctx, entity, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateWorkOrderStatus", reflect.TypeOf((*MockRepositoryInterface)(nil).UpdateWorkOrderStatus), ctx, entity, from)
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostDroneProfile(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime = time.Now()
+		mockUUID = uuid.New()
+		entity   = DroneProfileEntity{
+			Name:            "Survey quadcopter",
+			HorizontalSpeed: 10,
+			ClimbSpeed:      3,
+			DescentSpeed:    2,
+			HorizontalPower: 180,
+			ClimbPower:      250,
+			DescentPower:    120,
+			BatteryCapacity: 90,
+			CreatedAt:       mockTime,
+		}
+
+		query = `INSERT INTO "drone_profiles" ("name","horizontal_speed","climb_speed","descent_speed","horizontal_power","climb_power","descent_power","battery_capacity","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       DroneProfileEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.Name, entity.HorizontalSpeed, entity.ClimbSpeed, entity.DescentSpeed, entity.HorizontalPower, entity.ClimbPower, entity.DescentPower, entity.BatteryCapacity, entity.CreatedAt).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.Name, entity.HorizontalSpeed, entity.ClimbSpeed, entity.DescentSpeed, entity.HorizontalPower, entity.ClimbPower, entity.DescentPower, entity.BatteryCapacity, entity.CreatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostDroneProfile(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -32,7 +32,7 @@ func TestRepository_PostEstate(t *testing.T) {
 			CreatedAt:        mockTime,
 		}
 
-		query = `INSERT INTO "estates" ("width","length","total_distance","tree_count","tree_max_height","tree_min_height","tree_median_height","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+		query = `INSERT INTO "estates" ("width","length","total_distance","tree_count","tree_max_height","tree_min_height","tree_median_height","anchor_latitude","anchor_longitude","bearing","plot_size","clearance","min_cruise_altitude","start_end_altitude","traversal_pattern","traversal_corner","blocked_plots","shape_mask","relief","exclude_dead_trees","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21) RETURNING "id"`
 	)
 
 	tests := []struct {
@@ -51,7 +51,7 @@ func TestRepository_PostEstate(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(entity.Width, entity.Length, entity.TotalDistance, entity.TreeCount, entity.TreeMaxHeight, entity.TreeMinHeight, entity.TreeMedianHeight, entity.CreatedAt).
+					WithArgs(entity.Width, entity.Length, entity.TotalDistance, entity.TreeCount, entity.TreeMaxHeight, entity.TreeMinHeight, entity.TreeMedianHeight, nil, nil, 0.0, 10.0, 1, 0, 0, "row_serpentine", "x_min_y_min", 0, []byte(nil), 0, false, entity.CreatedAt).
 					WillReturnRows(rows)
 				mock.ExpectCommit()
 			},
@@ -64,7 +64,7 @@ func TestRepository_PostEstate(t *testing.T) {
 			prepareMock: func() {
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(entity.Width, entity.Length, entity.TotalDistance, entity.TreeCount, entity.TreeMaxHeight, entity.TreeMinHeight, entity.TreeMedianHeight, entity.CreatedAt).
+					WithArgs(entity.Width, entity.Length, entity.TotalDistance, entity.TreeCount, entity.TreeMaxHeight, entity.TreeMinHeight, entity.TreeMedianHeight, nil, nil, 0.0, 10.0, 1, 0, 0, "row_serpentine", "x_min_y_min", 0, []byte(nil), 0, false, entity.CreatedAt).
 					WillReturnError(sql.ErrNoRows)
 				mock.ExpectRollback()
 			},
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/util"
+)
+
+// flightLogBatchSize keeps the parameters of an insert of samples within the limit of postgres.
+const flightLogBatchSize = 1000
+
+func (r *Repository) PostFlightLog(ctx context.Context, entity FlightLogEntity) (*uuid.UUID, error) {
+	tx := util.GetTxFromContext(ctx, r.Db)
+	err := tx.WithContext(ctx).Session(&gorm.Session{CreateBatchSize: flightLogBatchSize}).Create(&entity).Error
+	if err != nil {
+		return nil, err
+	}
+	return &entity.ID, nil
+}
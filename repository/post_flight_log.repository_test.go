@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostFlightLog(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime = time.Now()
+		mockUUID = uuid.New()
+		entity   = FlightLogEntity{
+			EstateId:  mockUUID,
+			StartedAt: mockTime,
+			EndedAt:   mockTime,
+			Samples: []FlightLogSampleEntity{
+				{Sequence: 1, Sortie: 1, RecordedAt: mockTime, X: 1, Y: 1, Altitude: 0, Battery: 100},
+			},
+			CreatedAt: mockTime,
+		}
+
+		query        = `INSERT INTO "flight_logs" ("estate_id","max_distance","started_at","ended_at","created_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`
+		samplesQuery = `INSERT INTO "flight_log_samples" ("flight_log_id","sequence","sortie","recorded_at","x","y","altitude","battery") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) ON CONFLICT ("flight_log_id","sequence") DO UPDATE SET "flight_log_id"="excluded"."flight_log_id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       FlightLogEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, nil, entity.StartedAt, entity.EndedAt, entity.CreatedAt).
+					WillReturnRows(rows)
+				mock.ExpectExec(regexp.QuoteMeta(samplesQuery)).
+					WithArgs(mockUUID, 1, 1, mockTime, 1.0, 1.0, 0.0, 100.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, nil, entity.StartedAt, entity.EndedAt, entity.CreatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostFlightLog(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
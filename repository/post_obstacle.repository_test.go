@@ -0,0 +1,95 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostObstacle(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime   = time.Now()
+		mockUUID   = uuid.New()
+		mockAreaID = uuid.New()
+		mockEstate = uuid.New()
+		entity     = ObstacleEntity{
+			EstateId:  mockEstate,
+			Shape:     "rectangle",
+			NoFly:     true,
+			Areas:     []ObstacleAreaEntity{{MinX: 2, MinY: 3, MaxX: 4, MaxY: 5}},
+			CreatedAt: mockTime,
+		}
+
+		query     = `INSERT INTO "obstacles" ("estate_id","shape","height","no_fly","created_at") VALUES ($1,$2,$3,$4,$5) RETURNING "id"`
+		areaQuery = `INSERT INTO "obstacle_areas" ("obstacle_id","min_x","min_y","max_x","max_y") VALUES ($1,$2,$3,$4,$5) ON CONFLICT ("id") DO UPDATE SET "obstacle_id"="excluded"."obstacle_id" RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       ObstacleEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Shape, nil, entity.NoFly, entity.CreatedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockUUID))
+				mock.ExpectQuery(regexp.QuoteMeta(areaQuery)).
+					WithArgs(mockUUID, 2, 3, 4, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockAreaID))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Shape, nil, entity.NoFly, entity.CreatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostObstacle(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
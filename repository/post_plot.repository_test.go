@@ -31,7 +31,7 @@ func TestRepository_PostPlot(t *testing.T) {
 			CreatedAt:   mockTime,
 		}
 
-		query = `INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`
+		query = `INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","species_id","health_status","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING "id"`
 	)
 
 	tests := []struct {
@@ -50,7 +50,7 @@ func TestRepository_PostPlot(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(entity.EstateId, entity.X, entity.Y, entity.Distance, entity.OrderNumber, entity.TreeHeight, entity.CreatedAt).
+					WithArgs(entity.EstateId, entity.X, entity.Y, entity.Distance, entity.OrderNumber, entity.TreeHeight, entity.SpeciesId, entity.HealthStatus, entity.CreatedAt).
 					WillReturnRows(rows)
 				mock.ExpectCommit()
 			},
@@ -63,7 +63,7 @@ func TestRepository_PostPlot(t *testing.T) {
 			prepareMock: func() {
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(query)).
-					WithArgs(entity.EstateId, entity.X, entity.Y, entity.Distance, entity.OrderNumber, entity.TreeHeight, entity.CreatedAt).
+					WithArgs(entity.EstateId, entity.X, entity.Y, entity.Distance, entity.OrderNumber, entity.TreeHeight, entity.SpeciesId, entity.HealthStatus, entity.CreatedAt).
 					WillReturnError(sql.ErrNoRows)
 				mock.ExpectRollback()
 			},
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/util"
+)
+
+func (r *Repository) PostPlots(ctx context.Context, entities []PlotEntity) ([]uuid.UUID, error) {
+	tx := util.GetTxFromContext(ctx, r.Db)
+	err := tx.WithContext(ctx).Create(&entities).Error
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make([]uuid.UUID, len(entities))
+	for i := range entities {
+		ids[i] = entities[i].ID
+	}
+	return ids, nil
+}
@@ -0,0 +1,83 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostPlots(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime     = time.Now()
+		mockEstateID = uuid.New()
+		firstID      = uuid.New()
+		secondID     = uuid.New()
+		// the inserted entities get their id, so every test inserts its own
+		entities = func() []PlotEntity {
+			return []PlotEntity{
+				{EstateId: mockEstateID, X: 1, Y: 1, Distance: 13, OrderNumber: 1, TreeHeight: 2, CreatedAt: mockTime},
+				{EstateId: mockEstateID, X: 3, Y: 1, Distance: 35, OrderNumber: 3, TreeHeight: 2, CreatedAt: mockTime},
+			}
+		}
+
+		query = `INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","species_id","health_status","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9),($10,$11,$12,$13,$14,$15,$16,$17,$18) RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		expectedResp []uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			expectedResp: []uuid.UUID{firstID, secondID},
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(firstID).AddRow(secondID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Insert Error",
+			expectedErr: sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostPlots(context.Background(), entities())
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostSensor(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime   = time.Now()
+		mockUUID   = uuid.New()
+		mockEstate = uuid.New()
+		mockName   = "SM-0042"
+		entity     = SensorEntity{
+			EstateId:  mockEstate,
+			Kind:      "soil_moisture",
+			X:         3,
+			Y:         7,
+			Name:      &mockName,
+			CreatedAt: mockTime,
+		}
+
+		query = `INSERT INTO "sensors" ("estate_id","kind","x","y","name","created_at") VALUES ($1,$2,$3,$4,$5,$6) RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       SensorEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Kind, entity.X, entity.Y, entity.Name, entity.CreatedAt).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Kind, entity.X, entity.Y, entity.Name, entity.CreatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostSensor(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
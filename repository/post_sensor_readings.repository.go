@@ -0,0 +1,55 @@
+package repository
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"spgo/util"
+)
+
+// sensorReadingBatchSize keeps the parameters of an insert of readings within the limit of postgres.
+const sensorReadingBatchSize = 1000
+
+// PostSensorReadings stores the readings and returns how many were stored, a reading of a sensor at a time already
+// stored is skipped. The partitions of the months of the readings are created first when they do not exist yet.
+func (r *Repository) PostSensorReadings(ctx context.Context, entities []SensorReadingEntity) (int, error) {
+	if len(entities) == 0 {
+		return 0, nil
+	}
+
+	tx := util.GetTxFromContext(ctx, r.Db)
+	for _, month := range sensorReadingMonths(entities) {
+		if err := tx.WithContext(ctx).Exec(`SELECT create_sensor_readings_partition(?)`, month).Error; err != nil {
+			return 0, err
+		}
+	}
+
+	res := tx.WithContext(ctx).
+		Session(&gorm.Session{CreateBatchSize: sensorReadingBatchSize}).
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(&entities)
+	if res.Error != nil {
+		return 0, res.Error
+	}
+	return int(res.RowsAffected), nil
+}
+
+// sensorReadingMonths returns the first instant of every month the readings were recorded in, the oldest first.
+func sensorReadingMonths(entities []SensorReadingEntity) []time.Time {
+	seen := make(map[time.Time]bool)
+	var months []time.Time
+	for _, entity := range entities {
+		at := entity.RecordedAt.UTC()
+		month := time.Date(at.Year(), at.Month(), 1, 0, 0, 0, 0, time.UTC)
+		if !seen[month] {
+			seen[month] = true
+			months = append(months, month)
+		}
+	}
+	slices.SortFunc(months, func(a, b time.Time) int { return a.Compare(b) })
+	return months
+}
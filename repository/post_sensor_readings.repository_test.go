@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostSensorReadings(t *testing.T) {
+	mockSensorID := uuid.New()
+	mockTime := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	entities := []SensorReadingEntity{
+		{SensorId: mockSensorID, RecordedAt: mockTime, Value: 31.5},
+		{SensorId: mockSensorID, RecordedAt: mockTime.Add(time.Hour), Value: 30},
+	}
+	mockMonth := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	query := `INSERT INTO "sensor_readings" ("sensor_id","recorded_at","value") VALUES ($1,$2,$3),($4,$5,$6) ON CONFLICT DO NOTHING`
+	partitionQuery := `SELECT create_sensor_readings_partition($1)`
+
+	tests := []struct {
+		name         string
+		entities     []SensorReadingEntity
+		prepareMock  func(mock sqlmock.Sqlmock)
+		expectedResp int
+		expectedErr  error
+	}{
+		{
+			name:     "Duplicate Skipped",
+			entities: entities,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(partitionQuery)).WithArgs(mockMonth).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(mockSensorID, mockTime, 31.5, mockSensorID, mockTime.Add(time.Hour), 30.0).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			expectedResp: 1,
+		},
+		{
+			name:     "Insert Error",
+			entities: entities,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(partitionQuery)).WithArgs(mockMonth).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+		{
+			name: "Partition Per Month",
+			entities: []SensorReadingEntity{
+				{SensorId: mockSensorID, RecordedAt: mockTime, Value: 31.5},
+				{SensorId: mockSensorID, RecordedAt: mockMonth.Add(-time.Hour), Value: 30},
+			},
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(partitionQuery)).WithArgs(mockMonth.AddDate(0, -1, 0)).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec(regexp.QuoteMeta(partitionQuery)).WithArgs(mockMonth).WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(mockSensorID, mockTime, 31.5, mockSensorID, mockMonth.Add(-time.Hour), 30.0).
+					WillReturnResult(sqlmock.NewResult(0, 2))
+				mock.ExpectCommit()
+			},
+			expectedResp: 2,
+		},
+		{
+			name:     "Partition Error",
+			entities: entities,
+			prepareMock: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec(regexp.QuoteMeta(partitionQuery)).WithArgs(mockMonth).WillReturnError(sql.ErrConnDone)
+			},
+			expectedErr: sql.ErrConnDone,
+		},
+		{
+			name:        "No Reading",
+			prepareMock: func(mock sqlmock.Sqlmock) {},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			tt.prepareMock(mock)
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			stored, err := repo.PostSensorReadings(context.Background(), tt.entities)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, stored)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
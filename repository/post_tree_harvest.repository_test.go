@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostTreeHarvest(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime = time.Now()
+		mockUUID = uuid.New()
+		grade    = "A"
+		entity   = TreeHarvestEntity{
+			PlotId:      &mockUUID,
+			EstateId:    mockUUID,
+			X:           4,
+			Y:           2,
+			Quantity:    24.5,
+			Grade:       &grade,
+			HarvestedAt: mockTime,
+			CreatedAt:   mockTime,
+		}
+
+		query = `INSERT INTO "tree_harvests" ("plot_id","estate_id","x","y","quantity","grade","harvested_at","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       TreeHarvestEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockUUID)
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.PlotId, entity.EstateId, entity.X, entity.Y, entity.Quantity, entity.Grade, entity.HarvestedAt, entity.CreatedAt).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.PlotId, entity.EstateId, entity.X, entity.Y, entity.Quantity, entity.Grade, entity.HarvestedAt, entity.CreatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostTreeHarvest(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
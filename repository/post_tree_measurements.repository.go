@@ -0,0 +1,12 @@
+package repository
+
+import (
+	"context"
+
+	"spgo/util"
+)
+
+func (r *Repository) PostTreeMeasurements(ctx context.Context, entities []TreeMeasurementEntity) error {
+	tx := util.GetTxFromContext(ctx, r.Db)
+	return tx.WithContext(ctx).Create(&entities).Error
+}
@@ -0,0 +1,82 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostTreeMeasurements(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime     = time.Now()
+		mockEstateID = uuid.New()
+		firstPlotID  = uuid.New()
+		secondPlotID = uuid.New()
+		// the inserted entities get their id, so every test inserts its own
+		entities = func() []TreeMeasurementEntity {
+			return []TreeMeasurementEntity{
+				{PlotId: firstPlotID, EstateId: mockEstateID, Height: 2, MeasuredAt: mockTime, CreatedAt: mockTime},
+				{PlotId: secondPlotID, EstateId: mockEstateID, Height: 2, MeasuredAt: mockTime, CreatedAt: mockTime},
+			}
+		}
+
+		query = `INSERT INTO "tree_measurements" ("plot_id","estate_id","height","measured_at","created_at") VALUES ($1,$2,$3,$4,$5),($6,$7,$8,$9,$10) RETURNING "id"`
+	)
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		prepareMock func()
+	}{
+		{
+			name: "Successful Insert",
+			prepareMock: func() {
+				rows := sqlmock.NewRows([]string{"id"}).AddRow(uuid.New()).AddRow(uuid.New())
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(firstPlotID, mockEstateID, 2, mockTime, mockTime, secondPlotID, mockEstateID, 2, mockTime, mockTime).
+					WillReturnRows(rows)
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Insert Error",
+			expectedErr: sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			err = repo.PostTreeMeasurements(context.Background(), entities())
+			assert.Equal(t, tt.expectedErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_PostWorkOrder(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime   = time.Now()
+		mockUUID   = uuid.New()
+		mockAreaID = uuid.New()
+		mockEstate = uuid.New()
+		assignee   = "Budi"
+		entity     = WorkOrderEntity{
+			EstateId:  mockEstate,
+			Kind:      "prune",
+			Status:    "assigned",
+			Shape:     "rectangle",
+			Assignee:  &assignee,
+			Areas:     []WorkOrderAreaEntity{{MinX: 2, MinY: 3, MaxX: 4, MaxY: 5}},
+			CreatedAt: mockTime,
+			UpdatedAt: mockTime,
+		}
+
+		query     = `INSERT INTO "work_orders" ("estate_id","kind","status","shape","tree_id","assignee","notes","height","species_id","completed_at","created_at","updated_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12) RETURNING "id"`
+		areaQuery = `INSERT INTO "work_order_areas" ("work_order_id","min_x","min_y","max_x","max_y") VALUES ($1,$2,$3,$4,$5) ON CONFLICT ("id") DO UPDATE SET "work_order_id"="excluded"."work_order_id" RETURNING "id"`
+	)
+
+	tests := []struct {
+		name         string
+		entity       WorkOrderEntity
+		expectedResp *uuid.UUID
+		expectedErr  error
+		prepareMock  func()
+	}{
+		{
+			name:         "Successful Insert",
+			entity:       entity,
+			expectedResp: &mockUUID,
+			expectedErr:  nil,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Kind, entity.Status, entity.Shape, nil, entity.Assignee, nil, nil, nil, nil, entity.CreatedAt, entity.UpdatedAt).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockUUID))
+				mock.ExpectQuery(regexp.QuoteMeta(areaQuery)).
+					WithArgs(mockUUID, 2, 3, 4, 5).
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(mockAreaID))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:         "Insert Error",
+			entity:       entity,
+			expectedResp: nil,
+			expectedErr:  sql.ErrNoRows,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectQuery(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Kind, entity.Status, entity.Shape, nil, entity.Assignee, nil, nil, nil, nil, entity.CreatedAt, entity.UpdatedAt).
+					WillReturnError(sql.ErrNoRows)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			resp, err := repo.PostWorkOrder(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+			assert.Equal(t, tt.expectedResp, resp)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm/clause"
+
+	"spgo/util"
+)
+
+// SaveEstateElevation stores the elevation grid of an estate in place of the one it had.
+func (r *Repository) SaveEstateElevation(ctx context.Context, entity EstateElevationEntity) error {
+	tx := util.GetTxFromContext(ctx, r.Db)
+	return tx.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(&entity).Error
+}
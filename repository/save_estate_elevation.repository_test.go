@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_SaveEstateElevation(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		mockTime = time.Now()
+		entity   = EstateElevationEntity{
+			EstateId:   uuid.New(),
+			Columns:    2,
+			Rows:       1,
+			CellSize:   10,
+			Elevations: []byte{0, 0, 200, 66, 0, 0, 208, 66},
+			CreatedAt:  mockTime,
+		}
+
+		query = `INSERT INTO "estate_elevations" ("estate_id","columns","rows","cell_size","elevations","created_at") VALUES ($1,$2,$3,$4,$5,$6) ON CONFLICT ("estate_id") DO UPDATE SET "columns"="excluded"."columns","rows"="excluded"."rows","cell_size"="excluded"."cell_size","elevations"="excluded"."elevations"`
+	)
+
+	tests := []struct {
+		name        string
+		entity      EstateElevationEntity
+		expectedErr error
+		prepareMock func()
+	}{
+		{
+			name:        "Successful Upsert",
+			entity:      entity,
+			expectedErr: nil,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Columns, entity.Rows, entity.CellSize, entity.Elevations, entity.CreatedAt).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Upsert Error",
+			entity:      entity,
+			expectedErr: sql.ErrConnDone,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.EstateId, entity.Columns, entity.Rows, entity.CellSize, entity.Elevations, entity.CreatedAt).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			err = repo.SaveEstateElevation(context.Background(), tt.entity)
+			assert.Equal(t, tt.expectedErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -7,15 +7,28 @@ import (
 )
 
 type EstateEntity struct {
-	ID               uuid.UUID `gorm:"default:uuid_generate_v4()"`
-	Width            int
-	Length           int
-	TotalDistance    int
-	TreeCount        int
-	TreeMaxHeight    int
-	TreeMinHeight    int
-	TreeMedianHeight int
-	CreatedAt        time.Time
+	ID                uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	Width             int
+	Length            int
+	TotalDistance     int
+	TreeCount         int
+	TreeMaxHeight     int
+	TreeMinHeight     int
+	TreeMedianHeight  int
+	AnchorLatitude    *float64
+	AnchorLongitude   *float64
+	Bearing           float64
+	PlotSize          float64 `gorm:"default:10"`
+	Clearance         int     `gorm:"default:1"`
+	MinCruiseAltitude int
+	StartEndAltitude  int
+	TraversalPattern  string `gorm:"default:row_serpentine"`
+	TraversalCorner   string `gorm:"default:x_min_y_min"`
+	BlockedPlots      int
+	ShapeMask         []byte
+	Relief            int
+	ExcludeDeadTrees  bool
+	CreatedAt         time.Time
 }
 
 func (EstateEntity) TableName() string {
@@ -23,17 +36,394 @@ func (EstateEntity) TableName() string {
 }
 
 // x & y max will be 50,000. it enough to use uint16 which could store until 65,535
+// HealthStatus is the status of the latest inspection of the tree, nil while it was never inspected.
 type PlotEntity struct {
+	ID           uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId     uuid.UUID
+	X            uint16
+	Y            uint16
+	Distance     int
+	OrderNumber  int
+	TreeHeight   int
+	SpeciesId    *uuid.UUID
+	HealthStatus *string
+	CreatedAt    time.Time
+}
+
+func (PlotEntity) TableName() string {
+	return "plots"
+}
+
+// TreeMeasurementEntity is a height measurement of a tree, SpeciesId and RemovedAt are the species of the tree and the
+// time it was removed once it is removed. They are only written when the tree is removed.
+type TreeMeasurementEntity struct {
+	ID         uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	PlotId     uuid.UUID
+	EstateId   uuid.UUID
+	Height     int
+	MeasuredAt time.Time
+	SpeciesId  *uuid.UUID `gorm:"->"`
+	RemovedAt  *time.Time `gorm:"->"`
+	CreatedAt  time.Time
+}
+
+func (TreeMeasurementEntity) TableName() string {
+	return "tree_measurements"
+}
+
+// TreeInspectionEntity is an observation of a tree by a drone pass, Notes, ImageRef and Height are nil when they were
+// not recorded.
+type TreeInspectionEntity struct {
+	ID           uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	PlotId       uuid.UUID
+	EstateId     uuid.UUID
+	HealthStatus string
+	Notes        *string
+	ImageRef     *string
+	Height       *int
+	InspectedAt  time.Time
+	CreatedAt    time.Time
+}
+
+func (TreeInspectionEntity) TableName() string {
+	return "tree_inspections"
+}
+
+// TreeHarvestEntity is a harvest of a tree, Quantity is in kilograms and Grade is nil when the harvest was not graded.
+// PlotId is nil once the tree was removed, X and Y stay the plot of the tree.
+type TreeHarvestEntity struct {
 	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	PlotId      *uuid.UUID
 	EstateId    uuid.UUID
-	X           uint16
-	Y           uint16
-	Distance    int
-	OrderNumber int
-	TreeHeight  int
+	X           int
+	Y           int
+	Quantity    float64
+	Grade       *string
+	HarvestedAt time.Time
 	CreatedAt   time.Time
 }
 
-func (PlotEntity) TableName() string {
-	return "plots"
+func (TreeHarvestEntity) TableName() string {
+	return "tree_harvests"
+}
+
+type DroneProfileEntity struct {
+	ID              uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	Name            string
+	HorizontalSpeed float64
+	ClimbSpeed      float64
+	DescentSpeed    float64
+	HorizontalPower float64
+	ClimbPower      float64
+	DescentPower    float64
+	BatteryCapacity float64
+	CreatedAt       time.Time
+}
+
+func (DroneProfileEntity) TableName() string {
+	return "drone_profiles"
+}
+
+// SpeciesEntity is a tree species of the catalog, GrowthRate and HarvestCycle are nil when they are not known.
+type SpeciesEntity struct {
+	ID           uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	Name         string
+	MaxHeight    int
+	GrowthRate   *float64
+	HarvestCycle *int
+	CreatedAt    time.Time
+}
+
+func (SpeciesEntity) TableName() string {
+	return "species"
+}
+
+type ChargingPadEntity struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId  uuid.UUID
+	X         uint16
+	Y         uint16
+	CreatedAt time.Time
+}
+
+func (ChargingPadEntity) TableName() string {
+	return "charging_pads"
+}
+
+// ObstacleEntity is an obstacle over plots of an estate, Height is nil for a no-fly zone.
+type ObstacleEntity struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId  uuid.UUID
+	Shape     string
+	Height    *int
+	NoFly     bool
+	Areas     []ObstacleAreaEntity `gorm:"foreignKey:ObstacleId"`
+	CreatedAt time.Time
+}
+
+func (ObstacleEntity) TableName() string {
+	return "obstacles"
+}
+
+// ObstacleAreaEntity is a rectangle of plots covered by an obstacle, both bounds are inclusive.
+type ObstacleAreaEntity struct {
+	ID         uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	ObstacleId uuid.UUID
+	MinX       uint16
+	MinY       uint16
+	MaxX       uint16
+	MaxY       uint16
+}
+
+func (ObstacleAreaEntity) TableName() string {
+	return "obstacle_areas"
+}
+
+// EstateElevationEntity is the elevation grid of an estate, the elevations of its cells as little endian float32
+// row by row from y 1 up.
+type EstateElevationEntity struct {
+	EstateId   uuid.UUID `gorm:"primaryKey"`
+	Columns    int
+	Rows       int
+	CellSize   float64
+	Elevations []byte
+	CreatedAt  time.Time
+}
+
+func (EstateElevationEntity) TableName() string {
+	return "estate_elevations"
+}
+
+// FlightLogEntity is the telemetry of a real flight over an estate, MaxDistance is the one it was planned with, nil
+// when the plan was a single sortie.
+type FlightLogEntity struct {
+	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId    uuid.UUID
+	MaxDistance *int
+	StartedAt   time.Time
+	EndedAt     time.Time
+	Samples     []FlightLogSampleEntity `gorm:"foreignKey:FlightLogId"`
+	CreatedAt   time.Time
+}
+
+func (FlightLogEntity) TableName() string {
+	return "flight_logs"
+}
+
+// FlightLogSampleEntity is a sample of a flight log, X and Y are the position in plots, whole at the plot centers.
+type FlightLogSampleEntity struct {
+	FlightLogId uuid.UUID `gorm:"primaryKey"`
+	Sequence    int       `gorm:"primaryKey;autoIncrement:false"`
+	Sortie      int
+	RecordedAt  time.Time
+	X           float64
+	Y           float64
+	Altitude    float64
+	Battery     float64
+}
+
+func (FlightLogSampleEntity) TableName() string {
+	return "flight_log_samples"
+}
+
+// WorkOrderEntity is a task on the trees of an estate, TreeId is set when it targets a tree and Height and SpeciesId
+// are the saplings of a replant order.
+type WorkOrderEntity struct {
+	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId    uuid.UUID
+	Kind        string
+	Status      string
+	Shape       string
+	TreeId      *uuid.UUID
+	Assignee    *string
+	Notes       *string
+	Height      *int
+	SpeciesId   *uuid.UUID
+	Areas       []WorkOrderAreaEntity `gorm:"foreignKey:WorkOrderId"`
+	CompletedAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func (WorkOrderEntity) TableName() string {
+	return "work_orders"
+}
+
+// WorkOrderAreaEntity is a rectangle of plots targeted by a work order, both bounds are inclusive.
+type WorkOrderAreaEntity struct {
+	ID          uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	WorkOrderId uuid.UUID
+	MinX        uint16
+	MinY        uint16
+	MaxX        uint16
+	MaxY        uint16
+}
+
+func (WorkOrderAreaEntity) TableName() string {
+	return "work_order_areas"
+}
+
+// SensorEntity is a soil probe on a plot of an estate, Kind is soil_moisture or temperature.
+type SensorEntity struct {
+	ID        uuid.UUID `gorm:"default:uuid_generate_v4()"`
+	EstateId  uuid.UUID
+	Kind      string
+	X         uint16
+	Y         uint16
+	Name      *string
+	CreatedAt time.Time
+}
+
+func (SensorEntity) TableName() string {
+	return "sensors"
+}
+
+// SensorReadingEntity is a value recorded by a sensor, the soil moisture in percent or the temperature in degrees
+// Celsius.
+type SensorReadingEntity struct {
+	SensorId   uuid.UUID `gorm:"primaryKey"`
+	RecordedAt time.Time `gorm:"primaryKey"`
+	Value      float64
+}
+
+func (SensorReadingEntity) TableName() string {
+	return "sensor_readings"
+}
+
+// TreeHeightStats is the aggregated tree height of an estate, Median is kept fractional
+// so the caller decides how to round it.
+type TreeHeightStats struct {
+	Count  int
+	Min    int
+	Max    int
+	Median float64
+}
+
+type TreeHeightDistribution struct {
+	TreeHeightStats
+	Mean        float64
+	StdDev      float64
+	Percentiles []TreeHeightPercentile `gorm:"-"`
+	Histogram   []TreeHeightBucket     `gorm:"-"`
+}
+
+type TreeHeightPercentile struct {
+	Fraction float64
+	Height   float64
+}
+
+// TreeHeightBucket counts the trees with a height in [MinHeight, MinHeight+1).
+type TreeHeightBucket struct {
+	MinHeight int
+	Count     int
+}
+
+// PlotRegion is a rectangle of plots, both bounds are inclusive.
+type PlotRegion struct {
+	MinX int
+	MinY int
+	MaxX int
+	MaxY int
+}
+
+// TreeHeightFilter selects the trees aggregated by the tree height queries. AsOf uses the latest measurement of every
+// tree at that time instead of its current height, Region limits the trees to a part of the estate.
+type TreeHeightFilter struct {
+	AsOf   *time.Time
+	Region *PlotRegion
+}
+
+// SpeciesTreeHeightStats is the aggregated tree height of the trees of a species, SpeciesId and Name are nil for the
+// trees without species.
+type SpeciesTreeHeightStats struct {
+	SpeciesId *uuid.UUID
+	Name      *string
+	TreeHeightStats
+}
+
+// HealthTreeHeightStats is the aggregated tree height of the trees of a health status, HealthStatus is nil for the
+// trees never inspected.
+type HealthTreeHeightStats struct {
+	HealthStatus *string
+	TreeHeightStats
+}
+
+// YieldFilter selects and groups the harvests aggregated by GetYieldStats. GroupBy is one of estate, tree, row and
+// species, Period the date_trunc unit the harvests are split by when it is not nil. From is inclusive, To exclusive.
+type YieldFilter struct {
+	GroupBy string
+	Period  *string
+	From    *time.Time
+	To      *time.Time
+	Grade   *string
+}
+
+// YieldStats is the aggregated yield of a group of harvests, only the columns of its grouping are set. Trees and
+// TreeHeight, the sum of their current heights, only count the trees still standing, StandingQuantity is their part
+// of Quantity.
+type YieldStats struct {
+	TreeId           *uuid.UUID
+	X                *int
+	Y                *int
+	SpeciesId        *uuid.UUID
+	Name             *string
+	Period           *time.Time
+	Harvests         int
+	Trees            int
+	Quantity         float64
+	StandingQuantity float64
+	TreeHeight       int
+}
+
+// WorkOrderFilter selects the work orders of an estate, any of Statuses and any kind or assignee when they are nil.
+type WorkOrderFilter struct {
+	Statuses []string
+	Kind     *string
+	Assignee *string
+}
+
+// SensorFilter selects the readings of the sensors of an estate recorded from From inclusive to To exclusive, of any
+// kind when Kind is nil and of any plot when X and Y are nil.
+type SensorFilter struct {
+	Kind *string
+	X    *int
+	Y    *int
+	From time.Time
+	To   time.Time
+}
+
+// LatestSensorReading is the last reading of a sensor with the kind and the plot of the sensor.
+type LatestSensorReading struct {
+	SensorId   uuid.UUID
+	Kind       string
+	X          int
+	Y          int
+	Value      float64
+	RecordedAt time.Time
+}
+
+// SensorStats is the aggregated readings of the sensors of a kind, Sensors counts the sensors with readings.
+type SensorStats struct {
+	Kind     string
+	Sensors  int
+	Readings int
+	Min      float64
+	Max      float64
+	Mean     float64
+}
+
+// SensorBucket is the aggregated readings of a time bucket starting at Start.
+type SensorBucket struct {
+	Start    time.Time
+	Readings int
+	Min      float64
+	Max      float64
+	Mean     float64
+}
+
+type TileTreeHeightStats struct {
+	TileX int
+	TileY int
+	TreeHeightStats
+	Mean float64
 }
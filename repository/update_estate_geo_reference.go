@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"spgo/util"
+)
+
+// UpdateEstateGeoReference only writes the geo reference columns so it never overwrites the tree stats of the estate.
+func (r *Repository) UpdateEstateGeoReference(ctx context.Context, entity EstateEntity) error {
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	result := tx.WithContext(ctx).Model(&EstateEntity{}).
+		Where("id = ?", entity.ID).
+		Select("anchor_latitude", "anchor_longitude", "bearing", "plot_size").
+		Updates(&entity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
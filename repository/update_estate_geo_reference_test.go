@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_UpdateEstateGeoReference(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		latitude  = -6.2
+		longitude = 106.8
+		entity    = EstateEntity{
+			ID:              uuid.New(),
+			AnchorLatitude:  &latitude,
+			AnchorLongitude: &longitude,
+			Bearing:         15,
+			PlotSize:        10,
+		}
+
+		query = `UPDATE "estates" SET "anchor_latitude"=$1,"anchor_longitude"=$2,"bearing"=$3,"plot_size"=$4 WHERE id = $5`
+	)
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		prepareMock func()
+	}{
+		{
+			name:        "Successful Update",
+			expectedErr: nil,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(latitude, longitude, entity.Bearing, entity.PlotSize, entity.ID).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Estate Not Found",
+			expectedErr: gorm.ErrRecordNotFound,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(latitude, longitude, entity.Bearing, entity.PlotSize, entity.ID).
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Update Error",
+			expectedErr: sql.ErrConnDone,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(latitude, longitude, entity.Bearing, entity.PlotSize, entity.ID).
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			err = repo.UpdateEstateGeoReference(context.Background(), entity)
+			assert.Equal(t, tt.expectedErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"spgo/util"
+)
+
+/*
+UpdateWorkOrderStatus only writes the status columns of a work order, and only while it is still in status from so
+two concurrent moves of the same order cannot both succeed. It returns gorm.ErrRecordNotFound when the order is
+missing or was moved in between.
+*/
+func (r *Repository) UpdateWorkOrderStatus(ctx context.Context, entity WorkOrderEntity, from string) error {
+	tx := util.GetTxFromContext(ctx, r.Db)
+
+	result := tx.WithContext(ctx).Model(&WorkOrderEntity{}).
+		Where("id = ? AND status = ?", entity.ID, from).
+		Select("status", "assignee", "completed_at", "updated_at").
+		Updates(&entity)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+func TestRepository_UpdateWorkOrderStatus(t *testing.T) {
+	var (
+		db   *sql.DB
+		mock sqlmock.Sqlmock
+
+		completedAt = time.Now()
+		assignee    = "Budi"
+		entity      = WorkOrderEntity{
+			ID:          uuid.New(),
+			Status:      "completed",
+			Assignee:    &assignee,
+			CompletedAt: &completedAt,
+		}
+
+		query = `UPDATE "work_orders" SET "status"=$1,"assignee"=$2,"completed_at"=$3,"updated_at"=$4 WHERE id = $5 AND status = $6`
+	)
+
+	tests := []struct {
+		name        string
+		expectedErr error
+		prepareMock func()
+	}{
+		{
+			name:        "Successful Update",
+			expectedErr: nil,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.Status, assignee, completedAt, sqlmock.AnyArg(), entity.ID, "in_progress").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Moved In Between",
+			expectedErr: gorm.ErrRecordNotFound,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.Status, assignee, completedAt, sqlmock.AnyArg(), entity.ID, "in_progress").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name:        "Update Error",
+			expectedErr: sql.ErrConnDone,
+			prepareMock: func() {
+				mock.ExpectBegin()
+				mock.ExpectExec(regexp.QuoteMeta(query)).
+					WithArgs(entity.Status, assignee, completedAt, sqlmock.AnyArg(), entity.ID, "in_progress").
+					WillReturnError(sql.ErrConnDone)
+				mock.ExpectRollback()
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, _ = sqlmock.New()
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			tt.prepareMock()
+
+			repo := NewRepository(NewRepositoryOptions{Db: gdb})
+
+			err = repo.UpdateWorkOrderStatus(context.Background(), entity, "in_progress")
+			assert.Equal(t, tt.expectedErr, err)
+
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) AddChargingPad(ctx context.Context, estateId uuid.UUID, req generated.ChargingPadRequest) (generated.ChargingPadResponse, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.ChargingPadResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.ChargingPadResponse{}, http.StatusInternalServerError, err
+	}
+
+	if !inEstate(estate, req.X, req.Y) {
+		return generated.ChargingPadResponse{}, http.StatusBadRequest, errors.New("x or y is out of range")
+	}
+
+	obstacles, err := s.loadObstacles(ctx, estate)
+	if err != nil {
+		return generated.ChargingPadResponse{}, http.StatusInternalServerError, err
+	}
+	if obstacles.blocked(req.X, req.Y) {
+		return generated.ChargingPadResponse{}, http.StatusBadRequest, errors.New("plot with coordinate x and y is blocked by an obstacle")
+	}
+
+	pads, err := s.Repository.GetChargingPads(ctx, estateId)
+	if err != nil {
+		return generated.ChargingPadResponse{}, http.StatusInternalServerError, err
+	}
+	if len(pads) >= maxChargingPads {
+		return generated.ChargingPadResponse{}, http.StatusBadRequest, fmt.Errorf("estate cannot have more than %d charging pads", maxChargingPads)
+	}
+	for _, pad := range pads {
+		if int(pad.X) == req.X && int(pad.Y) == req.Y {
+			return generated.ChargingPadResponse{}, http.StatusBadRequest, errors.New("plot with coordinate x and y already has a charging pad")
+		}
+	}
+
+	id, err := s.Repository.PostChargingPad(ctx, repository.ChargingPadEntity{
+		EstateId: estateId,
+		X:        uint16(req.X),
+		Y:        uint16(req.Y),
+	})
+	if err != nil {
+		return generated.ChargingPadResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.ChargingPadResponse{Id: id}, http.StatusCreated, nil
+}
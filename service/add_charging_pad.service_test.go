@@ -0,0 +1,131 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddChargingPad(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	mockUUID := uuid.New()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 5}
+	fullEstate := make([]repository.ChargingPadEntity, 100)
+	blockedEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 5, BlockedPlots: 4}
+	mockObstacle := repository.ObstacleEntity{NoFly: true, Areas: []repository.ObstacleAreaEntity{{MinX: 2, MinY: 2, MaxX: 3, MaxY: 3}}}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.ChargingPadRequest
+		expectedResp   generated.ChargingPadResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Add",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return([]repository.ChargingPadEntity{{X: 1, Y: 1}}, nil)
+				mockRepo.EXPECT().PostChargingPad(gomock.Any(), repository.ChargingPadEntity{EstateId: mockEstateID, X: 10, Y: 5}).Return(&mockUUID, nil)
+			},
+			request:        generated.ChargingPadRequest{X: 10, Y: 5},
+			expectedResp:   generated.ChargingPadResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Out Of Range",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			request:        generated.ChargingPadRequest{X: 11, Y: 5},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("x or y is out of range"),
+		},
+		{
+			name: "Plot Already Has A Pad",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return([]repository.ChargingPadEntity{{X: 1, Y: 1}, {X: 3, Y: 2}}, nil)
+			},
+			request:        generated.ChargingPadRequest{X: 3, Y: 2},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot with coordinate x and y already has a charging pad"),
+		},
+		{
+			name: "Plot Blocked By An Obstacle",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(blockedEstate, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return([]repository.ObstacleEntity{mockObstacle}, nil)
+			},
+			request:        generated.ChargingPadRequest{X: 3, Y: 2},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot with coordinate x and y is blocked by an obstacle"),
+		},
+		{
+			name: "Too Many Pads",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(fullEstate, nil)
+			},
+			request:        generated.ChargingPadRequest{X: 3, Y: 2},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("estate cannot have more than 100 charging pads"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			request:        generated.ChargingPadRequest{X: 1, Y: 1},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().PostChargingPad(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+			},
+			request:        generated.ChargingPadRequest{X: 1, Y: 1},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.AddChargingPad(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) AddFlightLog(ctx context.Context, estateId uuid.UUID, log []byte, params generated.AddFlightLogParams) (generated.FlightLogReport, int, error) {
+	if params.MaxDistance != nil && *params.MaxDistance < 1 {
+		return generated.FlightLogReport{}, http.StatusBadRequest, errors.New("max_distance must be at least 1")
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FlightLogReport{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.FlightLogReport{}, http.StatusInternalServerError, err
+	}
+
+	var geo *geoReference
+	if g, ok := newGeoReference(estate); ok {
+		geo = &g
+	}
+	samples, err := parseFlightLog(log, geo)
+	if err != nil {
+		return generated.FlightLogReport{}, http.StatusBadRequest, err
+	}
+
+	// the plan is loaded first so a max_distance it cannot be cut by is not stored
+	plan, planned, httpStatus, err := s.flightLogPlan(ctx, estate, params.MaxDistance)
+	if err != nil {
+		return generated.FlightLogReport{}, httpStatus, err
+	}
+
+	flightLog := repository.FlightLogEntity{
+		EstateId:    estateId,
+		MaxDistance: params.MaxDistance,
+		StartedAt:   samples[0].RecordedAt,
+		EndedAt:     samples[len(samples)-1].RecordedAt,
+		Samples:     samples,
+	}
+	id, err := s.Repository.PostFlightLog(ctx, flightLog)
+	if err != nil {
+		return generated.FlightLogReport{}, http.StatusInternalServerError, err
+	}
+	flightLog.ID = *id
+
+	return flightLogReport(flightLog, estate, plan, planned), http.StatusCreated, nil
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddFlightLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockLogID := uuid.New()
+	mockContext := context.TODO()
+	maxDistance := 15
+	restPlot := generated.PlotPosition{X: &[]int{2}[0], Y: &[]int{1}[0]}
+
+	// a row of 3 empty plots flown 1 meter high, the plan flies plots 1 and 2 in a sortie of 12 meters and plot 3 in one of 2 meters
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 1, TotalDistance: 22, PlotSize: 10, Clearance: 1, MinCruiseAltitude: 1}
+	// the first sortie lands 3 meters past plot 2, the battery swapped the drone flies the second one as planned
+	mockLog := "timestamp,x,y,altitude,battery\n" +
+		"2026-10-01T08:00:00Z,1,1,0,100\n" +
+		"2026-10-01T08:00:05Z,1,1,1,99\n" +
+		"2026-10-01T08:00:10Z,2,1,1,97\n" +
+		"2026-10-01T08:00:15Z,2.3,1,0,96\n" +
+		"2026-10-01T08:05:00Z,3,1,0,100\n" +
+		"2026-10-01T08:05:05Z,3,1,1,99\n" +
+		"2026-10-01T08:05:10Z,3,1,0,98\n"
+
+	expectPlan := func(mockRepo *repository.MockRepositoryInterface) {
+		mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+		mockRepo.EXPECT().GetPlotByDistance(gomock.Any(), mockEstateID, maxDistance-1).Return(nil, gorm.ErrRecordNotFound)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+	}
+
+	tests := []struct {
+		name           string
+		log            string
+		params         generated.AddFlightLogParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   func(resp generated.FlightLogReport)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Successful Comparison",
+			log:    mockLog,
+			params: generated.AddFlightLogParams{MaxDistance: &maxDistance},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil).Times(2)
+				expectPlan(mockRepo)
+				mockRepo.EXPECT().PostFlightLog(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.FlightLogEntity) (*uuid.UUID, error) {
+					require.Equal(t, mockEstateID, entity.EstateId)
+					require.Equal(t, &maxDistance, entity.MaxDistance)
+					require.Equal(t, time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC), entity.StartedAt)
+					require.Equal(t, time.Date(2026, 10, 1, 8, 5, 10, 0, time.UTC), entity.EndedAt)
+					require.Len(t, entity.Samples, 7)
+					require.Equal(t, []int{1, 1, 1, 1, 2, 2, 2}, []int{
+						entity.Samples[0].Sortie, entity.Samples[1].Sortie, entity.Samples[2].Sortie, entity.Samples[3].Sortie,
+						entity.Samples[4].Sortie, entity.Samples[5].Sortie, entity.Samples[6].Sortie,
+					})
+					return &mockLogID, nil
+				})
+			},
+			expectedResp: func(resp generated.FlightLogReport) {
+				assert.Equal(t, mockLogID, *resp.Id)
+				assert.Equal(t, 17, *resp.Distance)
+				assert.Equal(t, 14, *resp.PlannedDistance)
+				assert.Equal(t, 3, *resp.DistanceDeviation)
+				assert.Equal(t, restPlot, *resp.Rest)
+				assert.Equal(t, restPlot, *resp.ActualRest)
+				assert.Equal(t, 3, *resp.RestDeviation)
+				// 17 meters for 6 percent of battery
+				assert.InDelta(t, 2.833, *resp.BatteryDistance, 1e-3)
+				assert.Equal(t, 283, *resp.MaxDistanceEstimate)
+
+				require.Len(t, *resp.Sorties, 2)
+				first, second := (*resp.Sorties)[0], (*resp.Sorties)[1]
+				assert.Equal(t, 15, *first.Distance)
+				assert.Equal(t, 12, *first.PlannedDistance)
+				assert.Equal(t, 3, *first.DistanceDeviation)
+				assert.Equal(t, restPlot, *first.PlannedEnd)
+				assert.Equal(t, 0, *first.TakeoffDeviation)
+				assert.Equal(t, 3, *first.LandingDeviation)
+				assert.Equal(t, 4.0, *first.BatteryUsed)
+				assert.Equal(t, 2, *second.Sortie)
+				assert.Equal(t, 2, *second.Distance)
+				assert.Equal(t, 0, *second.DistanceDeviation)
+				assert.Equal(t, 0, *second.LandingDeviation)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Sortie Column Beyond The Plan",
+			log:  "time,x,y,alt,battery,sortie\n1790000000,1,1,0,90,1\n1790000001,2,1,0,89,1\n1790000002,2,1,0,89,2\n1790000003,3,1,0,88,2\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil).Times(2)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().PostFlightLog(gomock.Any(), gomock.Any()).Return(&mockLogID, nil)
+			},
+			expectedResp: func(resp generated.FlightLogReport) {
+				assert.Nil(t, resp.Rest)
+				assert.Nil(t, resp.RestDeviation)
+				assert.Equal(t, 22, *resp.PlannedDistance)
+				require.Len(t, *resp.Sorties, 2)
+				// the whole plan is a single sortie, the second one flown has nothing to be compared with
+				assert.Equal(t, 10, *(*resp.Sorties)[0].Distance)
+				assert.Equal(t, -12, *(*resp.Sorties)[0].DistanceDeviation)
+				assert.Equal(t, 10, *(*resp.Sorties)[0].LandingDeviation)
+				assert.Nil(t, (*resp.Sorties)[1].PlannedDistance)
+				assert.Nil(t, (*resp.Sorties)[1].DistanceDeviation)
+				assert.Equal(t, 10, *(*resp.Sorties)[1].Distance)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Max Distance Below 1",
+			log:            mockLog,
+			params:         generated.AddFlightLogParams{MaxDistance: &[]int{0}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance must be at least 1"),
+		},
+		{
+			name: "Estate Not Found",
+			log:  mockLog,
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Latitude Without Geo-Reference",
+			log:  "timestamp,latitude,longitude,altitude,battery\n1790000000,0,0,0,90\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("estate is not geo-referenced, the flight log positions must be given in x and y"),
+		},
+		{
+			name: "Invalid Battery",
+			log:  "timestamp,x,y,altitude,battery\n1790000000,1,1,0,90\n1790000001,1,1,0,120\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("flight log line 3: battery must be between 0 and 100"),
+		},
+		{
+			name:   "Max Distance Too Short For The Plan",
+			log:    mockLog,
+			params: generated.AddFlightLogParams{MaxDistance: &[]int{1}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil).Times(2)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlotByDistance(gomock.Any(), mockEstateID, 0).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance is too short to fly over plot 1 of the traversal"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.AddFlightLog(mockContext, mockEstateID, []byte(tt.log), tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedResp != nil {
+				tt.expectedResp(resp)
+			}
+		})
+	}
+}
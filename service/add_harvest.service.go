@@ -0,0 +1,67 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) AddHarvest(ctx context.Context, estateId uuid.UUID, req generated.HarvestRequest) (generated.HarvestResponse, int, error) {
+	if req.Quantity <= 0 || req.Quantity > maxHarvestQuantity {
+		return generated.HarvestResponse{}, http.StatusBadRequest, fmt.Errorf("quantity must be greater than 0 and at most %d", maxHarvestQuantity)
+	}
+	if req.Grade != nil && len(*req.Grade) > maxHarvestGrade {
+		return generated.HarvestResponse{}, http.StatusBadRequest, fmt.Errorf("grade cannot be longer than %d characters", maxHarvestGrade)
+	}
+
+	harvestedAt := time.Now()
+	if req.HarvestedAt != nil {
+		if req.HarvestedAt.After(harvestedAt) {
+			return generated.HarvestResponse{}, http.StatusBadRequest, errors.New("harvested_at cannot be in the future")
+		}
+		harvestedAt = *req.HarvestedAt
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.HarvestResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.HarvestResponse{}, http.StatusInternalServerError, err
+	}
+
+	if !inEstate(estate, req.X, req.Y) {
+		return generated.HarvestResponse{}, http.StatusBadRequest, errors.New("x or y is out of range")
+	}
+
+	treeId, err := s.Repository.GetPlotByXAndY(ctx, estateId, req.X, req.Y)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.HarvestResponse{}, http.StatusBadRequest, errors.New("plot with coordinate x and y holds no tree")
+		}
+		return generated.HarvestResponse{}, http.StatusInternalServerError, err
+	}
+
+	id, err := s.Repository.PostTreeHarvest(ctx, repository.TreeHarvestEntity{
+		PlotId:      treeId,
+		EstateId:    estateId,
+		X:           req.X,
+		Y:           req.Y,
+		Quantity:    req.Quantity,
+		Grade:       req.Grade,
+		HarvestedAt: harvestedAt,
+	})
+	if err != nil {
+		return generated.HarvestResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.HarvestResponse{Id: id, TreeId: treeId}, http.StatusCreated, nil
+}
@@ -0,0 +1,120 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddHarvest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockHarvestID := uuid.New()
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 5}
+	harvestedAt := time.Date(2026, 9, 14, 7, 30, 0, 0, time.UTC)
+	grade := "A"
+
+	tests := []struct {
+		name           string
+		req            generated.HarvestRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.HarvestResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Harvest",
+			req:  generated.HarvestRequest{X: 4, Y: 2, Quantity: 24.5, Grade: &grade, HarvestedAt: &harvestedAt},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 4, 2).Return(&mockTreeID, nil)
+				mockRepo.EXPECT().PostTreeHarvest(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.TreeHarvestEntity) (*uuid.UUID, error) {
+					require.Equal(t, repository.TreeHarvestEntity{
+						PlotId:      &mockTreeID,
+						EstateId:    mockEstateID,
+						X:           4,
+						Y:           2,
+						Quantity:    24.5,
+						Grade:       &grade,
+						HarvestedAt: harvestedAt,
+					}, entity)
+					return &mockHarvestID, nil
+				})
+			},
+			expectedResp:   generated.HarvestResponse{Id: &mockHarvestID, TreeId: &mockTreeID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Quantity Not Positive",
+			req:            generated.HarvestRequest{X: 4, Y: 2, Quantity: 0},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("quantity must be greater than 0 and at most 10000"),
+		},
+		{
+			name:           "Harvested In The Future",
+			req:            generated.HarvestRequest{X: 4, Y: 2, Quantity: 24.5, HarvestedAt: &[]time.Time{time.Now().Add(time.Hour)}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("harvested_at cannot be in the future"),
+		},
+		{
+			name: "Estate Not Found",
+			req:  generated.HarvestRequest{X: 4, Y: 2, Quantity: 24.5},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Out Of Range",
+			req:  generated.HarvestRequest{X: 6, Y: 2, Quantity: 24.5},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("x or y is out of range"),
+		},
+		{
+			name: "Plot Without Tree",
+			req:  generated.HarvestRequest{X: 4, Y: 3, Quantity: 24.5},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 4, 3).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot with coordinate x and y holds no tree"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.AddHarvest(mockContext, mockEstateID, tt.req)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/util"
+)
+
+func (s *Service) AddObstacle(ctx context.Context, estateId uuid.UUID, req generated.ObstacleRequest) (generated.ObstacleResponse, int, error) {
+	noFly := req.NoFly != nil && *req.NoFly
+	if noFly == (req.Height != nil) {
+		return generated.ObstacleResponse{}, http.StatusBadRequest, errors.New("an obstacle must have either a height or no_fly set to true")
+	}
+	if req.Height != nil && (*req.Height < 1 || *req.Height > 300) {
+		return generated.ObstacleResponse{}, http.StatusBadRequest, errors.New("height must be between 1 and 300")
+	}
+
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.ObstacleResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+
+	areas, shape, err := obstacleAreas(req, estate)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusBadRequest, err
+	}
+	entity := repository.ObstacleEntity{EstateId: estateId, Shape: shape, Height: req.Height, NoFly: noFly, Areas: areas}
+
+	obstacles, err := s.loadObstacles(nCtx, estate)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+	obstacles.add(entity)
+	blocked := obstacles.count()
+	if blocked > maxBlockedPlots {
+		err = fmt.Errorf("an estate cannot have more than %d plots under obstacles", maxBlockedPlots)
+		return generated.ObstacleResponse{}, http.StatusBadRequest, err
+	}
+
+	// no tree or pad can stand under an obstacle
+	plots, err := s.Repository.GetPlots(nCtx, estateId)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+	for _, plot := range plots {
+		if obstacles.blocked(int(plot.X), int(plot.Y)) {
+			err = fmt.Errorf("obstacle covers the tree on plot (%d,%d)", plot.X, plot.Y)
+			return generated.ObstacleResponse{}, http.StatusBadRequest, err
+		}
+	}
+	pads, err := s.Repository.GetChargingPads(nCtx, estateId)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+	for _, pad := range pads {
+		if obstacles.blocked(int(pad.X), int(pad.Y)) {
+			err = fmt.Errorf("obstacle covers the charging pad on plot (%d,%d)", pad.X, pad.Y)
+			return generated.ObstacleResponse{}, http.StatusBadRequest, err
+		}
+	}
+
+	id, err := s.Repository.PostObstacle(nCtx, entity)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+
+	// the drone climbs over the obstacle or detours around it, every distance is recomputed
+	estate.BlockedPlots = blocked
+	err = s.recomputeFlightDistances(nCtx, &estate)
+	var unreachable unreachablePlotError
+	if errors.As(err, &unreachable) || errors.Is(err, errNoFlyEstate) {
+		return generated.ObstacleResponse{}, http.StatusBadRequest, err
+	}
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+
+	_, err = s.Repository.SaveEstate(nCtx, estate)
+	if err != nil {
+		return generated.ObstacleResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.ObstacleResponse{Id: id}, http.StatusCreated, nil
+}
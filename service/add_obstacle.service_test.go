@@ -0,0 +1,170 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddObstacle(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockObstacleID := uuid.New()
+	mockContext := context.TODO()
+	noFly := true
+	height := 12
+	outOfRange := 301
+
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 3, PlotSize: 10, Clearance: 1}
+	noFlyPlot := &generated.PlotRectangle{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1}
+
+	tests := []struct {
+		name           string
+		request        generated.ObstacleRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.ObstacleResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Detours Around A No-Fly Plot",
+			request: generated.ObstacleRequest{Rectangle: noFlyPlot, NoFly: &noFly},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				var posted repository.ObstacleEntity
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().PostObstacle(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, obstacle repository.ObstacleEntity) (*uuid.UUID, error) {
+					require.Equal(t, "rectangle", obstacle.Shape)
+					require.Equal(t, []repository.ObstacleAreaEntity{{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1}}, obstacle.Areas)
+					posted = obstacle
+					return &mockObstacleID, nil
+				})
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).DoAndReturn(func(ctx context.Context, estateId uuid.UUID) ([]repository.ObstacleEntity, error) {
+					return []repository.ObstacleEntity{posted}, nil
+				})
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					// 14 plots flown, the way from plot 2 to plot 4 is 40 meters instead of 20
+					require.Equal(t, 1, estate.BlockedPlots)
+					require.Equal(t, 170, estate.TotalDistance)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.ObstacleResponse{Id: &mockObstacleID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Height And No-Fly Together",
+			request:        generated.ObstacleRequest{Rectangle: noFlyPlot, Height: &height, NoFly: &noFly},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("an obstacle must have either a height or no_fly set to true"),
+		},
+		{
+			name:           "Height Out Of Range",
+			request:        generated.ObstacleRequest{Rectangle: noFlyPlot, Height: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 300"),
+		},
+		{
+			name:    "Rectangle Out Of Range",
+			request: generated.ObstacleRequest{Rectangle: &generated.PlotRectangle{MinX: 4, MinY: 1, MaxX: 6, MaxY: 1}, Height: &height},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("rectangle is out of range"),
+		},
+		{
+			name:    "Obstacle Covers A Tree",
+			request: generated.ObstacleRequest{Rectangle: noFlyPlot, Height: &height},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{{X: 3, Y: 1, TreeHeight: 5}}, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("obstacle covers the tree on plot (3,1)"),
+		},
+		{
+			name:    "Plot Cut Off By The No-Fly Zone",
+			request: generated.ObstacleRequest{Rectangle: &generated.PlotRectangle{MinX: 3, MinY: 1, MaxX: 3, MaxY: 3}, NoFly: &noFly},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				var posted repository.ObstacleEntity
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().PostObstacle(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, obstacle repository.ObstacleEntity) (*uuid.UUID, error) {
+					posted = obstacle
+					return &mockObstacleID, nil
+				})
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).DoAndReturn(func(ctx context.Context, estateId uuid.UUID) ([]repository.ObstacleEntity, error) {
+					return []repository.ObstacleEntity{posted}, nil
+				})
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot (4,1) cannot be reached around the no-fly zones"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.ObstacleRequest{Rectangle: noFlyPlot, NoFly: &noFly},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.AddObstacle(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) AddSensor(ctx context.Context, estateId uuid.UUID, req generated.SensorRequest) (generated.Sensor, int, error) {
+	if err := checkSensorKind(req.Kind); err != nil {
+		return generated.Sensor{}, http.StatusBadRequest, err
+	}
+	if req.Name != nil && *req.Name == "" {
+		return generated.Sensor{}, http.StatusBadRequest, errors.New("name cannot be empty")
+	}
+	if req.Name != nil && len(*req.Name) > maxSensorName {
+		return generated.Sensor{}, http.StatusBadRequest, fmt.Errorf("name cannot be longer than %d characters", maxSensorName)
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.Sensor{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.Sensor{}, http.StatusInternalServerError, err
+	}
+
+	if !inEstate(estate, req.X, req.Y) {
+		return generated.Sensor{}, http.StatusBadRequest, errors.New("x or y is out of range")
+	}
+
+	sensors, err := s.Repository.GetSensors(ctx, estateId)
+	if err != nil {
+		return generated.Sensor{}, http.StatusInternalServerError, err
+	}
+	if len(sensors) >= maxSensors {
+		return generated.Sensor{}, http.StatusBadRequest, fmt.Errorf("estate cannot have more than %d sensors", maxSensors)
+	}
+	for _, sensor := range sensors {
+		if int(sensor.X) == req.X && int(sensor.Y) == req.Y && sensor.Kind == string(req.Kind) {
+			return generated.Sensor{}, http.StatusBadRequest, fmt.Errorf("plot with coordinate x and y already has a %s sensor", req.Kind)
+		}
+	}
+
+	sensor := repository.SensorEntity{
+		EstateId: estateId,
+		Kind:     string(req.Kind),
+		X:        uint16(req.X),
+		Y:        uint16(req.Y),
+		Name:     req.Name,
+	}
+	id, err := s.Repository.PostSensor(ctx, sensor)
+	if err != nil {
+		return generated.Sensor{}, http.StatusInternalServerError, err
+	}
+	sensor.ID = *id
+
+	return sensorResponse(sensor), http.StatusCreated, nil
+}
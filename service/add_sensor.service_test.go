@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddSensor(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	mockUUID := uuid.New()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 5}
+	name := "SM-0042"
+	soilMoisture := generated.SoilMoisture
+	ten, five := 10, 5
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.SensorRequest
+		expectedResp   generated.Sensor
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Add",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				// a temperature sensor on the plot does not keep a soil moisture one out
+				mockRepo.EXPECT().GetSensors(gomock.Any(), mockEstateID).Return([]repository.SensorEntity{{Kind: "temperature", X: 10, Y: 5}}, nil)
+				mockRepo.EXPECT().PostSensor(gomock.Any(), repository.SensorEntity{EstateId: mockEstateID, Kind: "soil_moisture", X: 10, Y: 5, Name: &name}).Return(&mockUUID, nil)
+			},
+			request:        generated.SensorRequest{Kind: generated.SoilMoisture, X: 10, Y: 5, Name: &name},
+			expectedResp:   generated.Sensor{Id: &mockUUID, Kind: &soilMoisture, X: &ten, Y: &five, Name: &name},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Plot Already Has A Sensor Of The Kind",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetSensors(gomock.Any(), mockEstateID).Return([]repository.SensorEntity{{Kind: "soil_moisture", X: 10, Y: 5}}, nil)
+			},
+			request:        generated.SensorRequest{Kind: generated.SoilMoisture, X: 10, Y: 5},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot with coordinate x and y already has a soil_moisture sensor"),
+		},
+		{
+			name: "Out Of Range",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			request:        generated.SensorRequest{Kind: generated.Temperature, X: 11, Y: 5},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("x or y is out of range"),
+		},
+		{
+			name:           "Unknown Kind",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SensorRequest{Kind: "humidity", X: 1, Y: 1},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("kind must be one of soil_moisture, temperature"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			request:        generated.SensorRequest{Kind: generated.Temperature, X: 1, Y: 1},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.AddSensor(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+/*
+AddSensorReadings stores a batch of readings of the sensors of an estate. The readings are stored without a
+transaction and without reading the trees, an ingestion never waits on a tree mutation nor holds it back. A reading
+already stored is skipped and counted as a duplicate, a batch sent again is safe.
+*/
+func (s *Service) AddSensorReadings(ctx context.Context, estateId uuid.UUID, req generated.SensorReadingBatch) (generated.SensorReadingBatchResponse, int, error) {
+	if len(req.Readings) == 0 {
+		return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, errors.New("readings cannot be empty")
+	}
+	if len(req.Readings) > maxSensorReadings {
+		return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("a batch cannot have more than %d readings", maxSensorReadings)
+	}
+
+	latest := time.Now().Add(sensorClockSkew)
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	for i, reading := range req.Readings {
+		if reading.Value == nil {
+			return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("reading %d: value is missing", i+1)
+		}
+		if reading.RecordedAt.IsZero() {
+			return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("reading %d: recorded_at is missing", i+1)
+		}
+		if reading.RecordedAt.After(latest) {
+			return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("reading %d: recorded_at cannot be in the future", i+1)
+		}
+		if !seen[reading.SensorId] {
+			seen[reading.SensorId] = true
+			ids = append(ids, reading.SensorId)
+		}
+	}
+
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.SensorReadingBatchResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.SensorReadingBatchResponse{}, http.StatusInternalServerError, err
+	}
+
+	sensors, err := s.Repository.GetSensorsByIds(ctx, estateId, ids)
+	if err != nil {
+		return generated.SensorReadingBatchResponse{}, http.StatusInternalServerError, err
+	}
+	kinds := make(map[uuid.UUID]generated.SensorKind, len(sensors))
+	for _, sensor := range sensors {
+		kinds[sensor.ID] = generated.SensorKind(sensor.Kind)
+	}
+
+	entities := make([]repository.SensorReadingEntity, len(req.Readings))
+	for i, reading := range req.Readings {
+		kind, ok := kinds[reading.SensorId]
+		if !ok {
+			return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("reading %d: sensor %s is not a sensor of the estate", i+1, reading.SensorId)
+		}
+		bounds := sensorRanges[kind]
+		if *reading.Value < bounds[0] || *reading.Value > bounds[1] {
+			return generated.SensorReadingBatchResponse{}, http.StatusBadRequest, fmt.Errorf("reading %d: value of a %s sensor must be between %v and %v", i+1, kind, bounds[0], bounds[1])
+		}
+		entities[i] = repository.SensorReadingEntity{SensorId: reading.SensorId, RecordedAt: reading.RecordedAt, Value: *reading.Value}
+	}
+
+	stored, err := s.Repository.PostSensorReadings(ctx, entities)
+	if err != nil {
+		return generated.SensorReadingBatchResponse{}, http.StatusInternalServerError, err
+	}
+
+	duplicates := len(entities) - stored
+	return generated.SensorReadingBatchResponse{Stored: &stored, Duplicates: &duplicates}, http.StatusCreated, nil
+}
@@ -0,0 +1,146 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddSensorReadings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	moistureID, temperatureID, strangerID := uuid.New(), uuid.New(), uuid.New()
+	recordedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	mockSensors := []repository.SensorEntity{
+		{ID: moistureID, EstateId: mockEstateID, Kind: "soil_moisture", X: 3, Y: 2},
+		{ID: temperatureID, EstateId: mockEstateID, Kind: "temperature", X: 3, Y: 2},
+	}
+	value := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.SensorReadingBatch
+		expectedResp   generated.SensorReadingBatchResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Batch With A Duplicate",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensorsByIds(gomock.Any(), mockEstateID, []uuid.UUID{moistureID, temperatureID}).Return(mockSensors, nil)
+				mockRepo.EXPECT().PostSensorReadings(gomock.Any(), []repository.SensorReadingEntity{
+					{SensorId: moistureID, RecordedAt: recordedAt, Value: 31.5},
+					{SensorId: temperatureID, RecordedAt: recordedAt, Value: -2},
+					{SensorId: moistureID, RecordedAt: recordedAt, Value: 31.5},
+				}).Return(2, nil)
+			},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(31.5)},
+				{SensorId: temperatureID, RecordedAt: recordedAt, Value: value(-2)},
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(31.5)},
+			}},
+			expectedResp:   generated.SensorReadingBatchResponse{Stored: &[]int{2}[0], Duplicates: &[]int{1}[0]},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Sensor Of Another Estate",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensorsByIds(gomock.Any(), mockEstateID, []uuid.UUID{moistureID, strangerID}).Return(mockSensors[:1], nil)
+			},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(31.5)},
+				{SensorId: strangerID, RecordedAt: recordedAt, Value: value(20)},
+			}},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    fmt.Errorf("reading 2: sensor %s is not a sensor of the estate", strangerID),
+		},
+		{
+			name: "Moisture Out Of Range",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensorsByIds(gomock.Any(), mockEstateID, []uuid.UUID{moistureID}).Return(mockSensors[:1], nil)
+			},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(101)},
+			}},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("reading 1: value of a soil_moisture sensor must be between 0 and 100"),
+		},
+		{
+			name:         "Missing Value",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt},
+			}},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("reading 1: value is missing"),
+		},
+		{
+			name:         "Recorded In The Future",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(20)},
+				{SensorId: moistureID, RecordedAt: time.Now().Add(time.Hour), Value: value(20)},
+			}},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("reading 2: recorded_at cannot be in the future"),
+		},
+		{
+			name:           "Empty Batch",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SensorReadingBatch{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("readings cannot be empty"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			request: generated.SensorReadingBatch{Readings: []generated.SensorReading{
+				{SensorId: moistureID, RecordedAt: recordedAt, Value: value(20)},
+			}},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.AddSensorReadings(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
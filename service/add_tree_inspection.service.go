@@ -0,0 +1,101 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/util"
+)
+
+func (s *Service) AddTreeInspection(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID, req generated.TreeInspectionRequest) (generated.TreeInspectionResponse, int, error) {
+	resp := generated.TreeInspectionResponse{}
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	if err = checkHealthStatus("health_status", req.HealthStatus); err != nil {
+		return generated.TreeInspectionResponse{}, http.StatusBadRequest, err
+	}
+	if req.Notes != nil && len(*req.Notes) > maxInspectionNotes {
+		err = fmt.Errorf("notes cannot be longer than %d characters", maxInspectionNotes)
+		return generated.TreeInspectionResponse{}, http.StatusBadRequest, err
+	}
+	if req.ImageRef != nil && len(*req.ImageRef) > maxInspectionImageRef {
+		err = fmt.Errorf("image_ref cannot be longer than %d characters", maxInspectionImageRef)
+		return generated.TreeInspectionResponse{}, http.StatusBadRequest, err
+	}
+
+	inspectedAt := time.Now()
+	if req.InspectedAt != nil {
+		if req.InspectedAt.After(inspectedAt) {
+			err = errors.New("inspected_at cannot be in the future")
+			return generated.TreeInspectionResponse{}, http.StatusBadRequest, err
+		}
+		inspectedAt = *req.InspectedAt
+	}
+
+	plot, err := s.Repository.GetPlotByID(nCtx, estateId, treeId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TreeInspectionResponse{}, http.StatusNotFound, errors.New("tree not found")
+		}
+		return generated.TreeInspectionResponse{}, http.StatusInternalServerError, err
+	}
+
+	if req.Height != nil {
+		var status int
+		if status, err = s.checkTreeHeight(nCtx, plot.SpeciesId, *req.Height); err != nil {
+			return generated.TreeInspectionResponse{}, status, err
+		}
+	}
+
+	latest, err := s.Repository.GetLatestTreeInspection(nCtx, plot.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return generated.TreeInspectionResponse{}, http.StatusInternalServerError, err
+	}
+
+	resp.Id, err = s.Repository.PostTreeInspection(nCtx, repository.TreeInspectionEntity{
+		PlotId:       plot.ID,
+		EstateId:     plot.EstateId,
+		HealthStatus: string(req.HealthStatus),
+		Notes:        req.Notes,
+		ImageRef:     req.ImageRef,
+		Height:       req.Height,
+		InspectedAt:  inspectedAt,
+	})
+	if err != nil {
+		return generated.TreeInspectionResponse{}, http.StatusInternalServerError, err
+	}
+
+	// an inspection older than the latest one only fills the history, the tree keeps the latest health status
+	if latest == nil || !inspectedAt.Before(latest.InspectedAt) {
+		if err = s.updateTreeHealth(nCtx, plot, req.HealthStatus); err != nil {
+			return generated.TreeInspectionResponse{}, http.StatusInternalServerError, err
+		}
+	}
+
+	// the observed height is a measurement of the tree like any other
+	if req.Height != nil {
+		resp.MeasurementId, err = s.recordTreeMeasurement(nCtx, *plot, *req.Height, inspectedAt)
+		if err != nil {
+			return generated.TreeInspectionResponse{}, http.StatusInternalServerError, err
+		}
+	}
+
+	return resp, http.StatusCreated, nil
+}
@@ -0,0 +1,212 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddTreeInspection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockInspectionID := uuid.New()
+	mockMeasurementID := uuid.New()
+	mockContext := context.TODO()
+	mockLatest := time.Now().Add(-24 * time.Hour)
+	mockOlder := mockLatest.Add(-24 * time.Hour)
+	mockFuture := time.Now().Add(time.Hour)
+	notes := "no fronds left"
+	healthy := "healthy"
+
+	// a row of 5 plots with trees of 10, 20 and 10 meters on plots 2, 3 and 4, the tree of 20 meters is inspected
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 1, TotalDistance: 92, PlotSize: 10, Clearance: 1, TreeCount: 3}
+	mockPlots := func() []repository.PlotEntity {
+		return []repository.PlotEntity{
+			{EstateId: mockEstateID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, Distance: 31},
+			{ID: mockTreeID, EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 20, Distance: 51, HealthStatus: &healthy},
+			{EstateId: mockEstateID, X: 4, Y: 1, OrderNumber: 4, TreeHeight: 10, Distance: 71},
+		}
+	}
+	// the inspected tree is updated in place, every test gets its own
+	mockTree := func() *repository.PlotEntity {
+		return &mockPlots()[1]
+	}
+
+	tests := []struct {
+		name           string
+		request        generated.TreeInspectionRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.TreeInspectionResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Dead Tree Leaves The Flight Model",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Dead, Notes: &notes},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(mockTree(), nil)
+				mockRepo.EXPECT().GetLatestTreeInspection(gomock.Any(), mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostTreeInspection(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.TreeInspectionEntity) (*uuid.UUID, error) {
+					require.Equal(t, []any{mockTreeID, mockEstateID, "dead", &notes}, []any{entity.PlotId, entity.EstateId, entity.HealthStatus, entity.Notes})
+					return &mockInspectionID, nil
+				})
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, "dead", *plot.HealthStatus)
+					return &plot.ID, nil
+				})
+				excluding := mockEstate
+				excluding.ExcludeDeadTrees = true
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(excluding, nil)
+				dead := "dead"
+				plots := mockPlots()
+				plots[1].HealthStatus = &dead
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(plots, nil)
+				// the drone comes down to the ground over the dead tree
+				var distances []int
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					distances = append(distances, plot.Distance)
+					return &plot.ID, nil
+				}).Times(2)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{52, 73}, distances)
+					require.Equal(t, 94, estate.TotalDistance)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.TreeInspectionResponse{Id: &mockInspectionID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:    "Dead Tree Kept In The Flight Model",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Dead},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(mockTree(), nil)
+				mockRepo.EXPECT().GetLatestTreeInspection(gomock.Any(), mockTreeID).Return(&repository.TreeInspectionEntity{InspectedAt: mockLatest}, nil)
+				mockRepo.EXPECT().PostTreeInspection(gomock.Any(), gomock.Any()).Return(&mockInspectionID, nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).Return(&mockTreeID, nil)
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.TreeInspectionResponse{Id: &mockInspectionID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:    "Observed Height Is Measured",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Healthy, Height: &[]int{20}[0], InspectedAt: &mockOlder},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(mockTree(), nil)
+				mockRepo.EXPECT().GetLatestTreeInspection(gomock.Any(), mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostTreeInspection(gomock.Any(), gomock.Any()).Return(&mockInspectionID, nil)
+				mockRepo.EXPECT().GetLatestTreeMeasurement(gomock.Any(), mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.TreeMeasurementEntity) (*uuid.UUID, error) {
+					require.Equal(t, []any{20, mockOlder}, []any{entity.Height, entity.MeasuredAt})
+					return &mockMeasurementID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.TreeInspectionResponse{Id: &mockInspectionID, MeasurementId: &mockMeasurementID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:    "Older Inspection Only Fills The History",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Diseased, InspectedAt: &mockOlder},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(mockTree(), nil)
+				mockRepo.EXPECT().GetLatestTreeInspection(gomock.Any(), mockTreeID).Return(&repository.TreeInspectionEntity{InspectedAt: mockLatest}, nil)
+				mockRepo.EXPECT().PostTreeInspection(gomock.Any(), gomock.Any()).Return(&mockInspectionID, nil)
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.TreeInspectionResponse{Id: &mockInspectionID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:    "Unknown Health Status",
+			request: generated.TreeInspectionRequest{HealthStatus: "sick"},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("health_status must be one of healthy, stressed, diseased, dead"),
+		},
+		{
+			name:    "Inspected In The Future",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Healthy, InspectedAt: &mockFuture},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("inspected_at cannot be in the future"),
+		},
+		{
+			name:    "Observed Height Exceeds Without Species",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Healthy, Height: &[]int{31}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(mockTree(), nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 30 for a tree without species"),
+		},
+		{
+			name:    "Tree Not Found",
+			request: generated.TreeInspectionRequest{HealthStatus: generated.Healthy},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("tree not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.AddTreeInspection(mockContext, mockEstateID, mockTreeID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
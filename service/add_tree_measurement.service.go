@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/util"
+)
+
+func (s *Service) AddTreeMeasurement(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID, req generated.TreeMeasurementRequest) (generated.TreeMeasurementResponse, int, error) {
+	resp := generated.TreeMeasurementResponse{}
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	measuredAt := time.Now()
+	if req.MeasuredAt != nil {
+		if req.MeasuredAt.After(measuredAt) {
+			err = errors.New("measured_at cannot be in the future")
+			return generated.TreeMeasurementResponse{}, http.StatusBadRequest, err
+		}
+		measuredAt = *req.MeasuredAt
+	}
+
+	plot, err := s.Repository.GetPlotByID(nCtx, estateId, treeId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TreeMeasurementResponse{}, http.StatusNotFound, errors.New("tree not found")
+		}
+		return generated.TreeMeasurementResponse{}, http.StatusInternalServerError, err
+	}
+
+	status, err := s.checkTreeHeight(nCtx, plot.SpeciesId, req.Height)
+	if err != nil {
+		return generated.TreeMeasurementResponse{}, status, err
+	}
+
+	resp.Id, err = s.recordTreeMeasurement(nCtx, *plot, req.Height, measuredAt)
+	if err != nil {
+		return generated.TreeMeasurementResponse{}, http.StatusInternalServerError, err
+	}
+
+	return resp, http.StatusCreated, nil
+}
+
+// recordTreeMeasurement records a height measurement of a tree, the latest measurement becomes the height of the tree.
+func (s *Service) recordTreeMeasurement(ctx context.Context, plot repository.PlotEntity, height int, measuredAt time.Time) (*uuid.UUID, error) {
+	latest, err := s.Repository.GetLatestTreeMeasurement(ctx, plot.ID)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, err
+	}
+
+	id, err := s.Repository.PostTreeMeasurement(ctx, repository.TreeMeasurementEntity{
+		PlotId:     plot.ID,
+		EstateId:   plot.EstateId,
+		Height:     height,
+		MeasuredAt: measuredAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// a measurement older than the latest one only fills the history, the flight model keeps the latest height
+	if latest != nil && measuredAt.Before(latest.MeasuredAt) {
+		return id, nil
+	}
+
+	if plot.TreeHeight != height {
+		if err = s.updateTreeHeight(ctx, plot, height); err != nil {
+			return nil, err
+		}
+	}
+
+	return id, nil
+}
+
+// updateTreeHeight replaces the denormalized height of a tree and propagates it to the drone distances and the
+// tree stats of its estate.
+func (s *Service) updateTreeHeight(ctx context.Context, plot repository.PlotEntity, height int) error {
+	estate, err := s.Repository.GetEstate(ctx, plot.EstateId)
+	if err != nil {
+		return err
+	}
+
+	if !plainEstate(estate) {
+		// the tree may stand on the way of a detour, every distance is recomputed
+		plot.TreeHeight = height
+		if _, err = s.Repository.SavePlot(ctx, plot); err != nil {
+			return err
+		}
+		if err = s.recomputeFlightDistances(ctx, &estate); err != nil {
+			return err
+		}
+		return s.saveTreeHeightStats(ctx, estate)
+	}
+
+	plotPrev, err := s.Repository.GetPlotByOrderNumber(ctx, estate.ID, plot.OrderNumber-1)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	plotNext, err := s.Repository.GetPlotByOrderNumber(ctx, estate.ID, plot.OrderNumber+1)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	model := newFlightModel(estate)
+	plotCount := estate.Width * estate.Length
+	altitudePrev := model.neighbourAltitude(plotPrev, plot.OrderNumber-1, plotCount)
+	altitudeNext := model.neighbourAltitude(plotNext, plot.OrderNumber+1, plotCount)
+	altitude := model.altitude(&plot)
+
+	opb, err := s.Repository.GetOccupiedPlotBehind(ctx, estate.ID, plot.OrderNumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	plot.TreeHeight = height
+	plot.Distance = model.plotDistance(plot, opb)
+	estate.TotalDistance += climb(altitudePrev, model.altitude(&plot), altitudeNext) - climb(altitudePrev, altitude, altitudeNext)
+
+	_, err = s.Repository.SavePlot(ctx, plot)
+	if err != nil {
+		return err
+	}
+
+	opf, err := s.Repository.GetOccupiedPlotForward(ctx, estate.ID, plot.OrderNumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	if opf != nil {
+		newDistanceOpf := model.plotDistance(*opf, &plot)
+		additionalDistanceGap := newDistanceOpf - opf.Distance
+		opf.Distance = newDistanceOpf
+
+		_, err = s.Repository.SavePlot(ctx, *opf)
+		if err != nil {
+			return err
+		}
+
+		err = s.Repository.AdjustPlotForwardDistance(ctx, estate.ID, opf.OrderNumber, additionalDistanceGap)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.saveTreeHeightStats(ctx, estate)
+}
+
+// saveTreeHeightStats refreshes the tree height stats kept on an estate and saves it.
+func (s *Service) saveTreeHeightStats(ctx context.Context, estate repository.EstateEntity) error {
+	stats, err := s.Repository.GetTreeHeightStats(ctx, estate.ID)
+	if err != nil {
+		return err
+	}
+
+	estate.TreeMaxHeight = stats.Max
+	estate.TreeMinHeight = stats.Min
+	estate.TreeMedianHeight = int(stats.Median)
+
+	_, err = s.Repository.SaveEstate(ctx, estate)
+	return err
+}
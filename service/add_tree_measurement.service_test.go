@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestService_AddTreeMeasurement(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockUUID := uuid.New()
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockLatest := time.Now().Add(-24 * time.Hour)
+	mockOlder := mockLatest.Add(-24 * time.Hour)
+	mockFuture := time.Now().Add(time.Hour)
+
+	// 5x1 estate with trees on order number 2, 3 and 5
+	mockPlot := repository.PlotEntity{ID: mockTreeID, EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 20, Distance: 51}
+	mockSpeciesID := uuid.New()
+	mockSpeciesPlot := mockPlot
+	mockSpeciesPlot.SpeciesId = &mockSpeciesID
+	mockPlotBehind := repository.PlotEntity{EstateId: mockEstateID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, Distance: 31}
+	mockPlotForward := repository.PlotEntity{EstateId: mockEstateID, X: 5, Y: 1, OrderNumber: 5, TreeHeight: 10, Distance: 103}
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 1, TotalDistance: 100, TreeCount: 3, TreeMaxHeight: 20, TreeMinHeight: 10, TreeMedianHeight: 10, Clearance: 1}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		request        generated.TreeMeasurementRequest
+		expectedResp   generated.TreeMeasurementResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Latest Measurement Updates The Flight Model",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetLatestTreeMeasurement(gomock.Any(), mockTreeID).Return(&repository.TreeMeasurementEntity{MeasuredAt: mockLatest}, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 2).Return(&mockPlotBehind, nil)
+				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 4).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 3).Return(&mockPlotBehind, nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, 25, plot.TreeHeight)
+					require.Equal(t, 56, plot.Distance)
+					return &plot.ID, nil
+				})
+				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 3).Return(&mockPlotForward, nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, 113, plot.Distance)
+					return &plot.ID, nil
+				})
+				mockRepo.EXPECT().AdjustPlotForwardDistance(gomock.Any(), mockEstateID, 5, 10).Return(nil)
+				mockRepo.EXPECT().GetTreeHeightStats(gomock.Any(), mockEstateID).Return(repository.TreeHeightStats{Count: 3, Min: 10, Max: 25, Median: 10}, nil)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, 110, estate.TotalDistance)
+					require.Equal(t, 25, estate.TreeMaxHeight)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 25},
+			expectedResp:   generated.TreeMeasurementResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Older Measurement Only Fills The History",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetLatestTreeMeasurement(gomock.Any(), mockTreeID).Return(&repository.TreeMeasurementEntity{MeasuredAt: mockLatest}, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.TreeMeasurementEntity) (*uuid.UUID, error) {
+					require.Equal(t, mockOlder, entity.MeasuredAt)
+					return &mockUUID, nil
+				})
+				mock.ExpectCommit()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 15, MeasuredAt: &mockOlder},
+			expectedResp:   generated.TreeMeasurementResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Same Height Keeps The Flight Model",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetLatestTreeMeasurement(gomock.Any(), mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mock.ExpectCommit()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 20},
+			expectedResp:   generated.TreeMeasurementResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Height Exceeds The Species",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockSpeciesPlot, nil)
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockSpeciesID).Return(repository.SpeciesEntity{ID: mockSpeciesID, MaxHeight: 22}, nil)
+				mock.ExpectRollback()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 23},
+			expectedResp:   generated.TreeMeasurementResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 22, the max height of the species"),
+		},
+		{
+			name: "Measurement In The Future",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 20, MeasuredAt: &mockFuture},
+			expectedResp:   generated.TreeMeasurementResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("measured_at cannot be in the future"),
+		},
+		{
+			name: "Tree Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 20},
+			expectedResp:   generated.TreeMeasurementResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("tree not found"),
+		},
+		{
+			name: "PostTreeMeasurement Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetLatestTreeMeasurement(gomock.Any(), mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(nil, errors.New("database error"))
+				mock.ExpectRollback()
+			},
+			request:        generated.TreeMeasurementRequest{Height: 20},
+			expectedResp:   generated.TreeMeasurementResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("database error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			service := &Service{
+				Repository: mockRepo,
+				Db:         gdb,
+			}
+
+			resp, status, err := service.AddTreeMeasurement(context.TODO(), mockEstateID, mockTreeID, tt.request)
+
+			if tt.expectedErr != nil {
+				require.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tt.expectedResp, resp)
+			require.Equal(t, tt.expectedStatus, status)
+			require.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"math"
 	"net/http"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -38,36 +39,25 @@ func (s *Service) AddTreeToEstate(ctx echo.Context, req generated.TreeRequest, e
 		return generated.TreeResponse{}, http.StatusInternalServerError, err
 	}
 
-	opf, err := s.Repository.GetOccupiedPlotForward(nCtx, estate.ID, plot.OrderNumber)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+	// the planted height is the first measurement of the tree's growth history
+	_, err = s.Repository.PostTreeMeasurement(nCtx, repository.TreeMeasurementEntity{
+		PlotId:     *resp.Id,
+		EstateId:   estate.ID,
+		Height:     plot.TreeHeight,
+		MeasuredAt: time.Now(),
+	})
+	if err != nil {
 		return generated.TreeResponse{}, http.StatusInternalServerError, err
 	}
 
-	if opf != nil {
-		additionalDistanceGap := 0
-		distanceBetweenPlots := opf.OrderNumber - plot.OrderNumber
-		if distanceBetweenPlots == 1 {
-			treeHeightDifferent := math.Abs(float64(opf.TreeHeight - plot.TreeHeight))
-			newDistanceOpf := plot.Distance + int(treeHeightDifferent) + 10
-			additionalDistanceGap = newDistanceOpf - opf.Distance
-			opf.Distance = newDistanceOpf
-		} else {
-			remainingDistanceBetweenPlot := (distanceBetweenPlots - 1) * 10
-			distanceToOpf := plot.Distance + plot.TreeHeight + 1 + remainingDistanceBetweenPlot
-			newDistanceOpf := distanceToOpf + opf.TreeHeight + 1 + 10
-			additionalDistanceGap = newDistanceOpf - opf.Distance
-			opf.Distance = newDistanceOpf
-		}
-
-		_, err = s.Repository.SavePlot(nCtx, *opf)
-		if err != nil {
-			return generated.TreeResponse{}, http.StatusInternalServerError, err
-		}
-
-		err = s.Repository.AdjustPlotForwardDistance(nCtx, estate.ID, opf.OrderNumber, additionalDistanceGap)
-		if err != nil {
-			return generated.TreeResponse{}, http.StatusInternalServerError, err
-		}
+	if !plainEstate(*estate) {
+		// the tree may stand on the way of a detour, every distance is recomputed
+		err = s.recomputeFlightDistances(nCtx, estate)
+	} else {
+		err = s.addTreeForwardDistance(nCtx, estate, plot)
+	}
+	if err != nil {
+		return generated.TreeResponse{}, http.StatusInternalServerError, err
 	}
 
 	medianTreeHeight, err := s.Repository.GetMedianTreeHeight(nCtx, estate.ID)
@@ -79,26 +69,11 @@ func (s *Service) AddTreeToEstate(ctx echo.Context, req generated.TreeRequest, e
 		estate.TreeMinHeight = plot.TreeHeight
 	}
 
-	plotPrev, err := s.Repository.GetPlotByOrderNumber(nCtx, estate.ID, plot.OrderNumber-1)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return generated.TreeResponse{}, http.StatusInternalServerError, err
-	}
-
-	plotNext, err := s.Repository.GetPlotByOrderNumber(nCtx, estate.ID, plot.OrderNumber+1)
-	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
-		return generated.TreeResponse{}, http.StatusInternalServerError, err
-	}
-
-	if plotPrev != nil {
-		estate.TotalDistance += int(math.Abs(float64(plotPrev.TreeHeight - plot.TreeHeight)))
-	} else {
-		estate.TotalDistance += plot.TreeHeight
-	}
-
-	if plotNext != nil {
-		estate.TotalDistance += int(math.Abs(float64(plotNext.TreeHeight - plot.TreeHeight)))
-	} else {
-		estate.TotalDistance += plot.TreeHeight
+	if plainEstate(*estate) {
+		err = s.addTreeTotalDistance(nCtx, estate, plot)
+		if err != nil {
+			return generated.TreeResponse{}, http.StatusInternalServerError, err
+		}
 	}
 
 	estate.TreeCount++
@@ -114,6 +89,49 @@ func (s *Service) AddTreeToEstate(ctx echo.Context, req generated.TreeRequest, e
 	return resp, http.StatusOK, nil
 }
 
+// addTreeForwardDistance propagates a new tree to the distance of the trees after it.
+func (s *Service) addTreeForwardDistance(nCtx context.Context, estate *repository.EstateEntity, plot *repository.PlotEntity) error {
+	opf, err := s.Repository.GetOccupiedPlotForward(nCtx, estate.ID, plot.OrderNumber)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+	if opf == nil {
+		return nil
+	}
+
+	newDistanceOpf := newFlightModel(*estate).plotDistance(*opf, plot)
+	additionalDistanceGap := newDistanceOpf - opf.Distance
+	opf.Distance = newDistanceOpf
+
+	_, err = s.Repository.SavePlot(nCtx, *opf)
+	if err != nil {
+		return err
+	}
+
+	return s.Repository.AdjustPlotForwardDistance(nCtx, estate.ID, opf.OrderNumber, additionalDistanceGap)
+}
+
+// addTreeTotalDistance adds the climb over a new tree to the total distance of its estate.
+func (s *Service) addTreeTotalDistance(nCtx context.Context, estate *repository.EstateEntity, plot *repository.PlotEntity) error {
+	plotPrev, err := s.Repository.GetPlotByOrderNumber(nCtx, estate.ID, plot.OrderNumber-1)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	plotNext, err := s.Repository.GetPlotByOrderNumber(nCtx, estate.ID, plot.OrderNumber+1)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return err
+	}
+
+	// the plot was flown at the cruise altitude before the tree was planted
+	model := newFlightModel(*estate)
+	plotCount := estate.Width * estate.Length
+	altitudePrev := model.neighbourAltitude(plotPrev, plot.OrderNumber-1, plotCount)
+	altitudeNext := model.neighbourAltitude(plotNext, plot.OrderNumber+1, plotCount)
+	estate.TotalDistance += climb(altitudePrev, model.altitude(plot), altitudeNext) - climb(altitudePrev, model.altitude(nil), altitudeNext)
+	return nil
+}
+
 func (s *Service) constructPlot(nCtx context.Context, req generated.TreeRequest, estateId uuid.UUID) (*repository.PlotEntity, *repository.EstateEntity, int, error) {
 	_, err := s.Repository.GetPlotByXAndY(nCtx, estateId, req.X, req.Y)
 	if err == nil {
@@ -125,66 +143,40 @@ func (s *Service) constructPlot(nCtx context.Context, req generated.TreeRequest,
 		return nil, nil, http.StatusNotFound, err
 	}
 
-	if req.X > estate.Length || req.Y > estate.Width {
+	if !inEstate(estate, req.X, req.Y) {
 		return nil, nil, http.StatusBadRequest, errors.New("x or y is out of range")
 	}
 
+	// the height of the tree is capped by its species
+	status, err := s.checkTreeHeight(nCtx, req.SpeciesId, req.Height)
+	if err != nil {
+		return nil, nil, status, err
+	}
+
+	obstacles, err := s.loadObstacles(nCtx, estate)
+	if err != nil {
+		return nil, nil, http.StatusInternalServerError, err
+	}
+	if obstacles.blocked(req.X, req.Y) {
+		return nil, nil, http.StatusBadRequest, errors.New("plot with coordinate x and y is blocked by an obstacle")
+	}
+
 	plot := repository.PlotEntity{
 		EstateId:   estate.ID,
 		X:          uint16(req.X),
 		Y:          uint16(req.Y),
 		TreeHeight: req.Height,
+		SpeciesId:  req.SpeciesId,
 		Distance:   0,
 	}
 
-	if req.Y%2 == 1 { // Y is odd
-		plot.OrderNumber = (req.Y-1)*estate.Length + req.X
-	} else { // Y is even
-		plot.OrderNumber = (req.Y-1)*estate.Length + (estate.Length - req.X + 1)
-	}
+	plot.OrderNumber = newTraversal(estate).order(req.X, req.Y)
 
 	opb, err1 := s.Repository.GetOccupiedPlotBehind(nCtx, estate.ID, plot.OrderNumber)
-	if err1 != nil {
-		if errors.Is(err1, gorm.ErrRecordNotFound) {
-			/*
-				if there is no plot behind, then the distance is 10 * order number, which 10 is the width of every plot.
-				then to cover tree high and width of this plot we use 10 + tree height
-			*/
-			distanceToCurrentPlot := (plot.OrderNumber - 1) * 10
-			distanceToCoverTree := plot.TreeHeight + 1 + 10
-			plot.Distance = distanceToCurrentPlot + distanceToCoverTree
-		} else {
-			return nil, nil, http.StatusInternalServerError, err1
-		}
-	} else {
-		/*
-			if there is other tree, we need to recognise only the previous tree, the nearer one.
-			the rest/other tree before previous tree not counted because we can depend on previous tree's distance,
-			it already covered all distance.
-		*/
-		distanceBetweenPlots := plot.OrderNumber - opb.OrderNumber
-		if distanceBetweenPlots == 1 {
-			/*
-				if tree is 1 distance away from previous tree, then we need to cover only the tree height
-				difference between previous tree and current tree. because drone doesn't land to the ground.
-			*/
-			treeHeightDifferent := opb.TreeHeight - plot.TreeHeight
-			distanceToCoverTree := int(math.Abs(float64(treeHeightDifferent)) + 10)
-			plot.Distance = opb.Distance + distanceToCoverTree
-		} else {
-			/*
-				if tree is more than 1 distance away from previous tree, then we need to cover the distance between
-				previous tree and current tree. the distance between previous tree and current tree
-				is 10 * distanceBetweenPlots, which 10 is the width of every plot. then to cover tree high and width
-				of this plot we use 10 + tree height
-			*/
-			distancePreviousPlotIncludingDroneLanding := opb.Distance + opb.TreeHeight + 1
-			remainingDistanceBetweenPlot := (distanceBetweenPlots - 1) * 10
-			distanceToCurrentPlot := distancePreviousPlotIncludingDroneLanding + remainingDistanceBetweenPlot
-			distanceToCoverTree := plot.TreeHeight + 1 + 10
-			plot.Distance = distanceToCurrentPlot + distanceToCoverTree
-		}
+	if err1 != nil && !errors.Is(err1, gorm.ErrRecordNotFound) {
+		return nil, nil, http.StatusInternalServerError, err1
 	}
+	plot.Distance = newFlightModel(estate).plotDistance(plot, opb)
 
 	return &plot, &estate, http.StatusCreated, nil
 }
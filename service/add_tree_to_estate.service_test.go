@@ -2,6 +2,7 @@ package service
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"net/http"
@@ -28,6 +29,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 
 	mockUUID := uuid.New()
 	mockEstateID := uuid.New()
+	mockSpeciesID := uuid.New()
 	mockTime := time.Now()
 
 	tests := []struct {
@@ -54,6 +56,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -65,6 +68,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(10, nil)
@@ -74,8 +78,8 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockPlot.ID)
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
-					WithArgs(mockPlot.EstateId, mockPlot.X, mockPlot.Y, mockPlot.Distance, mockPlot.OrderNumber, mockPlot.TreeHeight, mockPlot.CreatedAt).
+					`INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","species_id","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+					WithArgs(mockPlot.EstateId, mockPlot.X, mockPlot.Y, mockPlot.Distance, mockPlot.OrderNumber, mockPlot.TreeHeight, mockPlot.SpeciesId, mockPlot.CreatedAt).
 					WillReturnRows(rows)
 				mock.ExpectCommit()
 			},
@@ -141,6 +145,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -161,11 +166,138 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			expectedStatus: http.StatusBadRequest,
 			expectedErr:    errors.New("x or y is out of range"),
 		},
+		{
+			name: "Plot Blocked By An Obstacle",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				height := 20
+				mockEstate := repository.EstateEntity{
+					ID:           mockEstateID,
+					Clearance:    1,
+					Length:       5,
+					Width:        10,
+					BlockedPlots: 2,
+				}
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 2, 3).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return([]repository.ObstacleEntity{
+					{Height: &height, Areas: []repository.ObstacleAreaEntity{{MinX: 2, MinY: 3, MaxX: 2, MaxY: 4}}},
+				}, nil)
+			},
+			request: generated.TreeRequest{
+				X:      2,
+				Y:      3,
+				Height: 10,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot with coordinate x and y is blocked by an obstacle"),
+		},
+		{
+			name: "Height Exceeds The Species",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 10}, nil)
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockSpeciesID).Return(repository.SpeciesEntity{ID: mockSpeciesID, MaxHeight: 20}, nil)
+			},
+			request: generated.TreeRequest{
+				X:         1,
+				Y:         2,
+				Height:    21,
+				SpeciesId: &mockSpeciesID,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 20, the max height of the species"),
+		},
+		{
+			name: "Height Exceeds Without Species",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 10}, nil)
+			},
+			request: generated.TreeRequest{
+				X:      1,
+				Y:      2,
+				Height: 31,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 30 for a tree without species"),
+		},
+		{
+			name: "Species Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 10}, nil)
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockSpeciesID).Return(repository.SpeciesEntity{}, gorm.ErrRecordNotFound)
+			},
+			request: generated.TreeRequest{
+				X:         1,
+				Y:         2,
+				Height:    10,
+				SpeciesId: &mockSpeciesID,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("species not found"),
+		},
+		{
+			name: "Tree Of A Tall Species",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Clearance: 1, Length: 5, Width: 10}, nil)
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockSpeciesID).Return(repository.SpeciesEntity{ID: mockSpeciesID, MaxHeight: 60}, nil)
+				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, 45, entity.TreeHeight)
+					require.Equal(t, &mockSpeciesID, entity.SpeciesId)
+					return nil, errors.New("some error")
+				})
+			},
+			request: generated.TreeRequest{
+				X:         1,
+				Y:         2,
+				Height:    45,
+				SpeciesId: &mockSpeciesID,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some error"),
+		},
+		{
+			name: "PostTreeMeasurement Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockEstate := repository.EstateEntity{
+					ID:        mockEstateID,
+					Clearance: 1,
+					Length:    5,
+					Width:     10,
+				}
+				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
+				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.TreeMeasurementEntity) (*uuid.UUID, error) {
+					require.Equal(t, mockUUID, entity.PlotId)
+					require.Equal(t, 10, entity.Height)
+					return nil, errors.New("some error")
+				})
+			},
+			request: generated.TreeRequest{
+				X:      1,
+				Y:      2,
+				Height: 10,
+			},
+			expectedResp:   generated.TreeResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some error"),
+		},
 		{
 			name: "Occupied Plot Forward Error",
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -177,6 +309,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 10).Return(nil, errors.New("some error"))
 			},
@@ -194,6 +327,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -205,6 +339,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(10, nil)
@@ -269,6 +404,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -286,8 +422,8 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(mockPlot.ID)
 				mock.ExpectBegin()
 				mock.ExpectQuery(regexp.QuoteMeta(
-					`INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7) RETURNING "id"`)).
-					WithArgs(mockPlot.EstateId, mockPlot.X, mockPlot.Y, mockPlot.Distance, mockPlot.OrderNumber, mockPlot.TreeHeight, mockPlot.CreatedAt).
+					`INSERT INTO "plots" ("estate_id","x","y","distance","order_number","tree_height","species_id","created_at") VALUES ($1,$2,$3,$4,$5,$6,$7,$8) RETURNING "id"`)).
+					WithArgs(mockPlot.EstateId, mockPlot.X, mockPlot.Y, mockPlot.Distance, mockPlot.OrderNumber, mockPlot.TreeHeight, mockPlot.SpeciesId, mockPlot.CreatedAt).
 					WillReturnRows(rows)
 				mock.ExpectCommit()
 			},
@@ -355,6 +491,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -380,6 +517,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -391,6 +529,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -413,6 +552,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -448,6 +588,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -459,6 +600,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlot, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -490,6 +632,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -501,6 +644,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlot, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -541,6 +685,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -552,6 +697,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotBehind, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotForward, errors.New("database error"))
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -590,6 +736,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -601,6 +748,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotBehind, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotForward, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -641,6 +789,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -652,6 +801,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotBehind, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotForward, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -692,6 +842,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}
 				mockEstate := repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -703,6 +854,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 3, 5).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotBehind, nil)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 23).Return(&mockOccupiedPlotForward, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), gomock.Any(), gomock.Any()).Return(&repository.PlotEntity{ID: mockUUID}, nil).AnyTimes()
@@ -724,6 +876,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -734,6 +887,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}, nil)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(0, errors.New("database error"))
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 			},
@@ -752,6 +906,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -762,6 +917,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}, nil)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(10, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 9).Return(nil, errors.New("plot by order number error"))
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
 				mockRepo.EXPECT().GetOccupiedPlotForward(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
@@ -790,6 +946,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -800,6 +957,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}, nil)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(10, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 9).Return(&prevPlot, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 11).Return(nil, errors.New("plot by order number error"))
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
@@ -820,6 +978,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				mockRepo.EXPECT().GetPlotByXAndY(gomock.Any(), mockEstateID, 1, 2).Return(nil, errors.New("not found"))
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
 					ID:               mockEstateID,
+					Clearance:        1,
 					Length:           5,
 					Width:            10,
 					TotalDistance:    20,
@@ -830,6 +989,7 @@ func TestService_AddTreeToEstate(t *testing.T) {
 				}, nil)
 				mockRepo.EXPECT().GetMedianTreeHeight(gomock.Any(), mockEstateID).Return(10, nil)
 				mockRepo.EXPECT().PostPlot(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
+				mockRepo.EXPECT().PostTreeMeasurement(gomock.Any(), gomock.Any()).Return(&mockUUID, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 9).Return(nil, nil)
 				mockRepo.EXPECT().GetPlotByOrderNumber(gomock.Any(), mockEstateID, 11).Return(nil, nil)
 				mockRepo.EXPECT().GetOccupiedPlotBehind(gomock.Any(), mockEstateID, 10).Return(nil, gorm.ErrRecordNotFound)
@@ -0,0 +1,103 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) AddWorkOrder(ctx context.Context, estateId uuid.UUID, req generated.WorkOrderRequest) (generated.WorkOrder, int, error) {
+	if err := checkWorkOrderKind(req.Kind); err != nil {
+		return generated.WorkOrder{}, http.StatusBadRequest, err
+	}
+	targets := 0
+	for _, given := range []bool{req.TreeId != nil, req.Rectangle != nil, req.Plots != nil} {
+		if given {
+			targets++
+		}
+	}
+	if targets != 1 {
+		return generated.WorkOrder{}, http.StatusBadRequest, errors.New("a work order must have exactly one of tree_id, rectangle and plots")
+	}
+	if req.Kind == generated.Replant && req.Height == nil {
+		return generated.WorkOrder{}, http.StatusBadRequest, errors.New("a replant order must have the height of its saplings")
+	}
+	if req.Kind != generated.Replant && (req.Height != nil || req.SpeciesId != nil) {
+		return generated.WorkOrder{}, http.StatusBadRequest, errors.New("only a replant order has a height and a species_id")
+	}
+	if req.Assignee != nil {
+		if err := checkWorkOrderAssignee(*req.Assignee); err != nil {
+			return generated.WorkOrder{}, http.StatusBadRequest, err
+		}
+	}
+	if req.Notes != nil && len(*req.Notes) > maxWorkOrderNotes {
+		return generated.WorkOrder{}, http.StatusBadRequest, fmt.Errorf("notes cannot be longer than %d characters", maxWorkOrderNotes)
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.WorkOrder{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.WorkOrder{}, http.StatusInternalServerError, err
+	}
+
+	// the saplings are capped by their species like any planted tree
+	if req.Height != nil {
+		status, err := s.checkTreeHeight(ctx, req.SpeciesId, *req.Height)
+		if err != nil {
+			return generated.WorkOrder{}, status, err
+		}
+	}
+
+	var areas []repository.WorkOrderAreaEntity
+	shape := workOrderShapeTree
+	if req.TreeId != nil {
+		plot, err := s.Repository.GetPlotByID(ctx, estateId, *req.TreeId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return generated.WorkOrder{}, http.StatusNotFound, errors.New("tree not found")
+			}
+			return generated.WorkOrder{}, http.StatusInternalServerError, err
+		}
+		areas = []repository.WorkOrderAreaEntity{{MinX: plot.X, MinY: plot.Y, MaxX: plot.X, MaxY: plot.Y}}
+	} else if areas, shape, err = workOrderAreas(req, estate); err != nil {
+		return generated.WorkOrder{}, http.StatusBadRequest, err
+	}
+
+	status := generated.Open
+	if req.Assignee != nil {
+		status = generated.Assigned
+	}
+	now := time.Now()
+	order := repository.WorkOrderEntity{
+		EstateId:  estateId,
+		Kind:      string(req.Kind),
+		Status:    string(status),
+		Shape:     shape,
+		TreeId:    req.TreeId,
+		Assignee:  req.Assignee,
+		Notes:     req.Notes,
+		Height:    req.Height,
+		SpeciesId: req.SpeciesId,
+		Areas:     areas,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	id, err := s.Repository.PostWorkOrder(ctx, order)
+	if err != nil {
+		return generated.WorkOrder{}, http.StatusInternalServerError, err
+	}
+	order.ID = *id
+
+	return workOrderResponse(order), http.StatusCreated, nil
+}
@@ -0,0 +1,162 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_AddWorkOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockOrderID := uuid.New()
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 5}
+	assignee := "Budi"
+	notes := "cut the dry fronds"
+
+	tests := []struct {
+		name           string
+		req            generated.WorkOrderRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   func(resp generated.WorkOrder)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Prune A Tree",
+			req:  generated.WorkOrderRequest{Kind: generated.Prune, TreeId: &mockTreeID, Notes: &notes},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&repository.PlotEntity{ID: mockTreeID, X: 4, Y: 2}, nil)
+				mockRepo.EXPECT().PostWorkOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.WorkOrderEntity) (*uuid.UUID, error) {
+					require.Equal(t, []any{"prune", "open", "tree", &mockTreeID, &notes}, []any{entity.Kind, entity.Status, entity.Shape, entity.TreeId, entity.Notes})
+					require.Equal(t, []repository.WorkOrderAreaEntity{{MinX: 4, MinY: 2, MaxX: 4, MaxY: 2}}, entity.Areas)
+					return &mockOrderID, nil
+				})
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, mockOrderID, *resp.Id)
+				assert.Equal(t, generated.Open, *resp.Status)
+				assert.Equal(t, mockTreeID, *resp.TreeId)
+				assert.Equal(t, []generated.PlotPosition{{X: &[]int{4}[0], Y: &[]int{2}[0]}}, *resp.Plots)
+				assert.Nil(t, resp.Rectangle)
+				assert.Nil(t, resp.CompletedAt)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "Replant A Rectangle Assigned",
+			req: generated.WorkOrderRequest{
+				Kind:      generated.Replant,
+				Rectangle: &generated.PlotRectangle{MinX: 1, MinY: 1, MaxX: 3, MaxY: 2},
+				Assignee:  &assignee,
+				Height:    &[]int{2}[0],
+			},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().PostWorkOrder(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.WorkOrderEntity) (*uuid.UUID, error) {
+					require.Equal(t, []any{"replant", "assigned", "rectangle", &assignee, 2}, []any{entity.Kind, entity.Status, entity.Shape, entity.Assignee, *entity.Height})
+					return &mockOrderID, nil
+				})
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, generated.Assigned, *resp.Status)
+				assert.Equal(t, generated.PlotRectangle{MinX: 1, MinY: 1, MaxX: 3, MaxY: 2}, *resp.Rectangle)
+				assert.Equal(t, assignee, *resp.Assignee)
+				assert.Nil(t, resp.Plots)
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Two Targets",
+			req:            generated.WorkOrderRequest{Kind: generated.Spray, TreeId: &mockTreeID, Plots: &[]generated.PlotPosition{}},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("a work order must have exactly one of tree_id, rectangle and plots"),
+		},
+		{
+			name:           "Replant Without Height",
+			req:            generated.WorkOrderRequest{Kind: generated.Replant, TreeId: &mockTreeID},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("a replant order must have the height of its saplings"),
+		},
+		{
+			name:           "Height Of A Remove Order",
+			req:            generated.WorkOrderRequest{Kind: generated.Remove, TreeId: &mockTreeID, Height: &[]int{2}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("only a replant order has a height and a species_id"),
+		},
+		{
+			name:           "Unknown Kind",
+			req:            generated.WorkOrderRequest{Kind: "fertilize", TreeId: &mockTreeID},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("kind must be one of prune, spray, replant, remove"),
+		},
+		{
+			name: "Plot Out Of Range",
+			req:  generated.WorkOrderRequest{Kind: generated.Spray, Plots: &[]generated.PlotPosition{{X: &[]int{11}[0], Y: &[]int{1}[0]}}},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot (11,1) is out of range"),
+		},
+		{
+			name: "Tree Not Found",
+			req:  generated.WorkOrderRequest{Kind: generated.Remove, TreeId: &mockTreeID},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("tree not found"),
+		},
+		{
+			name: "Estate Not Found",
+			req:  generated.WorkOrderRequest{Kind: generated.Remove, TreeId: &mockTreeID},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.AddWorkOrder(mockContext, mockEstateID, tt.req)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			} else {
+				assert.NoError(t, err)
+			}
+			if tt.expectedResp != nil {
+				tt.expectedResp(resp)
+			}
+		})
+	}
+}
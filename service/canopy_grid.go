@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// defaultMapSize and maxMapSize bound the width and the height of a map raster in cells, the larger estates are
+// downsampled so a raster never grows past maxMapSize x maxMapSize cells.
+const (
+	defaultMapSize = 1024
+	maxMapSize     = 2048
+)
+
+// canopyCell is a map cell holding at least one tree, X and Y are 0-based and Y grows with the plot y.
+type canopyCell struct {
+	X      int
+	Y      int
+	Height float64
+}
+
+/*
+canopyGrid is the canopy height raster of an estate. Each cell covers CellPlots x CellPlots plots, CellSize meters
+on a side at the plot size of the estate. Only the cells holding trees are kept so large estates never allocate their
+full grid.
+*/
+type canopyGrid struct {
+	Cols      int
+	Rows      int
+	CellPlots int
+	CellSize  float64
+	MaxHeight float64
+	Cells     []canopyCell
+}
+
+func (s *Service) loadCanopyGrid(ctx context.Context, estateId uuid.UUID, maxSize *int, aggregation *generated.MapAggregation) (canopyGrid, int, error) {
+	size := defaultMapSize
+	if maxSize != nil {
+		size = *maxSize
+	}
+	if size < 1 {
+		return canopyGrid{}, http.StatusBadRequest, errors.New("max_size must be at least 1")
+	}
+	if size > maxMapSize {
+		return canopyGrid{}, http.StatusBadRequest, fmt.Errorf("max_size cannot be greater than %d", maxMapSize)
+	}
+
+	useMean := false
+	if aggregation != nil {
+		switch *aggregation {
+		case generated.Max:
+		case generated.Mean:
+			useMean = true
+		default:
+			return canopyGrid{}, http.StatusBadRequest, errors.New("aggregation must be one of max or mean")
+		}
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return canopyGrid{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return canopyGrid{}, http.StatusInternalServerError, err
+	}
+
+	cellPlots := (max(estate.Length, estate.Width) + size - 1) / size
+	grid := canopyGrid{
+		Cols:      (estate.Length + cellPlots - 1) / cellPlots,
+		Rows:      (estate.Width + cellPlots - 1) / cellPlots,
+		CellPlots: cellPlots,
+		CellSize:  float64(cellPlots) * newFlightModel(estate).plotSize,
+	}
+
+	tiles, err := s.Repository.GetTreeHeightStatsByTile(ctx, estate.ID, repository.TreeHeightFilter{}, cellPlots, cellPlots)
+	if err != nil {
+		return canopyGrid{}, http.StatusInternalServerError, err
+	}
+
+	grid.Cells = make([]canopyCell, len(tiles))
+	for i, tile := range tiles {
+		height := float64(tile.Max)
+		if useMean {
+			height = tile.Mean
+		}
+		grid.Cells[i] = canopyCell{X: tile.TileX, Y: tile.TileY, Height: height}
+		grid.MaxHeight = max(grid.MaxHeight, height)
+	}
+
+	return grid, http.StatusOK, nil
+}
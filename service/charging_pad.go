@@ -0,0 +1,267 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxChargingPads bounds the charging pads of an estate, every plot the planner reaches is checked against each pad.
+const maxChargingPads = 100
+
+// errTooManySorties is returned when a plan needs more than maxMissionSorties sorties.
+var errTooManySorties = errors.New("too many sorties")
+
+// padSortie is a sortie flown from a charging pad to a charging pad, pads are indexes in the pads of the estate.
+type padSortie struct {
+	missionSortie
+	StartPad int
+	EndPad   int
+}
+
+/*
+padNetwork prices the deadhead legs between the charging pads of an estate and its plots. A deadhead leg flies
+straight from the center of the pad to the center of the plot, taking off and landing at the start/end altitude on
+the pad and arriving at the altitude of the plot, and cruises above the trees it crosses, at the minimum cruise
+altitude above the highest ground it crosses at least when it crosses any plot. A leg crossing a no-fly plot takes
+the detour around it instead.
+*/
+type padNetwork struct {
+	pads   []repository.ChargingPadEntity
+	trav   traversal
+	model  flightModel
+	meter  sortieMeter
+	canopy canopyIndex
+	grid   flightGrid
+	// detours holds the detour of every deadhead leg around the no-fly plots by pad and order number, nil when the leg is straight
+	detours map[[2]int]*detour
+	// ceiling is the highest altitude a deadhead leg ever cruises at
+	ceiling int
+}
+
+// newPadNetwork builds the network of the pads of an estate, plots are its trees with its height obstacles.
+func newPadNetwork(pads []repository.ChargingPadEntity, plots []repository.PlotEntity, trav traversal, model flightModel, meter sortieMeter, grid flightGrid) padNetwork {
+	// the first node of a canopy index is not a tree
+	nodes := make([]inspectionNode, 0, len(plots)+1)
+	nodes = append(nodes, inspectionNode{})
+	ceiling := max(model.altitude(nil), model.startEndAltitude) + model.terrain.relief()
+	for i := range plots {
+		altitude := model.ground(int(plots[i].X), int(plots[i].Y)) + model.altitude(&plots[i])
+		nodes = append(nodes, inspectionNode{x: int(plots[i].X), y: int(plots[i].Y), altitude: altitude})
+		ceiling = max(ceiling, altitude)
+	}
+
+	return padNetwork{pads: pads, trav: trav, model: model, meter: meter, canopy: newCanopyIndex(nodes), grid: grid, detours: map[[2]int]*detour{}, ceiling: ceiling}
+}
+
+/*
+deadhead returns the meters flown horizontally, climbed and descended from a pad to the plot at an order number
+reached at altitude, and the altitude cruised. The way back from the plot to the pad swaps the climb and the descent.
+*/
+func (n padNetwork) deadhead(pad int, order int, altitude int) (float64, float64, float64, int) {
+	padX, padY := int(n.pads[pad].X), int(n.pads[pad].Y)
+	x, y := n.trav.plot(order)
+	dx, dy := x-padX, y-padY
+
+	launch := n.model.ground(padX, padY) + n.model.startEndAltitude
+	cruise := max(altitude, launch)
+	if d := n.detour(pad, order); d != nil {
+		cruise = max(cruise, d.Altitude)
+		return d.Horizontal, float64(cruise - launch), float64(cruise - altitude), cruise
+	}
+	cruise = max(cruise, n.canopy.tallestAlong(float64(padX)-0.5, float64(padY)-0.5, float64(x)-0.5, float64(y)-0.5, 0, 0))
+	if max(abs(dx), abs(dy)) > 1 {
+		cruise = max(cruise, n.model.terrain.highestAlong([2]int{padX, padY}, [2]int{x, y})+n.model.altitude(nil))
+	}
+	return n.model.plotSize * math.Hypot(float64(dx), float64(dy)), float64(cruise - launch), float64(cruise - altitude), cruise
+}
+
+// detour returns the detour of the deadhead leg between a pad and the plot at an order number, a leg to a plot the
+// detours cannot reach is endless.
+func (n padNetwork) detour(pad int, order int) *detour {
+	if len(n.grid.noFly) == 0 {
+		return nil
+	}
+	if d, ok := n.detours[[2]int{pad, order}]; ok {
+		return d
+	}
+
+	x, y := n.trav.plot(order)
+	d, err := n.grid.legAround([2]int{int(n.pads[pad].X), int(n.pads[pad].Y)}, [2]int{x, y})
+	if err != nil {
+		d = &detour{Horizontal: math.Inf(1)}
+	}
+	n.detours[[2]int{pad, order}] = d
+	return d
+}
+
+// takeoffPad returns the pad the drone flies from to the plot at an order number at the lowest cost.
+func (n padNetwork) takeoffPad(order int, altitude int) int {
+	best, bestCost := 0, math.Inf(1)
+	for pad := range n.pads {
+		horizontal, climb, descent, _ := n.deadhead(pad, order, altitude)
+		if cost := n.meter(horizontal, climb, descent); cost < bestCost {
+			best, bestCost = pad, cost
+		}
+	}
+	return best
+}
+
+// landingPad returns the nearest pad from the plot at an order number, the one the drone reaches at the lowest
+// cost, with that cost and the horizontal distance to it.
+func (n padNetwork) landingPad(order int, altitude int) (int, float64, float64) {
+	best, bestCost, bestHorizontal := 0, math.Inf(1), 0.0
+	for pad := range n.pads {
+		horizontal, climb, descent, _ := n.deadhead(pad, order, altitude)
+		if cost := n.meter(horizontal, descent, climb); cost < bestCost {
+			best, bestCost, bestHorizontal = pad, cost, horizontal
+		}
+	}
+	return best, bestCost, bestHorizontal
+}
+
+/*
+splitSorties cuts the runs in sorties flown from pad to pad within limit, priced by the meter of the network. The
+first sortie takes off from the pad nearest the first plot and every other one from the pad the previous one landed
+on, a sortie goes on to the next plot as long as the drone can still reach the nearest pad from it.
+
+Checking the pads on every plot of a long run is slow, so the walk skips ahead along a run as far as the landing
+can be bounded: every plot further away adds at most the plot size to the way back to the same pad, and the climb
+and descent of a deadhead leg never exceed the ceiling of the network. The meters are linear, which makes the
+bound a cost per plot. A detour around no-fly plots can grow faster than that, the walk never skips ahead when the
+estate has any.
+*/
+func (n padNetwork) splitSorties(runs []flightRun, limit float64) ([]padSortie, error) {
+	if len(runs) == 0 {
+		return nil, errors.New("estate has no plots")
+	}
+
+	flat := n.meter(n.model.plotSize, 0, 0)
+	skipAhead := len(n.grid.noFly) == 0
+	last := runs[len(runs)-1].To
+	var sorties []padSortie
+	pad := n.takeoffPad(runs[0].From, runs[0].Altitude)
+	i := 0
+	for order := runs[0].From; order <= last; order++ {
+		for runs[i].To < order {
+			i++
+		}
+		order = max(order, runs[i].From)
+		horizontal, climb, descent, _ := n.deadhead(pad, order, runs[i].Altitude)
+		cost := n.meter(horizontal, climb, descent)
+		landing, landingCost, landingHorizontal := n.landingPad(order, runs[i].Altitude)
+		if cost+landingCost > limit {
+			return nil, plotOutOfReachError{order: order}
+		}
+		sortie := padSortie{missionSortie: missionSortie{From: order}, StartPad: pad}
+
+		for order < last {
+			run := runs[i]
+			if skipAhead && order < run.To {
+				top := max(n.ceiling, run.Altitude)
+				bound := n.meter(landingHorizontal, float64(top-run.Altitude), float64(top-n.model.startEndAltitude))
+				skip := min(float64(run.To-order), math.Floor((limit-cost-bound)/(2*flat)))
+				if skip >= 1 {
+					cost += skip * flat
+					order += int(skip)
+					landing, landingCost, landingHorizontal = n.landingPad(order, run.Altitude)
+					continue
+				}
+			}
+
+			next, nextOrder := i, order+1
+			step := flat
+			if order == run.To {
+				next++
+				nextOrder = runs[next].From
+				if runs[next].Detour != nil {
+					step = runs[next].Detour.cost(n.meter, run.Altitude, runs[next].Altitude)
+				} else {
+					step += verticalCost(n.meter, run.Altitude, runs[next].Altitude)
+				}
+			}
+			nextLanding, nextLandingCost, nextHorizontal := n.landingPad(nextOrder, runs[next].Altitude)
+			if cost+step+nextLandingCost > limit {
+				break
+			}
+			cost += step
+			order, i = nextOrder, next
+			landing, landingCost, landingHorizontal = nextLanding, nextLandingCost, nextHorizontal
+		}
+
+		sortie.To, sortie.EndPad = order, landing
+		sorties = append(sorties, sortie)
+		if len(sorties) > maxMissionSorties {
+			return nil, errTooManySorties
+		}
+		pad = landing
+	}
+	return sorties, nil
+}
+
+// runAt returns the run holding the plot at an order number.
+func runAt(runs []flightRun, order int) flightRun {
+	return runs[sort.Search(len(runs), func(i int) bool { return runs[i].To >= order })]
+}
+
+// runAltitude returns the altitude of the run holding the plot at an order number.
+func runAltitude(runs []flightRun, order int) int {
+	return runAt(runs, order).Altitude
+}
+
+/*
+padSorties returns the sorties of a plan flown from pad to pad with their deadhead legs, the flight time and energy
+of every sortie and of the plan when there is a drone profile, and the distance of the plan.
+*/
+func (n padNetwork) padSorties(sorties []padSortie, runs []flightRun, profile *droneProfile) ([]generated.DronePlanSortie, *float64, *float64, int) {
+	var flightTime, energy, distance float64
+	planSorties := make([]generated.DronePlanSortie, len(sorties))
+	for i, sortie := range sorties {
+		fromAltitude := runAltitude(runs, sortie.From)
+		toAltitude := runAltitude(runs, sortie.To)
+		inHorizontal, inClimb, inDescent, inCruise := n.deadhead(sortie.StartPad, sortie.From, fromAltitude)
+		outHorizontal, outDescent, outClimb, outCruise := n.deadhead(sortie.EndPad, sortie.To, toAltitude)
+		horizontal, climb, descent := flownLegs(runs, sortie.missionSortie, n.model, fromAltitude, toAltitude)
+
+		planSortie := sortieResponse(n.trav, sortie.missionSortie, horizontal+inHorizontal+outHorizontal, climb+inClimb+outClimb, descent+inDescent+outDescent, profile)
+		startPad, endPad := n.padResponse(sortie.StartPad), n.padResponse(sortie.EndPad)
+		planSortie.StartPad, planSortie.EndPad = &startPad, &endPad
+		planSortie.DeadheadIn = &generated.DeadheadLeg{
+			From:     &generated.PlotPosition{X: startPad.X, Y: startPad.Y},
+			To:       planSortie.Start,
+			Altitude: &inCruise,
+			Distance: roundedDistance(inHorizontal, inClimb, inDescent),
+		}
+		planSortie.DeadheadOut = &generated.DeadheadLeg{
+			From:     planSortie.End,
+			To:       &generated.PlotPosition{X: endPad.X, Y: endPad.Y},
+			Altitude: &outCruise,
+			Distance: roundedDistance(outHorizontal, outClimb, outDescent),
+		}
+		planSorties[i] = planSortie
+
+		distance += distanceMeter(horizontal+inHorizontal+outHorizontal, climb+inClimb+outClimb, descent+inDescent+outDescent)
+		if profile != nil {
+			flightTime += *planSortie.FlightTime
+			energy += *planSortie.Energy
+		}
+	}
+
+	if profile == nil {
+		return planSorties, nil, nil, int(math.Round(distance))
+	}
+	return planSorties, &flightTime, &energy, int(math.Round(distance))
+}
+
+func (n padNetwork) padResponse(pad int) generated.ChargingPad {
+	x, y := int(n.pads[pad].X), int(n.pads[pad].Y)
+	return generated.ChargingPad{Id: &n.pads[pad].ID, X: &x, Y: &y}
+}
+
+func roundedDistance(horizontal, climb, descent float64) *int {
+	distance := int(math.Round(distanceMeter(horizontal, climb, descent)))
+	return &distance
+}
@@ -0,0 +1,156 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestPadNetwork_Deadhead(t *testing.T) {
+	// 5 plots in a row with a tree of 4 meters on plot 3 and a pad on plot 1
+	estate := repository.EstateEntity{Length: 5, Width: 1}
+	model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 2}
+	plots := []repository.PlotEntity{{X: 3, Y: 1, OrderNumber: 3, TreeHeight: 4}}
+	network := newPadNetwork([]repository.ChargingPadEntity{{X: 1, Y: 1}}, plots, newTraversal(estate), model, distanceMeter, flightGrid{})
+
+	// the drone takes off over its first plot
+	horizontal, climb, descent, cruise := network.deadhead(0, 1, 2)
+	assert.Equal(t, []float64{0, 2, 0}, []float64{horizontal, climb, descent})
+	assert.Equal(t, 2, cruise)
+
+	// the leg to plot 5 climbs over the tree of plot 3
+	horizontal, climb, descent, cruise = network.deadhead(0, 5, 2)
+	assert.Equal(t, []float64{40, 5, 3}, []float64{horizontal, climb, descent})
+	assert.Equal(t, 5, cruise)
+
+	// the nearest pad is the only one, 5 meters up and 40 meters across
+	pad, cost, horizontal := network.landingPad(5, 2)
+	assert.Equal(t, 0, pad)
+	assert.Equal(t, 48.0, cost)
+	assert.Equal(t, 40.0, horizontal)
+}
+
+func TestPadNetwork_SplitSorties(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+
+	t.Run("Lands On The Nearest Pad", func(t *testing.T) {
+		// 8 plots in a row with pads on plots 1 and 6
+		estate := repository.EstateEntity{Length: 8, Width: 1}
+		trav := newTraversal(estate)
+		pads := []repository.ChargingPadEntity{{X: 1, Y: 1}, {X: 6, Y: 1}}
+		network := newPadNetwork(pads, nil, trav, model, distanceMeter, flightGrid{})
+		runs := flightRuns(trav.plotCount(), nil, model)
+
+		sorties, err := network.splitSorties(runs, 60)
+		require.NoError(t, err)
+		assert.Equal(t, []padSortie{
+			{missionSortie: missionSortie{From: 1, To: 6}, StartPad: 0, EndPad: 1},
+			{missionSortie: missionSortie{From: 7, To: 8}, StartPad: 1, EndPad: 1},
+		}, sorties)
+	})
+
+	t.Run("Plot Out Of Reach", func(t *testing.T) {
+		estate := repository.EstateEntity{Length: 8, Width: 1}
+		trav := newTraversal(estate)
+		network := newPadNetwork([]repository.ChargingPadEntity{{X: 1, Y: 1}}, nil, trav, model, distanceMeter, flightGrid{})
+
+		_, err := network.splitSorties(flightRuns(trav.plotCount(), nil, model), 60)
+		assert.Equal(t, plotOutOfReachError{order: 5}, err)
+	})
+
+	t.Run("Detours Around A No-Fly Plot", func(t *testing.T) {
+		// 5 plots in 2 rows with a pad on plot (1,1) and a no-fly plot on (3,1)
+		estate := repository.EstateEntity{Length: 5, Width: 2}
+		trav := newTraversal(estate)
+		grid := noFlyGrid(estate, model, nil, [2]int{3, 1})
+		network := newPadNetwork([]repository.ChargingPadEntity{{X: 1, Y: 1}}, nil, trav, model, distanceMeter, grid)
+		runs, err := grid.cutRuns(flightRuns(trav.plotCount(), nil, model), trav)
+		require.NoError(t, err)
+
+		horizontal, _, _, _ := network.deadhead(0, 5, 0)
+		assert.InDelta(t, 20+20*math.Sqrt2, horizontal, 1e-9)
+
+		sorties, err := network.splitSorties(runs, 100)
+		require.NoError(t, err)
+		assert.Equal(t, []padSortie{
+			{missionSortie: missionSortie{From: 1, To: 4}},
+			{missionSortie: missionSortie{From: 5, To: 5}},
+			{missionSortie: missionSortie{From: 6, To: 10}},
+		}, sorties)
+	})
+
+	t.Run("Skips Ahead Like The Plot By Plot Walk", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		patterns := []generated.TraversalPattern{generated.RowSerpentine, generated.ColumnSerpentine, generated.Spiral}
+		// a linear meter that prices climbing and descending unlike flying across
+		meters := []sortieMeter{distanceMeter, func(horizontal, climb, descent float64) float64 {
+			return horizontal + 3*climb + descent/2
+		}}
+
+		for i := 0; i < 300; i++ {
+			estate := repository.EstateEntity{Length: rng.Intn(20) + 1, Width: rng.Intn(20) + 1, TraversalPattern: string(patterns[rng.Intn(3)])}
+			trav := newTraversal(estate)
+			model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: rng.Intn(4), startEndAltitude: rng.Intn(3)}
+
+			var plots []repository.PlotEntity
+			for x := 1; x <= estate.Length; x++ {
+				for y := 1; y <= estate.Width; y++ {
+					if rng.Intn(4) == 0 {
+						plots = append(plots, repository.PlotEntity{X: uint16(x), Y: uint16(y), TreeHeight: rng.Intn(30) + 1})
+					}
+				}
+			}
+			trav.orderPlots(plots)
+			pads := make([]repository.ChargingPadEntity, rng.Intn(4)+1)
+			for p := range pads {
+				pads[p] = repository.ChargingPadEntity{X: uint16(rng.Intn(estate.Length) + 1), Y: uint16(rng.Intn(estate.Width) + 1)}
+			}
+
+			network := newPadNetwork(pads, plots, trav, model, meters[i%2], flightGrid{})
+			runs := flightRuns(trav.plotCount(), plots, model)
+			limit := float64(rng.Intn(600) + 50)
+
+			expected, expectedErr := walkPadSorties(network, runs, limit)
+			sorties, err := network.splitSorties(runs, limit)
+			require.Equal(t, expectedErr, err, "estate %d", i)
+			require.Equal(t, expected, sorties, "estate %d", i)
+		}
+	})
+}
+
+// walkPadSorties cuts the sorties from pad to pad checking the pads on every plot.
+func walkPadSorties(n padNetwork, runs []flightRun, limit float64) ([]padSortie, error) {
+	last := runs[len(runs)-1].To
+	var sorties []padSortie
+	pad := n.takeoffPad(1, runAltitude(runs, 1))
+	for order := 1; order <= last; order++ {
+		horizontal, climb, descent, _ := n.deadhead(pad, order, runAltitude(runs, order))
+		cost := n.meter(horizontal, climb, descent)
+		landing, landingCost, _ := n.landingPad(order, runAltitude(runs, order))
+		if cost+landingCost > limit {
+			return nil, plotOutOfReachError{order: order}
+		}
+
+		sortie := padSortie{missionSortie: missionSortie{From: order}, StartPad: pad}
+		for order < last {
+			step := n.meter(n.model.plotSize, 0, 0) + verticalCost(n.meter, runAltitude(runs, order), runAltitude(runs, order+1))
+			nextLanding, nextLandingCost, _ := n.landingPad(order+1, runAltitude(runs, order+1))
+			if cost+step+nextLandingCost > limit {
+				break
+			}
+			cost += step
+			order++
+			landing = nextLanding
+		}
+		sortie.To, sortie.EndPad = order, landing
+		sorties = append(sorties, sortie)
+		pad = landing
+	}
+	return sorties, nil
+}
@@ -0,0 +1,315 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// MAVLink commands and frames used in the exported missions.
+const (
+	mavCmdNavWaypoint         = 16
+	mavCmdNavLand             = 21
+	mavCmdNavTakeoff          = 22
+	mavFrameGlobal            = 0
+	mavFrameGlobalRelativeAlt = 3
+)
+
+const (
+	maxMissionSorties = 1000
+	defaultSortie     = 1
+)
+
+// flightRun is a stretch of consecutive plots in the traversal order flown at the same altitude over the same
+// ground, Detour is the way around the no-fly plots before it when there are any.
+type flightRun struct {
+	From     int
+	To       int
+	Altitude int
+	Ground   int
+	Detour   *detour
+}
+
+// missionSortie is the stretch of plots, by order number, flown between a takeoff and a landing.
+type missionSortie struct {
+	From int
+	To   int
+}
+
+type missionWaypoint struct {
+	Latitude  float64
+	Longitude float64
+	Altitude  int
+}
+
+// missionItem is a MAVLink mission command, positions are in degrees and the altitude is relative to home.
+type missionItem struct {
+	Command   int
+	Latitude  float64
+	Longitude float64
+	Altitude  int
+}
+
+// droneMission is one sortie of the drone plan of an estate ready to be written in a mission file format.
+type droneMission struct {
+	Home        missionWaypoint
+	Items       []missionItem
+	Sortie      int
+	SortieCount int
+}
+
+// dronePlan is the drone plan of a geo-referenced estate, the traversal in runs of constant altitude split in sorties.
+type dronePlan struct {
+	Estate    repository.EstateEntity
+	Geo       geoReference
+	Traversal traversal
+	Plots     []repository.PlotEntity
+	Runs      []flightRun
+	Sorties   []missionSortie
+}
+
+/*
+loadDronePlan builds the drone plan every flight export is made from. The drone takes off at the center of the first
+plot of a sortie, flies over the plot centers in the traversal order at the altitudes of the flight model of the
+estate over its terrain, and lands at the center of the last plot. When maxDistance is given the plan is split in sorties whose horizontal and
+vertical distance, takeoff and landing included, stay within it.
+*/
+func (s *Service) loadDronePlan(ctx context.Context, estateId uuid.UUID, maxDistance *int) (dronePlan, int, error) {
+	if maxDistance != nil && *maxDistance < 1 {
+		return dronePlan{}, http.StatusBadRequest, errors.New("max_distance must be at least 1")
+	}
+
+	estate, geo, httpStatus, err := s.loadGeoReference(ctx, estateId)
+	if err != nil {
+		return dronePlan{}, httpStatus, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return dronePlan{}, http.StatusInternalServerError, err
+	}
+
+	model := newFlightModel(estate)
+	trav := newTraversal(estate)
+	runs, httpStatus, err := s.obstacleRuns(ctx, estate, trav, generated.TerrainFollowing, plots)
+	if err != nil {
+		return dronePlan{}, httpStatus, err
+	}
+	sorties, err := splitSorties(runs, model, maxDistance)
+	if err != nil {
+		return dronePlan{}, http.StatusBadRequest, err
+	}
+
+	return dronePlan{Estate: estate, Geo: geo, Traversal: trav, Plots: plots, Runs: runs, Sorties: sorties}, http.StatusOK, nil
+}
+
+// waypoints returns the waypoints of a sortie of the plan.
+func (p dronePlan) waypoints(sortie missionSortie) []missionWaypoint {
+	return sortieWaypoints(p.Runs, sortie, p.Traversal, p.Geo)
+}
+
+// loadDroneMission builds the MAVLink mission of a sortie of the drone plan.
+func (s *Service) loadDroneMission(ctx context.Context, estateId uuid.UUID, maxDistance *int, sortie *int) (droneMission, int, error) {
+	number := defaultSortie
+	if sortie != nil {
+		number = *sortie
+	}
+	if number < 1 {
+		return droneMission{}, http.StatusBadRequest, errors.New("sortie must be at least 1")
+	}
+
+	plan, httpStatus, err := s.loadDronePlan(ctx, estateId, maxDistance)
+	if err != nil {
+		return droneMission{}, httpStatus, err
+	}
+	if number > len(plan.Sorties) {
+		return droneMission{}, http.StatusBadRequest, fmt.Errorf("sortie must be at most %d", len(plan.Sorties))
+	}
+
+	waypoints := plan.waypoints(plan.Sorties[number-1])
+	first := waypoints[0]
+	last := waypoints[len(waypoints)-1]
+	// the altitudes of the plan are above the lowest plot, the ones of the mission above the ground it takes off from
+	home := runAt(plan.Runs, plan.Sorties[number-1].From).Ground
+
+	items := make([]missionItem, 0, len(waypoints)+1)
+	items = append(items, missionItem{Command: mavCmdNavTakeoff, Latitude: first.Latitude, Longitude: first.Longitude, Altitude: first.Altitude - home})
+	for _, waypoint := range waypoints[1:] {
+		items = append(items, missionItem{Command: mavCmdNavWaypoint, Latitude: waypoint.Latitude, Longitude: waypoint.Longitude, Altitude: waypoint.Altitude - home})
+	}
+	items = append(items, missionItem{Command: mavCmdNavLand, Latitude: last.Latitude, Longitude: last.Longitude})
+
+	return droneMission{
+		Home:        missionWaypoint{Latitude: first.Latitude, Longitude: first.Longitude},
+		Items:       items,
+		Sortie:      number,
+		SortieCount: len(plan.Sorties),
+	}, http.StatusOK, nil
+}
+
+// flightRuns splits the traversal of the plots into runs of constant altitude, plots are the trees by order number.
+func flightRuns(plotCount int, plots []repository.PlotEntity, model flightModel) []flightRun {
+	runs := make([]flightRun, 0, len(plots)*2+1)
+	next := 1
+	for i := range plots {
+		if plots[i].OrderNumber > next {
+			runs = append(runs, flightRun{From: next, To: plots[i].OrderNumber - 1, Altitude: model.altitude(nil)})
+		}
+		runs = append(runs, flightRun{From: plots[i].OrderNumber, To: plots[i].OrderNumber, Altitude: model.altitude(&plots[i])})
+		next = plots[i].OrderNumber + 1
+	}
+	if next <= plotCount {
+		runs = append(runs, flightRun{From: next, To: plotCount, Altitude: model.altitude(nil)})
+	}
+	return runs
+}
+
+/*
+splitSorties cuts the runs in sorties that fit in maxDistance, the whole plan is a single sortie when it is nil.
+A sortie costs the climb from the start altitude after takeoff, the plot size between two plot centers, the
+altitude changes and the descent to the end altitude before landing.
+*/
+func splitSorties(runs []flightRun, model flightModel, maxDistance *int) ([]missionSortie, error) {
+	if len(runs) == 0 {
+		return nil, errors.New("estate has no plots")
+	}
+	if maxDistance == nil {
+		return []missionSortie{{From: runs[0].From, To: runs[len(runs)-1].To}}, nil
+	}
+
+	var sorties []missionSortie
+	err := walkSorties(runs, runs[0].From, runs[len(runs)-1].To, model, distanceMeter, float64(*maxDistance), func(sortie missionSortie, _ float64) bool {
+		sorties = append(sorties, sortie)
+		return len(sorties) <= maxMissionSorties
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(sorties) > maxMissionSorties {
+		return nil, fmt.Errorf("max_distance is too short, the plan needs more than %d sorties", maxMissionSorties)
+	}
+	return sorties, nil
+}
+
+// plotOutOfReachError is returned when a single plot cannot be flown within the limit of a sortie.
+type plotOutOfReachError struct {
+	order int
+}
+
+func (e plotOutOfReachError) Error() string {
+	return fmt.Sprintf("max_distance is too short to fly over plot %d of the traversal", e.order)
+}
+
+/*
+walkSorties cuts the plots from..to of the runs in sorties whose cost, priced by meter, stays within limit and hands
+every sortie with its cost to visit in the traversal order, it stops early when visit returns false. The drone
+reaches a run after no-fly plots by its detour.
+*/
+func walkSorties(runs []flightRun, from, to int, model flightModel, meter sortieMeter, limit float64, visit func(missionSortie, float64) bool) error {
+	// previous is the altitude over the last plot of the open sortie and previousLaunch the start/end altitude over it
+	var first, last, previous, previousLaunch int
+	var cost float64
+	open := false
+	// the cost of crossing a plot at a constant altitude
+	flat := meter(model.plotSize, 0, 0)
+
+	start := sort.Search(len(runs), func(i int) bool { return runs[i].To >= from })
+	for _, run := range runs[start:] {
+		if run.From > to {
+			break
+		}
+		run.From, run.To = max(run.From, from), min(run.To, to)
+
+		// the climb after takeoff and the descent before landing over a plot of the run
+		launch := model.launchAltitude(run)
+		takeoff := verticalCost(meter, launch, run.Altitude)
+		landing := verticalCost(meter, run.Altitude, launch)
+		for order := run.From; order <= run.To; {
+			if !open {
+				if takeoff+landing > limit {
+					return plotOutOfReachError{order: order}
+				}
+				first, cost, previous, previousLaunch, open = order, takeoff, run.Altitude, launch, true
+				order++
+			} else {
+				step := flat + verticalCost(meter, previous, run.Altitude)
+				if order == run.From && run.Detour != nil {
+					step = run.Detour.cost(meter, previous, run.Altitude)
+				}
+				if cost+step+landing > limit {
+					if !visit(missionSortie{From: first, To: last}, cost+verticalCost(meter, previous, previousLaunch)) {
+						return nil
+					}
+					open = false
+					continue
+				}
+				cost += step
+				previous, previousLaunch = run.Altitude, launch
+				order++
+			}
+
+			// the rest of the run is flat, so it is covered in one go as far as the limit allows
+			if order <= run.To {
+				count := min(run.To-order+1, int((limit-landing-cost)/flat))
+				if count > 0 {
+					cost += float64(count) * flat
+					order += count
+				}
+			}
+			last = order - 1
+		}
+	}
+
+	if open {
+		visit(missionSortie{From: first, To: last}, cost+verticalCost(meter, previous, previousLaunch))
+	}
+	return nil
+}
+
+/*
+sortieWaypoints lists the plot centers where the track of a sortie turns or changes altitude, the drone flies in a
+straight line between them: the ends of every run, the ends of every leg of the traversal crossed by a run and the
+turns of the detours around the no-fly plots.
+*/
+func sortieWaypoints(runs []flightRun, sortie missionSortie, tr traversal, geo geoReference) []missionWaypoint {
+	var waypoints []missionWaypoint
+	last := 0
+	add := func(order int, altitude int) {
+		if order == last {
+			return
+		}
+		last = order
+		x, y := tr.plot(order)
+		lat, lon := geo.plotCenter(x, y)
+		waypoints = append(waypoints, missionWaypoint{Latitude: lat, Longitude: lon, Altitude: altitude})
+	}
+
+	for _, run := range runs {
+		from := max(run.From, sortie.From)
+		to := min(run.To, sortie.To)
+		if from > to {
+			continue
+		}
+
+		if from > sortie.From && run.Detour != nil {
+			for _, turn := range run.Detour.Turns {
+				lat, lon := geo.plotCenter(turn[0], turn[1])
+				waypoints = append(waypoints, missionWaypoint{Latitude: lat, Longitude: lon, Altitude: run.Detour.Altitude})
+			}
+		}
+		add(from, run.Altitude)
+		for end := tr.legEnd(from); end < to; end = tr.legEnd(end + 1) {
+			add(end, run.Altitude)
+			add(end+1, run.Altitude)
+		}
+		add(to, run.Altitude)
+	}
+	return waypoints
+}
@@ -0,0 +1,115 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+)
+
+func TestFlightRuns(t *testing.T) {
+	plots := []repository.PlotEntity{
+		{OrderNumber: 1, TreeHeight: 3},
+		{OrderNumber: 4, TreeHeight: 10},
+		{OrderNumber: 5, TreeHeight: 2},
+	}
+
+	// the minimum cruise altitude keeps the drone 1 meter above the empty plots
+	runs := flightRuns(8, plots, flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 1})
+	assert.Equal(t, []flightRun{
+		{From: 1, To: 1, Altitude: 4},
+		{From: 2, To: 3, Altitude: 1},
+		{From: 4, To: 4, Altitude: 11},
+		{From: 5, To: 5, Altitude: 3},
+		{From: 6, To: 8, Altitude: 1},
+	}, runs)
+}
+
+func TestSplitSorties(t *testing.T) {
+	// a row of 5 plots with a 4 meter tree in the middle
+	runs := []flightRun{
+		{From: 1, To: 2, Altitude: 1},
+		{From: 3, To: 3, Altitude: 5},
+		{From: 4, To: 5, Altitude: 1},
+	}
+	longRow := []flightRun{{From: 1, To: 2000, Altitude: 1}}
+
+	tests := []struct {
+		name        string
+		runs        []flightRun
+		maxDistance *int
+		expected    []missionSortie
+		expectedErr string
+	}{
+		{
+			name:     "Single Sortie Without Max Distance",
+			runs:     runs,
+			expected: []missionSortie{{From: 1, To: 5}},
+		},
+		{
+			name:        "Split Before The Descent",
+			runs:        runs,
+			maxDistance: &[]int{30}[0],
+			expected:    []missionSortie{{From: 1, To: 3}, {From: 4, To: 5}},
+		},
+		{
+			name:        "Enough For The Whole Plan",
+			runs:        runs,
+			maxDistance: &[]int{50}[0],
+			expected:    []missionSortie{{From: 1, To: 5}},
+		},
+		{
+			name:        "Too Short For A Plot",
+			runs:        runs,
+			maxDistance: &[]int{9}[0],
+			expectedErr: "max_distance is too short to fly over plot 3 of the traversal",
+		},
+		{
+			name:        "Too Many Sorties",
+			runs:        longRow,
+			maxDistance: &[]int{2}[0],
+			expectedErr: "max_distance is too short, the plan needs more than 1000 sorties",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sorties, err := splitSorties(tt.runs, flightModel{plotSize: 10}, tt.maxDistance)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, sorties)
+		})
+	}
+}
+
+func TestSortieWaypoints(t *testing.T) {
+	anchor := 0.0
+	geo, ok := newGeoReference(repository.EstateEntity{AnchorLatitude: &anchor, AnchorLongitude: &anchor, PlotSize: 10})
+	require.True(t, ok)
+
+	// 3 x 2 estate with a 4 meter tree on plot (2,1)
+	runs := []flightRun{
+		{From: 1, To: 1, Altitude: 1},
+		{From: 2, To: 2, Altitude: 5},
+		{From: 3, To: 6, Altitude: 1},
+	}
+
+	waypoints := sortieWaypoints(runs, missionSortie{From: 1, To: 6}, newTraversal(repository.EstateEntity{Length: 3, Width: 2}), geo)
+
+	expected := []struct {
+		x, y     int
+		altitude int
+	}{
+		{1, 1, 1}, {2, 1, 5}, {3, 1, 1}, {3, 2, 1}, {1, 2, 1},
+	}
+	require.Len(t, waypoints, len(expected))
+	for i, waypoint := range waypoints {
+		lat, lon := geo.plotCenter(expected[i].x, expected[i].y)
+		assert.Equal(t, missionWaypoint{Latitude: lat, Longitude: lon, Altitude: expected[i].altitude}, waypoint)
+	}
+}
@@ -0,0 +1,125 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"spgo/repository"
+)
+
+// sortieMeter prices a stretch of a sortie from the meters it flies horizontally, climbs and descends.
+type sortieMeter func(horizontal, climb, descent float64) float64
+
+// distanceMeter prices a stretch by the distance flown, whatever the direction.
+func distanceMeter(horizontal, climb, descent float64) float64 {
+	return horizontal + climb + descent
+}
+
+// verticalCost prices the climb or the descent from an altitude to another.
+func verticalCost(meter sortieMeter, from, to int) float64 {
+	if to > from {
+		return meter(0, float64(to-from), 0)
+	}
+	return meter(0, 0, float64(from-to))
+}
+
+/*
+droneProfile estimates the flight time and the energy of a drone. Horizontal and vertical flight have their own
+speed and power draw, climbing and descending are timed and priced separately, and the drone is assumed to reach
+its speed at once.
+*/
+type droneProfile struct {
+	horizontalSpeed float64
+	climbSpeed      float64
+	descentSpeed    float64
+	horizontalPower float64
+	climbPower      float64
+	descentPower    float64
+	batteryCapacity float64
+}
+
+func newDroneProfile(entity repository.DroneProfileEntity) droneProfile {
+	return droneProfile{
+		horizontalSpeed: entity.HorizontalSpeed,
+		climbSpeed:      entity.ClimbSpeed,
+		descentSpeed:    entity.DescentSpeed,
+		horizontalPower: entity.HorizontalPower,
+		climbPower:      entity.ClimbPower,
+		descentPower:    entity.DescentPower,
+		batteryCapacity: entity.BatteryCapacity,
+	}
+}
+
+// flightTime returns the seconds taken to fly a stretch.
+func (p droneProfile) flightTime(horizontal, climb, descent float64) float64 {
+	return horizontal/p.horizontalSpeed + climb/p.climbSpeed + descent/p.descentSpeed
+}
+
+// energy returns the watt-hours drawn to fly a stretch.
+func (p droneProfile) energy(horizontal, climb, descent float64) float64 {
+	joules := p.horizontalPower*horizontal/p.horizontalSpeed + p.climbPower*climb/p.climbSpeed + p.descentPower*descent/p.descentSpeed
+	return joules / 3600
+}
+
+// splitSorties cuts the plots of the runs in sorties whose energy stays within the battery capacity.
+func (p droneProfile) splitSorties(runs []flightRun, plotCount int, model flightModel) ([]missionSortie, error) {
+	var sorties []missionSortie
+	err := walkSorties(runs, 1, plotCount, model, p.energy, p.batteryCapacity, func(sortie missionSortie, _ float64) bool {
+		sorties = append(sorties, sortie)
+		return len(sorties) <= maxMissionSorties
+	})
+	var outOfReach plotOutOfReachError
+	if errors.As(err, &outOfReach) {
+		return nil, fmt.Errorf("battery_capacity of the drone profile is too small to fly over plot %d of the traversal", outOfReach.order)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(sorties) > maxMissionSorties {
+		return nil, fmt.Errorf("battery_capacity of the drone profile is too small, the plan needs more than %d sorties", maxMissionSorties)
+	}
+	return sorties, nil
+}
+
+// sortieLegs returns the meters a sortie flies horizontally, climbs and descends, takeoff and landing included.
+func sortieLegs(runs []flightRun, sortie missionSortie, model flightModel) (float64, float64, float64) {
+	return flownLegs(runs, sortie, model, model.launchAltitude(runAt(runs, sortie.From)), model.launchAltitude(runAt(runs, sortie.To)))
+}
+
+// flownLegs returns the meters a sortie flies horizontally, climbs and descends from the altitude it starts at over
+// its first plot to the one it ends at over its last plot.
+func flownLegs(runs []flightRun, sortie missionSortie, model flightModel, start, end int) (float64, float64, float64) {
+	// the steps from a plot to the next one of the traversal, the detours are counted apart
+	steps := 0
+	var detours, climb, descent float64
+	previous := start
+	fly := func(altitude int) {
+		if altitude > previous {
+			climb += float64(altitude - previous)
+		} else {
+			descent += float64(previous - altitude)
+		}
+		previous = altitude
+	}
+
+	first := sort.Search(len(runs), func(i int) bool { return runs[i].To >= sortie.From })
+	for _, run := range runs[first:] {
+		if run.From > sortie.To {
+			break
+		}
+		from, to := max(run.From, sortie.From), min(run.To, sortie.To)
+		if from > sortie.From {
+			if run.Detour != nil {
+				detours += run.Detour.Horizontal
+				fly(run.Detour.Altitude)
+			} else {
+				steps++
+			}
+		}
+		steps += to - from
+		fly(run.Altitude)
+	}
+	fly(end)
+	return float64(steps)*model.plotSize + detours, climb, descent
+}
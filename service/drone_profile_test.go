@@ -0,0 +1,41 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDroneProfile(t *testing.T) {
+	// 10 J per meter cruising, 180 J per meter climbing and 7.2 J per meter descending
+	profile := droneProfile{horizontalSpeed: 10, climbSpeed: 2, descentSpeed: 5, horizontalPower: 100, climbPower: 360, descentPower: 36, batteryCapacity: 1000.0 / 3600}
+	model := flightModel{plotSize: 10, clearance: 1}
+	// 6 plots, a tree of 4 meters on plot 3
+	runs := []flightRun{
+		{From: 1, To: 2, Altitude: 0},
+		{From: 3, To: 3, Altitude: 5},
+		{From: 4, To: 6, Altitude: 0},
+	}
+
+	t.Run("Legs", func(t *testing.T) {
+		horizontal, climb, descent := sortieLegs(runs, missionSortie{From: 1, To: 6}, model)
+		assert.Equal(t, []float64{50, 5, 5}, []float64{horizontal, climb, descent})
+		assert.Equal(t, 8.5, profile.flightTime(horizontal, climb, descent))
+		assert.InDelta(t, 1436.0/3600, profile.energy(horizontal, climb, descent), 1e-9)
+	})
+
+	t.Run("Sorties Cut By Energy", func(t *testing.T) {
+		// the climb over the tree costs more than 9 plots of cruise, so it gets a sortie of its own
+		sorties, err := profile.splitSorties(runs, 6, model)
+		require.NoError(t, err)
+		assert.Equal(t, []missionSortie{{From: 1, To: 2}, {From: 3, To: 3}, {From: 4, To: 6}}, sorties)
+	})
+
+	t.Run("Battery Too Small", func(t *testing.T) {
+		small := profile
+		small.batteryCapacity = 900.0 / 3600
+		_, err := small.splitSorties(runs, 6, model)
+		assert.EqualError(t, err, "battery_capacity of the drone profile is too small to fly over plot 3 of the traversal")
+	})
+}
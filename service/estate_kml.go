@@ -0,0 +1,200 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"image/color"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	kmlNamespace = "http://www.opengis.net/kml/2.2"
+	// trees are styled by height bands of kmlHeightBand meters, trees taller than 30 meters share the last band
+	kmlHeightBand  = 5
+	kmlHeightBands = 6
+)
+
+type kmlRoot struct {
+	XMLName  xml.Name    `xml:"kml"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	Document kmlDocument `xml:"Document"`
+}
+
+type kmlDocument struct {
+	Name      string       `xml:"name"`
+	Styles    []kmlStyle   `xml:"Style"`
+	Placemark kmlPlacemark `xml:"Placemark"`
+	Folders   []kmlFolder  `xml:"Folder"`
+}
+
+type kmlStyle struct {
+	Id        string        `xml:"id,attr"`
+	IconStyle *kmlIconStyle `xml:"IconStyle,omitempty"`
+	LineStyle *kmlLineStyle `xml:"LineStyle,omitempty"`
+	PolyStyle *kmlPolyStyle `xml:"PolyStyle,omitempty"`
+}
+
+type kmlIconStyle struct {
+	Color string `xml:"color"`
+}
+
+type kmlLineStyle struct {
+	Color string `xml:"color"`
+	Width int    `xml:"width"`
+}
+
+type kmlPolyStyle struct {
+	Color string `xml:"color,omitempty"`
+	Fill  *int   `xml:"fill,omitempty"`
+}
+
+type kmlFolder struct {
+	Name       string         `xml:"name"`
+	Placemarks []kmlPlacemark `xml:"Placemark"`
+}
+
+type kmlPlacemark struct {
+	Name        string         `xml:"name"`
+	Description string         `xml:"description,omitempty"`
+	StyleUrl    string         `xml:"styleUrl"`
+	Point       *kmlPoint      `xml:"Point,omitempty"`
+	LineString  *kmlLineString `xml:"LineString,omitempty"`
+	Polygon     *kmlPolygon    `xml:"Polygon,omitempty"`
+}
+
+type kmlPoint struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+type kmlLineString struct {
+	Extrude      int    `xml:"extrude"`
+	AltitudeMode string `xml:"altitudeMode"`
+	Coordinates  string `xml:"coordinates"`
+}
+
+type kmlPolygon struct {
+	OuterBoundary kmlLinearRing `xml:"outerBoundaryIs>LinearRing"`
+}
+
+type kmlLinearRing struct {
+	Coordinates string `xml:"coordinates"`
+}
+
+/*
+loadEstateKml writes the KML document of a geo-referenced estate: the boundary, a placemark per tree styled by its
+height band and the drone path of every sortie. The path is built from the waypoints of the drone missions so it
+is exactly the route flown, its altitudes are absolute, the ground elevation plus the flight altitude. Over terrain
+the flight altitudes are above the lowest plot, so the ground elevation is the one of the lowest plot, read from the
+elevation grid when it is not given.
+*/
+func (s *Service) loadEstateKml(ctx context.Context, estateId uuid.UUID, maxDistance *int, groundElevation *float64) ([]byte, int, error) {
+	elevation := 0.0
+	if groundElevation != nil {
+		elevation = *groundElevation
+	}
+
+	plan, httpStatus, err := s.loadDronePlan(ctx, estateId, maxDistance)
+	if err != nil {
+		return nil, httpStatus, err
+	}
+	if groundElevation == nil && plan.Estate.Relief > 0 {
+		t, err := s.loadTerrain(ctx, plan.Estate, newFlightModel(plan.Estate))
+		if err != nil {
+			return nil, http.StatusInternalServerError, err
+		}
+		elevation = t.lowest
+	}
+
+	noFill := 0
+	styles := []kmlStyle{
+		{Id: "boundary", LineStyle: &kmlLineStyle{Color: "ff00ffff", Width: 2}, PolyStyle: &kmlPolyStyle{Fill: &noFill}},
+		{Id: "drone-path", LineStyle: &kmlLineStyle{Color: "ff0000ff", Width: 2}, PolyStyle: &kmlPolyStyle{Color: "7f0000ff"}},
+	}
+	palette := canopyPalette()
+	for band := 0; band < kmlHeightBands; band++ {
+		index := 1 + int(math.Round(float64(band)/float64(kmlHeightBands-1)*float64(len(palette)-2)))
+		styles = append(styles, kmlStyle{Id: kmlHeightBandStyle(band), IconStyle: &kmlIconStyle{Color: kmlColor(palette[index])}})
+	}
+
+	trees := kmlFolder{Name: "Trees", Placemarks: make([]kmlPlacemark, len(plan.Plots))}
+	for i, plot := range plan.Plots {
+		lat, lon := plan.Geo.plotCenter(int(plot.X), int(plot.Y))
+		band := min((plot.TreeHeight-1)/kmlHeightBand, kmlHeightBands-1)
+		trees.Placemarks[i] = kmlPlacemark{
+			Name:        fmt.Sprintf("Tree (%d,%d)", plot.X, plot.Y),
+			Description: fmt.Sprintf("Height %d m", plot.TreeHeight),
+			StyleUrl:    "#" + kmlHeightBandStyle(band),
+			Point:       &kmlPoint{Coordinates: kmlCoordinate(lon, lat, 0)},
+		}
+	}
+
+	path := kmlFolder{Name: "Drone path", Placemarks: make([]kmlPlacemark, len(plan.Sorties))}
+	for i, sortie := range plan.Sorties {
+		waypoints := plan.waypoints(sortie)
+		coordinates := make([]string, len(waypoints))
+		for j, waypoint := range waypoints {
+			coordinates[j] = kmlCoordinate(waypoint.Longitude, waypoint.Latitude, elevation+float64(waypoint.Altitude))
+		}
+		path.Placemarks[i] = kmlPlacemark{
+			Name:     fmt.Sprintf("Sortie %d", i+1),
+			StyleUrl: "#drone-path",
+			LineString: &kmlLineString{
+				Extrude:      1,
+				AltitudeMode: "absolute",
+				Coordinates:  strings.Join(coordinates, " "),
+			},
+		}
+	}
+
+	ring := plan.Geo.boundaryRing(plan.Estate.Length, plan.Estate.Width)
+	corners := make([]string, len(ring))
+	for i, position := range ring {
+		corners[i] = kmlCoordinate(position[0], position[1], 0)
+	}
+
+	doc := kmlRoot{
+		Xmlns: kmlNamespace,
+		Document: kmlDocument{
+			Name:   "Estate " + plan.Estate.ID.String(),
+			Styles: styles,
+			Placemark: kmlPlacemark{
+				Name:     "Boundary",
+				StyleUrl: "#boundary",
+				Polygon:  &kmlPolygon{OuterBoundary: kmlLinearRing{Coordinates: strings.Join(corners, " ")}},
+			},
+			Folders: []kmlFolder{trees, path},
+		},
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+	encoder := xml.NewEncoder(&buf)
+	encoder.Indent("", "  ")
+	if err = encoder.Encode(doc); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	buf.WriteByte('\n')
+
+	return buf.Bytes(), http.StatusOK, nil
+}
+
+func kmlHeightBandStyle(band int) string {
+	return fmt.Sprintf("height-%d-%d", band*kmlHeightBand+1, (band+1)*kmlHeightBand)
+}
+
+// kmlColor returns a color in the aabbggrr hex notation of KML.
+func kmlColor(c color.Color) string {
+	r, g, b, a := c.RGBA()
+	return fmt.Sprintf("%02x%02x%02x%02x", a>>8, b>>8, g>>8, r>>8)
+}
+
+func kmlCoordinate(lon, lat, altitude float64) string {
+	return strconv.FormatFloat(lon, 'f', 8, 64) + "," + strconv.FormatFloat(lat, 'f', 8, 64) + "," + strconv.FormatFloat(altitude, 'f', -1, 64)
+}
@@ -0,0 +1,382 @@
+package service
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"sort"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxBoundaryVertices bounds the vertices of an estate boundary, every row of the estate is cut against every edge.
+const maxBoundaryVertices = 1000
+
+// maxExcludedPlots bounds the plots left out of an estate one by one.
+const maxExcludedPlots = 10000
+
+// plotSpan is the plots from..to of a row or a column.
+type plotSpan struct {
+	from, to int
+}
+
+/*
+estateShape is the plots of an estate that is not a rectangle, kept as the spans of plots of every row so an estate
+of 50000x50000 plots takes a span or a few per row whatever its shape.
+*/
+type estateShape struct {
+	length, width int
+	rows          [][]plotSpan
+	count         int
+}
+
+/*
+newEstateShape returns the shape of an estate request, nil when the estate is a rectangle. A plot is in the estate
+when its center is inside the boundary or on its edges, the vertices being plot centers, and is not excluded.
+*/
+func newEstateShape(req generated.EstateRequest) (*estateShape, error) {
+	if req.Boundary == nil && req.ExcludedPlots == nil {
+		return nil, nil
+	}
+
+	s := &estateShape{length: req.Length, width: req.Width, rows: make([][]plotSpan, req.Width)}
+	if req.Boundary != nil {
+		vertices, err := boundaryVertices(*req.Boundary, req.Length, req.Width)
+		if err != nil {
+			return nil, err
+		}
+		for y := 1; y <= req.Width; y++ {
+			s.rows[y-1] = boundaryRow(vertices, y)
+		}
+	} else {
+		full := []plotSpan{{from: 1, to: req.Length}}
+		for y := range s.rows {
+			s.rows[y] = full
+		}
+	}
+
+	if req.ExcludedPlots != nil {
+		if err := s.exclude(*req.ExcludedPlots); err != nil {
+			return nil, err
+		}
+	}
+
+	s.countPlots()
+	if s.count == 0 {
+		return nil, errors.New("the estate shape must hold at least one plot")
+	}
+	if s.count == req.Length*req.Width {
+		return nil, nil
+	}
+	return s, nil
+}
+
+func boundaryVertices(boundary []generated.PlotPosition, length, width int) ([][2]int, error) {
+	if len(boundary) < 3 || len(boundary) > maxBoundaryVertices {
+		return nil, fmt.Errorf("boundary must have between 3 and %d vertices", maxBoundaryVertices)
+	}
+
+	vertices := make([][2]int, len(boundary))
+	for i, vertex := range boundary {
+		if vertex.X == nil || vertex.Y == nil {
+			return nil, errors.New("every vertex of the boundary must have x and y")
+		}
+		x, y := *vertex.X, *vertex.Y
+		if x < 1 || y < 1 || x > length || y > width {
+			return nil, fmt.Errorf("boundary vertex (%d,%d) is out of range", x, y)
+		}
+		vertices[i] = [2]int{x, y}
+	}
+	return vertices, nil
+}
+
+/*
+boundaryRow returns the spans of row y inside a polygon. The edges crossing the row, counted half-open so a vertex
+on it counts once, pair up into the spans inside, and the plots the edges go through are added for the plots on
+the boundary.
+*/
+func boundaryRow(vertices [][2]int, y int) []plotSpan {
+	// a crossing is at x = num/den, den > 0
+	type crossing struct{ num, den int }
+	var crossings []crossing
+	var spans []plotSpan
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		if a[1] == b[1] {
+			if a[1] == y {
+				spans = append(spans, plotSpan{from: min(a[0], b[0]), to: max(a[0], b[0])})
+			}
+			continue
+		}
+		if y < min(a[1], b[1]) || y > max(a[1], b[1]) {
+			continue
+		}
+
+		num, den := a[0]*(b[1]-a[1])+(y-a[1])*(b[0]-a[0]), b[1]-a[1]
+		if den < 0 {
+			num, den = -num, -den
+		}
+		if num%den == 0 {
+			spans = append(spans, plotSpan{from: num / den, to: num / den})
+		}
+		if (a[1] <= y && y < b[1]) || (b[1] <= y && y < a[1]) {
+			crossings = append(crossings, crossing{num: num, den: den})
+		}
+	}
+
+	sort.Slice(crossings, func(i, j int) bool {
+		return crossings[i].num*crossings[j].den < crossings[j].num*crossings[i].den
+	})
+	for i := 0; i+1 < len(crossings); i += 2 {
+		from := ceilDiv(crossings[i].num, crossings[i].den)
+		to := floorDiv(crossings[i+1].num, crossings[i+1].den)
+		if from <= to {
+			spans = append(spans, plotSpan{from: from, to: to})
+		}
+	}
+	return mergedSpans(spans)
+}
+
+// mergedSpans sorts spans and merges the ones that overlap or touch.
+func mergedSpans(spans []plotSpan) []plotSpan {
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].from < spans[j].from
+	})
+	merged := spans[:1]
+	for _, span := range spans[1:] {
+		last := &merged[len(merged)-1]
+		if span.from <= last.to+1 {
+			last.to = max(last.to, span.to)
+			continue
+		}
+		merged = append(merged, span)
+	}
+	return merged
+}
+
+func floorDiv(num, den int) int {
+	q := num / den
+	if num%den != 0 && num < 0 {
+		q--
+	}
+	return q
+}
+
+func ceilDiv(num, den int) int {
+	return -floorDiv(-num, den)
+}
+
+// exclude takes plots out of the shape.
+func (s *estateShape) exclude(plots []generated.PlotPosition) error {
+	if len(plots) > maxExcludedPlots {
+		return fmt.Errorf("an estate cannot have more than %d excluded plots", maxExcludedPlots)
+	}
+
+	excluded := map[int][]int{}
+	for _, plot := range plots {
+		if plot.X == nil || plot.Y == nil {
+			return errors.New("every excluded plot must have x and y")
+		}
+		x, y := *plot.X, *plot.Y
+		if x < 1 || y < 1 || x > s.length || y > s.width {
+			return fmt.Errorf("excluded plot (%d,%d) is out of range", x, y)
+		}
+		excluded[y] = append(excluded[y], x)
+	}
+
+	for y, xs := range excluded {
+		sort.Ints(xs)
+		var row []plotSpan
+		for _, span := range s.rows[y-1] {
+			for _, x := range xs {
+				if x < span.from || x > span.to {
+					continue
+				}
+				if x > span.from {
+					row = append(row, plotSpan{from: span.from, to: x - 1})
+				}
+				span.from = x + 1
+			}
+			if span.from <= span.to {
+				row = append(row, span)
+			}
+		}
+		s.rows[y-1] = row
+	}
+	return nil
+}
+
+func (s *estateShape) countPlots() {
+	s.count = 0
+	for _, row := range s.rows {
+		for _, span := range row {
+			s.count += span.to - span.from + 1
+		}
+	}
+}
+
+// contains reports whether plot (x,y) is in the shape.
+func (s *estateShape) contains(x, y int) bool {
+	if y < 1 || y > s.width {
+		return false
+	}
+	row := s.rows[y-1]
+	i := sort.Search(len(row), func(i int) bool { return row[i].to >= x })
+	return i < len(row) && row[i].from <= x
+}
+
+// countIn returns the number of plots of the shape in a region.
+func (s *estateShape) countIn(region repository.PlotRegion) int {
+	count := 0
+	for y := max(region.MinY, 1); y <= min(region.MaxY, s.width); y++ {
+		for _, span := range s.rows[y-1] {
+			if from, to := max(span.from, region.MinX), min(span.to, region.MaxX); from <= to {
+				count += to - from + 1
+			}
+		}
+	}
+	return count
+}
+
+/*
+columns returns the spans of plots of every column. The rows are swept in order and a column only changes where a
+row and the previous one differ, so the sweep costs the length of the boundary rather than the area.
+*/
+func (s *estateShape) columns() [][]plotSpan {
+	columns := make([][]plotSpan, s.length)
+	opened := make([]int, s.length+1)
+	var previous []plotSpan
+	for y := 1; y <= s.width+1; y++ {
+		var row []plotSpan
+		if y <= s.width {
+			row = s.rows[y-1]
+		}
+		spanDiff(previous, row, func(from, to int, entering bool) {
+			for x := from; x <= to; x++ {
+				if entering {
+					opened[x] = y
+				} else {
+					columns[x-1] = append(columns[x-1], plotSpan{from: opened[x], to: y - 1})
+				}
+			}
+		})
+		previous = row
+	}
+	return columns
+}
+
+// spanDiff calls visit with the plots in only one of two rows of spans, entering when they are in the second one.
+func spanDiff(a, b []plotSpan, visit func(from, to int, entering bool)) {
+	type edge struct{ at, a, b int }
+	edges := make([]edge, 0, 2*(len(a)+len(b)))
+	for _, span := range a {
+		edges = append(edges, edge{at: span.from, a: 1}, edge{at: span.to + 1, a: -1})
+	}
+	for _, span := range b {
+		edges = append(edges, edge{at: span.from, b: 1}, edge{at: span.to + 1, b: -1})
+	}
+	sort.Slice(edges, func(i, j int) bool {
+		return edges[i].at < edges[j].at
+	})
+
+	inA, inB := 0, 0
+	for i := 0; i < len(edges); {
+		at := edges[i].at
+		for ; i < len(edges) && edges[i].at == at; i++ {
+			inA += edges[i].a
+			inB += edges[i].b
+		}
+		if inA != inB && i < len(edges) {
+			visit(at, edges[i].at-1, inB > 0)
+		}
+	}
+}
+
+/*
+encode writes the shape as runs of identical rows, each the number of rows, the number of spans and the gap before
+every span and its length, all as unsigned varints.
+*/
+func (s *estateShape) encode() []byte {
+	var mask []byte
+	for y := 0; y < len(s.rows); {
+		repeat := 1
+		for y+repeat < len(s.rows) && equalSpans(s.rows[y], s.rows[y+repeat]) {
+			repeat++
+		}
+		mask = binary.AppendUvarint(mask, uint64(repeat))
+		mask = binary.AppendUvarint(mask, uint64(len(s.rows[y])))
+		end := 0
+		for _, span := range s.rows[y] {
+			mask = binary.AppendUvarint(mask, uint64(span.from-end-1))
+			mask = binary.AppendUvarint(mask, uint64(span.to-span.from))
+			end = span.to
+		}
+		y += repeat
+	}
+	return mask
+}
+
+func equalSpans(a, b []plotSpan) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// inEstate reports whether plot (x,y) is in an estate, within its shape when it is not a rectangle.
+func inEstate(estate repository.EstateEntity, x, y int) bool {
+	if x < 1 || y < 1 || x > estate.Length || y > estate.Width {
+		return false
+	}
+	shape := estateShapeOf(estate)
+	return shape == nil || shape.contains(x, y)
+}
+
+// estateShapeOf returns the shape of an estate read from its mask, nil when the estate is a rectangle.
+func estateShapeOf(estate repository.EstateEntity) *estateShape {
+	if len(estate.ShapeMask) == 0 {
+		return nil
+	}
+
+	s := &estateShape{length: estate.Length, width: estate.Width, rows: make([][]plotSpan, estate.Width)}
+	mask := estate.ShapeMask
+	next := func() int {
+		value, n := binary.Uvarint(mask)
+		if n <= 0 {
+			mask = nil
+			return 0
+		}
+		mask = mask[n:]
+		return int(value)
+	}
+
+	// rows of a run share their spans, a mask cut short leaves the rows after it empty
+	for y := 0; y < len(s.rows) && len(mask) > 0; {
+		repeat, count := next(), next()
+		row := make([]plotSpan, 0, min(count, s.length))
+		end := 0
+		for i := 0; i < count && len(mask) > 0; i++ {
+			from := end + next() + 1
+			end = from + next()
+			if end > s.length {
+				break
+			}
+			row = append(row, plotSpan{from: from, to: end})
+		}
+		for ; repeat > 0 && y < len(s.rows); repeat-- {
+			s.rows[y] = row
+			y++
+		}
+	}
+	s.countPlots()
+	return s
+}
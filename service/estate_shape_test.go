@@ -0,0 +1,194 @@
+package service
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func positions(plots ...[2]int) *[]generated.PlotPosition {
+	list := make([]generated.PlotPosition, len(plots))
+	for i, plot := range plots {
+		x, y := plot[0], plot[1]
+		list[i] = generated.PlotPosition{X: &x, Y: &y}
+	}
+	return &list
+}
+
+// insidePolygon reports whether point (x,y) is inside a polygon or on its edges, by the crossings of a ray along x.
+func insidePolygon(vertices [][2]int, x, y int) bool {
+	inside := false
+	for i, a := range vertices {
+		b := vertices[(i+1)%len(vertices)]
+		cross := (b[0]-a[0])*(y-a[1]) - (b[1]-a[1])*(x-a[0])
+		if cross == 0 && x >= min(a[0], b[0]) && x <= max(a[0], b[0]) && y >= min(a[1], b[1]) && y <= max(a[1], b[1]) {
+			return true
+		}
+		if (a[1] > y) != (b[1] > y) {
+			// the edge crosses the ray when x is left of it, with the sign of the edge going up or down
+			if (cross > 0) == (b[1] > a[1]) {
+				inside = !inside
+			}
+		}
+	}
+	return inside
+}
+
+func TestEstateShape(t *testing.T) {
+	t.Run("Triangle Boundary", func(t *testing.T) {
+		shape, err := newEstateShape(generated.EstateRequest{Length: 5, Width: 3, Boundary: positions([2]int{1, 1}, [2]int{5, 1}, [2]int{1, 3})})
+		require.NoError(t, err)
+		assert.Equal(t, [][]plotSpan{{{1, 5}}, {{1, 3}}, {{1, 1}}}, shape.rows)
+		assert.Equal(t, 9, shape.count)
+		assert.Equal(t, [][]plotSpan{{{1, 3}}, {{1, 2}}, {{1, 2}}, {{1, 1}}, {{1, 1}}}, shape.columns())
+	})
+
+	t.Run("Excluded Plots", func(t *testing.T) {
+		shape, err := newEstateShape(generated.EstateRequest{Length: 4, Width: 2, ExcludedPlots: positions([2]int{2, 1}, [2]int{3, 1}, [2]int{4, 2})})
+		require.NoError(t, err)
+		assert.Equal(t, [][]plotSpan{{{1, 1}, {4, 4}}, {{1, 3}}}, shape.rows)
+		assert.True(t, shape.contains(4, 1))
+		assert.False(t, shape.contains(3, 1))
+		assert.Equal(t, 3, shape.countIn(repository.PlotRegion{MinX: 2, MinY: 1, MaxX: 4, MaxY: 2}))
+	})
+
+	t.Run("Rectangle Boundary", func(t *testing.T) {
+		shape, err := newEstateShape(generated.EstateRequest{Length: 4, Width: 2, Boundary: positions([2]int{1, 1}, [2]int{4, 1}, [2]int{4, 2}, [2]int{1, 2})})
+		require.NoError(t, err)
+		assert.Nil(t, shape)
+	})
+
+	t.Run("Invalid Shapes", func(t *testing.T) {
+		_, err := newEstateShape(generated.EstateRequest{Length: 4, Width: 2, Boundary: positions([2]int{1, 1}, [2]int{4, 1})})
+		assert.EqualError(t, err, "boundary must have between 3 and 1000 vertices")
+		_, err = newEstateShape(generated.EstateRequest{Length: 4, Width: 2, Boundary: positions([2]int{1, 1}, [2]int{5, 1}, [2]int{1, 2})})
+		assert.EqualError(t, err, "boundary vertex (5,1) is out of range")
+		_, err = newEstateShape(generated.EstateRequest{Length: 2, Width: 1, ExcludedPlots: positions([2]int{1, 1}, [2]int{2, 1})})
+		assert.EqualError(t, err, "the estate shape must hold at least one plot")
+	})
+
+	t.Run("Random Shapes", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		for i := 0; i < 200; i++ {
+			length, width := rng.Intn(12)+1, rng.Intn(12)+1
+			vertices := make([][2]int, rng.Intn(6)+3)
+			for j := range vertices {
+				vertices[j] = [2]int{rng.Intn(length) + 1, rng.Intn(width) + 1}
+			}
+			var excluded [][2]int
+			for j := rng.Intn(5); j > 0; j-- {
+				excluded = append(excluded, [2]int{rng.Intn(length) + 1, rng.Intn(width) + 1})
+			}
+
+			shape, err := newEstateShape(generated.EstateRequest{Length: length, Width: width, Boundary: positions(vertices...), ExcludedPlots: positions(excluded...)})
+			expected := map[[2]int]bool{}
+			for x := 1; x <= length; x++ {
+				for y := 1; y <= width; y++ {
+					expected[[2]int{x, y}] = insidePolygon(vertices, x, y)
+				}
+			}
+			for _, plot := range excluded {
+				delete(expected, plot)
+			}
+			count := 0
+			for _, inside := range expected {
+				if inside {
+					count++
+				}
+			}
+			if count == 0 {
+				require.Error(t, err, "shape %d", i)
+				continue
+			}
+			require.NoError(t, err, "shape %d", i)
+			if count == length*width {
+				require.Nil(t, shape, "shape %d", i)
+				continue
+			}
+
+			columns := shape.columns()
+			for x := 1; x <= length; x++ {
+				for y := 1; y <= width; y++ {
+					inColumn := false
+					for _, span := range columns[x-1] {
+						inColumn = inColumn || (y >= span.from && y <= span.to)
+					}
+					require.Equal(t, expected[[2]int{x, y}], shape.contains(x, y), "shape %d plot (%d,%d)", i, x, y)
+					require.Equal(t, expected[[2]int{x, y}], inColumn, "shape %d plot (%d,%d)", i, x, y)
+				}
+			}
+
+			decoded := estateShapeOf(repository.EstateEntity{Length: length, Width: width, ShapeMask: shape.encode()})
+			require.Equal(t, count, decoded.count, "shape %d", i)
+			for y := range shape.rows {
+				require.True(t, equalSpans(shape.rows[y], decoded.rows[y]), "shape %d row %d", i, y+1)
+			}
+		}
+	})
+}
+
+func TestTraversal_Shape(t *testing.T) {
+	patterns := []generated.TraversalPattern{generated.RowSerpentine, generated.ColumnSerpentine}
+	corners := []generated.TraversalCorner{generated.XMinYMin, generated.XMaxYMin, generated.XMinYMax, generated.XMaxYMax}
+	rng := rand.New(rand.NewSource(2))
+
+	// every plot of the shape is visited once and the drone moves to a neighbour plot at every step but the breaks
+	for i := 0; i < 50; i++ {
+		length, width := rng.Intn(8)+1, rng.Intn(8)+1
+		var excluded [][2]int
+		for j := rng.Intn(length * width); j > 0; j-- {
+			excluded = append(excluded, [2]int{rng.Intn(length) + 1, rng.Intn(width) + 1})
+		}
+		shape, err := newEstateShape(generated.EstateRequest{Length: length, Width: width, ExcludedPlots: positions(excluded...)})
+		if err != nil || shape == nil {
+			continue
+		}
+		estate := repository.EstateEntity{Length: length, Width: width, ShapeMask: shape.encode()}
+
+		for _, pattern := range patterns {
+			for _, corner := range corners {
+				tr, err := newTraversal(estate).withOverride(&pattern, &corner)
+				require.NoError(t, err)
+				t.Run(fmt.Sprintf("%d %s %s", i, pattern, corner), func(t *testing.T) {
+					require.Equal(t, shape.count, tr.plotCount())
+					breaks := map[int]bool{}
+					for _, order := range tr.breaks() {
+						breaks[order] = true
+					}
+
+					var previous [2]int
+					for order := 1; order <= tr.plotCount(); order++ {
+						x, y := tr.plot(order)
+						require.True(t, shape.contains(x, y), "plot (%d,%d) of order %d", x, y, order)
+						require.Equal(t, order, tr.order(x, y))
+
+						if order > 1 {
+							step := abs(x-previous[0]) + abs(y-previous[1])
+							require.Equal(t, breaks[order-1], step != 1, "step to order %d", order)
+						}
+						end := tr.legEnd(order)
+						require.True(t, end >= order && end <= tr.plotCount())
+						for o := order; o < end; o++ {
+							require.False(t, breaks[o], "leg from order %d to %d holds a break", order, end)
+						}
+						previous = [2]int{x, y}
+					}
+				})
+			}
+		}
+	}
+
+	t.Run("No Spiral", func(t *testing.T) {
+		shape, err := newEstateShape(generated.EstateRequest{Length: 2, Width: 2, ExcludedPlots: positions([2]int{2, 2})})
+		require.NoError(t, err)
+		spiral := generated.Spiral
+		_, err = newTraversal(repository.EstateEntity{Length: 2, Width: 2, ShapeMask: shape.encode()}).withOverride(&spiral, nil)
+		assert.Equal(t, errSpiralShape, err)
+	})
+}
@@ -0,0 +1,86 @@
+package service
+
+import (
+	"errors"
+	"math"
+	"sort"
+)
+
+// maxFleetDrones bounds the drones of a fleet plan.
+const maxFleetDrones = 100
+
+// fleetLeg is the segment of the traversal a drone of the fleet sweeps, To is before From when the drone stays on the ground.
+type fleetLeg struct {
+	From     int
+	To       int
+	Distance float64
+	Landings int
+}
+
+/*
+segmentCost returns the distance a drone with a battery range of maxDistance flies to sweep the plots from..to and
+the number of times it lands, ok is false when it needs more than budget or more than maxMissionSorties sorties.
+*/
+func segmentCost(runs []flightRun, from, to int, model flightModel, maxDistance int, budget float64) (float64, int, bool) {
+	distance := 0.0
+	landings := 0
+	err := walkSorties(runs, from, to, model, distanceMeter, float64(maxDistance), func(_ missionSortie, cost float64) bool {
+		distance += cost
+		landings++
+		return distance <= budget && landings <= maxMissionSorties
+	})
+	return distance, landings, err == nil && distance <= budget && landings <= maxMissionSorties
+}
+
+/*
+assignFleet cuts the traversal greedily: every drone in turn takes the longest segment it sweeps within budget,
+ok is false when plots are left over. The distance of a segment grows with it, so the longest one is searched
+by bisection.
+*/
+func assignFleet(runs []flightRun, plotCount int, drones []int, model flightModel, budget float64) ([]fleetLeg, bool) {
+	legs := make([]fleetLeg, len(drones))
+	next := 1
+	for d, maxDistance := range drones {
+		reach := sort.Search(plotCount-next+1, func(k int) bool {
+			_, _, ok := segmentCost(runs, next, next+k, model, maxDistance, budget)
+			return !ok
+		})
+		legs[d] = fleetLeg{From: next, To: next + reach - 1}
+		if reach > 0 {
+			legs[d].Distance, legs[d].Landings, _ = segmentCost(runs, next, next+reach-1, model, maxDistance, budget)
+		}
+		next += reach
+	}
+	return legs, next > plotCount
+}
+
+/*
+planFleet splits the traversal between drones with the given battery ranges, in their order, minimizing the
+longest distance flown by a drone: the makespan is bisected to the meter over the greedy cut.
+*/
+func planFleet(runs []flightRun, plotCount int, drones []int, model flightModel) ([]fleetLeg, error) {
+	best, ok := assignFleet(runs, plotCount, drones, model, math.Inf(1))
+	if !ok {
+		return nil, errors.New("the drones cannot sweep the whole estate, their max_distance is too short")
+	}
+
+	low, high := 0, int(math.Ceil(fleetMakespan(best)))
+	for low < high {
+		budget := (low + high) / 2
+		if legs, ok := assignFleet(runs, plotCount, drones, model, float64(budget)); ok {
+			best, high = legs, budget
+		} else {
+			low = budget + 1
+		}
+	}
+	return best, nil
+}
+
+// fleetMakespan returns the longest distance flown by a drone of the fleet.
+func fleetMakespan(legs []fleetLeg) float64 {
+	makespan := 0.0
+	for _, leg := range legs {
+		makespan = max(makespan, leg.Distance)
+	}
+	return makespan
+}
@@ -0,0 +1,65 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanFleet(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+	// a row of 10 empty plots flown at ground level
+	flat := []flightRun{{From: 1, To: 10, Altitude: 0}}
+
+	tests := []struct {
+		name        string
+		runs        []flightRun
+		plotCount   int
+		drones      []int
+		expected    []fleetLeg
+		expectedErr string
+	}{
+		{
+			name:      "Even Split",
+			runs:      flat,
+			plotCount: 10,
+			drones:    []int{1000, 1000},
+			expected:  []fleetLeg{{From: 1, To: 5, Distance: 40, Landings: 1}, {From: 6, To: 10, Distance: 40, Landings: 1}},
+		},
+		{
+			// the first drone lands every 3 plots and skips the hop to the next sortie, so it takes the longer segment
+			name:      "Short Battery",
+			runs:      flat,
+			plotCount: 10,
+			drones:    []int{25, 1000},
+			expected:  []fleetLeg{{From: 1, To: 7, Distance: 40, Landings: 3}, {From: 8, To: 10, Distance: 20, Landings: 1}},
+		},
+		{
+			name:      "Idle Drones",
+			runs:      []flightRun{{From: 1, To: 2, Altitude: 0}},
+			plotCount: 2,
+			drones:    []int{1000, 1000, 1000},
+			expected:  []fleetLeg{{From: 1, To: 1, Landings: 1}, {From: 2, To: 2, Landings: 1}, {From: 3, To: 2}},
+		},
+		{
+			name:        "Battery Too Short",
+			runs:        []flightRun{{From: 1, To: 1, Altitude: 0}, {From: 2, To: 2, Altitude: 11}, {From: 3, To: 3, Altitude: 0}},
+			plotCount:   3,
+			drones:      []int{20},
+			expectedErr: "the drones cannot sweep the whole estate, their max_distance is too short",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			legs, err := planFleet(tt.runs, tt.plotCount, tt.drones, model)
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, legs)
+		})
+	}
+}
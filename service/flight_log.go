@@ -0,0 +1,395 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxFlightLogSamples bounds the samples of a flight log, more than a day of flights at a sample a second.
+const maxFlightLogSamples = 100000
+
+// Without a sortie column a flight log is cut in sorties where the drone was on the ground between two samples.
+const (
+	// a battery rising this many percent was swapped or charged
+	sortieBatteryRise = 5.0
+	// no sample is recorded while the drone is switched off
+	sortieGap = 2 * time.Minute
+)
+
+// flightLogColumnNames maps the column names a flight log header may use to the column they stand for.
+var flightLogColumnNames = map[string]string{
+	"timestamp": "timestamp",
+	"time":      "timestamp",
+	"latitude":  "latitude",
+	"lat":       "latitude",
+	"longitude": "longitude",
+	"lon":       "longitude",
+	"lng":       "longitude",
+	"x":         "x",
+	"y":         "y",
+	"altitude":  "altitude",
+	"alt":       "altitude",
+	"battery":   "battery",
+	"sortie":    "sortie",
+}
+
+// flightLogHeader returns the index of every known column of a flight log header, the other columns are ignored.
+func flightLogHeader(header []string) (map[string]int, error) {
+	columns := map[string]int{}
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))
+		column, ok := flightLogColumnNames[name]
+		if !ok {
+			continue
+		}
+		if _, ok := columns[column]; ok {
+			return nil, fmt.Errorf("flight log column %s is given twice", column)
+		}
+		columns[column] = i
+	}
+
+	for _, column := range []string{"timestamp", "altitude", "battery"} {
+		if _, ok := columns[column]; !ok {
+			return nil, fmt.Errorf("flight log has no %s column", column)
+		}
+	}
+	_, latitude := columns["latitude"]
+	_, longitude := columns["longitude"]
+	_, x := columns["x"]
+	_, y := columns["y"]
+	if latitude != longitude || x != y || latitude == x {
+		return nil, errors.New("flight log must have either latitude and longitude or x and y columns")
+	}
+	return columns, nil
+}
+
+/*
+parseFlightLog reads the samples of a CSV flight log in the order they were recorded and numbers their sorties from
+1. Positions in latitude and longitude are placed on the plots by the geo-reference of the estate, geo is nil when it
+has none. The sorties follow the sortie column of the log, without it a new sortie starts where the battery rises or
+no sample was recorded for a while.
+*/
+func parseFlightLog(content []byte, geo *geoReference) ([]repository.FlightLogSampleEntity, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.TrimLeadingSpace = true
+
+	header, err := r.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("flight log cannot be empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	columns, err := flightLogHeader(header)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := columns["latitude"]; ok && geo == nil {
+		return nil, errors.New("estate is not geo-referenced, the flight log positions must be given in x and y")
+	}
+	_, numbered := columns["sortie"]
+
+	var samples []repository.FlightLogSampleEntity
+	var previousNumber int
+	sortie := 1
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			return nil, errors.New("every row of the flight log must have as many values as its header")
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(samples) == maxFlightLogSamples {
+			return nil, fmt.Errorf("a flight log cannot have more than %d samples", maxFlightLogSamples)
+		}
+		line, _ := r.FieldPos(0)
+
+		sample, number, err := parseFlightLogSample(record, columns, geo)
+		if err != nil {
+			return nil, fmt.Errorf("flight log line %d: %v", line, err)
+		}
+
+		if len(samples) > 0 {
+			previous := samples[len(samples)-1]
+			if sample.RecordedAt.Before(previous.RecordedAt) {
+				return nil, fmt.Errorf("flight log line %d: timestamp is before the one of the previous sample", line)
+			}
+			if numbered && number < previousNumber {
+				return nil, fmt.Errorf("flight log line %d: sortie is lower than the one of the previous sample", line)
+			}
+			landed := sample.Battery-previous.Battery >= sortieBatteryRise || sample.RecordedAt.Sub(previous.RecordedAt) > sortieGap
+			if numbered && number != previousNumber || !numbered && landed {
+				sortie++
+			}
+		}
+		if sortie > maxMissionSorties {
+			return nil, fmt.Errorf("a flight log cannot have more than %d sorties", maxMissionSorties)
+		}
+
+		sample.Sequence = len(samples) + 1
+		sample.Sortie = sortie
+		samples = append(samples, sample)
+		previousNumber = number
+	}
+
+	if len(samples) == 0 {
+		return nil, errors.New("flight log has no samples")
+	}
+	return samples, nil
+}
+
+// parseFlightLogSample reads a row of a flight log, with the sortie number of its sortie column when there is one.
+func parseFlightLogSample(record []string, columns map[string]int, geo *geoReference) (repository.FlightLogSampleEntity, int, error) {
+	var sample repository.FlightLogSampleEntity
+	value := func(column string) (float64, error) {
+		field := strings.TrimSpace(record[columns[column]])
+		v, err := strconv.ParseFloat(field, 64)
+		if err != nil || math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, fmt.Errorf("%s %q is not a number", column, field)
+		}
+		return v, nil
+	}
+
+	recordedAt, err := parseFlightLogTime(strings.TrimSpace(record[columns["timestamp"]]))
+	if err != nil {
+		return sample, 0, err
+	}
+	sample.RecordedAt = recordedAt
+
+	if _, ok := columns["latitude"]; ok {
+		lat, err := value("latitude")
+		if err != nil {
+			return sample, 0, err
+		}
+		lon, err := value("longitude")
+		if err != nil {
+			return sample, 0, err
+		}
+		if lat < -90 || lat > 90 || lon < -180 || lon > 180 {
+			return sample, 0, errors.New("latitude must be between -90 and 90 and longitude between -180 and 180")
+		}
+		// plot (x,y) covers [x-1,x] x [y-1,y] of the grid, its center is half a plot before x and y
+		gx, gy := geo.toGrid(lat, lon)
+		sample.X, sample.Y = gx+0.5, gy+0.5
+	} else {
+		if sample.X, err = value("x"); err != nil {
+			return sample, 0, err
+		}
+		if sample.Y, err = value("y"); err != nil {
+			return sample, 0, err
+		}
+	}
+
+	if sample.Altitude, err = value("altitude"); err != nil {
+		return sample, 0, err
+	}
+	if sample.Altitude < minElevation || sample.Altitude > maxElevation {
+		return sample, 0, fmt.Errorf("altitude must be between %d and %d", minElevation, maxElevation)
+	}
+	if sample.Battery, err = value("battery"); err != nil {
+		return sample, 0, err
+	}
+	if sample.Battery < 0 || sample.Battery > 100 {
+		return sample, 0, errors.New("battery must be between 0 and 100")
+	}
+
+	if _, ok := columns["sortie"]; !ok {
+		return sample, 0, nil
+	}
+	field := strings.TrimSpace(record[columns["sortie"]])
+	number, err := strconv.Atoi(field)
+	if err != nil {
+		return sample, 0, fmt.Errorf("sortie %q is not a whole number", field)
+	}
+	return sample, number, nil
+}
+
+// parseFlightLogTime reads an RFC 3339 timestamp or a number of unix seconds.
+func parseFlightLogTime(field string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339Nano, field); err == nil {
+		return t.UTC(), nil
+	}
+	seconds, err := strconv.ParseFloat(field, 64)
+	// unix seconds up to the year 5138
+	if err != nil || !(seconds >= 0 && seconds < 1e11) {
+		return time.Time{}, fmt.Errorf("timestamp %q is neither RFC 3339 nor unix seconds", field)
+	}
+	whole, fraction := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(math.Round(fraction*1e9))).UTC(), nil
+}
+
+// loggedSortie is the samples of a flight log from a takeoff to the landing after it.
+type loggedSortie []repository.FlightLogSampleEntity
+
+// loggedSorties cuts the samples of a flight log by sortie.
+func loggedSorties(samples []repository.FlightLogSampleEntity) []loggedSortie {
+	var sorties []loggedSortie
+	from := 0
+	for i := range samples {
+		if i == len(samples)-1 || samples[i+1].Sortie != samples[i].Sortie {
+			sorties = append(sorties, samples[from:i+1])
+			from = i + 1
+		}
+	}
+	return sorties
+}
+
+// distance returns the meters flown in the sortie, horizontally between the samples plus every climb and descent,
+// as the distances of the drone plan are counted.
+func (l loggedSortie) distance(plotSize float64) float64 {
+	var distance float64
+	for i := 1; i < len(l); i++ {
+		distance += math.Hypot(l[i].X-l[i-1].X, l[i].Y-l[i-1].Y)*plotSize + math.Abs(l[i].Altitude-l[i-1].Altitude)
+	}
+	return distance
+}
+
+// batteryUsed returns the percent of battery drawn from the takeoff to the landing.
+func (l loggedSortie) batteryUsed() float64 {
+	return max(l[0].Battery-l[len(l)-1].Battery, 0)
+}
+
+// loggedPlot returns the plot of the estate a sample is over, the nearest one when it is outside the estate.
+func loggedPlot(estate repository.EstateEntity, sample repository.FlightLogSampleEntity) generated.PlotPosition {
+	x := min(max(int(math.Round(sample.X)), 1), estate.Length)
+	y := min(max(int(math.Round(sample.Y)), 1), estate.Width)
+	return generated.PlotPosition{X: &x, Y: &y}
+}
+
+// plotDeviation returns the meters between a sample and the center of a plot.
+func plotDeviation(sample repository.FlightLogSampleEntity, plot generated.PlotPosition, plotSize float64) *int {
+	deviation := int(math.Round(math.Hypot(sample.X-float64(*plot.X), sample.Y-float64(*plot.Y)) * plotSize))
+	return &deviation
+}
+
+/*
+flightLogPlan returns the drone plan a flight log is compared with, terrain following over the traversal of the
+estate cut in sorties by maxDistance, and its sorties. The plan only lists its sorties with charging pads, without
+them they are cut the way the exported missions are.
+*/
+func (s *Service) flightLogPlan(ctx context.Context, estate repository.EstateEntity, maxDistance *int) (generated.DronePlanResponse, []generated.DronePlanSortie, int, error) {
+	plan, httpStatus, err := s.GetEstateDronePlan(ctx, estate.ID, generated.GetEstateIdDronePlanParams{MaxDistance: maxDistance})
+	if err != nil {
+		return generated.DronePlanResponse{}, nil, httpStatus, err
+	}
+	if plan.Sorties != nil {
+		return plan, *plan.Sorties, http.StatusOK, nil
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estate.ID)
+	if err != nil {
+		return generated.DronePlanResponse{}, nil, http.StatusInternalServerError, err
+	}
+	trav := newTraversal(estate)
+	runs, httpStatus, err := s.obstacleRuns(ctx, estate, trav, generated.TerrainFollowing, plots)
+	if err != nil {
+		return generated.DronePlanResponse{}, nil, httpStatus, err
+	}
+	model := newFlightModel(estate)
+	sorties, err := splitSorties(runs, model, maxDistance)
+	if err != nil {
+		return generated.DronePlanResponse{}, nil, http.StatusBadRequest, err
+	}
+
+	planned := make([]generated.DronePlanSortie, len(sorties))
+	for i, sortie := range sorties {
+		horizontal, climb, descent := sortieLegs(runs, sortie, model)
+		planned[i] = sortieResponse(trav, sortie, horizontal, climb, descent, nil)
+	}
+	return plan, planned, http.StatusOK, nil
+}
+
+/*
+flightLogReport compares the sorties of a flight log with the ones of the drone plan in their order, a sortie flown
+beyond the plan or planned but not flown is reported with one side only. The meters flown per percent of battery
+give the max_distance a full battery flies.
+*/
+func flightLogReport(flightLog repository.FlightLogEntity, estate repository.EstateEntity, plan generated.DronePlanResponse, planned []generated.DronePlanSortie) generated.FlightLogReport {
+	plotSize := newFlightModel(estate).plotSize
+	logged := loggedSorties(flightLog.Samples)
+
+	report := generated.FlightLogReport{
+		Id:          &flightLog.ID,
+		MaxDistance: flightLog.MaxDistance,
+		StartedAt:   &flightLog.StartedAt,
+		EndedAt:     &flightLog.EndedAt,
+	}
+
+	var distance, batteryUsed float64
+	plannedDistance := 0
+	sorties := make([]generated.FlightLogSortie, max(len(logged), len(planned)))
+	for i := range sorties {
+		number := i + 1
+		sortie := generated.FlightLogSortie{Sortie: &number}
+
+		if i < len(planned) {
+			sortie.PlannedStart, sortie.PlannedEnd, sortie.PlannedDistance = planned[i].Start, planned[i].End, planned[i].Distance
+			// a sortie of a plan with charging pads takes off from and lands on a pad
+			if pad := planned[i].StartPad; pad != nil {
+				sortie.PlannedStart = &generated.PlotPosition{X: pad.X, Y: pad.Y}
+			}
+			if pad := planned[i].EndPad; pad != nil {
+				sortie.PlannedEnd = &generated.PlotPosition{X: pad.X, Y: pad.Y}
+			}
+			plannedDistance += *planned[i].Distance
+		}
+
+		if i < len(logged) {
+			l := logged[i]
+			takeoff, landing := l[0], l[len(l)-1]
+			start, end := loggedPlot(estate, takeoff), loggedPlot(estate, landing)
+			flown, used := l.distance(plotSize), l.batteryUsed()
+			distance += flown
+			batteryUsed += used
+
+			sortie.StartedAt, sortie.EndedAt = &takeoff.RecordedAt, &landing.RecordedAt
+			sortie.Start, sortie.End = &start, &end
+			sortie.Distance = roundedDistance(flown, 0, 0)
+			sortie.BatteryUsed = &used
+			if i < len(planned) {
+				deviation := *sortie.Distance - *sortie.PlannedDistance
+				sortie.DistanceDeviation = &deviation
+				sortie.TakeoffDeviation = plotDeviation(takeoff, *sortie.PlannedStart, plotSize)
+				sortie.LandingDeviation = plotDeviation(landing, *sortie.PlannedEnd, plotSize)
+			}
+		}
+
+		sorties[i] = sortie
+	}
+	report.Sorties = &sorties
+
+	report.Distance = roundedDistance(distance, 0, 0)
+	deviation := *report.Distance - plannedDistance
+	report.PlannedDistance, report.DistanceDeviation = &plannedDistance, &deviation
+
+	report.ActualRest = sorties[0].End
+	if plan.Rest != nil {
+		report.Rest = &generated.PlotPosition{X: plan.Rest.X, Y: plan.Rest.Y}
+		first := logged[0]
+		report.RestDeviation = plotDeviation(first[len(first)-1], *report.Rest, plotSize)
+	}
+
+	if batteryUsed > 0 {
+		perPercent := distance / batteryUsed
+		estimate := int(math.Round(perPercent * 100))
+		report.BatteryDistance, report.MaxDistanceEstimate = &perPercent, &estimate
+	}
+
+	return report
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+)
+
+func TestParseFlightLog(t *testing.T) {
+	equator := 0.0
+	geo, _ := newGeoReference(repository.EstateEntity{AnchorLatitude: &equator, AnchorLongitude: &equator, PlotSize: 10})
+
+	tests := []struct {
+		name            string
+		log             string
+		geo             *geoReference
+		expectedSorties []int
+		expectedX       []float64
+		expectedErr     string
+	}{
+		{
+			name:            "Sorties Cut By Battery Swap And Gap",
+			log:             "timestamp,x,y,altitude,battery,speed\n0,1,1,0,50,0\n1,2,1,0,49,3\n2,2,1,0,95,0\n3,3,1,0,94,3\n200,3,1,0,94,0\n",
+			expectedSorties: []int{1, 1, 2, 2, 3},
+			expectedX:       []float64{1, 2, 2, 3, 3},
+		},
+		{
+			name:            "Sortie Column Renumbered",
+			log:             "Timestamp,X,Y,Altitude,Battery,Sortie\n0,1,1,0,50,4\n1,2,1,0,60,4\n2,2,1,0,95,9\n",
+			expectedSorties: []int{1, 1, 2},
+			expectedX:       []float64{1, 2, 2},
+		},
+		{
+			// one kilometer along the equator is 100 plots east of the anchor
+			name:            "Latitude And Longitude On The Plots",
+			log:             "timestamp,lat,lon,alt,battery\n2026-10-01T08:00:00+02:00,0,0,0,50\n2026-10-01T08:00:01+02:00,0,0.008983152841195214,0,49\n",
+			geo:             &geo,
+			expectedSorties: []int{1, 1},
+			expectedX:       []float64{0.5, 100.5},
+		},
+		{
+			name:        "Missing Position",
+			log:         "timestamp,x,altitude,battery\n0,1,0,50\n",
+			expectedErr: "flight log must have either latitude and longitude or x and y columns",
+		},
+		{
+			name:        "Missing Battery",
+			log:         "timestamp,x,y,altitude\n0,1,1,0\n",
+			expectedErr: "flight log has no battery column",
+		},
+		{
+			name:        "Timestamp Going Back",
+			log:         "timestamp,x,y,altitude,battery\n5,1,1,0,50\n4,1,1,0,50\n",
+			expectedErr: "flight log line 3: timestamp is before the one of the previous sample",
+		},
+		{
+			name:        "Invalid Timestamp",
+			log:         "timestamp,x,y,altitude,battery\nyesterday,1,1,0,50\n",
+			expectedErr: `flight log line 2: timestamp "yesterday" is neither RFC 3339 nor unix seconds`,
+		},
+		{
+			name:        "Sortie Going Back",
+			log:         "timestamp,x,y,altitude,battery,sortie\n0,1,1,0,50,2\n1,1,1,0,50,1\n",
+			expectedErr: "flight log line 3: sortie is lower than the one of the previous sample",
+		},
+		{
+			name:        "Header Only",
+			log:         "timestamp,x,y,altitude,battery\n",
+			expectedErr: "flight log has no samples",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			samples, err := parseFlightLog([]byte(tt.log), tt.geo)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, samples, len(tt.expectedSorties))
+			for i, sample := range samples {
+				assert.Equal(t, i+1, sample.Sequence)
+				assert.Equal(t, tt.expectedSorties[i], sample.Sortie)
+				assert.InDelta(t, tt.expectedX[i], sample.X, 1e-6)
+			}
+		})
+	}
+
+}
+
+func TestParseFlightLogTime(t *testing.T) {
+	at, err := parseFlightLogTime("1790000000.25")
+	require.NoError(t, err)
+	assert.Equal(t, time.Unix(1790000000, 250000000).UTC(), at)
+
+	at, err = parseFlightLogTime("2026-10-01T08:00:00+02:00")
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2026, 10, 1, 6, 0, 0, 0, time.UTC), at)
+}
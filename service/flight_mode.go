@@ -0,0 +1,216 @@
+package service
+
+import (
+	"errors"
+	"math"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// flightModes lists the flight modes in the order they are compared.
+var flightModes = []generated.FlightMode{generated.TerrainFollowing, generated.FixedEstateMax, generated.FixedRowMax}
+
+func parseFlightMode(mode *generated.FlightMode) (generated.FlightMode, error) {
+	if mode == nil {
+		return generated.TerrainFollowing, nil
+	}
+	switch *mode {
+	case generated.TerrainFollowing, generated.FixedEstateMax, generated.FixedRowMax:
+		return *mode, nil
+	default:
+		return "", errors.New("flight_mode must be one of terrain_following, fixed_estate_max or fixed_row_max")
+	}
+}
+
+/*
+altitudeRuns returns the runs of constant altitude the drone flies over an estate in a flight mode, plots are the
+trees by order number in the traversal. The fixed modes fly above the tallest tree of the estate or of every
+straight leg of the traversal, a row for row_serpentine, as if it stood on every plot, so the clearance and the
+minimum cruise altitude of the estate still apply.
+*/
+func altitudeRuns(mode generated.FlightMode, trav traversal, plots []repository.PlotEntity, model flightModel) []flightRun {
+	plotCount := trav.plotCount()
+	if model.terrain != nil {
+		return terrainRuns(mode, trav, plots, model)
+	}
+
+	switch mode {
+	case generated.FixedEstateMax:
+		tallest := 0
+		for _, plot := range plots {
+			tallest = max(tallest, plot.TreeHeight)
+		}
+		return []flightRun{{From: 1, To: plotCount, Altitude: model.fixedAltitude(tallest)}}
+	case generated.FixedRowMax:
+		var runs []flightRun
+		i := 0
+		for from := 1; from <= plotCount; {
+			to := trav.legEnd(from)
+			tallest := 0
+			for ; i < len(plots) && plots[i].OrderNumber <= to; i++ {
+				tallest = max(tallest, plots[i].TreeHeight)
+			}
+			runs = append(runs, flightRun{From: from, To: to, Altitude: model.fixedAltitude(tallest)})
+			from = to + 1
+		}
+		return runs
+	default:
+		return flightRuns(plotCount, plots, model)
+	}
+}
+
+// fixedAltitude returns the altitude flown above the given tallest tree, there is no tree when it is 0.
+func (m flightModel) fixedAltitude(tallest int) int {
+	if tallest == 0 {
+		return m.altitude(nil)
+	}
+	return m.altitude(&repository.PlotEntity{TreeHeight: tallest})
+}
+
+// runsDistance returns the distance of the traversal flown in runs, takeoff, landing and detours included.
+func (m flightModel) runsDistance(runs []flightRun) int {
+	if len(runs) == 0 {
+		return 0
+	}
+
+	flown := 0
+	detours := 0.0
+	distance := 0
+	previous := m.launchAltitude(runs[0])
+	for _, run := range runs {
+		if run.Detour != nil {
+			// the detour replaces the plot size from the plot before it
+			detours += run.Detour.Horizontal - m.plotSize
+			distance += abs(run.Detour.Altitude - previous)
+			previous = run.Detour.Altitude
+		}
+		flown += run.To - run.From + 1
+		distance += abs(run.Altitude - previous)
+		previous = run.Altitude
+	}
+	return distance + m.span(flown) + int(math.Round(detours)) + abs(m.launchAltitude(runs[len(runs)-1])-previous)
+}
+
+/*
+restPlot returns the order number of the plot where the drone first lands when it can travel maxDistance, the last
+plot it can cross and still descend to the end altitude. The drone lands on the first plot when it cannot even
+cross it, and on the last plot when the whole traversal fits.
+*/
+func (m flightModel) restPlot(runs []flightRun, maxDistance int) int {
+	rest := runs[0].From
+	traveled := 0
+	previous := m.launchAltitude(runs[0])
+	// the plots crossed before the run and the meters the detours add to them
+	crossed := 0
+	detours := 0.0
+
+	for _, run := range runs {
+		// distance once the plots before the run are crossed and the drone is at the altitude of the run
+		if run.Detour != nil {
+			traveled += abs(run.Detour.Altitude - previous)
+			previous = run.Detour.Altitude
+			detours += run.Detour.Horizontal - m.plotSize
+		}
+		traveled += abs(run.Altitude - previous)
+		previous = run.Altitude
+		budget := maxDistance - traveled - abs(m.launchAltitude(run)-run.Altitude) - int(math.Round(detours))
+		// the horizontal distance once the plot at an order number of the run is crossed, but the detours
+		span := func(order int) int {
+			return m.span(crossed + order - run.From + 1)
+		}
+		if budget < span(run.From) {
+			return rest
+		}
+
+		// the furthest plot of the run whose span fits in the budget
+		order := min(run.To, run.From-1-crossed+int(math.Floor(float64(budget)/m.plotSize)))
+		for order < run.To && span(order+1) <= budget {
+			order++
+		}
+		for span(order) > budget {
+			order--
+		}
+		rest = order
+		if order < run.To {
+			return rest
+		}
+		crossed += run.To - run.From + 1
+	}
+	return rest
+}
+
+/*
+terrainRuns returns the runs of a flight mode over terrain, plots are the trees by order number in the traversal.
+Following the terrain the drone flies at the altitude of the flight model above the ground under every plot, the
+fixed modes fly at the highest of these altitudes over the estate or over every straight leg of the traversal. A leg
+is walked from square to square of the plots sharing their ground, so the runs are built without a look at every plot.
+*/
+func terrainRuns(mode generated.FlightMode, trav traversal, plots []repository.PlotEntity, model flightModel) []flightRun {
+	plotCount := trav.plotCount()
+	var runs []flightRun
+	highest := 0
+	i := 0
+	for from := 1; from <= plotCount; {
+		to := trav.legEnd(from)
+		leg := len(runs)
+		// a leg goes straight, one plot along x or y at a time
+		x, y := trav.plot(from)
+		dx, dy := 0, 0
+		if from < to {
+			nextX, nextY := trav.plot(from + 1)
+			dx, dy = nextX-x, nextY-y
+		}
+		for order := from; order <= to; {
+			ground := model.ground(x, y)
+			last := min(to, order+model.terrain.sampled(x, y, dx, dy))
+			x, y = x+dx*(last-order+1), y+dy*(last-order+1)
+
+			// the trees of the square break its plots
+			for order <= last {
+				end, altitude := last, ground+model.altitude(nil)
+				if i < len(plots) && plots[i].OrderNumber == order {
+					end, altitude = order, ground+model.altitude(&plots[i])
+					i++
+				} else if i < len(plots) && plots[i].OrderNumber <= last {
+					end = plots[i].OrderNumber - 1
+				}
+				if n := len(runs) - 1; n >= leg && runs[n].Altitude == altitude && runs[n].Ground == ground {
+					runs[n].To = end
+				} else {
+					runs = append(runs, flightRun{From: order, To: end, Altitude: altitude, Ground: ground})
+				}
+				order = end + 1
+			}
+		}
+
+		legHighest := 0
+		for _, run := range runs[leg:] {
+			legHighest = max(legHighest, run.Altitude)
+		}
+		if mode == generated.FixedRowMax {
+			for k := leg; k < len(runs); k++ {
+				runs[k].Altitude = legHighest
+			}
+		}
+		highest = max(highest, legHighest)
+		from = to + 1
+	}
+
+	if mode == generated.FixedEstateMax {
+		for k := range runs {
+			runs[k].Altitude = highest
+		}
+	}
+
+	// the runs of the legs are joined where the drone goes on at the same altitude over the same ground
+	joined := runs[:0]
+	for _, run := range runs {
+		if n := len(joined) - 1; n >= 0 && joined[n].Altitude == run.Altitude && joined[n].Ground == run.Ground {
+			joined[n].To = run.To
+			continue
+		}
+		joined = append(joined, run)
+	}
+	return joined
+}
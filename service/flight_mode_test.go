@@ -0,0 +1,35 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFlightModel_RestPlot(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+	// 6 plots, a tree of 4 meters on plot 3
+	runs := []flightRun{
+		{From: 1, To: 2, Altitude: 0},
+		{From: 3, To: 3, Altitude: 5},
+		{From: 4, To: 6, Altitude: 0},
+	}
+
+	tests := []struct {
+		name        string
+		maxDistance int
+		expected    int
+	}{
+		{name: "Cannot Cross The First Plot", maxDistance: 5, expected: 1},
+		{name: "Stops Before The Climb", maxDistance: 39, expected: 2},
+		{name: "Lands On The Tree", maxDistance: 40, expected: 3},
+		{name: "Stops Inside A Run", maxDistance: 59, expected: 4},
+		{name: "Whole Traversal", maxDistance: 1000, expected: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, model.restPlot(runs, tt.maxDistance))
+		})
+	}
+}
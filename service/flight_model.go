@@ -0,0 +1,247 @@
+package service
+
+import (
+	"context"
+	"math"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// defaultClearance is the height in meters the drone keeps above the trees when the estate does not set it.
+const defaultClearance = 1
+
+/*
+flightModel holds the flight settings of an estate every drone distance is computed from. The drone takes off and
+lands at the start/end altitude, crosses every plot in the traversal order over plotSize meters, flies at the
+minimum cruise altitude over empty plots and at the tree height plus the clearance over trees, never lower than
+the minimum cruise altitude. Over terrain every altitude is above the ground under the plot, the altitudes of a
+flight are then above the lowest plot of the estate.
+*/
+type flightModel struct {
+	plotSize          float64
+	clearance         int
+	minCruiseAltitude int
+	startEndAltitude  int
+	terrain           *terrain
+}
+
+func newFlightModel(estate repository.EstateEntity) flightModel {
+	plotSize := estate.PlotSize
+	if plotSize <= 0 {
+		plotSize = defaultPlotSize
+	}
+
+	return flightModel{
+		plotSize:          plotSize,
+		clearance:         estate.Clearance,
+		minCruiseAltitude: estate.MinCruiseAltitude,
+		startEndAltitude:  estate.StartEndAltitude,
+	}
+}
+
+// altitude returns the altitude the drone flies at over a plot, plot is nil for an empty plot.
+func (m flightModel) altitude(plot *repository.PlotEntity) int {
+	if plot == nil {
+		return m.minCruiseAltitude
+	}
+	return max(m.minCruiseAltitude, plot.TreeHeight+m.clearance)
+}
+
+// ground returns the ground under plot (x,y) above the lowest plot of the estate, 0 on flat ground.
+func (m flightModel) ground(x, y int) int {
+	return m.terrain.ground(x, y)
+}
+
+// launchAltitude returns the start/end altitude over the plots of a run, above the ground under them.
+func (m flightModel) launchAltitude(run flightRun) int {
+	return run.Ground + m.startEndAltitude
+}
+
+// neighbourAltitude returns the altitude over the plot at an order number next to a tree, the drone is at the
+// start/end altitude before the first plot and after the last one.
+func (m flightModel) neighbourAltitude(neighbour *repository.PlotEntity, orderNumber int, plotCount int) int {
+	if orderNumber < 1 || orderNumber > plotCount {
+		return m.startEndAltitude
+	}
+	return m.altitude(neighbour)
+}
+
+// span returns the horizontal distance covered once the first plots of the traversal are crossed, rounded to the
+// meter from the start of the traversal so the rounding never adds up.
+func (m flightModel) span(plots int) int {
+	return int(math.Round(float64(plots) * m.plotSize))
+}
+
+/*
+plotDistance returns the distance the drone has traveled once it has crossed the given plot. behind is the nearest
+occupied plot before the given plot in the traversal order, or nil when there is none.
+*/
+func (m flightModel) plotDistance(plot repository.PlotEntity, behind *repository.PlotEntity) int {
+	altitude := m.altitude(&plot)
+	cruise := m.altitude(nil)
+
+	if behind == nil {
+		/*
+			if there is no plot behind, the drone climbs from the start altitude to the cruise altitude over the
+			empty plots before this one, then to the altitude over this tree.
+		*/
+		if plot.OrderNumber == 1 {
+			return abs(altitude-m.startEndAltitude) + m.span(1)
+		}
+		return abs(cruise-m.startEndAltitude) + abs(altitude-cruise) + m.span(plot.OrderNumber)
+	}
+
+	/*
+		if there is other tree, we need to recognise only the previous tree, the nearer one.
+		the rest/other tree before previous tree not counted because we can depend on previous tree's distance,
+		it already covered all distance.
+	*/
+	distanceBetweenPlots := m.span(plot.OrderNumber) - m.span(behind.OrderNumber)
+	behindAltitude := m.altitude(behind)
+	if plot.OrderNumber-behind.OrderNumber == 1 {
+		// the drone goes straight from the altitude over the previous tree to the altitude over this one
+		return behind.Distance + abs(altitude-behindAltitude) + distanceBetweenPlots
+	}
+
+	// the drone goes back to the cruise altitude over the empty plots between the two trees
+	return behind.Distance + abs(cruise-behindAltitude) + abs(altitude-cruise) + distanceBetweenPlots
+}
+
+// totalDistance returns the distance of the whole traversal, takeoff and landing included, plots are the trees by order number.
+func (m flightModel) totalDistance(plotCount int, plots []repository.PlotEntity) int {
+	distance := m.span(plotCount)
+	cruise := m.altitude(nil)
+	previous := m.startEndAltitude
+	last := 0
+
+	for i := range plots {
+		if plots[i].OrderNumber > last+1 {
+			distance += abs(cruise - previous)
+			previous = cruise
+		}
+		altitude := m.altitude(&plots[i])
+		distance += abs(altitude - previous)
+		previous = altitude
+		last = plots[i].OrderNumber
+	}
+	if last < plotCount {
+		distance += abs(cruise - previous)
+		previous = cruise
+	}
+
+	return distance + abs(m.startEndAltitude-previous)
+}
+
+// climb is the vertical distance flown to and from a plot flown at altitude between the altitudes of its neighbours.
+func climb(previous int, altitude int, next int) int {
+	return abs(altitude-previous) + abs(next-altitude)
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
+
+/*
+plainEstate reports whether an estate is a rectangle on flat ground without obstacles that flies over all its trees,
+where the drone goes on from plot to plot and the distances follow from the order numbers alone. Any other estate
+has its distances computed from its runs.
+*/
+func plainEstate(estate repository.EstateEntity) bool {
+	return estate.BlockedPlots == 0 && len(estate.ShapeMask) == 0 && estate.Relief == 0 && !estate.ExcludeDeadTrees
+}
+
+/*
+recomputeFlightDistances recomputes the order number and the distance of every tree and the total distance of an
+estate from its traversal and flight settings.
+*/
+func (s *Service) recomputeFlightDistances(ctx context.Context, estate *repository.EstateEntity) error {
+	plots, err := s.Repository.GetPlots(ctx, estate.ID)
+	if err != nil {
+		return err
+	}
+
+	// a plot holds a single tree, so the trees are known by their plot across the reordering
+	orderNumbers := make(map[[2]uint16]int, len(plots))
+	for _, plot := range plots {
+		orderNumbers[[2]uint16{plot.X, plot.Y}] = plot.OrderNumber
+	}
+	trav := newTraversal(*estate)
+	trav.orderPlots(plots)
+
+	model := newFlightModel(*estate)
+	if !plainEstate(*estate) {
+		return s.recomputeObstacleDistances(ctx, estate, trav, plots, orderNumbers)
+	}
+
+	var behind *repository.PlotEntity
+	for i := range plots {
+		distance := model.plotDistance(plots[i], behind)
+		if distance != plots[i].Distance || plots[i].OrderNumber != orderNumbers[[2]uint16{plots[i].X, plots[i].Y}] {
+			plots[i].Distance = distance
+			if _, err = s.Repository.SavePlot(ctx, plots[i]); err != nil {
+				return err
+			}
+		}
+		behind = &plots[i]
+	}
+
+	estate.TotalDistance = model.totalDistance(estate.Width*estate.Length, plots)
+	return nil
+}
+
+/*
+recomputeObstacleDistances recomputes the distances of the trees of an estate that is not plain, plots are its trees
+by order number and orderNumbers their order number before the recomputation. The drone follows the terrain, climbs
+over the height obstacles and detours around the no-fly zones, which fails when a plot cannot be reached.
+*/
+func (s *Service) recomputeObstacleDistances(ctx context.Context, estate *repository.EstateEntity, trav traversal, plots []repository.PlotEntity, orderNumbers map[[2]uint16]int) error {
+	runs, _, err := s.obstacleRuns(ctx, *estate, trav, generated.TerrainFollowing, plots)
+	if err != nil {
+		return err
+	}
+
+	model := newFlightModel(*estate)
+	distances := model.runDistances(runs, plots)
+	for i := range plots {
+		if distances[i] != plots[i].Distance || plots[i].OrderNumber != orderNumbers[[2]uint16{plots[i].X, plots[i].Y}] {
+			plots[i].Distance = distances[i]
+			if _, err = s.Repository.SavePlot(ctx, plots[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	estate.TotalDistance = model.runsDistance(runs)
+	return nil
+}
+
+// runDistances returns the distance the drone has traveled once it has crossed every plot, plots are flown plots by order number.
+func (m flightModel) runDistances(runs []flightRun, plots []repository.PlotEntity) []int {
+	distances := make([]int, len(plots))
+	if len(runs) == 0 {
+		return distances
+	}
+	vertical := 0
+	previous := m.launchAltitude(runs[0])
+	crossed := 0
+	detours := 0.0
+	i := 0
+	for _, run := range runs {
+		if run.Detour != nil {
+			detours += run.Detour.Horizontal - m.plotSize
+			vertical += abs(run.Detour.Altitude - previous)
+			previous = run.Detour.Altitude
+		}
+		vertical += abs(run.Altitude - previous)
+		previous = run.Altitude
+		for ; i < len(plots) && plots[i].OrderNumber <= run.To; i++ {
+			distances[i] = vertical + m.span(crossed+plots[i].OrderNumber-run.From+1) + int(math.Round(detours))
+		}
+		crossed += run.To - run.From + 1
+	}
+	return distances
+}
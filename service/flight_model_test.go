@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spgo/repository"
+)
+
+func TestFlightModel(t *testing.T) {
+	// a row of 5 plots with trees of 10, 20 and 10 meters on plots 2, 3 and 4
+	plots := []repository.PlotEntity{
+		{OrderNumber: 2, TreeHeight: 10},
+		{OrderNumber: 3, TreeHeight: 20},
+		{OrderNumber: 4, TreeHeight: 10},
+	}
+
+	tests := []struct {
+		name              string
+		model             flightModel
+		expectedDistances []int
+		expectedTotal     int
+	}{
+		{
+			name:              "Default Settings",
+			model:             newFlightModel(repository.EstateEntity{Clearance: defaultClearance}),
+			expectedDistances: []int{31, 51, 71},
+			expectedTotal:     92,
+		},
+		{
+			name:              "Cruise Above The Small Trees",
+			model:             flightModel{plotSize: 10, clearance: 2, minCruiseAltitude: 15, startEndAltitude: 3},
+			expectedDistances: []int{32, 49, 66},
+			expectedTotal:     88,
+		},
+		{
+			name:              "Fractional Plot Size",
+			model:             flightModel{plotSize: 12.5, clearance: 1},
+			expectedDistances: []int{36, 59, 81},
+			expectedTotal:     105,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var behind *repository.PlotEntity
+			distances := make([]int, len(plots))
+			for i := range plots {
+				plot := plots[i]
+				plot.Distance = tt.model.plotDistance(plot, behind)
+				distances[i] = plot.Distance
+				behind = &plot
+			}
+
+			assert.Equal(t, tt.expectedDistances, distances)
+			assert.Equal(t, tt.expectedTotal, tt.model.totalDistance(5, plots))
+		})
+	}
+}
+
+func TestFlightModel_IncrementalTotalDistance(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 5, startEndAltitude: 2}
+	heights := map[int]int{1: 3, 2: 10, 3: 20, 5: 10}
+
+	// planting the trees one by one in any order ends with the distance of the whole traversal
+	for _, order := range [][]int{{1, 2, 3, 5}, {5, 3, 2, 1}, {3, 1, 5, 2}} {
+		planted := map[int]*repository.PlotEntity{}
+		total := model.totalDistance(5, nil)
+		for _, orderNumber := range order {
+			plot := &repository.PlotEntity{OrderNumber: orderNumber, TreeHeight: heights[orderNumber]}
+			previous := model.neighbourAltitude(planted[orderNumber-1], orderNumber-1, 5)
+			next := model.neighbourAltitude(planted[orderNumber+1], orderNumber+1, 5)
+			total += climb(previous, model.altitude(plot), next) - climb(previous, model.altitude(nil), next)
+			planted[orderNumber] = plot
+		}
+
+		plots := []repository.PlotEntity{*planted[1], *planted[2], *planted[3], *planted[5]}
+		assert.Equal(t, model.totalDistance(5, plots), total, "planting order %v", order)
+	}
+}
@@ -0,0 +1,184 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/repository"
+)
+
+// WGS84 ellipsoid parameters.
+const (
+	wgs84SemiMajorAxis = 6378137.0
+	wgs84Flattening    = 1 / 298.257223563
+	wgs84Eccentricity2 = wgs84Flattening * (2 - wgs84Flattening)
+)
+
+const defaultPlotSize = 10.0
+
+// GeoJSON object types.
+const (
+	geoJSONFeature           = "Feature"
+	geoJSONFeatureCollection = "FeatureCollection"
+	geoJSONPoint             = "Point"
+	geoJSONLineString        = "LineString"
+	geoJSONPolygon           = "Polygon"
+)
+
+/*
+geoReference places the plot grid of an estate on the WGS84 ellipsoid. The grid lies on the plane tangent to the
+ellipsoid at the anchor, the outer corner of plot (1,1), which keeps the plots square on the ground whatever the
+size of the estate. Grid positions are counted in plots from the anchor, plot (x,y) covers [x-1,x] x [y-1,y] and
+the y axis points to the bearing of the estate.
+*/
+type geoReference struct {
+	plotSize   float64
+	sinBearing float64
+	cosBearing float64
+	// anchor and the east, north and up unit vectors of the tangent plane, in earth-centered earth-fixed meters
+	anchor [3]float64
+	east   [3]float64
+	north  [3]float64
+	up     [3]float64
+}
+
+func newGeoReference(estate repository.EstateEntity) (geoReference, bool) {
+	if estate.AnchorLatitude == nil || estate.AnchorLongitude == nil {
+		return geoReference{}, false
+	}
+
+	sinLat, cosLat := math.Sincos(*estate.AnchorLatitude * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(*estate.AnchorLongitude * math.Pi / 180)
+	sinBearing, cosBearing := math.Sincos(estate.Bearing * math.Pi / 180)
+
+	plotSize := estate.PlotSize
+	if plotSize <= 0 {
+		plotSize = defaultPlotSize
+	}
+
+	return geoReference{
+		plotSize:   plotSize,
+		sinBearing: sinBearing,
+		cosBearing: cosBearing,
+		anchor:     geodeticToECEF(*estate.AnchorLatitude, *estate.AnchorLongitude),
+		east:       [3]float64{-sinLon, cosLon, 0},
+		north:      [3]float64{-sinLat * cosLon, -sinLat * sinLon, cosLat},
+		up:         surfaceNormal(*estate.AnchorLatitude, *estate.AnchorLongitude),
+	}, true
+}
+
+// toLatLon converts a grid position to WGS84 latitude and longitude in degrees.
+func (g geoReference) toLatLon(px, py float64) (float64, float64) {
+	lx := px * g.plotSize
+	ly := py * g.plotSize
+	east := lx*g.cosBearing + ly*g.sinBearing
+	north := ly*g.cosBearing - lx*g.sinBearing
+
+	var p [3]float64
+	for i := range p {
+		p[i] = g.anchor[i] + east*g.east[i] + north*g.north[i]
+	}
+	return ecefToGeodetic(p)
+}
+
+/*
+toGrid converts a WGS84 latitude and longitude in degrees to a grid position. It is the exact inverse of toLatLon,
+the position is where the normal to the ellipsoid at the given point crosses the tangent plane of the grid.
+*/
+func (g geoReference) toGrid(lat, lon float64) (float64, float64) {
+	p := geodeticToECEF(lat, lon)
+	normal := surfaceNormal(lat, lon)
+
+	var height, slope float64
+	for i := range p {
+		height += (p[i] - g.anchor[i]) * g.up[i]
+		slope += normal[i] * g.up[i]
+	}
+
+	var east, north float64
+	for i := range p {
+		d := p[i] - normal[i]*height/slope - g.anchor[i]
+		east += d * g.east[i]
+		north += d * g.north[i]
+	}
+
+	lx := east*g.cosBearing - north*g.sinBearing
+	ly := east*g.sinBearing + north*g.cosBearing
+	return lx / g.plotSize, ly / g.plotSize
+}
+
+// plotCenter returns the latitude and longitude of the center of plot (x,y).
+func (g geoReference) plotCenter(x, y int) (float64, float64) {
+	return g.toLatLon(float64(x)-0.5, float64(y)-0.5)
+}
+
+// position returns a grid position as a GeoJSON position, longitude first.
+func (g geoReference) position(px, py float64) []float64 {
+	lat, lon := g.toLatLon(px, py)
+	return []float64{lon, lat}
+}
+
+// boundaryRing returns the corners of an estate as a closed ring of GeoJSON positions, counterclockwise from the anchor.
+func (g geoReference) boundaryRing(length, width int) [][]float64 {
+	return [][]float64{
+		g.position(0, 0),
+		g.position(float64(length), 0),
+		g.position(float64(length), float64(width)),
+		g.position(0, float64(width)),
+		g.position(0, 0),
+	}
+}
+
+func geodeticToECEF(lat, lon float64) [3]float64 {
+	sinLat, cosLat := math.Sincos(lat * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(lon * math.Pi / 180)
+	n := wgs84SemiMajorAxis / math.Sqrt(1-wgs84Eccentricity2*sinLat*sinLat)
+
+	return [3]float64{
+		n * cosLat * cosLon,
+		n * cosLat * sinLon,
+		n * (1 - wgs84Eccentricity2) * sinLat,
+	}
+}
+
+func surfaceNormal(lat, lon float64) [3]float64 {
+	sinLat, cosLat := math.Sincos(lat * math.Pi / 180)
+	sinLon, cosLon := math.Sincos(lon * math.Pi / 180)
+	return [3]float64{cosLat * cosLon, cosLat * sinLon, sinLat}
+}
+
+// ecefToGeodetic returns the latitude and longitude in degrees of an earth-centered earth-fixed point,
+// the latitude converges to well under a millimeter in a few iterations for points near the surface.
+func ecefToGeodetic(p [3]float64) (float64, float64) {
+	r := math.Hypot(p[0], p[1])
+	lat := math.Atan2(p[2], r*(1-wgs84Eccentricity2))
+	for i := 0; i < 5; i++ {
+		sinLat := math.Sin(lat)
+		n := wgs84SemiMajorAxis / math.Sqrt(1-wgs84Eccentricity2*sinLat*sinLat)
+		lat = math.Atan2(p[2]+wgs84Eccentricity2*n*sinLat, r)
+	}
+
+	return lat * 180 / math.Pi, math.Atan2(p[1], p[0]) * 180 / math.Pi
+}
+
+func (s *Service) loadGeoReference(ctx context.Context, estateId uuid.UUID) (repository.EstateEntity, geoReference, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return repository.EstateEntity{}, geoReference{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return repository.EstateEntity{}, geoReference{}, http.StatusInternalServerError, err
+	}
+
+	geo, ok := newGeoReference(estate)
+	if !ok {
+		return repository.EstateEntity{}, geoReference{}, http.StatusConflict, errors.New("estate is not geo-referenced")
+	}
+
+	return estate, geo, http.StatusOK, nil
+}
@@ -0,0 +1,81 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+)
+
+func TestGeoReference(t *testing.T) {
+	equator := 0.0
+	primeMeridian := 0.0
+	// one kilometer along the equator
+	lonPerKm := 0.008983152841195214
+
+	tests := []struct {
+		name        string
+		estate      repository.EstateEntity
+		px, py      float64
+		expectedLat float64
+		expectedLon float64
+	}{
+		{
+			name:        "X Points East",
+			estate:      repository.EstateEntity{AnchorLatitude: &equator, AnchorLongitude: &primeMeridian, PlotSize: 10},
+			px:          100,
+			expectedLat: 0,
+			expectedLon: lonPerKm,
+		},
+		{
+			name:        "Y Points East When Bearing Is 90",
+			estate:      repository.EstateEntity{AnchorLatitude: &equator, AnchorLongitude: &primeMeridian, Bearing: 90, PlotSize: 10},
+			py:          100,
+			expectedLat: 0,
+			expectedLon: lonPerKm,
+		},
+		{
+			name:        "Default Plot Size",
+			estate:      repository.EstateEntity{AnchorLatitude: &equator, AnchorLongitude: &primeMeridian},
+			px:          100,
+			expectedLat: 0,
+			expectedLon: lonPerKm,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			geo, ok := newGeoReference(tt.estate)
+			require.True(t, ok)
+
+			lat, lon := geo.toLatLon(tt.px, tt.py)
+			assert.InDelta(t, tt.expectedLat, lat, 1e-9)
+			assert.InDelta(t, tt.expectedLon, lon, 1e-9)
+		})
+	}
+}
+
+func TestGeoReference_RoundTrip(t *testing.T) {
+	latitude := -6.2
+	longitude := 106.8
+	geo, ok := newGeoReference(repository.EstateEntity{AnchorLatitude: &latitude, AnchorLongitude: &longitude, Bearing: 15, PlotSize: 10})
+	require.True(t, ok)
+
+	lat, lon := geo.toLatLon(0, 0)
+	assert.InDelta(t, latitude, lat, 1e-9)
+	assert.InDelta(t, longitude, lon, 1e-9)
+
+	for _, p := range [][2]float64{{0.5, 0.5}, {37.3, 12.9}, {5000, 2500}} {
+		lat, lon := geo.toLatLon(p[0], p[1])
+		px, py := geo.toGrid(lat, lon)
+		assert.InDelta(t, p[0], px, 1e-4)
+		assert.InDelta(t, p[1], py, 1e-4)
+	}
+}
+
+func TestNewGeoReference_NotGeoReferenced(t *testing.T) {
+	_, ok := newGeoReference(repository.EstateEntity{})
+	assert.False(t, ok)
+}
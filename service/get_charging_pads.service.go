@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetChargingPads(ctx context.Context, estateId uuid.UUID) (generated.ChargingPadList, int, error) {
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.ChargingPadList{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.ChargingPadList{}, http.StatusInternalServerError, err
+	}
+
+	entities, err := s.Repository.GetChargingPads(ctx, estateId)
+	if err != nil {
+		return generated.ChargingPadList{}, http.StatusInternalServerError, err
+	}
+
+	network := padNetwork{pads: entities}
+	pads := make([]generated.ChargingPad, len(entities))
+	for i := range entities {
+		pads[i] = network.padResponse(i)
+	}
+	return generated.ChargingPadList{Pads: &pads}, http.StatusOK, nil
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetChargingPads(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+	one, two, four := 1, 2, 4
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.ChargingPadList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Get",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return([]repository.ChargingPadEntity{
+					{ID: firstID, EstateId: mockEstateID, X: 1, Y: 1},
+					{ID: secondID, EstateId: mockEstateID, X: 4, Y: 2},
+				}, nil)
+			},
+			expectedResp: generated.ChargingPadList{Pads: &[]generated.ChargingPad{
+				{Id: &firstID, X: &one, Y: &one},
+				{Id: &secondID, X: &four, Y: &two},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No Pad",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp:   generated.ChargingPadList{Pads: &[]generated.ChargingPad{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetChargingPads(mockContext, mockEstateID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
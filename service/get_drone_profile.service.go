@@ -0,0 +1,34 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetDroneProfile(ctx context.Context, id uuid.UUID) (generated.DroneProfile, int, error) {
+	profile, err := s.Repository.GetDroneProfile(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.DroneProfile{}, http.StatusNotFound, errors.New("drone profile not found")
+		}
+		return generated.DroneProfile{}, http.StatusInternalServerError, err
+	}
+
+	return generated.DroneProfile{
+		Id:              &profile.ID,
+		Name:            &profile.Name,
+		HorizontalSpeed: &profile.HorizontalSpeed,
+		ClimbSpeed:      &profile.ClimbSpeed,
+		DescentSpeed:    &profile.DescentSpeed,
+		HorizontalPower: &profile.HorizontalPower,
+		ClimbPower:      &profile.ClimbPower,
+		DescentPower:    &profile.DescentPower,
+		BatteryCapacity: &profile.BatteryCapacity,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,102 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetDroneProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockUUID := uuid.New()
+	mockProfile := repository.DroneProfileEntity{
+		ID:              mockUUID,
+		Name:            "quadcopter",
+		HorizontalSpeed: 10,
+		ClimbSpeed:      2,
+		DescentSpeed:    5,
+		HorizontalPower: 100,
+		ClimbPower:      360,
+		DescentPower:    36,
+		BatteryCapacity: 50,
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.DroneProfile
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Get",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetDroneProfile(gomock.Any(), mockUUID).Return(mockProfile, nil)
+			},
+			expectedResp: generated.DroneProfile{
+				Id:              &mockProfile.ID,
+				Name:            &mockProfile.Name,
+				HorizontalSpeed: &mockProfile.HorizontalSpeed,
+				ClimbSpeed:      &mockProfile.ClimbSpeed,
+				DescentSpeed:    &mockProfile.DescentSpeed,
+				HorizontalPower: &mockProfile.HorizontalPower,
+				ClimbPower:      &mockProfile.ClimbPower,
+				DescentPower:    &mockProfile.DescentPower,
+				BatteryCapacity: &mockProfile.BatteryCapacity,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Profile Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetDroneProfile(gomock.Any(), mockUUID).Return(repository.DroneProfileEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.DroneProfile{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("drone profile not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetDroneProfile(gomock.Any(), mockUUID).Return(repository.DroneProfileEntity{}, errors.New("repository error"))
+			},
+			expectedResp:   generated.DroneProfile{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetDroneProfile(mockContext, mockUUID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
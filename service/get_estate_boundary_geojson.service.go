@@ -0,0 +1,35 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateBoundaryGeoJson(ctx context.Context, estateId uuid.UUID) (generated.EstateBoundaryFeature, int, error) {
+	estate, geo, httpStatus, err := s.loadGeoReference(ctx, estateId)
+	if err != nil {
+		return generated.EstateBoundaryFeature{}, httpStatus, err
+	}
+
+	featureType := geoJSONFeature
+	geometryType := geoJSONPolygon
+	coordinates := [][][]float64{geo.boundaryRing(estate.Length, estate.Width)}
+	return generated.EstateBoundaryFeature{
+		Type: &featureType,
+		Geometry: &generated.GeoJsonPolygon{
+			Type:        &geometryType,
+			Coordinates: &coordinates,
+		},
+		Properties: &generated.EstateBoundaryProperties{
+			Id:       &estate.ID,
+			Width:    &estate.Width,
+			Length:   &estate.Length,
+			Bearing:  &estate.Bearing,
+			PlotSize: &estate.PlotSize,
+		},
+	}, http.StatusOK, nil
+}
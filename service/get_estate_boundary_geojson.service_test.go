@@ -0,0 +1,77 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateBoundaryGeoJson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	lonPerPlot := 0.00008983152841195214
+	latPerPlot := 0.00009043694770492493
+
+	t.Run("Counterclockwise Ring", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
+			ID:              mockEstateID,
+			Length:          5,
+			Width:           3,
+			AnchorLatitude:  &anchor,
+			AnchorLongitude: &anchor,
+			PlotSize:        10,
+		}, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		resp, status, err := svc.GetEstateBoundaryGeoJson(mockContext, mockEstateID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Feature", *resp.Type)
+		assert.Equal(t, "Polygon", *resp.Geometry.Type)
+		assert.Equal(t, 5, *resp.Properties.Length)
+		assert.Equal(t, 3, *resp.Properties.Width)
+
+		expected := [][]float64{{0, 0}, {5 * lonPerPlot, 0}, {5 * lonPerPlot, 3 * latPerPlot}, {0, 3 * latPerPlot}, {0, 0}}
+		rings := *resp.Geometry.Coordinates
+		require.Len(t, rings, 1)
+		require.Len(t, rings[0], len(expected))
+		for i, position := range rings[0] {
+			assert.InDelta(t, expected[i][0], position[0], 1e-9)
+			assert.InDelta(t, expected[i][1], position[1], 1e-9)
+		}
+	})
+
+	t.Run("Not Geo-Referenced", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateBoundaryGeoJson(mockContext, mockEstateID)
+		assert.Equal(t, http.StatusConflict, status)
+		assert.EqualError(t, err, "estate is not geo-referenced")
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, errors.New("repository error"))
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateBoundaryGeoJson(mockContext, mockEstateID)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.EqualError(t, err, "repository error")
+	})
+}
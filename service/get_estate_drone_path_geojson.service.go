@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateDronePathGeoJson(ctx context.Context, estateId uuid.UUID) (generated.DronePathFeature, int, error) {
+	estate, geo, httpStatus, err := s.loadGeoReference(ctx, estateId)
+	if err != nil {
+		return generated.DronePathFeature{}, httpStatus, err
+	}
+
+	trav := newTraversal(estate)
+	var coordinates [][]float64
+	if !plainEstate(estate) {
+		// the track takes detours, it is drawn from the waypoints of the whole traversal
+		plots, err := s.Repository.GetPlots(ctx, estateId)
+		if err != nil {
+			return generated.DronePathFeature{}, http.StatusInternalServerError, err
+		}
+		runs, httpStatus, err := s.obstacleRuns(ctx, estate, trav, generated.TerrainFollowing, plots)
+		if err != nil {
+			return generated.DronePathFeature{}, httpStatus, err
+		}
+		for _, waypoint := range sortieWaypoints(runs, missionSortie{From: runs[0].From, To: runs[len(runs)-1].To}, trav, geo) {
+			coordinates = append(coordinates, []float64{waypoint.Longitude, waypoint.Latitude})
+		}
+	} else {
+		/*
+			the track is straight over every leg of the traversal, so the centers of the first and the last plot of
+			every leg are enough to draw it.
+		*/
+		coordinates = make([][]float64, 0, estate.Width*2)
+		add := func(order int) {
+			lat, lon := geo.plotCenter(trav.plot(order))
+			coordinates = append(coordinates, []float64{lon, lat})
+		}
+		for from := 1; from <= trav.plotCount(); {
+			to := trav.legEnd(from)
+			add(from)
+			if to != from {
+				add(to)
+			}
+			from = to + 1
+		}
+	}
+
+	featureType := geoJSONFeature
+	geometryType := geoJSONLineString
+	return generated.DronePathFeature{
+		Type: &featureType,
+		Geometry: &generated.GeoJsonLineString{
+			Type:        &geometryType,
+			Coordinates: &coordinates,
+		},
+		Properties: &generated.DronePathProperties{
+			Distance: &estate.TotalDistance,
+		},
+	}, http.StatusOK, nil
+}
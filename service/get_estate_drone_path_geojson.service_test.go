@@ -0,0 +1,67 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateDronePathGeoJson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	lonPerPlot := 0.00008983152841195214
+	latPerPlot := 0.00009043694770492493
+
+	t.Run("Serpentine Track", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{
+			ID:              mockEstateID,
+			Length:          5,
+			Width:           3,
+			TotalDistance:   150,
+			AnchorLatitude:  &anchor,
+			AnchorLongitude: &anchor,
+			PlotSize:        10,
+		}, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		resp, status, err := svc.GetEstateDronePathGeoJson(mockContext, mockEstateID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "Feature", *resp.Type)
+		assert.Equal(t, "LineString", *resp.Geometry.Type)
+		assert.Equal(t, 150, *resp.Properties.Distance)
+
+		// plot centers of (1,1) (5,1) (5,2) (1,2) (1,3) (5,3)
+		expected := [][2]float64{{0.5, 0.5}, {4.5, 0.5}, {4.5, 1.5}, {0.5, 1.5}, {0.5, 2.5}, {4.5, 2.5}}
+		coordinates := *resp.Geometry.Coordinates
+		require.Len(t, coordinates, len(expected))
+		for i, position := range coordinates {
+			assert.InDelta(t, expected[i][0]*lonPerPlot, position[0], 1e-9)
+			assert.InDelta(t, expected[i][1]*latPerPlot, position[1], 1e-9)
+		}
+	})
+
+	t.Run("Estate Not Found", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateDronePathGeoJson(mockContext, mockEstateID)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.EqualError(t, err, "estate not found")
+	})
+}
@@ -3,70 +3,235 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"math"
+	"net/http"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 
 	"spgo/generated"
+	"spgo/repository"
 )
 
-func (s *Service) GetEstateDronePlan(ctx context.Context, estateId uuid.UUID, maxDistance *int) (generated.DronePlanResponse, error) {
+func (s *Service) GetEstateDronePlan(ctx context.Context, estateId uuid.UUID, params generated.GetEstateIdDronePlanParams) (generated.DronePlanResponse, int, error) {
 	resp := generated.DronePlanResponse{}
+	mode, err := parseFlightMode(params.FlightMode)
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusBadRequest, err
+	}
+	if params.MaxDistance != nil && *params.MaxDistance < 1 {
+		return generated.DronePlanResponse{}, http.StatusBadRequest, errors.New("max_distance must be at least 1")
+	}
+	if params.MaxDistance != nil && params.ProfileId != nil {
+		return generated.DronePlanResponse{}, http.StatusBadRequest, errors.New("max_distance cannot be given with profile_id, the battery of the profile cuts the sorties")
+	}
+
 	estate, err := s.Repository.GetEstate(ctx, estateId)
 	if err != nil {
 		if errors.Is(err, gorm.ErrRecordNotFound) {
-			return generated.DronePlanResponse{}, errors.New("estate not found")
+			return generated.DronePlanResponse{}, http.StatusNotFound, errors.New("estate not found")
 		}
-		return generated.DronePlanResponse{}, err
+		return generated.DronePlanResponse{}, http.StatusInternalServerError, err
+	}
+
+	trav, err := newTraversal(estate).withOverride(params.TraversalPattern, params.StartCorner)
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusBadRequest, err
+	}
+
+	var profile *droneProfile
+	if params.ProfileId != nil {
+		entity, err := s.Repository.GetDroneProfile(ctx, *params.ProfileId)
+		if err != nil {
+			if errors.Is(err, gorm.ErrRecordNotFound) {
+				return generated.DronePlanResponse{}, http.StatusNotFound, errors.New("drone profile not found")
+			}
+			return generated.DronePlanResponse{}, http.StatusInternalServerError, err
+		}
+		loaded := newDroneProfile(entity)
+		profile = &loaded
+	}
+
+	pads, err := s.Repository.GetChargingPads(ctx, estateId)
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusInternalServerError, err
+	}
+
+	resp.FlightMode = &mode
+	/*
+		the distances kept on the estate are the ones of terrain following over its own traversal without pads, the
+		rest is only found from them when the traversal goes on from plot to plot without obstacles or gaps
+	*/
+	if mode != generated.TerrainFollowing || params.TraversalPattern != nil || params.StartCorner != nil || profile != nil || len(pads) > 0 || !plainEstate(estate) {
+		return s.computedDronePlan(ctx, estate, trav, mode, params.MaxDistance, profile, pads)
 	}
 
 	resp.Distance = &estate.TotalDistance
 
-	if maxDistance != nil {
-		var x, y uint16
-		var plotDistance int
-		*maxDistance--
-		plot, err := s.Repository.GetPlotByDistance(ctx, estateId, *maxDistance)
+	if params.MaxDistance != nil {
+		maxDistance := *params.MaxDistance - 1
+		var orderNumber, plotDistance int
+		plot, err := s.Repository.GetPlotByDistance(ctx, estateId, maxDistance)
 		if err != nil {
 			if errors.Is(err, gorm.ErrRecordNotFound) {
 				// If plot not found, set plot distance to 0 (start from the beginning)
 				plotDistance = 0
-				x = 1 // Start x from 1
-				y = 1 // Start y from 1
+				orderNumber = 1 // Start from the first plot
 			} else {
-				return generated.DronePlanResponse{}, err
+				return generated.DronePlanResponse{}, http.StatusInternalServerError, err
 			}
 		} else {
 			plotDistance = plot.Distance
-			x = plot.X
-			y = plot.Y
+			orderNumber = plot.OrderNumber
 		}
 
-		remainBattery := *maxDistance - plotDistance
-		remainPlot := remainBattery / 10
+		remainBattery := maxDistance - plotDistance
+		remainPlot := int(float64(remainBattery) / newFlightModel(estate).plotSize)
+		x, y := trav.plot(min(orderNumber+remainPlot, trav.plotCount()))
 
-		if int(x)+remainPlot <= estate.Length {
-			x = uint16(int(x) + remainPlot)
-		} else {
-			remainPlot -= (estate.Length - int(x))
-			y++
-			for remainPlot > estate.Length {
-				remainPlot -= estate.Length
-				y++
-			}
-			x = uint16(remainPlot)
+		resp.Rest = &struct {
+			X *int `json:"x,omitempty"`
+			Y *int `json:"y,omitempty"`
+		}{
+			X: &x,
+			Y: &y,
 		}
+	}
+
+	return resp, http.StatusOK, nil
+}
+
+/*
+computedDronePlan computes the drone plan of a flight mode and a traversal from the trees of the estate. With a drone
+profile the plan is cut in sorties by its battery capacity, each with its flight time and energy. With charging pads
+the sorties fly from pad to pad. The drone climbs over the height obstacles and detours around the no-fly zones.
+*/
+func (s *Service) computedDronePlan(ctx context.Context, estate repository.EstateEntity, trav traversal, mode generated.FlightMode, maxDistance *int, profile *droneProfile, pads []repository.ChargingPadEntity) (generated.DronePlanResponse, int, error) {
+	plots, err := s.Repository.GetPlots(ctx, estate.ID)
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusInternalServerError, err
+	}
+	trav.orderPlots(plots)
+
+	plots, grid, err := s.withObstacles(ctx, estate, trav, plots, newFlightModel(estate))
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusInternalServerError, err
+	}
+	model := grid.model
+	runs, err := grid.cutRuns(altitudeRuns(mode, trav, plots, model), trav)
+	if err != nil {
+		return generated.DronePlanResponse{}, http.StatusBadRequest, err
+	}
+	distance := model.runsDistance(runs)
+	resp := generated.DronePlanResponse{FlightMode: &mode, Distance: &distance}
 
-		xInt := int(x)
-		yInt := int(y)
+	if len(pads) > 0 {
+		return padDronePlan(resp, newPadNetwork(pads, plots, trav, model, distanceMeter, grid), runs, maxDistance, profile)
+	}
+
+	if maxDistance != nil {
+		x, y := trav.plot(model.restPlot(runs, *maxDistance))
+		resp.Rest = &struct {
+			X *int `json:"x,omitempty"`
+			Y *int `json:"y,omitempty"`
+		}{
+			X: &x,
+			Y: &y,
+		}
+	}
+
+	if profile != nil {
+		sorties, err := profile.splitSorties(runs, trav.plotCount(), model)
+		if err != nil {
+			return generated.DronePlanResponse{}, http.StatusBadRequest, err
+		}
+		resp.Sorties, resp.FlightTime, resp.Energy = profileSorties(sorties, runs, trav, model, *profile)
+
+		x, y := trav.plot(sorties[0].To)
+		resp.Rest = &struct {
+			X *int `json:"x,omitempty"`
+			Y *int `json:"y,omitempty"`
+		}{
+			X: &x,
+			Y: &y,
+		}
+	}
+
+	return resp, http.StatusOK, nil
+}
+
+/*
+padDronePlan cuts the plan in sorties flown from pad to pad, by max_distance or the battery capacity of the drone
+profile, the drone rests on the pad the first sortie lands on.
+*/
+func padDronePlan(resp generated.DronePlanResponse, network padNetwork, runs []flightRun, maxDistance *int, profile *droneProfile) (generated.DronePlanResponse, int, error) {
+	limit := math.Inf(1)
+	if maxDistance != nil {
+		limit = float64(*maxDistance)
+	}
+	if profile != nil {
+		network.meter, limit = profile.energy, profile.batteryCapacity
+	}
+
+	sorties, err := network.splitSorties(runs, limit)
+	var outOfReach plotOutOfReachError
+	switch {
+	case errors.As(err, &outOfReach) && profile != nil:
+		return generated.DronePlanResponse{}, http.StatusBadRequest, fmt.Errorf("battery_capacity of the drone profile is too small to fly over plot %d of the traversal from a charging pad and back", outOfReach.order)
+	case errors.As(err, &outOfReach):
+		return generated.DronePlanResponse{}, http.StatusBadRequest, fmt.Errorf("max_distance is too short to fly over plot %d of the traversal from a charging pad and back", outOfReach.order)
+	case errors.Is(err, errTooManySorties) && profile != nil:
+		return generated.DronePlanResponse{}, http.StatusBadRequest, fmt.Errorf("battery_capacity of the drone profile is too small, the plan needs more than %d sorties", maxMissionSorties)
+	case errors.Is(err, errTooManySorties):
+		return generated.DronePlanResponse{}, http.StatusBadRequest, fmt.Errorf("max_distance is too short, the plan needs more than %d sorties", maxMissionSorties)
+	case err != nil:
+		return generated.DronePlanResponse{}, http.StatusBadRequest, err
+	}
+
+	planSorties, flightTime, energy, distance := network.padSorties(sorties, runs, profile)
+	resp.Sorties, resp.FlightTime, resp.Energy, resp.Distance = &planSorties, flightTime, energy, &distance
+
+	if maxDistance != nil || profile != nil {
+		rest := network.padResponse(sorties[0].EndPad)
 		resp.Rest = &struct {
 			X *int `json:"x,omitempty"`
 			Y *int `json:"y,omitempty"`
 		}{
-			X: &xInt,
-			Y: &yInt,
+			X: rest.X,
+			Y: rest.Y,
 		}
 	}
 
-	return resp, nil
+	return resp, http.StatusOK, nil
+}
+
+// profileSorties returns the sorties of a plan with their flight time and energy, and the totals of the plan.
+func profileSorties(sorties []missionSortie, runs []flightRun, trav traversal, model flightModel, profile droneProfile) (*[]generated.DronePlanSortie, *float64, *float64) {
+	var flightTime, energy float64
+	planSorties := make([]generated.DronePlanSortie, len(sorties))
+	for i, sortie := range sorties {
+		horizontal, climb, descent := sortieLegs(runs, sortie, model)
+		planSorties[i] = sortieResponse(trav, sortie, horizontal, climb, descent, &profile)
+		flightTime += *planSorties[i].FlightTime
+		energy += *planSorties[i].Energy
+	}
+	return &planSorties, &flightTime, &energy
+}
+
+// sortieResponse returns a sortie of a plan flying the given meters, with its flight time and energy when there is a drone profile.
+func sortieResponse(trav traversal, sortie missionSortie, horizontal, climb, descent float64, profile *droneProfile) generated.DronePlanSortie {
+	startX, startY := trav.plot(sortie.From)
+	endX, endY := trav.plot(sortie.To)
+	planSortie := generated.DronePlanSortie{
+		Start:    &generated.PlotPosition{X: &startX, Y: &startY},
+		End:      &generated.PlotPosition{X: &endX, Y: &endY},
+		Distance: roundedDistance(horizontal, climb, descent),
+	}
+	if profile != nil {
+		sortieTime := profile.flightTime(horizontal, climb, descent)
+		sortieEnergy := profile.energy(horizontal, climb, descent)
+		planSortie.FlightTime, planSortie.Energy = &sortieTime, &sortieEnergy
+	}
+	return planSortie
 }
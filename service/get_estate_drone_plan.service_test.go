@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
@@ -27,14 +28,55 @@ func TestService_GetEstateDronePlan(t *testing.T) {
 	mockX := 9 // Update as per your expected behavior
 	mockY := 5 // Update as per your expected behavior
 	respDistance := 200
+	terrainFollowing := generated.TerrainFollowing
+	fixedEstateMax := generated.FixedEstateMax
+	fixedRowMax := generated.FixedRowMax
+	invalidMode := generated.FlightMode("hovering")
+	columnSerpentine := generated.ColumnSerpentine
+	mockProfileID := uuid.New()
+
+	// 6 plots in a row with a tree of 4 meters on plot 3, flown with a drone drawing 10 J per meter cruising, 180 J
+	// per meter climbing and 7.2 J per meter descending from a battery of 1000 J
+	mockProfileEstate := repository.EstateEntity{ID: mockEstateID, Length: 6, Width: 1, PlotSize: 10, Clearance: 1}
+	mockProfilePlots := []repository.PlotEntity{{X: 3, Y: 1, OrderNumber: 3, TreeHeight: 4}}
+	mockProfile := repository.DroneProfileEntity{ID: mockProfileID, HorizontalSpeed: 10, ClimbSpeed: 2, DescentSpeed: 5, HorizontalPower: 100, ClimbPower: 360, DescentPower: 36, BatteryCapacity: 1000.0 / 3600}
+	sortieEnergies := []float64{100.0 / 3600, 936.0 / 3600, 200.0 / 3600}
+	totalEnergy := sortieEnergies[0] + sortieEnergies[1] + sortieEnergies[2]
+	position := func(x, y int) *generated.PlotPosition {
+		return &generated.PlotPosition{X: &x, Y: &y}
+	}
+	invalidCorner := generated.TraversalCorner("center")
+
+	// 8 plots in a row with charging pads on plots 1 and 6, the drone reaches plot 6 and comes back for plots 7 and 8
+	mockPadEstate := repository.EstateEntity{ID: mockEstateID, Length: 8, Width: 1, PlotSize: 10, Clearance: 1}
+	mockPads := []repository.ChargingPadEntity{{ID: uuid.New(), X: 1, Y: 1}, {ID: uuid.New(), X: 6, Y: 1}}
+	firstPad := generated.ChargingPad{Id: &mockPads[0].ID, X: &[]int{1}[0], Y: &[]int{1}[0]}
+	secondPad := generated.ChargingPad{Id: &mockPads[1].ID, X: &[]int{6}[0], Y: &[]int{1}[0]}
+
+	// 5x3 estate with plot (3,1) in a no-fly zone, the drone detours from plot 2 to plot 4 by the second row
+	mockNoFlyEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 3, PlotSize: 10, Clearance: 1, BlockedPlots: 1}
+	mockNoFly := []repository.ObstacleEntity{{NoFly: true, Areas: []repository.ObstacleAreaEntity{{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1}}}}
+
+	// 3x3 estate with trees of 10, 2 and 20 meters on the middle plot of every row
+	mockFixedEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 3, TotalDistance: 150, PlotSize: 10, Clearance: 1}
+	mockFixedPlots := []repository.PlotEntity{
+		{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10},
+		{X: 2, Y: 2, OrderNumber: 5, TreeHeight: 2},
+		{X: 2, Y: 3, OrderNumber: 8, TreeHeight: 20},
+	}
 
 	tests := []struct {
-		name         string
-		prepareMocks func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
-		estateID     uuid.UUID
-		maxDistance  *int
-		expectedResp generated.DronePlanResponse
-		expectedErr  error
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		estateID       uuid.UUID
+		maxDistance    *int
+		flightMode     *generated.FlightMode
+		pattern        *generated.TraversalPattern
+		startCorner    *generated.TraversalCorner
+		profileID      *uuid.UUID
+		expectedResp   generated.DronePlanResponse
+		expectedStatus int
+		expectedErr    error
 	}{
 		{
 			name: "Successful Scenario",
@@ -42,22 +84,26 @@ func TestService_GetEstateDronePlan(t *testing.T) {
 				mockEstate := repository.EstateEntity{
 					ID:            mockEstateID,
 					Length:        10,
+					Width:         10,
 					TotalDistance: 200,
 				}
 				mockPlot := repository.PlotEntity{
-					ID:       uuid.New(),
-					EstateId: mockEstateID,
-					X:        5, // Update with correct mock data
-					Y:        5, // Update with correct mock data
-					Distance: 150,
+					ID:          uuid.New(),
+					EstateId:    mockEstateID,
+					X:           5, // Update with correct mock data
+					Y:           5, // Update with correct mock data
+					OrderNumber: 45,
+					Distance:    150,
 				}
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
 				mockRepo.EXPECT().GetPlotByDistance(gomock.Any(), mockEstateID, mockMaxDistance-1).Return(&mockPlot, nil)
 			},
 			estateID:    mockEstateID,
 			maxDistance: &mockMaxDistance,
 			expectedResp: generated.DronePlanResponse{
-				Distance: &respDistance,
+				FlightMode: &terrainFollowing,
+				Distance:   &respDistance,
 				Rest: &struct {
 					X *int `json:"x,omitempty"`
 					Y *int `json:"y,omitempty"`
@@ -66,27 +112,272 @@ func TestService_GetEstateDronePlan(t *testing.T) {
 					Y: &mockY,
 				},
 			},
-			expectedErr: nil,
+			expectedStatus: http.StatusOK,
+			expectedErr:    nil,
+		},
+		{
+			name: "Fixed Estate Max",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockFixedEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockFixedPlots, nil)
+			},
+			estateID:    mockEstateID,
+			maxDistance: &[]int{100}[0],
+			flightMode:  &fixedEstateMax,
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &fixedEstateMax,
+				Distance:   &[]int{132}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{2}[0],
+					Y: &[]int{2}[0],
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Fixed Row Max",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockFixedEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockFixedPlots, nil)
+			},
+			estateID:    mockEstateID,
+			maxDistance: &[]int{60}[0],
+			flightMode:  &fixedRowMax,
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &fixedRowMax,
+				Distance:   &[]int{148}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{3}[0],
+					Y: &[]int{1}[0],
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Column Serpentine Override",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockFixedEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				// the trees are reordered, so the plan works on a copy of the fixture
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(append([]repository.PlotEntity(nil), mockFixedPlots...), nil)
+			},
+			estateID:    mockEstateID,
+			maxDistance: &[]int{100}[0],
+			pattern:     &columnSerpentine,
+			// the middle column is crossed over plots 4 to 6 with the trees of 20, 2 and 10 meters in a row
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &terrainFollowing,
+				Distance:   &[]int{148}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{2}[0],
+					Y: &[]int{2}[0],
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Fixed Row Max Over Columns",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockFixedEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(append([]repository.PlotEntity(nil), mockFixedPlots...), nil)
+			},
+			estateID:   mockEstateID,
+			flightMode: &fixedRowMax,
+			pattern:    &columnSerpentine,
+			// every tree is in the middle column, the only leg flown above the trees
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &fixedRowMax,
+				Distance:   &[]int{132}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Invalid Start Corner",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockFixedEstate, nil)
+			},
+			estateID:       mockEstateID,
+			startCorner:    &invalidCorner,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("start_corner must be one of x_min_y_min, x_max_y_min, x_min_y_max or x_max_y_max"),
+		},
+		{
+			name: "Drone Profile",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockProfileEstate, nil)
+				mockRepo.EXPECT().GetDroneProfile(gomock.Any(), mockProfileID).Return(mockProfile, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockProfilePlots, nil)
+			},
+			estateID:  mockEstateID,
+			profileID: &mockProfileID,
+			// the climb over the tree takes a sortie of its own, the drone first lands on plot 2
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &terrainFollowing,
+				Distance:   &[]int{70}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{2}[0],
+					Y: &[]int{1}[0],
+				},
+				FlightTime: &[]float64{6.5}[0],
+				Energy:     &totalEnergy,
+				Sorties: &[]generated.DronePlanSortie{
+					{Start: position(1, 1), End: position(2, 1), Distance: &[]int{10}[0], FlightTime: &[]float64{1}[0], Energy: &sortieEnergies[0]},
+					{Start: position(3, 1), End: position(3, 1), Distance: &[]int{10}[0], FlightTime: &[]float64{3.5}[0], Energy: &sortieEnergies[1]},
+					{Start: position(4, 1), End: position(6, 1), Distance: &[]int{20}[0], FlightTime: &[]float64{2}[0], Energy: &sortieEnergies[2]},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Charging Pads",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockPadEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(mockPads, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			estateID:    mockEstateID,
+			maxDistance: &[]int{60}[0],
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &terrainFollowing,
+				Distance:   &[]int{90}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{6}[0],
+					Y: &[]int{1}[0],
+				},
+				Sorties: &[]generated.DronePlanSortie{
+					{
+						Start: position(1, 1), End: position(6, 1), Distance: &[]int{50}[0],
+						StartPad: &firstPad, EndPad: &secondPad,
+						DeadheadIn:  &generated.DeadheadLeg{From: position(1, 1), To: position(1, 1), Altitude: &[]int{0}[0], Distance: &[]int{0}[0]},
+						DeadheadOut: &generated.DeadheadLeg{From: position(6, 1), To: position(6, 1), Altitude: &[]int{0}[0], Distance: &[]int{0}[0]},
+					},
+					{
+						Start: position(7, 1), End: position(8, 1), Distance: &[]int{40}[0],
+						StartPad: &secondPad, EndPad: &secondPad,
+						DeadheadIn:  &generated.DeadheadLeg{From: position(6, 1), To: position(7, 1), Altitude: &[]int{0}[0], Distance: &[]int{10}[0]},
+						DeadheadOut: &generated.DeadheadLeg{From: position(8, 1), To: position(6, 1), Altitude: &[]int{0}[0], Distance: &[]int{20}[0]},
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Detour Around A No-Fly Plot",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockNoFlyEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return(mockNoFly, nil)
+			},
+			estateID:    mockEstateID,
+			maxDistance: &[]int{60}[0],
+			expectedResp: generated.DronePlanResponse{
+				FlightMode: &terrainFollowing,
+				Distance:   &[]int{170}[0],
+				Rest: &struct {
+					X *int `json:"x,omitempty"`
+					Y *int `json:"y,omitempty"`
+				}{
+					X: &[]int{4}[0],
+					Y: &[]int{1}[0],
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Charging Pad Out Of Reach",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockPadEstate, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(mockPads[:1], nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			estateID:       mockEstateID,
+			maxDistance:    &[]int{60}[0],
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance is too short to fly over plot 5 of the traversal from a charging pad and back"),
+		},
+		{
+			name: "Drone Profile Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockProfileEstate, nil)
+				mockRepo.EXPECT().GetDroneProfile(gomock.Any(), mockProfileID).Return(repository.DroneProfileEntity{}, gorm.ErrRecordNotFound)
+			},
+			estateID:       mockEstateID,
+			profileID:      &mockProfileID,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("drone profile not found"),
+		},
+		{
+			name:           "Max Distance With Drone Profile",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			estateID:       mockEstateID,
+			maxDistance:    &mockMaxDistance,
+			profileID:      &mockProfileID,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance cannot be given with profile_id, the battery of the profile cuts the sorties"),
+		},
+		{
+			name:           "Invalid Flight Mode",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			estateID:       mockEstateID,
+			flightMode:     &invalidMode,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("flight_mode must be one of terrain_following, fixed_estate_max or fixed_row_max"),
+		},
+		{
+			name:           "Max Distance Too Short",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			estateID:       mockEstateID,
+			maxDistance:    &[]int{0}[0],
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance must be at least 1"),
 		},
 		{
 			name: "Estate Not Found",
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
 			},
-			estateID:     mockEstateID,
-			maxDistance:  &mockMaxDistance,
-			expectedResp: generated.DronePlanResponse{},
-			expectedErr:  errors.New("estate not found"),
+			estateID:       mockEstateID,
+			maxDistance:    &mockMaxDistance,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
 		},
 		{
 			name: "Other Repository Error",
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, errors.New("some repository error"))
 			},
-			estateID:     mockEstateID,
-			maxDistance:  &mockMaxDistance,
-			expectedResp: generated.DronePlanResponse{},
-			expectedErr:  errors.New("some repository error"),
+			estateID:       mockEstateID,
+			maxDistance:    &mockMaxDistance,
+			expectedResp:   generated.DronePlanResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some repository error"),
 		},
 	}
 
@@ -106,9 +397,16 @@ func TestService_GetEstateDronePlan(t *testing.T) {
 				Repository: mockRepo,
 			}
 
-			resp, err := service.GetEstateDronePlan(mockContext, tt.estateID, tt.maxDistance)
+			resp, status, err := service.GetEstateDronePlan(mockContext, tt.estateID, generated.GetEstateIdDronePlanParams{
+				MaxDistance:      tt.maxDistance,
+				FlightMode:       tt.flightMode,
+				TraversalPattern: tt.pattern,
+				StartCorner:      tt.startCorner,
+				ProfileId:        tt.profileID,
+			})
 
 			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
 			} else {
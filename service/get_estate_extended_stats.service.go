@@ -0,0 +1,91 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) GetEstateExtendedStats(ctx context.Context, id uuid.UUID, asOf *time.Time, percentiles []float64) (generated.EstateStatsResponse, int, error) {
+	// the repository returns one height per distinct fraction in ascending order
+	percentiles = append([]float64{}, percentiles...)
+	sort.Float64s(percentiles)
+	percentiles = slices.Compact(percentiles)
+
+	fractions := make([]float64, len(percentiles))
+	for i, percentile := range percentiles {
+		if percentile < 0 || percentile > 100 {
+			return generated.EstateStatsResponse{}, http.StatusBadRequest, errors.New("percentiles must be between 0 and 100")
+		}
+		fractions[i] = percentile / 100
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.EstateStatsResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	filter := repository.TreeHeightFilter{AsOf: asOf}
+	distribution, err := s.Repository.GetTreeHeightDistribution(ctx, estate.ID, filter, fractions)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	species, err := s.speciesStats(ctx, estate.ID, filter)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	health, err := s.healthStats(ctx, estate.ID, filter)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	heightPercentiles := make([]generated.HeightPercentile, 0, len(distribution.Percentiles))
+	for i := range distribution.Percentiles {
+		if i >= len(percentiles) {
+			break
+		}
+		heightPercentiles = append(heightPercentiles, generated.HeightPercentile{
+			Percentile: &percentiles[i],
+			Height:     &distribution.Percentiles[i].Height,
+		})
+	}
+
+	histogram := make([]generated.HeightBucket, len(distribution.Histogram))
+	for i := range distribution.Histogram {
+		maxHeight := distribution.Histogram[i].MinHeight + 1
+		histogram[i] = generated.HeightBucket{
+			MinHeight: &distribution.Histogram[i].MinHeight,
+			MaxHeight: &maxHeight,
+			Count:     &distribution.Histogram[i].Count,
+		}
+	}
+
+	median := int(distribution.Median)
+	return generated.EstateStatsResponse{
+		Count:       &distribution.Count,
+		Max:         &distribution.Max,
+		Min:         &distribution.Min,
+		Median:      &median,
+		MedianExact: &distribution.Median,
+		Mean:        &distribution.Mean,
+		Stddev:      &distribution.StdDev,
+		Percentiles: &heightPercentiles,
+		Histogram:   &histogram,
+		Species:     species,
+		Health:      health,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateExtendedStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		percentiles    []float64
+		expectedResp   generated.EstateStatsResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Scenario",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetTreeHeightDistribution(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, []float64{0.1, 0.9}).Return(repository.TreeHeightDistribution{
+					TreeHeightStats: repository.TreeHeightStats{Count: 4, Min: 3, Max: 6, Median: 4.5},
+					Mean:            4.5,
+					StdDev:          1.25,
+					Percentiles: []repository.TreeHeightPercentile{
+						{Fraction: 0.1, Height: 3.3},
+						{Fraction: 0.9, Height: 5.7},
+					},
+					Histogram: []repository.TreeHeightBucket{
+						{MinHeight: 3, Count: 1},
+						{MinHeight: 4, Count: 2},
+						{MinHeight: 6, Count: 1},
+					},
+				}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}).Return(nil, nil)
+			},
+			percentiles: []float64{90, 10, 90},
+			expectedResp: generated.EstateStatsResponse{
+				Count:       &[]int{4}[0],
+				Min:         &[]int{3}[0],
+				Max:         &[]int{6}[0],
+				Median:      &[]int{4}[0],
+				MedianExact: &[]float64{4.5}[0],
+				Mean:        &[]float64{4.5}[0],
+				Stddev:      &[]float64{1.25}[0],
+				Percentiles: &[]generated.HeightPercentile{
+					{Percentile: &[]float64{10}[0], Height: &[]float64{3.3}[0]},
+					{Percentile: &[]float64{90}[0], Height: &[]float64{5.7}[0]},
+				},
+				Histogram: &[]generated.HeightBucket{
+					{MinHeight: &[]int{3}[0], MaxHeight: &[]int{4}[0], Count: &[]int{1}[0]},
+					{MinHeight: &[]int{4}[0], MaxHeight: &[]int{5}[0], Count: &[]int{2}[0]},
+					{MinHeight: &[]int{6}[0], MaxHeight: &[]int{7}[0], Count: &[]int{1}[0]},
+				},
+				Species: &[]generated.SpeciesStats{},
+				Health:  &[]generated.HealthStats{},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Percentile Out Of Range",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			percentiles:    []float64{101},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("percentiles must be between 0 and 100"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Distribution Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetTreeHeightDistribution(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, []float64{}).Return(repository.TreeHeightDistribution{}, errors.New("some repository error"))
+			},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, status, err := service.GetEstateExtendedStats(mockContext, mockEstateID, nil, tt.percentiles)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateFlightModeComparison(ctx context.Context, estateId uuid.UUID) (generated.FlightModeComparison, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FlightModeComparison{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.FlightModeComparison{}, http.StatusInternalServerError, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return generated.FlightModeComparison{}, http.StatusInternalServerError, err
+	}
+
+	model := newFlightModel(estate)
+	trav := newTraversal(estate)
+	modes := make([]generated.FlightModeDistance, len(flightModes))
+	cheapest := 0
+	for i := range flightModes {
+		// terrain following is the distance kept on the estate, the one reported by the drone plan
+		distance := estate.TotalDistance
+		if flightModes[i] != generated.TerrainFollowing {
+			runs, httpStatus, err := s.obstacleRuns(ctx, estate, trav, flightModes[i], plots)
+			if err != nil {
+				return generated.FlightModeComparison{}, httpStatus, err
+			}
+			distance = model.runsDistance(runs)
+		}
+		modes[i] = generated.FlightModeDistance{FlightMode: &flightModes[i], Distance: &distance}
+		if distance < *modes[cheapest].Distance {
+			cheapest = i
+		}
+	}
+
+	return generated.FlightModeComparison{Cheapest: modes[cheapest].FlightMode, Modes: &modes}, http.StatusOK, nil
+}
@@ -0,0 +1,112 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateFlightModeComparison(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	terrainFollowing := generated.TerrainFollowing
+	fixedEstateMax := generated.FixedEstateMax
+	fixedRowMax := generated.FixedRowMax
+
+	// 3x3 estate with trees of 10, 2 and 20 meters on the middle plot of every row
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 3, TotalDistance: 150, PlotSize: 10, Clearance: 1}
+	mockPlots := []repository.PlotEntity{
+		{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10},
+		{X: 2, Y: 2, OrderNumber: 5, TreeHeight: 2},
+		{X: 2, Y: 3, OrderNumber: 8, TreeHeight: 20},
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.FlightModeComparison
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Cheapest Mode",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+			},
+			expectedResp: generated.FlightModeComparison{
+				Cheapest: &fixedEstateMax,
+				Modes: &[]generated.FlightModeDistance{
+					{FlightMode: &terrainFollowing, Distance: &[]int{150}[0]},
+					{FlightMode: &fixedEstateMax, Distance: &[]int{132}[0]},
+					{FlightMode: &fixedRowMax, Distance: &[]int{148}[0]},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Tie Keeps The First Mode",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 3, TotalDistance: 90, PlotSize: 10, Clearance: 1}, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp: generated.FlightModeComparison{
+				Cheapest: &terrainFollowing,
+				Modes: &[]generated.FlightModeDistance{
+					{FlightMode: &terrainFollowing, Distance: &[]int{90}[0]},
+					{FlightMode: &fixedEstateMax, Distance: &[]int{90}[0]},
+					{FlightMode: &fixedRowMax, Distance: &[]int{90}[0]},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateFlightModeComparison(mockContext, mockEstateID)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) GetEstateForecast(ctx context.Context, estateId uuid.UUID, params generated.GetEstateForecastParams) (generated.EstateForecast, int, error) {
+	now := time.Now()
+	if params.At.Before(now) {
+		return generated.EstateForecast{}, http.StatusBadRequest, errors.New("at cannot be in the past")
+	}
+	if params.At.After(now.AddDate(maxForecastYears, 0, 0)) {
+		return generated.EstateForecast{}, http.StatusBadRequest, fmt.Errorf("at cannot be more than %d years ahead", maxForecastYears)
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.EstateForecast{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.EstateForecast{}, http.StatusInternalServerError, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return generated.EstateForecast{}, http.StatusInternalServerError, err
+	}
+
+	measurements, err := s.Repository.GetEstateTreeMeasurements(ctx, estateId)
+	if err != nil {
+		return generated.EstateForecast{}, http.StatusInternalServerError, err
+	}
+
+	species, err := s.Repository.GetSpeciesCatalog(ctx)
+	if err != nil {
+		return generated.EstateForecast{}, http.StatusInternalServerError, err
+	}
+	catalog := make(map[uuid.UUID]repository.SpeciesEntity, len(species))
+	for _, entity := range species {
+		catalog[entity.ID] = entity
+	}
+
+	histories := treeHistories(measurements)
+	// the removed trees are left out of the forecast but their growth still fits the models
+	models := fitGrowthModels(append(removedTrees(histories), plots...), histories, catalog)
+	projected := projectTrees(plots, histories, models, params.At)
+
+	// the projected trees are flown as the drone plan flies the stored ones, over the terrain and around the obstacles
+	runs, httpStatus, err := s.obstacleRuns(ctx, estate, newTraversal(estate), generated.TerrainFollowing, projected)
+	if err != nil {
+		return generated.EstateForecast{}, httpStatus, err
+	}
+	distance := newFlightModel(estate).runsDistance(runs)
+
+	count, lowest, highest, median := heightStats(projected)
+	forecasts := speciesForecasts(projected, models, catalog)
+	return generated.EstateForecast{
+		At:              &params.At,
+		Count:           &count,
+		Max:             &highest,
+		Min:             &lowest,
+		Median:          &median,
+		Species:         &forecasts,
+		Distance:        &distance,
+		CurrentDistance: &estate.TotalDistance,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,138 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateForecast(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	year := time.Duration(365.25*24) * time.Hour
+	now := time.Now()
+	nextYear := generated.GetEstateForecastParams{At: now.Add(year)}
+
+	// a row of 4 plots with an oak of 10 meters, a pine of 10 meters and a tree of 5 meters without species
+	oakID, pineID := uuid.New(), uuid.New()
+	oakPlotID, pinePlotID := uuid.New(), uuid.New()
+	pineGrowth := 2.0
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 4, Width: 1, TotalDistance: 62, PlotSize: 10, Clearance: 1}
+	mockPlots := []repository.PlotEntity{
+		{ID: oakPlotID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, SpeciesId: &oakID},
+		{ID: pinePlotID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 10, SpeciesId: &pineID},
+		{ID: uuid.New(), X: 4, Y: 1, OrderNumber: 4, TreeHeight: 5, CreatedAt: now},
+	}
+	mockCatalog := []repository.SpeciesEntity{
+		{ID: oakID, Name: "oak", MaxHeight: 40},
+		{ID: pineID, Name: "pine", MaxHeight: 20, GrowthRate: &pineGrowth},
+	}
+	// the oak doubled in 2 years, the pine was measured once
+	mockMeasurements := []repository.TreeMeasurementEntity{
+		{PlotId: oakPlotID, Height: 5, MeasuredAt: now.Add(-2 * year)},
+		{PlotId: oakPlotID, Height: 10, MeasuredAt: now},
+		{PlotId: pinePlotID, Height: 10, MeasuredAt: now.Add(-24 * time.Hour)},
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateForecastParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Projected Estate",
+			params: nextYear,
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+				mockRepo.EXPECT().GetEstateTreeMeasurements(gomock.Any(), mockEstateID).Return(mockMeasurements, nil)
+				mockRepo.EXPECT().GetSpeciesCatalog(gomock.Any()).Return(mockCatalog, nil)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "At In The Past",
+			params:         generated.GetEstateForecastParams{At: now.Add(-time.Hour)},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("at cannot be in the past"),
+		},
+		{
+			name:           "At Too Far Ahead",
+			params:         generated.GetEstateForecastParams{At: now.Add(101 * year)},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("at cannot be more than 100 years ahead"),
+		},
+		{
+			name:   "Estate Not Found",
+			params: nextYear,
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:   "Repository Error",
+			params: nextYear,
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+				mockRepo.EXPECT().GetEstateTreeMeasurements(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateForecast(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				assert.Equal(t, generated.EstateForecast{}, resp)
+				return
+			}
+			require.NoError(t, err)
+
+			// the oak grows to 13 meters and the pine to 12, the tree without species does not grow
+			assert.Equal(t, []int{3, 5, 13, 12}, []int{*resp.Count, *resp.Min, *resp.Max, *resp.Median})
+			// the drone climbs 3 meters more over the oak and 2 over the pine, and comes back down from them
+			assert.Equal(t, []int{68, 62}, []int{*resp.Distance, *resp.CurrentDistance})
+
+			species := *resp.Species
+			require.Len(t, species, 3)
+			assert.Equal(t, []any{oakID, "oak", 13, generated.History}, []any{*species[0].SpeciesId, *species[0].Name, *species[0].Max, *species[0].FittedFrom})
+			assert.InDelta(t, math.Log(7.0/3)/2, *species[0].LogisticRate, 1e-9)
+			assert.Equal(t, []any{pineID, "pine", 12, generated.Catalog}, []any{*species[1].SpeciesId, *species[1].Name, *species[1].Max, *species[1].FittedFrom})
+			assert.InDelta(t, 0.4, *species[1].LogisticRate, 1e-9)
+			assert.Nil(t, species[2].SpeciesId)
+			assert.Equal(t, []any{5, generated.None, 0.0}, []any{*species[2].Max, *species[2].FittedFrom, *species[2].LogisticRate})
+		})
+	}
+}
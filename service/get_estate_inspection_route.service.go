@@ -0,0 +1,65 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateInspectionRoute(ctx context.Context, estateId uuid.UUID) (generated.InspectionRoute, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.InspectionRoute{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.InspectionRoute{}, http.StatusInternalServerError, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return generated.InspectionRoute{}, http.StatusInternalServerError, err
+	}
+
+	_, grid, err := s.withObstacles(ctx, estate, newTraversal(estate), plots, newFlightModel(estate))
+	if err != nil {
+		return generated.InspectionRoute{}, http.StatusInternalServerError, err
+	}
+	model := grid.model
+	nodes := inspectionNodes(estate, plots, model, grid)
+	costs, err := inspectionCosts(nodes, model, grid)
+	if err != nil {
+		return generated.InspectionRoute{}, http.StatusBadRequest, err
+	}
+	landing := inspectionLanding(nodes, model)
+	order, optimized := planInspectionRoute(costs, landing, time.Now().Add(inspectionTimeBudget))
+
+	// the route starts at the home plot, the trees follow it
+	route := make([]generated.InspectionStop, 0, len(plots))
+	for _, node := range order[1:] {
+		plot := plots[node-1]
+		// the altitude of a stop is above the ground under its tree
+		altitude := model.altitude(&plot)
+		route = append(route, generated.InspectionStop{
+			X:          &nodes[node].x,
+			Y:          &nodes[node].y,
+			TreeHeight: &plot.TreeHeight,
+			Altitude:   &altitude,
+		})
+	}
+
+	distance := routeDistance(order, costs, landing)
+	savings := estate.TotalDistance - distance
+	return generated.InspectionRoute{
+		Route:         &route,
+		Distance:      &distance,
+		SweepDistance: &estate.TotalDistance,
+		Savings:       &savings,
+		Optimized:     &optimized,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,111 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateInspectionRoute(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	optimized := true
+
+	// a row of 10 plots with trees of 4 and 9 meters on plots 3 and 8, the sweep flies 100 meters and climbs 30
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 1, TotalDistance: 130, PlotSize: 10, Clearance: 1}
+	mockPlots := []repository.PlotEntity{
+		{X: 3, Y: 1, OrderNumber: 3, TreeHeight: 4},
+		{X: 8, Y: 1, OrderNumber: 8, TreeHeight: 9},
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.InspectionRoute
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Visits Only The Trees",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+			},
+			// 20 meters and a 5 meter climb to the first tree, 50 meters and a 5 meter climb to the second, 10 meters down
+			expectedResp: generated.InspectionRoute{
+				Route: &[]generated.InspectionStop{
+					{X: &[]int{3}[0], Y: &[]int{1}[0], TreeHeight: &[]int{4}[0], Altitude: &[]int{5}[0]},
+					{X: &[]int{8}[0], Y: &[]int{1}[0], TreeHeight: &[]int{9}[0], Altitude: &[]int{10}[0]},
+				},
+				Distance:      &[]int{90}[0],
+				SweepDistance: &[]int{130}[0],
+				Savings:       &[]int{40}[0],
+				Optimized:     &optimized,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No Tree",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp: generated.InspectionRoute{
+				Route:         &[]generated.InspectionStop{},
+				Distance:      &[]int{0}[0],
+				SweepDistance: &[]int{130}[0],
+				Savings:       &[]int{130}[0],
+				Optimized:     &optimized,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateInspectionRoute(mockContext, mockEstateID)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
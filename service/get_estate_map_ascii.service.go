@@ -0,0 +1,66 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"sort"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+const asciiGridNoData = "-9999"
+
+func (s *Service) GetEstateMapAscii(ctx context.Context, id uuid.UUID, params generated.GetEstateMapAsciiParams) ([]byte, int, error) {
+	grid, status, err := s.loadCanopyGrid(ctx, id, params.MaxSize, params.Aggregation)
+	if err != nil {
+		return nil, status, err
+	}
+
+	// the raster is written from the top row down with the cells sorted in the same order, only the cells holding
+	// trees are kept besides the text of the raster, which maxMapSize bounds
+	sort.Slice(grid.Cells, func(i, j int) bool {
+		if grid.Cells[i].Y != grid.Cells[j].Y {
+			return grid.Cells[i].Y > grid.Cells[j].Y
+		}
+		return grid.Cells[i].X < grid.Cells[j].X
+	})
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+
+	if params.Raw == nil || !*params.Raw {
+		w.WriteString("ncols " + strconv.Itoa(grid.Cols) + "\n")
+		w.WriteString("nrows " + strconv.Itoa(grid.Rows) + "\n")
+		w.WriteString("xllcorner 0\n")
+		w.WriteString("yllcorner 0\n")
+		w.WriteString("cellsize " + strconv.FormatFloat(grid.CellSize, 'f', -1, 64) + "\n")
+		w.WriteString("NODATA_value " + asciiGridNoData + "\n")
+	}
+
+	next := 0
+	for row := grid.Rows - 1; row >= 0; row-- {
+		for col := 0; col < grid.Cols; col++ {
+			if col > 0 {
+				w.WriteByte(' ')
+			}
+			if next < len(grid.Cells) && grid.Cells[next].Y == row && grid.Cells[next].X == col {
+				w.WriteString(strconv.FormatFloat(grid.Cells[next].Height, 'f', -1, 64))
+				next++
+			} else {
+				w.WriteString(asciiGridNoData)
+			}
+		}
+		w.WriteByte('\n')
+	}
+
+	if err = w.Flush(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return buf.Bytes(), http.StatusOK, nil
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateMapAscii(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 3}
+	mockTiles := []repository.TileTreeHeightStats{
+		{TileX: 0, TileY: 0, TreeHeightStats: repository.TreeHeightStats{Count: 2, Max: 12}, Mean: 9.5},
+		{TileX: 2, TileY: 1, TreeHeightStats: repository.TreeHeightStats{Count: 1, Max: 4}, Mean: 4},
+	}
+
+	tests := []struct {
+		name         string
+		params       generated.GetEstateMapAsciiParams
+		cellPlots    int
+		expectedResp string
+	}{
+		{
+			name:      "ESRI Grid With Max Heights",
+			params:    generated.GetEstateMapAsciiParams{MaxSize: &[]int{3}[0]},
+			cellPlots: 2,
+			expectedResp: "ncols 3\n" +
+				"nrows 2\n" +
+				"xllcorner 0\n" +
+				"yllcorner 0\n" +
+				"cellsize 20\n" +
+				"NODATA_value -9999\n" +
+				"-9999 -9999 4\n" +
+				"12 -9999 -9999\n",
+		},
+		{
+			name: "Raw Grid With Mean Heights",
+			params: generated.GetEstateMapAsciiParams{
+				MaxSize:     &[]int{3}[0],
+				Aggregation: &[]generated.MapAggregation{generated.Mean}[0],
+				Raw:         &[]bool{true}[0],
+			},
+			cellPlots: 2,
+			expectedResp: "-9999 -9999 4\n" +
+				"9.5 -9999 -9999\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, tt.cellPlots, tt.cellPlots).
+				Return(append([]repository.TileTreeHeightStats{}, mockTiles...), nil)
+
+			svc := &service.Service{Repository: mockRepo}
+			resp, status, err := svc.GetEstateMapAscii(mockContext, mockEstateID, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, status)
+			assert.Equal(t, tt.expectedResp, string(resp))
+		})
+	}
+
+	t.Run("Cell Size From The Plot Size", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		estate := mockEstate
+		estate.PlotSize = 5
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(estate, nil)
+		mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, 2, 2).Return(nil, nil)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapAscii(mockContext, mockEstateID, generated.GetEstateMapAsciiParams{MaxSize: &[]int{3}[0]})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		// a cell of 2 x 2 plots of 5 meters
+		assert.Contains(t, string(resp), "cellsize 10\n")
+	})
+
+	t.Run("Max Size Too Large", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapAscii(mockContext, mockEstateID, generated.GetEstateMapAsciiParams{MaxSize: &[]int{2049}[0]})
+		assert.Nil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.EqualError(t, err, "max_size cannot be greater than 2048")
+	})
+}
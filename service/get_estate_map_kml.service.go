@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateMapKml(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmlParams) ([]byte, int, error) {
+	return s.loadEstateKml(ctx, id, params.MaxDistance, params.GroundElevation)
+}
@@ -0,0 +1,136 @@
+package service_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+type kmlTestPlacemark struct {
+	Name       string `xml:"name"`
+	StyleUrl   string `xml:"styleUrl"`
+	Point      string `xml:"Point>coordinates"`
+	LineString struct {
+		AltitudeMode string `xml:"altitudeMode"`
+		Coordinates  string `xml:"coordinates"`
+	} `xml:"LineString"`
+	Polygon string `xml:"Polygon>outerBoundaryIs>LinearRing>coordinates"`
+}
+
+type kmlTestDocument struct {
+	Document struct {
+		Styles []struct {
+			Id string `xml:"id,attr"`
+		} `xml:"Style"`
+		Placemark kmlTestPlacemark `xml:"Placemark"`
+		Folders   []struct {
+			Name       string             `xml:"name"`
+			Placemarks []kmlTestPlacemark `xml:"Placemark"`
+		} `xml:"Folder"`
+	} `xml:"Document"`
+}
+
+func TestService_GetEstateMapKml(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          3,
+		Width:           2,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+		Clearance:       1,
+		// the drone keeps 1 meter above the empty plots
+		MinCruiseAltitude: 1,
+	}
+	mockPlots := []repository.PlotEntity{
+		{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 4},
+		{X: 1, Y: 2, OrderNumber: 6, TreeHeight: 30},
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateMapKmlParams
+		expectedPaths  []string
+		expectedStatus int
+	}{
+		{
+			name:   "Single Sortie",
+			params: generated.GetEstateMapKmlParams{GroundElevation: &[]float64{100}[0]},
+			expectedPaths: []string{
+				"0.00004492,0.00004522,101 0.00013475,0.00004522,105 0.00022458,0.00004522,101 " +
+					"0.00022458,0.00013566,101 0.00013475,0.00013566,101 0.00004492,0.00013566,131",
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Split In Sorties",
+			params: generated.GetEstateMapKmlParams{MaxDistance: &[]int{62}[0]},
+			expectedPaths: []string{
+				"0.00004492,0.00004522,1 0.00013475,0.00004522,5 0.00022458,0.00004522,1 " +
+					"0.00022458,0.00013566,1 0.00013475,0.00013566,1",
+				"0.00004492,0.00013566,31",
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateMapKml(mockContext, mockEstateID, tt.params)
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.True(t, strings.HasPrefix(string(resp), xml.Header))
+
+			var doc kmlTestDocument
+			require.NoError(t, xml.Unmarshal(resp, &doc))
+
+			styles := make([]string, len(doc.Document.Styles))
+			for i, style := range doc.Document.Styles {
+				styles[i] = style.Id
+			}
+			assert.Equal(t, []string{"boundary", "drone-path", "height-1-5", "height-6-10", "height-11-15", "height-16-20", "height-21-25", "height-26-30"}, styles)
+
+			assert.Equal(t, "#boundary", doc.Document.Placemark.StyleUrl)
+			assert.Equal(t, "0.00000000,0.00000000,0 0.00026949,0.00000000,0 0.00026949,0.00018087,0 0.00000000,0.00018087,0 0.00000000,0.00000000,0", doc.Document.Placemark.Polygon)
+
+			require.Len(t, doc.Document.Folders, 2)
+			trees := doc.Document.Folders[0]
+			assert.Equal(t, "Trees", trees.Name)
+			require.Len(t, trees.Placemarks, 2)
+			assert.Equal(t, "Tree (2,1)", trees.Placemarks[0].Name)
+			assert.Equal(t, "#height-1-5", trees.Placemarks[0].StyleUrl)
+			assert.Equal(t, "0.00013475,0.00004522,0", trees.Placemarks[0].Point)
+			assert.Equal(t, "#height-26-30", trees.Placemarks[1].StyleUrl)
+
+			path := doc.Document.Folders[1]
+			assert.Equal(t, "Drone path", path.Name)
+			require.Len(t, path.Placemarks, len(tt.expectedPaths))
+			for i, placemark := range path.Placemarks {
+				assert.Equal(t, "absolute", placemark.LineString.AltitudeMode)
+				assert.Equal(t, tt.expectedPaths[i], placemark.LineString.Coordinates)
+			}
+		})
+	}
+}
@@ -0,0 +1,35 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+// GetEstateMapKmz zips the KML document of the estate, Google Earth opens the doc.kml entry of the archive.
+func (s *Service) GetEstateMapKmz(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmzParams) ([]byte, int, error) {
+	doc, httpStatus, err := s.loadEstateKml(ctx, id, params.MaxDistance, params.GroundElevation)
+	if err != nil {
+		return nil, httpStatus, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	w, err := archive.Create("doc.kml")
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if _, err = w.Write(doc); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	if err = archive.Close(); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return buf.Bytes(), http.StatusOK, nil
+}
@@ -0,0 +1,76 @@
+package service_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateMapKmz(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          3,
+		Width:           2,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+		Clearance:       1,
+		// the drone keeps 1 meter above the empty plots
+		MinCruiseAltitude: 1,
+	}
+	mockPlots := []repository.PlotEntity{{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 4}}
+
+	t.Run("Zipped KML", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil).Times(2)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil).Times(2)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		kml, _, err := svc.GetEstateMapKml(mockContext, mockEstateID, generated.GetEstateMapKmlParams{})
+		require.NoError(t, err)
+
+		resp, status, err := svc.GetEstateMapKmz(mockContext, mockEstateID, generated.GetEstateMapKmzParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		archive, err := zip.NewReader(bytes.NewReader(resp), int64(len(resp)))
+		require.NoError(t, err)
+		require.Len(t, archive.File, 1)
+		assert.Equal(t, "doc.kml", archive.File[0].Name)
+
+		entry, err := archive.File[0].Open()
+		require.NoError(t, err)
+		defer entry.Close()
+		doc, err := io.ReadAll(entry)
+		require.NoError(t, err)
+		assert.Equal(t, kml, doc)
+	})
+
+	t.Run("Not Geo-Referenced", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 2}, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateMapKmz(mockContext, mockEstateID, generated.GetEstateMapKmzParams{})
+		assert.Equal(t, http.StatusConflict, status)
+		assert.EqualError(t, err, "estate is not geo-referenced")
+	})
+}
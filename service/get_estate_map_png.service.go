@@ -0,0 +1,62 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+// the canopy palette goes from light green for the lowest trees to dark green for the tallest one,
+// index 0 is kept transparent for the cells without trees
+var (
+	canopyLow  = color.NRGBA{R: 217, G: 240, B: 163, A: 255}
+	canopyHigh = color.NRGBA{R: 0, G: 69, B: 41, A: 255}
+)
+
+func canopyPalette() color.Palette {
+	palette := make(color.Palette, 256)
+	palette[0] = color.NRGBA{}
+	for i := 1; i < len(palette); i++ {
+		t := float64(i-1) / float64(len(palette)-2)
+		palette[i] = color.NRGBA{
+			R: uint8(math.Round(float64(canopyLow.R) + t*(float64(canopyHigh.R)-float64(canopyLow.R)))),
+			G: uint8(math.Round(float64(canopyLow.G) + t*(float64(canopyHigh.G)-float64(canopyLow.G)))),
+			B: uint8(math.Round(float64(canopyLow.B) + t*(float64(canopyHigh.B)-float64(canopyLow.B)))),
+			A: 255,
+		}
+	}
+	return palette
+}
+
+func (s *Service) GetEstateMapPng(ctx context.Context, id uuid.UUID, params generated.GetEstateMapPngParams) ([]byte, int, error) {
+	grid, status, err := s.loadCanopyGrid(ctx, id, params.MaxSize, params.Aggregation)
+	if err != nil {
+		return nil, status, err
+	}
+
+	palette := canopyPalette()
+	img := image.NewPaletted(image.Rect(0, 0, grid.Cols, grid.Rows), palette)
+	for _, cell := range grid.Cells {
+		index := 1
+		if grid.MaxHeight > 0 {
+			index += int(math.Round(cell.Height / grid.MaxHeight * float64(len(palette)-2)))
+		}
+		// the image origin is top left while y 1 is the bottom row of the estate
+		img.SetColorIndex(cell.X, grid.Rows-1-cell.Y, uint8(index))
+	}
+
+	var buf bytes.Buffer
+	if err = png.Encode(&buf, img); err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	return buf.Bytes(), http.StatusOK, nil
+}
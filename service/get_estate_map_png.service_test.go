@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image/color"
+	"image/png"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateMapPng(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+
+	t.Run("Renders One Pixel Per Plot", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 4, Width: 3}, nil)
+		mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, 1, 1).Return([]repository.TileTreeHeightStats{
+			{TileX: 0, TileY: 0, TreeHeightStats: repository.TreeHeightStats{Count: 1, Max: 10}, Mean: 10},
+			{TileX: 3, TileY: 2, TreeHeightStats: repository.TreeHeightStats{Count: 1, Max: 20}, Mean: 20},
+		}, nil)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		img, err := png.Decode(bytes.NewReader(resp))
+		require.NoError(t, err)
+		assert.Equal(t, 4, img.Bounds().Dx())
+		assert.Equal(t, 3, img.Bounds().Dy())
+
+		// plot (1,1) is the bottom left pixel and plot (4,3), the tallest tree, the top right one
+		_, _, _, emptyAlpha := img.At(1, 1).RGBA()
+		assert.Equal(t, uint32(0), emptyAlpha)
+		assert.Equal(t, color.NRGBA{R: 0, G: 69, B: 41, A: 255}, color.NRGBAModel.Convert(img.At(3, 0)))
+		lowR, _, _, lowAlpha := img.At(0, 2).RGBA()
+		assert.Equal(t, uint32(0xffff), lowAlpha)
+		assert.Greater(t, lowR, uint32(0))
+	})
+
+	t.Run("Downsamples Large Estates", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 50000, Width: 20000}, nil)
+		mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, 50, 50).Return([]repository.TileTreeHeightStats{}, nil)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{MaxSize: &[]int{1000}[0]})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+
+		img, err := png.Decode(bytes.NewReader(resp))
+		require.NoError(t, err)
+		assert.Equal(t, 1000, img.Bounds().Dx())
+		assert.Equal(t, 400, img.Bounds().Dy())
+	})
+
+	t.Run("Estate Not Found", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{})
+		assert.Nil(t, resp)
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.EqualError(t, err, "estate not found")
+	})
+
+	t.Run("Invalid Aggregation", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		aggregation := generated.MapAggregation("median")
+
+		svc := &service.Service{Repository: mockRepo}
+		_, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{Aggregation: &aggregation})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.EqualError(t, err, "aggregation must be one of max or mean")
+	})
+
+	t.Run("Max Size Too Large", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+
+		svc := &service.Service{Repository: mockRepo}
+		resp, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{MaxSize: &[]int{50000}[0]})
+		assert.Nil(t, resp)
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.EqualError(t, err, "max_size cannot be greater than 2048")
+	})
+
+	t.Run("Tiles Repository Error", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 4, Width: 3}, nil)
+		mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}, 1, 1).Return(nil, errors.New("some repository error"))
+
+		svc := &service.Service{Repository: mockRepo}
+		_, status, err := svc.GetEstateMapPng(mockContext, mockEstateID, generated.GetEstateMapPngParams{})
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.EqualError(t, err, "some repository error")
+	})
+}
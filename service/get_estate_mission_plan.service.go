@@ -0,0 +1,107 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+// QGroundControl plan file constants, the vehicle is an ArduPilot multirotor.
+const (
+	qgcFirmwareArduPilot = 3
+	qgcVehicleMultirotor = 2
+	qgcAltitudeRelative  = 1
+	qgcCruiseSpeed       = 15
+	qgcHoverSpeed        = 5
+)
+
+type qgcPlan struct {
+	FileType      string         `json:"fileType"`
+	GeoFence      qgcGeoFence    `json:"geoFence"`
+	GroundStation string         `json:"groundStation"`
+	Mission       qgcMission     `json:"mission"`
+	RallyPoints   qgcRallyPoints `json:"rallyPoints"`
+	Version       int            `json:"version"`
+}
+
+type qgcGeoFence struct {
+	Circles  []interface{} `json:"circles"`
+	Polygons []interface{} `json:"polygons"`
+	Version  int           `json:"version"`
+}
+
+type qgcRallyPoints struct {
+	Points  []interface{} `json:"points"`
+	Version int           `json:"version"`
+}
+
+type qgcMission struct {
+	CruiseSpeed         int              `json:"cruiseSpeed"`
+	FirmwareType        int              `json:"firmwareType"`
+	HoverSpeed          int              `json:"hoverSpeed"`
+	Items               []qgcMissionItem `json:"items"`
+	PlannedHomePosition [3]float64       `json:"plannedHomePosition"`
+	VehicleType         int              `json:"vehicleType"`
+	Version             int              `json:"version"`
+}
+
+type qgcMissionItem struct {
+	AMSLAltAboveTerrain *float64   `json:"AMSLAltAboveTerrain"`
+	Altitude            int        `json:"Altitude"`
+	AltitudeMode        int        `json:"AltitudeMode"`
+	AutoContinue        bool       `json:"autoContinue"`
+	Command             int        `json:"command"`
+	DoJumpId            int        `json:"doJumpId"`
+	Frame               int        `json:"frame"`
+	Params              [7]float64 `json:"params"`
+	Type                string     `json:"type"`
+}
+
+func (s *Service) GetEstateMissionPlan(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionPlanParams) (MissionFile, int, error) {
+	mission, httpStatus, err := s.loadDroneMission(ctx, id, params.MaxDistance, params.Sortie)
+	if err != nil {
+		return MissionFile{}, httpStatus, err
+	}
+
+	items := make([]qgcMissionItem, len(mission.Items))
+	for i, item := range mission.Items {
+		items[i] = qgcMissionItem{
+			Altitude:     item.Altitude,
+			AltitudeMode: qgcAltitudeRelative,
+			AutoContinue: true,
+			Command:      item.Command,
+			DoJumpId:     i + 1,
+			Frame:        mavFrameGlobalRelativeAlt,
+			Params:       [7]float64{0, 0, 0, 0, item.Latitude, item.Longitude, float64(item.Altitude)},
+			Type:         "SimpleItem",
+		}
+	}
+
+	plan := qgcPlan{
+		FileType:      "Plan",
+		GeoFence:      qgcGeoFence{Circles: []interface{}{}, Polygons: []interface{}{}, Version: 2},
+		GroundStation: "QGroundControl",
+		Mission: qgcMission{
+			CruiseSpeed:         qgcCruiseSpeed,
+			FirmwareType:        qgcFirmwareArduPilot,
+			HoverSpeed:          qgcHoverSpeed,
+			Items:               items,
+			PlannedHomePosition: [3]float64{mission.Home.Latitude, mission.Home.Longitude, 0},
+			VehicleType:         qgcVehicleMultirotor,
+			Version:             2,
+		},
+		RallyPoints: qgcRallyPoints{Points: []interface{}{}, Version: 2},
+		Version:     1,
+	}
+
+	content, err := json.MarshalIndent(plan, "", "    ")
+	if err != nil {
+		return MissionFile{}, http.StatusInternalServerError, err
+	}
+
+	return MissionFile{Content: content, Sortie: mission.Sortie, SortieCount: mission.SortieCount}, http.StatusOK, nil
+}
@@ -0,0 +1,107 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateMissionPlan(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          3,
+		Width:           2,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+		Clearance:       1,
+		// the drone keeps 1 meter above the empty plots
+		MinCruiseAltitude: 1,
+	}
+	mockPlots := []repository.PlotEntity{{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 4}}
+
+	t.Run("Whole Plan", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		resp, status, err := svc.GetEstateMissionPlan(mockContext, mockEstateID, generated.GetEstateMissionPlanParams{})
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, 1, resp.Sortie)
+		assert.Equal(t, 1, resp.SortieCount)
+
+		var plan struct {
+			FileType string `json:"fileType"`
+			Mission  struct {
+				FirmwareType        int       `json:"firmwareType"`
+				PlannedHomePosition []float64 `json:"plannedHomePosition"`
+				Items               []struct {
+					Altitude int       `json:"Altitude"`
+					Command  int       `json:"command"`
+					DoJumpId int       `json:"doJumpId"`
+					Frame    int       `json:"frame"`
+					Params   []float64 `json:"params"`
+				} `json:"items"`
+			} `json:"mission"`
+		}
+		require.NoError(t, json.Unmarshal(resp.Content, &plan))
+
+		assert.Equal(t, "Plan", plan.FileType)
+		assert.Equal(t, 3, plan.Mission.FirmwareType)
+		require.Len(t, plan.Mission.PlannedHomePosition, 3)
+		assert.InDelta(t, 0.00004522, plan.Mission.PlannedHomePosition[0], 1e-8)
+		assert.InDelta(t, 0.00004492, plan.Mission.PlannedHomePosition[1], 1e-8)
+
+		// takeoff, the tree, the end of the first row, the start and the end of the second row, then landing
+		expectedCommands := []int{22, 16, 16, 16, 16, 21}
+		expectedAltitudes := []int{1, 5, 1, 1, 1, 0}
+		require.Len(t, plan.Mission.Items, len(expectedCommands))
+		for i, item := range plan.Mission.Items {
+			assert.Equal(t, expectedCommands[i], item.Command)
+			assert.Equal(t, expectedAltitudes[i], item.Altitude)
+			assert.Equal(t, i+1, item.DoJumpId)
+			assert.Equal(t, 3, item.Frame)
+			assert.Equal(t, float64(expectedAltitudes[i]), item.Params[6])
+		}
+	})
+
+	t.Run("Estate Not Found", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateMissionPlan(mockContext, mockEstateID, generated.GetEstateMissionPlanParams{})
+		assert.Equal(t, http.StatusNotFound, status)
+		assert.EqualError(t, err, "estate not found")
+	})
+
+	t.Run("Max Distance Too Short", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateMissionPlan(mockContext, mockEstateID, generated.GetEstateMissionPlanParams{MaxDistance: &[]int{5}[0]})
+		assert.Equal(t, http.StatusBadRequest, status)
+		assert.EqualError(t, err, "max_distance is too short to fly over plot 2 of the traversal")
+	})
+}
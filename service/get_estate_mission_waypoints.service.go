@@ -0,0 +1,51 @@
+package service
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"strconv"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+/*
+GetEstateMissionWaypoints writes a sortie in the QGC WPL 110 text format read by Mission Planner and MAVProxy.
+Every line is index, current, frame, command, the four params, latitude, longitude, altitude and autocontinue,
+separated by tabs. The first line is the home position.
+*/
+func (s *Service) GetEstateMissionWaypoints(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionWaypointsParams) (MissionFile, int, error) {
+	mission, httpStatus, err := s.loadDroneMission(ctx, id, params.MaxDistance, params.Sortie)
+	if err != nil {
+		return MissionFile{}, httpStatus, err
+	}
+
+	var buf bytes.Buffer
+	w := bufio.NewWriter(&buf)
+	w.WriteString("QGC WPL 110\n")
+	writeWaypointLine(w, 0, true, mavFrameGlobal, mavCmdNavWaypoint, mission.Home.Latitude, mission.Home.Longitude, 0)
+	for i, item := range mission.Items {
+		writeWaypointLine(w, i+1, false, mavFrameGlobalRelativeAlt, item.Command, item.Latitude, item.Longitude, item.Altitude)
+	}
+
+	if err = w.Flush(); err != nil {
+		return MissionFile{}, http.StatusInternalServerError, err
+	}
+
+	return MissionFile{Content: buf.Bytes(), Sortie: mission.Sortie, SortieCount: mission.SortieCount}, http.StatusOK, nil
+}
+
+func writeWaypointLine(w *bufio.Writer, index int, current bool, frame int, command int, latitude float64, longitude float64, altitude int) {
+	currentFlag := "0"
+	if current {
+		currentFlag = "1"
+	}
+
+	w.WriteString(strconv.Itoa(index) + "\t" + currentFlag + "\t" + strconv.Itoa(frame) + "\t" + strconv.Itoa(command))
+	w.WriteString("\t0\t0\t0\t0\t")
+	w.WriteString(strconv.FormatFloat(latitude, 'f', 8, 64) + "\t" + strconv.FormatFloat(longitude, 'f', 8, 64))
+	w.WriteString("\t" + strconv.Itoa(altitude) + "\t1\n")
+}
@@ -0,0 +1,147 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateMissionWaypoints(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          3,
+		Width:           2,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+		Clearance:       1,
+		// the drone keeps 1 meter above the empty plots
+		MinCruiseAltitude: 1,
+	}
+	mockPlots := []repository.PlotEntity{{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 4}}
+
+	tests := []struct {
+		name                string
+		params              generated.GetEstateMissionWaypointsParams
+		prepareMocks        func(mockRepo *repository.MockRepositoryInterface)
+		expectedLines       []string
+		expectedSortie      int
+		expectedSortieCount int
+		expectedStatus      int
+		expectedErr         error
+	}{
+		{
+			name:   "Whole Plan",
+			params: generated.GetEstateMissionWaypointsParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+			},
+			expectedLines: []string{
+				"QGC WPL 110",
+				"0\t1\t0\t16\t0\t0\t0\t0\t0.00004522\t0.00004492\t0\t1",
+				"1\t0\t3\t22\t0\t0\t0\t0\t0.00004522\t0.00004492\t1\t1",
+				"2\t0\t3\t16\t0\t0\t0\t0\t0.00004522\t0.00013475\t5\t1",
+				"3\t0\t3\t16\t0\t0\t0\t0\t0.00004522\t0.00022458\t1\t1",
+				"4\t0\t3\t16\t0\t0\t0\t0\t0.00013566\t0.00022458\t1\t1",
+				"5\t0\t3\t16\t0\t0\t0\t0\t0.00013566\t0.00004492\t1\t1",
+				"6\t0\t3\t21\t0\t0\t0\t0\t0.00013566\t0.00004492\t0\t1",
+			},
+			expectedSortie:      1,
+			expectedSortieCount: 1,
+			expectedStatus:      http.StatusOK,
+		},
+		{
+			name:   "Second Sortie",
+			params: generated.GetEstateMissionWaypointsParams{MaxDistance: &[]int{30}[0], Sortie: &[]int{2}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+			},
+			expectedLines: []string{
+				"QGC WPL 110",
+				"0\t1\t0\t16\t0\t0\t0\t0\t0.00013566\t0.00022458\t0\t1",
+				"1\t0\t3\t22\t0\t0\t0\t0\t0.00013566\t0.00022458\t1\t1",
+				"2\t0\t3\t16\t0\t0\t0\t0\t0.00013566\t0.00004492\t1\t1",
+				"3\t0\t3\t21\t0\t0\t0\t0\t0.00013566\t0.00004492\t0\t1",
+			},
+			expectedSortie:      2,
+			expectedSortieCount: 2,
+			expectedStatus:      http.StatusOK,
+		},
+		{
+			name:   "Sortie Out Of Range",
+			params: generated.GetEstateMissionWaypointsParams{Sortie: &[]int{2}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("sortie must be at most 1"),
+		},
+		{
+			name:           "Invalid Sortie",
+			params:         generated.GetEstateMissionWaypointsParams{Sortie: &[]int{0}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("sortie must be at least 1"),
+		},
+		{
+			name:   "Not Geo-Referenced",
+			params: generated.GetEstateMissionWaypointsParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 2}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErr:    errors.New("estate is not geo-referenced"),
+		},
+		{
+			name:   "Repository Error",
+			params: generated.GetEstateMissionWaypointsParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateMissionWaypoints(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedSortie, resp.Sortie)
+			assert.Equal(t, tt.expectedSortieCount, resp.SortieCount)
+			assert.Equal(t, strings.Join(tt.expectedLines, "\n")+"\n", string(resp.Content))
+		})
+	}
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+const defaultGridSize = 10
+
+func (s *Service) GetEstateRegionStats(ctx context.Context, id uuid.UUID, params generated.GetEstateIdStatsParams) (generated.EstateStatsResponse, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.EstateStatsResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	region := repository.PlotRegion{MinX: 1, MinY: 1, MaxX: estate.Length, MaxY: estate.Width}
+	if params.MinX != nil {
+		region.MinX = *params.MinX
+	}
+	if params.MinY != nil {
+		region.MinY = *params.MinY
+	}
+	if params.MaxX != nil {
+		region.MaxX = *params.MaxX
+	}
+	if params.MaxY != nil {
+		region.MaxY = *params.MaxY
+	}
+	if region.MinX < 1 || region.MinY < 1 || region.MaxX > estate.Length || region.MaxY > estate.Width ||
+		region.MinX > region.MaxX || region.MinY > region.MaxY {
+		return generated.EstateStatsResponse{}, http.StatusBadRequest, errors.New("region is out of range")
+	}
+
+	filter := repository.TreeHeightFilter{AsOf: params.AsOf, Region: &region}
+
+	stats, err := s.Repository.GetFilteredTreeHeightStats(ctx, estate.ID, filter)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	species, err := s.speciesStats(ctx, estate.ID, filter)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	health, err := s.healthStats(ctx, estate.ID, filter)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	// the plots under an obstacle or outside the shape of the estate cannot hold a tree, they are left out of the occupancy
+	obstacles, err := s.loadObstacles(ctx, estate)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+	shape := estateShapeOf(estate)
+	plotsIn := func(region repository.PlotRegion) int {
+		if shape != nil {
+			return shape.countIn(region)
+		}
+		return plotCount(region)
+	}
+
+	median := int(stats.Median)
+	occupancy := plantedRatio(stats.Count, plotsIn(region)-obstacles.countIn(region))
+	resp := generated.EstateStatsResponse{
+		Count:     &stats.Count,
+		Max:       &stats.Max,
+		Min:       &stats.Min,
+		Median:    &median,
+		Occupancy: &occupancy,
+		Species:   species,
+		Health:    health,
+	}
+
+	if params.GroupBy == nil {
+		return resp, http.StatusOK, nil
+	}
+
+	var tileWidth, tileLength int
+	switch *params.GroupBy {
+	case generated.GetEstateIdStatsParamsGroupByRow:
+		tileWidth, tileLength = estate.Length, 1
+	case generated.GetEstateIdStatsParamsGroupByColumn:
+		tileWidth, tileLength = 1, estate.Width
+	case generated.GetEstateIdStatsParamsGroupByGrid:
+		tileWidth, tileLength = defaultGridSize, defaultGridSize
+		if params.GridSize != nil {
+			if *params.GridSize < 1 {
+				return generated.EstateStatsResponse{}, http.StatusBadRequest, errors.New("grid_size must be at least 1")
+			}
+			tileWidth, tileLength = *params.GridSize, *params.GridSize
+		}
+	default:
+		return generated.EstateStatsResponse{}, http.StatusBadRequest, errors.New("group_by must be one of row, column or grid")
+	}
+
+	tiles, err := s.Repository.GetTreeHeightStatsByTile(ctx, estate.ID, filter, tileWidth, tileLength)
+	if err != nil {
+		return generated.EstateStatsResponse{}, http.StatusInternalServerError, err
+	}
+
+	blocked := obstacles.countByTile(region, tileWidth, tileLength)
+	groups := make([]generated.GroupStats, len(tiles))
+	for i, tile := range tiles {
+		bounds := tileRegion(tile, tileWidth, tileLength, region)
+		groupOccupancy := plantedRatio(tile.Count, plotsIn(bounds)-blocked[[2]int{tile.TileX, tile.TileY}])
+		groups[i] = generated.GroupStats{
+			MinX:      &bounds.MinX,
+			MinY:      &bounds.MinY,
+			MaxX:      &bounds.MaxX,
+			MaxY:      &bounds.MaxY,
+			Count:     &tiles[i].Count,
+			Max:       &tiles[i].Max,
+			Min:       &tiles[i].Min,
+			Median:    &tiles[i].Median,
+			Occupancy: &groupOccupancy,
+		}
+	}
+	resp.Groups = &groups
+
+	return resp, http.StatusOK, nil
+}
+
+// tileRegion returns the plots of the tile that are inside the region.
+func tileRegion(tile repository.TileTreeHeightStats, tileWidth int, tileLength int, region repository.PlotRegion) repository.PlotRegion {
+	return repository.PlotRegion{
+		MinX: max(tile.TileX*tileWidth+1, region.MinX),
+		MinY: max(tile.TileY*tileLength+1, region.MinY),
+		MaxX: min((tile.TileX+1)*tileWidth, region.MaxX),
+		MaxY: min((tile.TileY+1)*tileLength, region.MaxY),
+	}
+}
+
+func plotCount(region repository.PlotRegion) int {
+	return (region.MaxX - region.MinX + 1) * (region.MaxY - region.MinY + 1)
+}
+
+// plantedRatio returns the ratio of the plots free of obstacles holding a tree, 0 when there is none.
+func plantedRatio(trees int, plots int) float64 {
+	if plots == 0 {
+		return 0
+	}
+	return float64(trees) / float64(plots)
+}
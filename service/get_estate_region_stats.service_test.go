@@ -0,0 +1,224 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateRegionStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 25, Width: 20}
+	mockSpeciesID := uuid.New()
+	mockSpeciesName := "Oil palm"
+	groupBy := func(g generated.GetEstateIdStatsParamsGroupBy) *generated.GetEstateIdStatsParamsGroupBy {
+		return &g
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		params         generated.GetEstateIdStatsParams
+		expectedResp   generated.EstateStatsResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Region Only",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetFilteredTreeHeightStats(gomock.Any(), mockEstateID, repository.TreeHeightFilter{
+					Region: &repository.PlotRegion{MinX: 1, MinY: 1, MaxX: 10, MaxY: 5},
+				}).Return(repository.TreeHeightStats{Count: 5, Min: 3, Max: 9, Median: 4}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, repository.TreeHeightFilter{
+					Region: &repository.PlotRegion{MinX: 1, MinY: 1, MaxX: 10, MaxY: 5},
+				}).Return([]repository.SpeciesTreeHeightStats{
+					{SpeciesId: &mockSpeciesID, Name: &mockSpeciesName, TreeHeightStats: repository.TreeHeightStats{Count: 3, Min: 3, Max: 9, Median: 7}},
+					{TreeHeightStats: repository.TreeHeightStats{Count: 2, Min: 3, Max: 4, Median: 3.5}},
+				}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+			},
+			params: generated.GetEstateIdStatsParams{MaxX: &[]int{10}[0], MaxY: &[]int{5}[0]},
+			expectedResp: generated.EstateStatsResponse{
+				Count:     &[]int{5}[0],
+				Min:       &[]int{3}[0],
+				Max:       &[]int{9}[0],
+				Median:    &[]int{4}[0],
+				Occupancy: &[]float64{0.1}[0],
+				Species: &[]generated.SpeciesStats{
+					{SpeciesId: &mockSpeciesID, Name: &mockSpeciesName, Count: &[]int{3}[0], Min: &[]int{3}[0], Max: &[]int{9}[0], Median: &[]int{7}[0]},
+					{Count: &[]int{2}[0], Min: &[]int{3}[0], Max: &[]int{4}[0], Median: &[]int{3}[0]},
+				},
+				Health: &[]generated.HealthStats{},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Obstacles Left Out Of The Occupancy",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				blockedEstate := mockEstate
+				blockedEstate.BlockedPlots = 30
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(blockedEstate, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return([]repository.ObstacleEntity{
+					{NoFly: true, Areas: []repository.ObstacleAreaEntity{{MinX: 1, MinY: 1, MaxX: 5, MaxY: 6}}},
+				}, nil)
+				mockRepo.EXPECT().GetFilteredTreeHeightStats(gomock.Any(), mockEstateID, repository.TreeHeightFilter{
+					Region: &repository.PlotRegion{MinX: 1, MinY: 1, MaxX: 10, MaxY: 5},
+				}).Return(repository.TreeHeightStats{Count: 5, Min: 3, Max: 9, Median: 4}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+			},
+			params: generated.GetEstateIdStatsParams{MaxX: &[]int{10}[0], MaxY: &[]int{5}[0]},
+			expectedResp: generated.EstateStatsResponse{
+				Count:     &[]int{5}[0],
+				Min:       &[]int{3}[0],
+				Max:       &[]int{9}[0],
+				Median:    &[]int{4}[0],
+				Occupancy: &[]float64{0.2}[0],
+				Species:   &[]generated.SpeciesStats{},
+				Health:    &[]generated.HealthStats{},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Group By Grid Clipped To The Estate",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				filter := repository.TreeHeightFilter{
+					Region: &repository.PlotRegion{MinX: 1, MinY: 1, MaxX: 25, MaxY: 20},
+				}
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetFilteredTreeHeightStats(gomock.Any(), mockEstateID, filter).Return(repository.TreeHeightStats{Count: 3, Min: 2, Max: 8, Median: 5}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, filter, 10, 10).Return([]repository.TileTreeHeightStats{
+					{TileX: 0, TileY: 0, TreeHeightStats: repository.TreeHeightStats{Count: 2, Min: 2, Max: 8, Median: 5}},
+					{TileX: 2, TileY: 1, TreeHeightStats: repository.TreeHeightStats{Count: 1, Min: 5, Max: 5, Median: 5}},
+				}, nil)
+			},
+			params: generated.GetEstateIdStatsParams{GroupBy: groupBy(generated.GetEstateIdStatsParamsGroupByGrid)},
+			expectedResp: generated.EstateStatsResponse{
+				Count:     &[]int{3}[0],
+				Min:       &[]int{2}[0],
+				Max:       &[]int{8}[0],
+				Median:    &[]int{5}[0],
+				Occupancy: &[]float64{0.006}[0],
+				Species:   &[]generated.SpeciesStats{},
+				Health:    &[]generated.HealthStats{},
+				Groups: &[]generated.GroupStats{
+					{
+						MinX: &[]int{1}[0], MinY: &[]int{1}[0], MaxX: &[]int{10}[0], MaxY: &[]int{10}[0],
+						Count: &[]int{2}[0], Min: &[]int{2}[0], Max: &[]int{8}[0], Median: &[]float64{5}[0],
+						Occupancy: &[]float64{0.02}[0],
+					},
+					{
+						MinX: &[]int{21}[0], MinY: &[]int{11}[0], MaxX: &[]int{25}[0], MaxY: &[]int{20}[0],
+						Count: &[]int{1}[0], Min: &[]int{5}[0], Max: &[]int{5}[0], Median: &[]float64{5}[0],
+						Occupancy: &[]float64{0.02}[0],
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Group By Row Inside A Region",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				filter := repository.TreeHeightFilter{
+					Region: &repository.PlotRegion{MinX: 6, MinY: 1, MaxX: 15, MaxY: 20},
+				}
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetFilteredTreeHeightStats(gomock.Any(), mockEstateID, filter).Return(repository.TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByTile(gomock.Any(), mockEstateID, filter, 25, 1).Return([]repository.TileTreeHeightStats{
+					{TileX: 0, TileY: 3, TreeHeightStats: repository.TreeHeightStats{Count: 1, Min: 4, Max: 4, Median: 4}},
+				}, nil)
+			},
+			params: generated.GetEstateIdStatsParams{GroupBy: groupBy(generated.GetEstateIdStatsParamsGroupByRow), MinX: &[]int{6}[0], MaxX: &[]int{15}[0]},
+			expectedResp: generated.EstateStatsResponse{
+				Count:     &[]int{1}[0],
+				Min:       &[]int{4}[0],
+				Max:       &[]int{4}[0],
+				Median:    &[]int{4}[0],
+				Occupancy: &[]float64{0.005}[0],
+				Species:   &[]generated.SpeciesStats{},
+				Health:    &[]generated.HealthStats{},
+				Groups: &[]generated.GroupStats{
+					{
+						MinX: &[]int{6}[0], MinY: &[]int{4}[0], MaxX: &[]int{15}[0], MaxY: &[]int{4}[0],
+						Count: &[]int{1}[0], Min: &[]int{4}[0], Max: &[]int{4}[0], Median: &[]float64{4}[0],
+						Occupancy: &[]float64{0.1}[0],
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Region Out Of Range",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			params:         generated.GetEstateIdStatsParams{MaxX: &[]int{26}[0]},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("region is out of range"),
+		},
+		{
+			name: "Invalid Grid Size",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetFilteredTreeHeightStats(gomock.Any(), mockEstateID, gomock.Any()).Return(repository.TreeHeightStats{}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, gomock.Any()).Return(nil, nil)
+			},
+			params:         generated.GetEstateIdStatsParams{GroupBy: groupBy(generated.GetEstateIdStatsParamsGroupByGrid), GridSize: &[]int{0}[0]},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("grid_size must be at least 1"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			params:         generated.GetEstateIdStatsParams{GroupBy: groupBy(generated.GetEstateIdStatsParamsGroupByColumn)},
+			expectedResp:   generated.EstateStatsResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, status, err := service.GetEstateRegionStats(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -2,10 +2,12 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 
 	"spgo/generated"
+	"spgo/repository"
 )
 
 func (s *Service) GetEstateStats(ctx context.Context, id uuid.UUID) (generated.EstateStatsResponse, error) {
@@ -14,10 +16,54 @@ func (s *Service) GetEstateStats(ctx context.Context, id uuid.UUID) (generated.E
 		return generated.EstateStatsResponse{}, err
 	}
 
+	species, err := s.speciesStats(ctx, estate.ID, repository.TreeHeightFilter{})
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	health, err := s.healthStats(ctx, estate.ID, repository.TreeHeightFilter{})
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	return generated.EstateStatsResponse{
+		Max:     &estate.TreeMaxHeight,
+		Min:     &estate.TreeMinHeight,
+		Median:  &estate.TreeMedianHeight,
+		Count:   &estate.TreeCount,
+		Species: species,
+		Health:  health,
+	}, nil
+}
+
+func (s *Service) GetEstateStatsAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (generated.EstateStatsResponse, error) {
+	estate, err := s.Repository.GetEstate(ctx, id)
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	stats, err := s.Repository.GetTreeHeightStatsAsOf(ctx, estate.ID, asOf)
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	species, err := s.speciesStats(ctx, estate.ID, repository.TreeHeightFilter{AsOf: &asOf})
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	health, err := s.healthStats(ctx, estate.ID, repository.TreeHeightFilter{AsOf: &asOf})
+	if err != nil {
+		return generated.EstateStatsResponse{}, err
+	}
+
+	median := int(stats.Median)
 	return generated.EstateStatsResponse{
-		Max:    &estate.TreeMaxHeight,
-		Min:    &estate.TreeMinHeight,
-		Median: &estate.TreeMedianHeight,
-		Count:  &estate.TreeCount,
+		Max:     &stats.Max,
+		Min:     &stats.Min,
+		Median:  &median,
+		Count:   &stats.Count,
+		Species: species,
+		Health:  health,
 	}, nil
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
@@ -21,6 +22,9 @@ func TestService_GetEstateStats(t *testing.T) {
 
 	mockEstateID := uuid.New()
 	mockContext := context.TODO()
+	mockSpeciesID := uuid.New()
+	mockSpeciesName := "Oil palm"
+	mockDead := "dead"
 
 	tests := []struct {
 		name         string
@@ -40,6 +44,13 @@ func TestService_GetEstateStats(t *testing.T) {
 					TreeCount:        500,
 				}
 				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}).Return([]repository.SpeciesTreeHeightStats{
+					{SpeciesId: &mockSpeciesID, Name: &mockSpeciesName, TreeHeightStats: repository.TreeHeightStats{Count: 500, Min: 50, Max: 100, Median: 75.5}},
+				}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, repository.TreeHeightFilter{}).Return([]repository.HealthTreeHeightStats{
+					{HealthStatus: &mockDead, TreeHeightStats: repository.TreeHeightStats{Count: 20, Min: 50, Max: 60, Median: 55}},
+					{TreeHeightStats: repository.TreeHeightStats{Count: 480, Min: 50, Max: 100, Median: 75.5}},
+				}, nil)
 			},
 			estateID: mockEstateID,
 			expectedResp: generated.EstateStatsResponse{
@@ -47,6 +58,13 @@ func TestService_GetEstateStats(t *testing.T) {
 				Min:    &[]int{50}[0],
 				Median: &[]int{75}[0],
 				Count:  &[]int{500}[0],
+				Species: &[]generated.SpeciesStats{
+					{SpeciesId: &mockSpeciesID, Name: &mockSpeciesName, Count: &[]int{500}[0], Min: &[]int{50}[0], Max: &[]int{100}[0], Median: &[]int{75}[0]},
+				},
+				Health: &[]generated.HealthStats{
+					{HealthStatus: &[]generated.HealthStatus{generated.Dead}[0], Count: &[]int{20}[0], Min: &[]int{50}[0], Max: &[]int{60}[0], Median: &[]int{55}[0]},
+					{Count: &[]int{480}[0], Min: &[]int{50}[0], Max: &[]int{100}[0], Median: &[]int{75}[0]},
+				},
 			},
 			expectedErr: nil,
 		},
@@ -90,3 +108,84 @@ func TestService_GetEstateStats(t *testing.T) {
 		})
 	}
 }
+
+func TestService_GetEstateStatsAsOf(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	mockAsOf := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name         string
+		prepareMocks func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp generated.EstateStatsResponse
+		expectedErr  error
+	}{
+		{
+			name: "Successful Scenario",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsAsOf(gomock.Any(), mockEstateID, mockAsOf).Return(repository.TreeHeightStats{
+					Count:  4,
+					Min:    3,
+					Max:    20,
+					Median: 7.5,
+				}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsBySpecies(gomock.Any(), mockEstateID, repository.TreeHeightFilter{AsOf: &mockAsOf}).Return([]repository.SpeciesTreeHeightStats{
+					{TreeHeightStats: repository.TreeHeightStats{Count: 4, Min: 3, Max: 20, Median: 7.5}},
+				}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsByHealth(gomock.Any(), mockEstateID, repository.TreeHeightFilter{AsOf: &mockAsOf}).Return(nil, nil)
+			},
+			expectedResp: generated.EstateStatsResponse{
+				Max:    &[]int{20}[0],
+				Min:    &[]int{3}[0],
+				Median: &[]int{7}[0],
+				Count:  &[]int{4}[0],
+				Species: &[]generated.SpeciesStats{
+					{Count: &[]int{4}[0], Min: &[]int{3}[0], Max: &[]int{20}[0], Median: &[]int{7}[0]},
+				},
+				Health: &[]generated.HealthStats{},
+			},
+			expectedErr: nil,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedResp: generated.EstateStatsResponse{},
+			expectedErr:  gorm.ErrRecordNotFound,
+		},
+		{
+			name: "Stats Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetTreeHeightStatsAsOf(gomock.Any(), mockEstateID, mockAsOf).Return(repository.TreeHeightStats{}, errors.New("some repository error"))
+			},
+			expectedResp: generated.EstateStatsResponse{},
+			expectedErr:  errors.New("some repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, err := service.GetEstateStatsAsOf(mockContext, mockEstateID, mockAsOf)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateTrees(ctx context.Context, estateId uuid.UUID, params generated.GetEstateTreesParams) (generated.TreeList, int, error) {
+	var statuses []string
+	if params.Health != nil {
+		for _, status := range *params.Health {
+			if err := checkHealthStatus("health", status); err != nil {
+				return generated.TreeList{}, http.StatusBadRequest, err
+			}
+			statuses = append(statuses, string(status))
+		}
+	}
+	uninspected := params.Uninspected != nil && *params.Uninspected
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TreeList{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.TreeList{}, http.StatusInternalServerError, err
+	}
+
+	plots, err := s.Repository.GetPlotsByHealth(ctx, estate.ID, statuses, uninspected)
+	if err != nil {
+		return generated.TreeList{}, http.StatusInternalServerError, err
+	}
+
+	trees := make([]generated.Tree, len(plots))
+	for i := range plots {
+		x, y := int(plots[i].X), int(plots[i].Y)
+		trees[i] = generated.Tree{
+			Id:           &plots[i].ID,
+			X:            &x,
+			Y:            &y,
+			Height:       &plots[i].TreeHeight,
+			SpeciesId:    plots[i].SpeciesId,
+			HealthStatus: (*generated.HealthStatus)(plots[i].HealthStatus),
+			Distance:     &plots[i].Distance,
+		}
+	}
+
+	return generated.TreeList{Trees: &trees}, http.StatusOK, nil
+}
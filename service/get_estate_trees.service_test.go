@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateTrees(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockSpeciesID := uuid.New()
+	mockContext := context.TODO()
+	dead := "dead"
+
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 2}
+	mockPlot := repository.PlotEntity{ID: mockTreeID, EstateId: mockEstateID, X: 4, Y: 2, OrderNumber: 7, TreeHeight: 8, Distance: 78, SpeciesId: &mockSpeciesID, HealthStatus: &dead}
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateTreesParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.TreeList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Filtered By Health",
+			params: generated.GetEstateTreesParams{Health: &[]generated.HealthStatus{generated.Diseased, generated.Dead}, Uninspected: &[]bool{true}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotsByHealth(gomock.Any(), mockEstateID, []string{"diseased", "dead"}, true).Return([]repository.PlotEntity{mockPlot}, nil)
+			},
+			expectedResp: generated.TreeList{
+				Trees: &[]generated.Tree{
+					{
+						Id:           &mockTreeID,
+						X:            &[]int{4}[0],
+						Y:            &[]int{2}[0],
+						Height:       &[]int{8}[0],
+						SpeciesId:    &mockSpeciesID,
+						HealthStatus: &[]generated.HealthStatus{generated.Dead}[0],
+						Distance:     &[]int{78}[0],
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Every Tree",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlotsByHealth(gomock.Any(), mockEstateID, nil, false).Return(nil, nil)
+			},
+			expectedResp:   generated.TreeList{Trees: &[]generated.Tree{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Health Status",
+			params:         generated.GetEstateTreesParams{Health: &[]generated.HealthStatus{"sick"}},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedResp:   generated.TreeList{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("health must be one of healthy, stressed, diseased, dead"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.TreeList{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, status, err := service.GetEstateTrees(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
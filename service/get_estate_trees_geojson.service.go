@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateTreesGeoJson(ctx context.Context, estateId uuid.UUID) (generated.TreeFeatureCollection, int, error) {
+	_, geo, httpStatus, err := s.loadGeoReference(ctx, estateId)
+	if err != nil {
+		return generated.TreeFeatureCollection{}, httpStatus, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return generated.TreeFeatureCollection{}, http.StatusInternalServerError, err
+	}
+
+	featureType := geoJSONFeature
+	geometryType := geoJSONPoint
+	features := make([]generated.TreeFeature, len(plots))
+	for i := range plots {
+		x := int(plots[i].X)
+		y := int(plots[i].Y)
+		lat, lon := geo.plotCenter(x, y)
+		coordinates := []float64{lon, lat}
+		features[i] = generated.TreeFeature{
+			Type: &featureType,
+			Geometry: &generated.GeoJsonPoint{
+				Type:        &geometryType,
+				Coordinates: &coordinates,
+			},
+			Properties: &generated.TreeFeatureProperties{
+				Id:     &plots[i].ID,
+				X:      &x,
+				Y:      &y,
+				Height: &plots[i].TreeHeight,
+			},
+		}
+	}
+
+	collectionType := geoJSONFeatureCollection
+	return generated.TreeFeatureCollection{
+		Type:     &collectionType,
+		Features: &features,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,91 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateTreesGeoJson(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	lonPerPlot := 0.00008983152841195214
+	latPerPlot := 0.00009043694770492493
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          5,
+		Width:           3,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+	}
+	mockPlots := []repository.PlotEntity{
+		{ID: uuid.New(), EstateId: mockEstateID, X: 2, Y: 1, TreeHeight: 5},
+		{ID: uuid.New(), EstateId: mockEstateID, X: 4, Y: 3, TreeHeight: 12},
+	}
+
+	t.Run("Trees As Points", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		resp, status, err := svc.GetEstateTreesGeoJson(mockContext, mockEstateID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, "FeatureCollection", *resp.Type)
+
+		features := *resp.Features
+		require.Len(t, features, len(mockPlots))
+		for i, feature := range features {
+			plot := mockPlots[i]
+			assert.Equal(t, "Feature", *feature.Type)
+			assert.Equal(t, "Point", *feature.Geometry.Type)
+			assert.Equal(t, plot.ID, *feature.Properties.Id)
+			assert.Equal(t, int(plot.X), *feature.Properties.X)
+			assert.Equal(t, int(plot.Y), *feature.Properties.Y)
+			assert.Equal(t, plot.TreeHeight, *feature.Properties.Height)
+
+			position := *feature.Geometry.Coordinates
+			assert.InDelta(t, (float64(plot.X)-0.5)*lonPerPlot, position[0], 1e-9)
+			assert.InDelta(t, (float64(plot.Y)-0.5)*latPerPlot, position[1], 1e-9)
+		}
+	})
+
+	t.Run("No Trees", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		resp, status, err := svc.GetEstateTreesGeoJson(mockContext, mockEstateID)
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, status)
+		assert.NotNil(t, resp.Features)
+		assert.Empty(t, *resp.Features)
+	})
+
+	t.Run("Repository Error", func(t *testing.T) {
+		mockRepo := repository.NewMockRepositoryInterface(ctrl)
+		mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+		mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+
+		svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+		_, status, err := svc.GetEstateTreesGeoJson(mockContext, mockEstateID)
+		assert.Equal(t, http.StatusInternalServerError, status)
+		assert.EqualError(t, err, "repository error")
+	})
+}
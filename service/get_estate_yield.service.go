@@ -0,0 +1,53 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) GetEstateYield(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldParams) (generated.YieldReport, int, error) {
+	filter, err := yieldFilter(params.GroupBy, params.Period, params.From, params.To, params.Grade)
+	if err != nil {
+		return generated.YieldReport{}, http.StatusBadRequest, err
+	}
+
+	groups, status, err := s.loadYieldGroups(ctx, estateId, filter)
+	if err != nil {
+		return generated.YieldReport{}, status, err
+	}
+
+	groupBy := generated.YieldGrouping(filter.GroupBy)
+	return generated.YieldReport{
+		GroupBy: &groupBy,
+		Period:  (*generated.YieldPeriod)(filter.Period),
+		Groups:  &groups,
+	}, http.StatusOK, nil
+}
+
+// loadYieldGroups aggregates the harvests of an estate selected by the filter, with the status to answer on error.
+func (s *Service) loadYieldGroups(ctx context.Context, estateId uuid.UUID, filter repository.YieldFilter) ([]generated.YieldGroup, int, error) {
+	if _, err := s.Repository.GetEstate(ctx, estateId); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, http.StatusNotFound, errors.New("estate not found")
+		}
+		return nil, http.StatusInternalServerError, err
+	}
+
+	stats, err := s.Repository.GetYieldStats(ctx, estateId, filter)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	groups := make([]generated.YieldGroup, len(stats))
+	for i := range stats {
+		groups[i] = yieldGroup(filter, stats[i])
+	}
+	return groups, http.StatusOK, nil
+}
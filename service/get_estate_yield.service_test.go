@@ -0,0 +1,141 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateYield(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockSpeciesID := uuid.New()
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 5}
+	speciesName := "Oil palm"
+	month := "month"
+	september := time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)
+	from := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateYieldParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.YieldReport
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Whole Estate",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetYieldStats(gomock.Any(), mockEstateID, repository.YieldFilter{GroupBy: "estate"}).
+					Return([]repository.YieldStats{{Harvests: 3, Trees: 2, Quantity: 60, StandingQuantity: 60, TreeHeight: 24}}, nil)
+			},
+			expectedResp: generated.YieldReport{
+				GroupBy: &[]generated.YieldGrouping{generated.YieldGroupingEstate}[0],
+				Groups: &[]generated.YieldGroup{{
+					Harvests:        &[]int{3}[0],
+					Trees:           &[]int{2}[0],
+					Quantity:        &[]float64{60}[0],
+					QuantityPerTree: &[]float64{30}[0],
+					YieldPerMeter:   &[]float64{2.5}[0],
+				}},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Species Per Month",
+			params: generated.GetEstateYieldParams{
+				GroupBy: &[]generated.YieldGrouping{generated.YieldGroupingSpecies}[0],
+				Period:  &[]generated.YieldPeriod{generated.YieldPeriodMonth}[0],
+				From:    &from,
+				To:      &to,
+			},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetYieldStats(gomock.Any(), mockEstateID, repository.YieldFilter{GroupBy: "species", Period: &month, From: &from, To: &to}).
+					Return([]repository.YieldStats{
+						{SpeciesId: &mockSpeciesID, Name: &speciesName, Period: &september, Harvests: 2, Trees: 1, Quantity: 40, StandingQuantity: 40, TreeHeight: 10},
+						// the trees without species, one of them removed since its harvest
+						{Period: &september, Harvests: 3, Trees: 1, Quantity: 17, StandingQuantity: 5, TreeHeight: 2},
+					}, nil)
+			},
+			expectedResp: generated.YieldReport{
+				GroupBy: &[]generated.YieldGrouping{generated.YieldGroupingSpecies}[0],
+				Period:  &[]generated.YieldPeriod{generated.YieldPeriodMonth}[0],
+				Groups: &[]generated.YieldGroup{
+					{
+						SpeciesId:       &mockSpeciesID,
+						SpeciesName:     &speciesName,
+						Period:          &september,
+						Harvests:        &[]int{2}[0],
+						Trees:           &[]int{1}[0],
+						Quantity:        &[]float64{40}[0],
+						QuantityPerTree: &[]float64{40}[0],
+						YieldPerMeter:   &[]float64{4}[0],
+					},
+					{
+						Period:          &september,
+						Harvests:        &[]int{3}[0],
+						Trees:           &[]int{1}[0],
+						Quantity:        &[]float64{17}[0],
+						QuantityPerTree: &[]float64{5}[0],
+						YieldPerMeter:   &[]float64{2.5}[0],
+					},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Grouping",
+			params:         generated.GetEstateYieldParams{GroupBy: &[]generated.YieldGrouping{"block"}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("group_by must be one of estate, tree, row, species"),
+		},
+		{
+			name:           "From After To",
+			params:         generated.GetEstateYieldParams{From: &to, To: &from},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("from must be before to"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateYield(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
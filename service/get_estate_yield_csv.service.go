@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetEstateYieldCsv(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldCsvParams) ([]byte, int, error) {
+	filter, err := yieldFilter(params.GroupBy, params.Period, params.From, params.To, params.Grade)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+
+	groups, status, err := s.loadYieldGroups(ctx, estateId, filter)
+	if err != nil {
+		return nil, status, err
+	}
+
+	resp, err := yieldCsv(filter, groups)
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+	return resp, http.StatusOK, nil
+}
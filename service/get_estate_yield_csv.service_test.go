@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetEstateYieldCsv(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.MustParse("5c1f2f0e-0000-4000-8000-000000000001")
+	mockTreeID := uuid.MustParse("5c1f2f0e-0000-4000-8000-000000000002")
+	mockContext := context.TODO()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 5}
+	week := "week"
+	monday := time.Date(2026, 9, 14, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		params         generated.GetEstateYieldCsvParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedCsv    string
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Tree Per Week",
+			params: generated.GetEstateYieldCsvParams{
+				GroupBy: &[]generated.YieldGrouping{generated.YieldGroupingTree}[0],
+				Period:  &[]generated.YieldPeriod{generated.YieldPeriodWeek}[0],
+			},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetYieldStats(gomock.Any(), mockEstateID, repository.YieldFilter{GroupBy: "tree", Period: &week}).
+					Return([]repository.YieldStats{
+						{TreeId: &mockTreeID, X: &[]int{4}[0], Y: &[]int{2}[0], Period: &monday, Harvests: 2, Trees: 1, Quantity: 30.5, StandingQuantity: 30.5, TreeHeight: 10},
+						// the harvests of the removed trees
+						{Period: &monday, Harvests: 1, Quantity: 12},
+					}, nil)
+			},
+			expectedCsv: "tree_id,x,y,period,harvests,trees,quantity,quantity_per_tree,yield_per_meter\n" +
+				"5c1f2f0e-0000-4000-8000-000000000002,4,2,2026-09-14T00:00:00Z,2,1,30.5,30.5,3.05\n" +
+				",,,2026-09-14T00:00:00Z,1,0,12,,\n",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Rows Without Harvests",
+			params: generated.GetEstateYieldCsvParams{GroupBy: &[]generated.YieldGrouping{generated.YieldGroupingRow}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetYieldStats(gomock.Any(), mockEstateID, repository.YieldFilter{GroupBy: "row"}).Return(nil, nil)
+			},
+			expectedCsv:    "row,harvests,trees,quantity,quantity_per_tree,yield_per_meter\n",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Period",
+			params:         generated.GetEstateYieldCsvParams{Period: &[]generated.YieldPeriod{"quarter"}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("period must be one of day, week, month, year"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetEstateYieldCsv(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedCsv, string(resp))
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedErr, err)
+		})
+	}
+}
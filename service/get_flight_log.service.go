@@ -0,0 +1,38 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetFlightLog(ctx context.Context, estateId uuid.UUID, logId uuid.UUID) (generated.FlightLogReport, int, error) {
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FlightLogReport{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.FlightLogReport{}, http.StatusInternalServerError, err
+	}
+
+	flightLog, err := s.Repository.GetFlightLog(ctx, estateId, logId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FlightLogReport{}, http.StatusNotFound, errors.New("flight log not found")
+		}
+		return generated.FlightLogReport{}, http.StatusInternalServerError, err
+	}
+
+	// the log is compared with the plan of the estate as it is now, its trees may have grown since the flight
+	plan, planned, httpStatus, err := s.flightLogPlan(ctx, estate, flightLog.MaxDistance)
+	if err != nil {
+		return generated.FlightLogReport{}, httpStatus, err
+	}
+
+	return flightLogReport(flightLog, estate, plan, planned), http.StatusOK, nil
+}
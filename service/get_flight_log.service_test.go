@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetFlightLog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockLogID := uuid.New()
+	mockContext := context.TODO()
+	mockTime := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+
+	// a row of 3 empty plots flown 1 meter high in a single sortie of 22 meters
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 1, TotalDistance: 22, PlotSize: 10, Clearance: 1, MinCruiseAltitude: 1}
+	mockLog := repository.FlightLogEntity{
+		ID:        mockLogID,
+		EstateId:  mockEstateID,
+		StartedAt: mockTime,
+		EndedAt:   mockTime.Add(20 * time.Second),
+		Samples: []repository.FlightLogSampleEntity{
+			{Sequence: 1, Sortie: 1, RecordedAt: mockTime, X: 1, Y: 1, Altitude: 0, Battery: 80},
+			{Sequence: 2, Sortie: 1, RecordedAt: mockTime.Add(5 * time.Second), X: 1, Y: 1, Altitude: 2, Battery: 79},
+			{Sequence: 3, Sortie: 1, RecordedAt: mockTime.Add(15 * time.Second), X: 3, Y: 1, Altitude: 2, Battery: 76},
+			{Sequence: 4, Sortie: 1, RecordedAt: mockTime.Add(20 * time.Second), X: 3, Y: 1, Altitude: 0, Battery: 75},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   func(resp generated.FlightLogReport)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Comparison",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil).Times(2)
+				mockRepo.EXPECT().GetFlightLog(gomock.Any(), mockEstateID, mockLogID).Return(mockLog, nil)
+				mockRepo.EXPECT().GetChargingPads(gomock.Any(), mockEstateID).Return(nil, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp: func(resp generated.FlightLogReport) {
+				assert.Equal(t, mockLogID, *resp.Id)
+				assert.Equal(t, mockTime, *resp.StartedAt)
+				// the drone climbed 2 meters instead of 1
+				assert.Equal(t, 24, *resp.Distance)
+				assert.Equal(t, 22, *resp.PlannedDistance)
+				assert.Equal(t, 2, *resp.DistanceDeviation)
+				assert.Equal(t, 5.0, *(*resp.Sorties)[0].BatteryUsed)
+				assert.Equal(t, 480, *resp.MaxDistanceEstimate)
+				assert.Equal(t, 0, *(*resp.Sorties)[0].LandingDeviation)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Flight Log Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetFlightLog(gomock.Any(), mockEstateID, mockLogID).Return(repository.FlightLogEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("flight log not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.GetFlightLog(mockContext, mockEstateID, mockLogID)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedErr, err)
+			if tt.expectedResp != nil {
+				require.NotNil(t, resp.Sorties)
+				tt.expectedResp(resp)
+			}
+		})
+	}
+}
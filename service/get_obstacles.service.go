@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetObstacles(ctx context.Context, estateId uuid.UUID) (generated.ObstacleList, int, error) {
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.ObstacleList{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.ObstacleList{}, http.StatusInternalServerError, err
+	}
+
+	entities, err := s.Repository.GetObstacles(ctx, estateId)
+	if err != nil {
+		return generated.ObstacleList{}, http.StatusInternalServerError, err
+	}
+
+	obstacles := make([]generated.Obstacle, len(entities))
+	for i := range entities {
+		obstacles[i] = obstacleResponse(entities[i])
+	}
+	return generated.ObstacleList{Obstacles: &obstacles}, http.StatusOK, nil
+}
@@ -0,0 +1,94 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetObstacles(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+	one, two, three := 1, 2, 3
+	height := 12
+	noFly, climbed := true, false
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.ObstacleList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Get",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return([]repository.ObstacleEntity{
+					{ID: firstID, EstateId: mockEstateID, Shape: "rectangle", NoFly: true, Areas: []repository.ObstacleAreaEntity{{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}}},
+					{ID: secondID, EstateId: mockEstateID, Shape: "plots", Height: &height, Areas: []repository.ObstacleAreaEntity{
+						{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1},
+						{MinX: 2, MinY: 3, MaxX: 2, MaxY: 3},
+					}},
+				}, nil)
+			},
+			expectedResp: generated.ObstacleList{Obstacles: &[]generated.Obstacle{
+				{Id: &firstID, Rectangle: &generated.PlotRectangle{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}, NoFly: &noFly},
+				{Id: &secondID, Plots: &[]generated.PlotPosition{{X: &three, Y: &one}, {X: &two, Y: &three}}, Height: &height, NoFly: &climbed},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No Obstacle",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetObstacles(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp:   generated.ObstacleList{Obstacles: &[]generated.Obstacle{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetObstacles(mockContext, mockEstateID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
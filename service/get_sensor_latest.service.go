@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetSensorLatest(ctx context.Context, estateId uuid.UUID, params generated.GetSensorLatestParams) (generated.SensorLatest, int, error) {
+	if params.Kind != nil {
+		if err := checkSensorKind(*params.Kind); err != nil {
+			return generated.SensorLatest{}, http.StatusBadRequest, err
+		}
+	}
+
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.SensorLatest{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.SensorLatest{}, http.StatusInternalServerError, err
+	}
+
+	readings, err := s.Repository.GetLatestSensorReadings(ctx, estateId, (*string)(params.Kind))
+	if err != nil {
+		return generated.SensorLatest{}, http.StatusInternalServerError, err
+	}
+
+	// the readings come in the order of their plots, the readings of a plot follow each other
+	plots := []generated.SensorPlot{}
+	for i := range readings {
+		reading := readings[i]
+		if len(plots) == 0 || *plots[len(plots)-1].X != reading.X || *plots[len(plots)-1].Y != reading.Y {
+			plots = append(plots, generated.SensorPlot{X: &reading.X, Y: &reading.Y, Readings: &[]generated.SensorValue{}})
+		}
+		kind := generated.SensorKind(reading.Kind)
+		plot := plots[len(plots)-1].Readings
+		*plot = append(*plot, generated.SensorValue{
+			SensorId:   &reading.SensorId,
+			Kind:       &kind,
+			Value:      &reading.Value,
+			RecordedAt: &reading.RecordedAt,
+		})
+	}
+	return generated.SensorLatest{Plots: &plots}, http.StatusOK, nil
+}
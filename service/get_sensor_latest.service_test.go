@@ -0,0 +1,109 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetSensorLatest(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	firstID, secondID, thirdID := uuid.New(), uuid.New(), uuid.New()
+	recordedAt := time.Date(2026, 10, 1, 8, 0, 0, 0, time.UTC)
+	soilMoisture, temperature := generated.SoilMoisture, generated.Temperature
+	two, three, four := 2, 3, 4
+	moisture, warm, dry := 31.5, 24.0, 12.5
+
+	tests := []struct {
+		name           string
+		params         generated.GetSensorLatestParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.SensorLatest
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Readings Per Plot",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetLatestSensorReadings(gomock.Any(), mockEstateID, nil).Return([]repository.LatestSensorReading{
+					{SensorId: firstID, Kind: "soil_moisture", X: 3, Y: 2, Value: moisture, RecordedAt: recordedAt},
+					{SensorId: secondID, Kind: "temperature", X: 3, Y: 2, Value: warm, RecordedAt: recordedAt},
+					{SensorId: thirdID, Kind: "soil_moisture", X: 4, Y: 2, Value: dry, RecordedAt: recordedAt},
+				}, nil)
+			},
+			expectedResp: generated.SensorLatest{Plots: &[]generated.SensorPlot{
+				{X: &three, Y: &two, Readings: &[]generated.SensorValue{
+					{SensorId: &firstID, Kind: &soilMoisture, Value: &moisture, RecordedAt: &recordedAt},
+					{SensorId: &secondID, Kind: &temperature, Value: &warm, RecordedAt: &recordedAt},
+				}},
+				{X: &four, Y: &two, Readings: &[]generated.SensorValue{
+					{SensorId: &thirdID, Kind: &soilMoisture, Value: &dry, RecordedAt: &recordedAt},
+				}},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "No Reading Of The Kind",
+			params: generated.GetSensorLatestParams{Kind: &temperature},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				kind := "temperature"
+				mockRepo.EXPECT().GetLatestSensorReadings(gomock.Any(), mockEstateID, &kind).Return(nil, nil)
+			},
+			expectedResp:   generated.SensorLatest{Plots: &[]generated.SensorPlot{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Kind",
+			params:         generated.GetSensorLatestParams{Kind: &[]generated.SensorKind{"humidity"}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("kind must be one of soil_moisture, temperature"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetSensorLatest(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetSensorSeries(ctx context.Context, estateId uuid.UUID, params generated.GetSensorSeriesParams) (generated.SensorSeries, int, error) {
+	filter, err := sensorFilter(&params.Kind, params.From, params.To)
+	if err != nil {
+		return generated.SensorSeries{}, http.StatusBadRequest, err
+	}
+
+	bucket := generated.SensorBucketSizeHour
+	if params.Bucket != nil {
+		bucket = *params.Bucket
+	}
+	size, ok := sensorBucketSizes[bucket]
+	if !ok {
+		return generated.SensorSeries{}, http.StatusBadRequest, fmt.Errorf("bucket must be one of %s, %s, %s", generated.SensorBucketSizeHour, generated.SensorBucketSizeDay, generated.SensorBucketSizeWeek)
+	}
+	if sensorBucketCount(filter, size) > maxSensorBuckets {
+		return generated.SensorSeries{}, http.StatusBadRequest, fmt.Errorf("a series cannot have more than %d buckets", maxSensorBuckets)
+	}
+	if (params.X == nil) != (params.Y == nil) {
+		return generated.SensorSeries{}, http.StatusBadRequest, errors.New("x and y must be given together")
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.SensorSeries{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.SensorSeries{}, http.StatusInternalServerError, err
+	}
+	if params.X != nil {
+		if !inEstate(estate, *params.X, *params.Y) {
+			return generated.SensorSeries{}, http.StatusBadRequest, errors.New("x or y is out of range")
+		}
+		filter.X, filter.Y = params.X, params.Y
+	}
+
+	entities, err := s.Repository.GetSensorSeries(ctx, estateId, string(bucket), filter)
+	if err != nil {
+		return generated.SensorSeries{}, http.StatusInternalServerError, err
+	}
+
+	buckets := make([]generated.SensorBucket, len(entities))
+	for i := range entities {
+		buckets[i] = generated.SensorBucket{
+			Start:    &entities[i].Start,
+			Readings: &entities[i].Readings,
+			Min:      &entities[i].Min,
+			Max:      &entities[i].Max,
+			Mean:     &entities[i].Mean,
+		}
+	}
+	return generated.SensorSeries{Kind: &params.Kind, Bucket: &bucket, From: &filter.From, To: &filter.To, Buckets: &buckets}, http.StatusOK, nil
+}
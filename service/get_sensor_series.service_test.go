@@ -0,0 +1,127 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetSensorSeries(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 5}
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(2 * 24 * time.Hour)
+	temperature := generated.Temperature
+	day, hour := generated.SensorBucketSizeDay, generated.SensorBucketSizeHour
+	x, y := 3, 2
+
+	tests := []struct {
+		name           string
+		params         generated.GetSensorSeriesParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.SensorSeries
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Daily On A Plot",
+			params: generated.GetSensorSeriesParams{Kind: temperature, Bucket: &day, X: &x, Y: &y, From: &from, To: &to},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				kind := "temperature"
+				mockRepo.EXPECT().GetSensorSeries(gomock.Any(), mockEstateID, "day", repository.SensorFilter{Kind: &kind, X: &x, Y: &y, From: from, To: to}).
+					Return([]repository.SensorBucket{{Start: from, Readings: 24, Min: 18.5, Max: 31, Mean: 24.25}}, nil)
+			},
+			expectedResp: generated.SensorSeries{Kind: &temperature, Bucket: &day, From: &from, To: &to, Buckets: &[]generated.SensorBucket{
+				{Start: &from, Readings: &[]int{24}[0], Min: &[]float64{18.5}[0], Max: &[]float64{31}[0], Mean: &[]float64{24.25}[0]},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Hourly Over The Estate By Default",
+			params: generated.GetSensorSeriesParams{Kind: temperature, From: &from, To: &to},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				kind := "temperature"
+				mockRepo.EXPECT().GetSensorSeries(gomock.Any(), mockEstateID, "hour", repository.SensorFilter{Kind: &kind, From: from, To: to}).Return(nil, nil)
+			},
+			expectedResp:   generated.SensorSeries{Kind: &temperature, Bucket: &hour, From: &from, To: &to, Buckets: &[]generated.SensorBucket{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Too Many Buckets",
+			params:         generated.GetSensorSeriesParams{Kind: temperature, From: &from, To: &[]time.Time{from.AddDate(2, 0, 0)}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("a series cannot have more than 10000 buckets"),
+		},
+		{
+			name:           "Unknown Bucket",
+			params:         generated.GetSensorSeriesParams{Kind: temperature, Bucket: &[]generated.SensorBucketSize{"month"}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("bucket must be one of hour, day, week"),
+		},
+		{
+			name:           "X Without Y",
+			params:         generated.GetSensorSeriesParams{Kind: temperature, X: &x},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("x and y must be given together"),
+		},
+		{
+			name:   "Plot Out Of Range",
+			params: generated.GetSensorSeriesParams{Kind: temperature, X: &[]int{11}[0], Y: &y},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("x or y is out of range"),
+		},
+		{
+			name:   "Estate Not Found",
+			params: generated.GetSensorSeriesParams{Kind: temperature},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetSensorSeries(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetSensorStats(ctx context.Context, estateId uuid.UUID, params generated.GetSensorStatsParams) (generated.SensorStatsReport, int, error) {
+	filter, err := sensorFilter(params.Kind, params.From, params.To)
+	if err != nil {
+		return generated.SensorStatsReport{}, http.StatusBadRequest, err
+	}
+
+	_, err = s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.SensorStatsReport{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.SensorStatsReport{}, http.StatusInternalServerError, err
+	}
+
+	entities, err := s.Repository.GetSensorStats(ctx, estateId, filter)
+	if err != nil {
+		return generated.SensorStatsReport{}, http.StatusInternalServerError, err
+	}
+
+	stats := make([]generated.SensorKindStats, len(entities))
+	for i := range entities {
+		kind := generated.SensorKind(entities[i].Kind)
+		stats[i] = generated.SensorKindStats{
+			Kind:     &kind,
+			Sensors:  &entities[i].Sensors,
+			Readings: &entities[i].Readings,
+			Min:      &entities[i].Min,
+			Max:      &entities[i].Max,
+			Mean:     &entities[i].Mean,
+		}
+	}
+	return generated.SensorStatsReport{From: &filter.From, To: &filter.To, Stats: &stats}, http.StatusOK, nil
+}
@@ -0,0 +1,110 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetSensorStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	from := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+	soilMoisture := generated.SoilMoisture
+
+	tests := []struct {
+		name           string
+		params         generated.GetSensorStatsParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   func(resp generated.SensorStatsReport)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Soil Moisture Of A Week",
+			params: generated.GetSensorStatsParams{Kind: &soilMoisture, From: &from, To: &to},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				kind := "soil_moisture"
+				mockRepo.EXPECT().GetSensorStats(gomock.Any(), mockEstateID, repository.SensorFilter{Kind: &kind, From: from, To: to}).
+					Return([]repository.SensorStats{{Kind: kind, Sensors: 2, Readings: 336, Min: 18.5, Max: 42, Mean: 30.25}}, nil)
+			},
+			expectedResp: func(resp generated.SensorStatsReport) {
+				assert.Equal(t, generated.SensorStatsReport{From: &from, To: &to, Stats: &[]generated.SensorKindStats{
+					{Kind: &soilMoisture, Sensors: &[]int{2}[0], Readings: &[]int{336}[0], Min: &[]float64{18.5}[0], Max: &[]float64{42}[0], Mean: &[]float64{30.25}[0]},
+				}}, resp)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Last 24 Hours By Default",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensorStats(gomock.Any(), mockEstateID, gomock.Any()).DoAndReturn(func(ctx context.Context, estateID uuid.UUID, filter repository.SensorFilter) ([]repository.SensorStats, error) {
+					require.Nil(t, filter.Kind)
+					require.Equal(t, 24*time.Hour, filter.To.Sub(filter.From))
+					require.WithinDuration(t, time.Now(), filter.To, time.Minute)
+					return nil, nil
+				})
+			},
+			expectedResp: func(resp generated.SensorStatsReport) {
+				assert.Empty(t, *resp.Stats)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "From After To",
+			params:         generated.GetSensorStatsParams{From: &to, To: &from},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("from must be before to"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetSensorStats(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			if tt.expectedResp != nil {
+				tt.expectedResp(resp)
+			}
+		})
+	}
+}
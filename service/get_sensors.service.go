@@ -0,0 +1,33 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetSensors(ctx context.Context, estateId uuid.UUID) (generated.SensorList, int, error) {
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.SensorList{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.SensorList{}, http.StatusInternalServerError, err
+	}
+
+	entities, err := s.Repository.GetSensors(ctx, estateId)
+	if err != nil {
+		return generated.SensorList{}, http.StatusInternalServerError, err
+	}
+
+	sensors := make([]generated.Sensor, len(entities))
+	for i := range entities {
+		sensors[i] = sensorResponse(entities[i])
+	}
+	return generated.SensorList{Sensors: &sensors}, http.StatusOK, nil
+}
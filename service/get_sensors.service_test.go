@@ -0,0 +1,100 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetSensors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+	soilMoisture, temperature := generated.SoilMoisture, generated.Temperature
+	two, three, four := 2, 3, 4
+	name := "North gate"
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.SensorList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Sensors Of The Estate",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensors(gomock.Any(), mockEstateID).Return([]repository.SensorEntity{
+					{ID: firstID, EstateId: mockEstateID, Kind: "soil_moisture", X: 3, Y: 2, Name: &name},
+					{ID: secondID, EstateId: mockEstateID, Kind: "temperature", X: 4, Y: 2},
+				}, nil)
+			},
+			expectedResp: generated.SensorList{Sensors: &[]generated.Sensor{
+				{Id: &firstID, Kind: &soilMoisture, X: &three, Y: &two, Name: &name},
+				{Id: &secondID, Kind: &temperature, X: &four, Y: &two},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "No Sensor",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensors(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp:   generated.SensorList{Sensors: &[]generated.Sensor{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetSensors(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetSensors(mockContext, mockEstateID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,37 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetSpecies(ctx context.Context, id uuid.UUID) (generated.Species, int, error) {
+	species, err := s.Repository.GetSpecies(ctx, id)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.Species{}, http.StatusNotFound, errors.New("species not found")
+		}
+		return generated.Species{}, http.StatusInternalServerError, err
+	}
+
+	return speciesOf(species), http.StatusOK, nil
+}
+
+func (s *Service) GetSpeciesCatalog(ctx context.Context) (generated.SpeciesList, int, error) {
+	catalog, err := s.Repository.GetSpeciesCatalog(ctx)
+	if err != nil {
+		return generated.SpeciesList{}, http.StatusInternalServerError, err
+	}
+
+	species := make([]generated.Species, len(catalog))
+	for i := range catalog {
+		species[i] = speciesOf(catalog[i])
+	}
+	return generated.SpeciesList{Species: &species}, http.StatusOK, nil
+}
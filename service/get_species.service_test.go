@@ -0,0 +1,108 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetSpecies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockUUID := uuid.New()
+	growthRate := 0.6
+	mockSpecies := repository.SpeciesEntity{ID: mockUUID, Name: "Oil palm", MaxHeight: 20, GrowthRate: &growthRate}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.Species
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Get",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockUUID).Return(mockSpecies, nil)
+			},
+			expectedResp: generated.Species{
+				Id:         &mockSpecies.ID,
+				Name:       &mockSpecies.Name,
+				MaxHeight:  &mockSpecies.MaxHeight,
+				GrowthRate: &growthRate,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Species Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockUUID).Return(repository.SpeciesEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.Species{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("species not found"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockUUID).Return(repository.SpeciesEntity{}, errors.New("repository error"))
+			},
+			expectedResp:   generated.Species{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetSpecies(mockContext, mockUUID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
+
+func TestService_GetSpeciesCatalog(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := repository.NewMockRepositoryInterface(ctrl)
+	palm := repository.SpeciesEntity{ID: uuid.New(), Name: "Oil palm", MaxHeight: 20}
+	teak := repository.SpeciesEntity{ID: uuid.New(), Name: "Teak", MaxHeight: 40}
+	mockRepo.EXPECT().GetSpeciesCatalog(gomock.Any()).Return([]repository.SpeciesEntity{palm, teak}, nil)
+
+	svs := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+	resp, status, err := svs.GetSpeciesCatalog(context.TODO())
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, generated.SpeciesList{Species: &[]generated.Species{
+		{Id: &palm.ID, Name: &palm.Name, MaxHeight: &palm.MaxHeight},
+		{Id: &teak.ID, Name: &teak.Name, MaxHeight: &teak.MaxHeight},
+	}}, resp)
+}
@@ -0,0 +1,69 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+const hoursPerYear = 365.25 * 24
+
+func (s *Service) GetTreeGrowth(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID) (generated.TreeGrowthResponse, int, error) {
+	plot, err := s.Repository.GetPlotByID(ctx, estateId, treeId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TreeGrowthResponse{}, http.StatusNotFound, errors.New("tree not found")
+		}
+		return generated.TreeGrowthResponse{}, http.StatusInternalServerError, err
+	}
+
+	measurements, err := s.Repository.GetTreeMeasurements(ctx, plot.ID)
+	if err != nil {
+		return generated.TreeGrowthResponse{}, http.StatusInternalServerError, err
+	}
+
+	x := int(plot.X)
+	y := int(plot.Y)
+	curve := make([]generated.TreeMeasurement, 0, len(measurements))
+	for i := range measurements {
+		point := generated.TreeMeasurement{
+			Height:     &measurements[i].Height,
+			MeasuredAt: &measurements[i].MeasuredAt,
+		}
+		if i > 0 {
+			point.GrowthRate = growthRate(measurements[i-1], measurements[i])
+		}
+		curve = append(curve, point)
+	}
+
+	resp := generated.TreeGrowthResponse{
+		Id:           &plot.ID,
+		X:            &x,
+		Y:            &y,
+		Height:       &plot.TreeHeight,
+		Measurements: &curve,
+	}
+	if len(measurements) > 1 {
+		resp.GrowthRate = growthRate(measurements[0], measurements[len(measurements)-1])
+	}
+
+	return resp, http.StatusOK, nil
+}
+
+// growthRate returns the growth in meters per year between two measurements,
+// nil when both were taken at the same time.
+func growthRate(from, to repository.TreeMeasurementEntity) *float64 {
+	years := to.MeasuredAt.Sub(from.MeasuredAt).Hours() / hoursPerYear
+	if years <= 0 {
+		return nil
+	}
+
+	rate := float64(to.Height-from.Height) / years
+	return &rate
+}
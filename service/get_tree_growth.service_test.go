@@ -0,0 +1,106 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetTreeGrowth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockContext := context.TODO()
+	planted := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	firstYear := planted.Add(365.25 * 24 * time.Hour)
+	secondYear := firstYear.Add(365.25 * 24 * time.Hour)
+
+	mockPlot := repository.PlotEntity{ID: mockTreeID, EstateId: mockEstateID, X: 4, Y: 2, TreeHeight: 8}
+	mockMeasurements := []repository.TreeMeasurementEntity{
+		{PlotId: mockTreeID, Height: 2, MeasuredAt: planted},
+		{PlotId: mockTreeID, Height: 6, MeasuredAt: firstYear},
+		{PlotId: mockTreeID, Height: 8, MeasuredAt: secondYear},
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.TreeGrowthResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Scenario",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetTreeMeasurements(gomock.Any(), mockTreeID).Return(mockMeasurements, nil)
+			},
+			expectedResp: generated.TreeGrowthResponse{
+				Id:         &mockTreeID,
+				X:          &[]int{4}[0],
+				Y:          &[]int{2}[0],
+				Height:     &[]int{8}[0],
+				GrowthRate: &[]float64{3}[0],
+				Measurements: &[]generated.TreeMeasurement{
+					{Height: &[]int{2}[0], MeasuredAt: &planted},
+					{Height: &[]int{6}[0], MeasuredAt: &firstYear, GrowthRate: &[]float64{4}[0]},
+					{Height: &[]int{8}[0], MeasuredAt: &secondYear, GrowthRate: &[]float64{2}[0]},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Tree Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.TreeGrowthResponse{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("tree not found"),
+		},
+		{
+			name: "Measurements Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetTreeMeasurements(gomock.Any(), mockTreeID).Return(nil, errors.New("some repository error"))
+			},
+			expectedResp:   generated.TreeGrowthResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, status, err := service.GetTreeGrowth(mockContext, mockEstateID, mockTreeID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetTreeInspections(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID) (generated.TreeInspectionList, int, error) {
+	plot, err := s.Repository.GetPlotByID(ctx, estateId, treeId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TreeInspectionList{}, http.StatusNotFound, errors.New("tree not found")
+		}
+		return generated.TreeInspectionList{}, http.StatusInternalServerError, err
+	}
+
+	inspections, err := s.Repository.GetTreeInspections(ctx, plot.ID)
+	if err != nil {
+		return generated.TreeInspectionList{}, http.StatusInternalServerError, err
+	}
+
+	list := make([]generated.TreeInspection, len(inspections))
+	for i := range inspections {
+		list[i] = generated.TreeInspection{
+			Id:           &inspections[i].ID,
+			HealthStatus: (*generated.HealthStatus)(&inspections[i].HealthStatus),
+			Notes:        inspections[i].Notes,
+			ImageRef:     inspections[i].ImageRef,
+			Height:       inspections[i].Height,
+			InspectedAt:  &inspections[i].InspectedAt,
+		}
+	}
+
+	return generated.TreeInspectionList{
+		Id:           &plot.ID,
+		HealthStatus: (*generated.HealthStatus)(plot.HealthStatus),
+		Inspections:  &list,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,105 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetTreeInspections(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockTreeID := uuid.New()
+	mockFirstID := uuid.New()
+	mockSecondID := uuid.New()
+	mockContext := context.TODO()
+	firstPass := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	secondPass := firstPass.Add(30 * 24 * time.Hour)
+	notes := "yellowing fronds"
+	image := "s3://inspections/plot-4-2.jpg"
+	diseased := "diseased"
+
+	mockPlot := repository.PlotEntity{ID: mockTreeID, EstateId: mockEstateID, X: 4, Y: 2, TreeHeight: 8, HealthStatus: &diseased}
+	mockInspections := []repository.TreeInspectionEntity{
+		{ID: mockFirstID, PlotId: mockTreeID, HealthStatus: "stressed", Notes: &notes, InspectedAt: firstPass},
+		{ID: mockSecondID, PlotId: mockTreeID, HealthStatus: "diseased", ImageRef: &image, Height: &[]int{8}[0], InspectedAt: secondPass},
+	}
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.TreeInspectionList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Scenario",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetTreeInspections(gomock.Any(), mockTreeID).Return(mockInspections, nil)
+			},
+			expectedResp: generated.TreeInspectionList{
+				Id:           &mockTreeID,
+				HealthStatus: &[]generated.HealthStatus{generated.Diseased}[0],
+				Inspections: &[]generated.TreeInspection{
+					{Id: &mockFirstID, HealthStatus: &[]generated.HealthStatus{generated.Stressed}[0], Notes: &notes, InspectedAt: &firstPass},
+					{Id: &mockSecondID, HealthStatus: &[]generated.HealthStatus{generated.Diseased}[0], ImageRef: &image, Height: &[]int{8}[0], InspectedAt: &secondPass},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Tree Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(nil, gorm.ErrRecordNotFound)
+			},
+			expectedResp:   generated.TreeInspectionList{},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("tree not found"),
+		},
+		{
+			name: "Inspections Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetPlotByID(gomock.Any(), mockEstateID, mockTreeID).Return(&mockPlot, nil)
+				mockRepo.EXPECT().GetTreeInspections(gomock.Any(), mockTreeID).Return(nil, errors.New("some repository error"))
+			},
+			expectedResp:   generated.TreeInspectionList{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("some repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			service := &service.Service{
+				Repository: mockRepo,
+			}
+
+			resp, status, err := service.GetTreeInspections(mockContext, mockEstateID, mockTreeID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) GetWorkOrder(ctx context.Context, estateId uuid.UUID, orderId uuid.UUID) (generated.WorkOrder, int, error) {
+	order, err := s.Repository.GetWorkOrder(ctx, estateId, orderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.WorkOrder{}, http.StatusNotFound, errors.New("work order not found")
+		}
+		return generated.WorkOrder{}, http.StatusInternalServerError, err
+	}
+
+	return workOrderResponse(order), http.StatusOK, nil
+}
@@ -0,0 +1,86 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetWorkOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	mockOrderID := uuid.New()
+	mockTreeID := uuid.New()
+	three, one := 3, 1
+	height := 2
+	completedAt := time.Date(2026, 9, 14, 8, 0, 0, 0, time.UTC)
+	replant, completed := generated.Replant, generated.Completed
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.WorkOrder
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Completed Replant Of A Tree",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(repository.WorkOrderEntity{
+					ID: mockOrderID, EstateId: mockEstateID, Kind: "replant", Status: "completed", Shape: "tree", TreeId: &mockTreeID, Height: &height,
+					Areas:       []repository.WorkOrderAreaEntity{{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1}},
+					CompletedAt: &completedAt, CreatedAt: completedAt, UpdatedAt: completedAt,
+				}, nil)
+			},
+			expectedResp: generated.WorkOrder{
+				Id: &mockOrderID, Kind: &replant, Status: &completed, TreeId: &mockTreeID, Height: &height,
+				Plots:       &[]generated.PlotPosition{{X: &three, Y: &one}},
+				CompletedAt: &completedAt, CreatedAt: &completedAt, UpdatedAt: &completedAt,
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Work Order Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(repository.WorkOrderEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("work order not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetWorkOrder(mockContext, mockEstateID, mockOrderID)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) GetWorkOrders(ctx context.Context, estateId uuid.UUID, params generated.GetWorkOrdersParams) (generated.WorkOrderList, int, error) {
+	filter := repository.WorkOrderFilter{Statuses: openWorkOrderStatuses, Assignee: params.Assignee}
+	if params.Status != nil {
+		filter.Statuses = nil
+		for _, status := range *params.Status {
+			if err := checkWorkOrderStatus("status", status); err != nil {
+				return generated.WorkOrderList{}, http.StatusBadRequest, err
+			}
+			filter.Statuses = append(filter.Statuses, string(status))
+		}
+	}
+	if params.Kind != nil {
+		if err := checkWorkOrderKind(*params.Kind); err != nil {
+			return generated.WorkOrderList{}, http.StatusBadRequest, err
+		}
+		filter.Kind = (*string)(params.Kind)
+	}
+
+	_, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.WorkOrderList{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.WorkOrderList{}, http.StatusInternalServerError, err
+	}
+
+	entities, err := s.Repository.GetWorkOrders(ctx, estateId, filter)
+	if err != nil {
+		return generated.WorkOrderList{}, http.StatusInternalServerError, err
+	}
+
+	orders := make([]generated.WorkOrder, len(entities))
+	for i := range entities {
+		orders[i] = workOrderResponse(entities[i])
+	}
+	return generated.WorkOrderList{WorkOrders: &orders}, http.StatusOK, nil
+}
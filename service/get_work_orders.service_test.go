@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_GetWorkOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockEstateID := uuid.New()
+	firstID, secondID := uuid.New(), uuid.New()
+	one, two, three := 1, 2, 3
+	assignee := "Budi"
+	createdAt := time.Date(2026, 9, 14, 8, 0, 0, 0, time.UTC)
+	prune, spray := generated.Prune, generated.Spray
+	open, assigned := generated.Open, generated.Assigned
+	mockOrders := []repository.WorkOrderEntity{
+		{ID: firstID, EstateId: mockEstateID, Kind: "prune", Status: "open", Shape: "rectangle", Areas: []repository.WorkOrderAreaEntity{{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}}, CreatedAt: createdAt, UpdatedAt: createdAt},
+		{ID: secondID, EstateId: mockEstateID, Kind: "spray", Status: "assigned", Shape: "plots", Assignee: &assignee, Areas: []repository.WorkOrderAreaEntity{
+			{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1},
+			{MinX: 2, MinY: 3, MaxX: 2, MaxY: 3},
+		}, CreatedAt: createdAt, UpdatedAt: createdAt},
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.GetWorkOrdersParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.WorkOrderList
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Open Orders By Default",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				mockRepo.EXPECT().GetWorkOrders(gomock.Any(), mockEstateID, repository.WorkOrderFilter{Statuses: []string{"open", "assigned", "in_progress"}}).Return(mockOrders, nil)
+			},
+			expectedResp: generated.WorkOrderList{WorkOrders: &[]generated.WorkOrder{
+				{Id: &firstID, Kind: &prune, Status: &open, Rectangle: &generated.PlotRectangle{MinX: 1, MinY: 1, MaxX: 2, MaxY: 3}, CreatedAt: &createdAt, UpdatedAt: &createdAt},
+				{Id: &secondID, Kind: &spray, Status: &assigned, Assignee: &assignee, Plots: &[]generated.PlotPosition{{X: &three, Y: &one}, {X: &two, Y: &three}}, CreatedAt: &createdAt, UpdatedAt: &createdAt},
+			}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Completed Orders Of An Assignee",
+			params: generated.GetWorkOrdersParams{Status: &[]generated.WorkOrderStatus{generated.Completed}, Kind: &prune, Assignee: &assignee},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID}, nil)
+				kind := "prune"
+				mockRepo.EXPECT().GetWorkOrders(gomock.Any(), mockEstateID, repository.WorkOrderFilter{Statuses: []string{"completed"}, Kind: &kind, Assignee: &assignee}).Return(nil, nil)
+			},
+			expectedResp:   generated.WorkOrderList{WorkOrders: &[]generated.WorkOrder{}},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unknown Status",
+			params:         generated.GetWorkOrdersParams{Status: &[]generated.WorkOrderStatus{generated.Open, "done"}},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("status must be one of open, assigned, in_progress, completed, cancelled"),
+		},
+		{
+			name:           "Unknown Kind",
+			params:         generated.GetWorkOrdersParams{Kind: &[]generated.WorkOrderKind{"weed"}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("kind must be one of prune, spray, replant, remove"),
+		},
+		{
+			name: "Estate Not Found",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.GetWorkOrders(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+package service
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxForecastYears bounds how far ahead the trees of an estate are projected.
+const maxForecastYears = 100
+
+/*
+growthModel is the logistic growth of the trees of a species: a tree of height h grows by rate*h*(1-h/limit) meters
+per year and levels off at the limit, the max height of the species.
+*/
+type growthModel struct {
+	limit  int
+	rate   float64
+	source generated.SpeciesForecastFittedFrom
+}
+
+// project returns the height a tree reaches after the given years, a tree at or above the limit keeps its height.
+func (m growthModel) project(height int, years float64) int {
+	if height >= m.limit || m.rate <= 0 || years <= 0 {
+		return height
+	}
+	h := float64(m.limit) / (1 + float64(m.limit-height)/float64(height)*math.Exp(-m.rate*years))
+	return int(math.Round(h))
+}
+
+// logit linearizes the logistic growth, the logit of the height of a tree grows by the rate every year.
+func (m growthModel) logit(height int) float64 {
+	h := math.Max(0.5, math.Min(float64(height), float64(m.limit)-0.5))
+	return math.Log(h / math.Max(0.5, float64(m.limit)-h))
+}
+
+// treeHistory is the measurements of a tree, oldest first.
+type treeHistory []repository.TreeMeasurementEntity
+
+// treeHistories groups the measurements of an estate by tree, they come tree by tree, oldest first.
+func treeHistories(measurements []repository.TreeMeasurementEntity) map[uuid.UUID]treeHistory {
+	histories := map[uuid.UUID]treeHistory{}
+	for i := 0; i < len(measurements); {
+		j := i
+		for j < len(measurements) && measurements[j].PlotId == measurements[i].PlotId {
+			j++
+		}
+		histories[measurements[i].PlotId] = measurements[i:j]
+		i = j
+	}
+	return histories
+}
+
+// removedTrees returns the trees removed from the estate as plots of their species, by id. Their history still
+// counts toward the growth model of their species.
+func removedTrees(histories map[uuid.UUID]treeHistory) []repository.PlotEntity {
+	var trees []repository.PlotEntity
+	for id, history := range histories {
+		if last := history[len(history)-1]; last.RemovedAt != nil {
+			trees = append(trees, repository.PlotEntity{ID: id, SpeciesId: last.SpeciesId})
+		}
+	}
+	sort.Slice(trees, func(i, j int) bool { return trees[i].ID.String() < trees[j].ID.String() })
+	return trees
+}
+
+/*
+fitGrowthModels returns the growth model of every species of the trees, by species id and the nil uuid for the
+trees without species. The rate is the slope of the logit of the measured heights over the years, fitted by least
+squares across the trees of the species with an intercept per tree so trees of different ages share a rate. When the
+history holds no growth, the rate follows from the growth rate of the species in the catalog, taken as the growth
+at mid height where the logistic growth is the fastest.
+*/
+func fitGrowthModels(plots []repository.PlotEntity, histories map[uuid.UUID]treeHistory, catalog map[uuid.UUID]repository.SpeciesEntity) map[uuid.UUID]growthModel {
+	models := map[uuid.UUID]growthModel{}
+	sxx := map[uuid.UUID]float64{}
+	sxy := map[uuid.UUID]float64{}
+	for _, plot := range plots {
+		key := speciesKey(plot)
+		model, ok := models[key]
+		if !ok {
+			model = growthModel{limit: defaultMaxTreeHeight, source: generated.None}
+			if species, found := catalog[key]; found {
+				model.limit = species.MaxHeight
+			}
+			models[key] = model
+		}
+
+		history := histories[plot.ID]
+		if len(history) < 2 {
+			continue
+		}
+		years := make([]float64, len(history))
+		meanYears, meanLogit := 0.0, 0.0
+		for i := range history {
+			years[i] = history[i].MeasuredAt.Sub(history[0].MeasuredAt).Hours() / hoursPerYear
+			meanYears += years[i] / float64(len(history))
+			meanLogit += model.logit(history[i].Height) / float64(len(history))
+		}
+		for i := range history {
+			sxx[key] += (years[i] - meanYears) * (years[i] - meanYears)
+			sxy[key] += (years[i] - meanYears) * (model.logit(history[i].Height) - meanLogit)
+		}
+	}
+
+	for key, model := range models {
+		if sxx[key] > 0 && sxy[key] > 0 {
+			model.rate = sxy[key] / sxx[key]
+			model.source = generated.History
+		} else if species, found := catalog[key]; found && species.GrowthRate != nil && *species.GrowthRate > 0 {
+			model.rate = 4 * *species.GrowthRate / float64(model.limit)
+			model.source = generated.Catalog
+		}
+		models[key] = model
+	}
+	return models
+}
+
+// speciesKey returns the species id of a tree, the nil uuid for a tree without species.
+func speciesKey(plot repository.PlotEntity) uuid.UUID {
+	if plot.SpeciesId == nil {
+		return uuid.Nil
+	}
+	return *plot.SpeciesId
+}
+
+/*
+projectTrees returns the trees with their height projected at the given time from their latest measurement, or from
+their planting when they were never measured. The trees are copies, the stored ones are left as they are.
+*/
+func projectTrees(plots []repository.PlotEntity, histories map[uuid.UUID]treeHistory, models map[uuid.UUID]growthModel, at time.Time) []repository.PlotEntity {
+	projected := make([]repository.PlotEntity, len(plots))
+	for i, plot := range plots {
+		since := plot.CreatedAt
+		if history := histories[plot.ID]; len(history) > 0 {
+			since = history[len(history)-1].MeasuredAt
+		}
+		plot.TreeHeight = models[speciesKey(plot)].project(plot.TreeHeight, at.Sub(since).Hours()/hoursPerYear)
+		projected[i] = plot
+	}
+	return projected
+}
+
+// heightStats returns the count, min, max and median of the heights of trees, the median truncated like the stored stats.
+func heightStats(plots []repository.PlotEntity) (int, int, int, int) {
+	if len(plots) == 0 {
+		return 0, 0, 0, 0
+	}
+	heights := make([]int, len(plots))
+	for i := range plots {
+		heights[i] = plots[i].TreeHeight
+	}
+	sort.Ints(heights)
+
+	n := len(heights)
+	median := heights[n/2]
+	if n%2 == 0 {
+		median = (heights[n/2-1] + heights[n/2]) / 2
+	}
+	return n, heights[0], heights[n-1], median
+}
+
+// speciesForecasts returns the projected stats and the growth model per species, by species name and the trees without species last.
+func speciesForecasts(projected []repository.PlotEntity, models map[uuid.UUID]growthModel, catalog map[uuid.UUID]repository.SpeciesEntity) []generated.SpeciesForecast {
+	trees := map[uuid.UUID][]repository.PlotEntity{}
+	for _, plot := range projected {
+		trees[speciesKey(plot)] = append(trees[speciesKey(plot)], plot)
+	}
+
+	keys := make([]uuid.UUID, 0, len(trees))
+	for key := range trees {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		if (keys[i] == uuid.Nil) != (keys[j] == uuid.Nil) {
+			return keys[j] == uuid.Nil
+		}
+		if a, b := catalog[keys[i]].Name, catalog[keys[j]].Name; a != b {
+			return a < b
+		}
+		return keys[i].String() < keys[j].String()
+	})
+
+	forecasts := make([]generated.SpeciesForecast, len(keys))
+	for i, key := range keys {
+		count, lowest, highest, median := heightStats(trees[key])
+		model := models[key]
+		forecasts[i] = generated.SpeciesForecast{
+			Count:        &count,
+			Max:          &highest,
+			Min:          &lowest,
+			Median:       &median,
+			LogisticRate: &model.rate,
+			FittedFrom:   &model.source,
+		}
+		if key != uuid.Nil {
+			id, name := key, catalog[key].Name
+			forecasts[i].SpeciesId = &id
+			forecasts[i].Name = &name
+		}
+	}
+	return forecasts
+}
@@ -0,0 +1,84 @@
+package service
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestGrowthModel_Project(t *testing.T) {
+	model := growthModel{limit: 20, rate: math.Log(3)}
+
+	// a tree at mid height grows to 3/4 of the limit once its logit grew by ln(3)
+	assert.Equal(t, 15, model.project(10, 1))
+	assert.Equal(t, 20, model.project(19, 50))
+	// the trees at the limit, or taller from before the limit, keep their height
+	assert.Equal(t, 20, model.project(20, 1))
+	assert.Equal(t, 25, model.project(25, 1))
+	assert.Equal(t, 10, model.project(10, 0))
+	assert.Equal(t, 10, growthModel{limit: 20}.project(10, 1))
+}
+
+func TestFitGrowthModels(t *testing.T) {
+	year := time.Duration(hoursPerYear) * time.Hour
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	speciesID := uuid.New()
+	growth := 1.0
+	catalog := map[uuid.UUID]repository.SpeciesEntity{speciesID: {ID: speciesID, MaxHeight: 20, GrowthRate: &growth}}
+
+	// a young and an old tree of the species grow at the same rate, a tree without species is measured once
+	young, old, plain := uuid.New(), uuid.New(), uuid.New()
+	plots := []repository.PlotEntity{
+		{ID: young, SpeciesId: &speciesID},
+		{ID: old, SpeciesId: &speciesID},
+		{ID: plain},
+	}
+	histories := treeHistories([]repository.TreeMeasurementEntity{
+		{PlotId: young, Height: 2, MeasuredAt: start},
+		{PlotId: young, Height: 5, MeasuredAt: start.Add(2 * year)},
+		{PlotId: old, Height: 10, MeasuredAt: start},
+		{PlotId: old, Height: 15, MeasuredAt: start.Add(year)},
+		{PlotId: plain, Height: 4, MeasuredAt: start},
+	})
+	assert.Len(t, histories, 3)
+
+	models := fitGrowthModels(plots, histories, catalog)
+	// the logit of the young tree grows by ln(3) in 2 years, the one of the old tree in a year, the longer history weighs more
+	expected := 0.6 * math.Log(3)
+	assert.Equal(t, 20, models[speciesID].limit)
+	assert.Equal(t, generated.History, models[speciesID].source)
+	assert.InDelta(t, expected, models[speciesID].rate, 1e-9)
+	assert.Equal(t, growthModel{limit: defaultMaxTreeHeight, source: generated.None}, models[uuid.Nil])
+
+	// a removed tree keeps its history, its species is taken from its measurements
+	removedAt := start.Add(3 * year)
+	histories = treeHistories([]repository.TreeMeasurementEntity{
+		{PlotId: old, Height: 10, MeasuredAt: start, SpeciesId: &speciesID, RemovedAt: &removedAt},
+		{PlotId: old, Height: 15, MeasuredAt: start.Add(year), SpeciesId: &speciesID, RemovedAt: &removedAt},
+		{PlotId: plain, Height: 4, MeasuredAt: start},
+	})
+	removed := removedTrees(histories)
+	assert.Equal(t, []repository.PlotEntity{{ID: old, SpeciesId: &speciesID}}, removed)
+	models = fitGrowthModels(append(removed, plots[2]), histories, catalog)
+	assert.Equal(t, generated.History, models[speciesID].source)
+	assert.InDelta(t, math.Log(3), models[speciesID].rate, 1e-9)
+
+	// without growth in the history the rate grows a tree at mid height by the growth rate of the catalog
+	models = fitGrowthModels(plots, treeHistories(nil), catalog)
+	assert.Equal(t, growthModel{limit: 20, rate: 0.2, source: generated.Catalog}, models[speciesID])
+}
+
+func TestHeightStats(t *testing.T) {
+	plots := []repository.PlotEntity{{TreeHeight: 8}, {TreeHeight: 3}, {TreeHeight: 5}, {TreeHeight: 10}}
+	count, lowest, highest, median := heightStats(plots)
+	assert.Equal(t, []int{4, 3, 10, 6}, []int{count, lowest, highest, median})
+
+	count, lowest, highest, median = heightStats(nil)
+	assert.Equal(t, []int{0, 0, 0, 0}, []int{count, lowest, highest, median})
+}
@@ -0,0 +1,304 @@
+package service
+
+import (
+	"math"
+	"time"
+
+	"spgo/repository"
+)
+
+// inspectionTimeBudget bounds the time spent shortening an inspection route with 2-opt.
+const inspectionTimeBudget = 2 * time.Second
+
+// inspectionNode is a stop of an inspection route, the home plot the drone takes off from or a tree.
+type inspectionNode struct {
+	x, y     int
+	altitude int
+}
+
+/*
+canopyIndex finds the trees crossed by the straight track between two stops. The trees are bucketed in a grid of
+square cells of bucket plots and a track only looks at the trees of the cells it goes through, so a track costs
+about the square root of the number of trees whatever the size of the estate.
+*/
+type canopyIndex struct {
+	nodes      []inspectionNode
+	bucket     int
+	minX, minY int
+	columns    int
+	cells      [][]int
+}
+
+// newCanopyIndex indexes the trees among the nodes, the first node is the home plot and is not a tree.
+func newCanopyIndex(nodes []inspectionNode) canopyIndex {
+	c := canopyIndex{nodes: nodes, bucket: 1}
+	if len(nodes) < 2 {
+		return c
+	}
+
+	c.minX, c.minY = nodes[1].x, nodes[1].y
+	maxX, maxY := c.minX, c.minY
+	for _, node := range nodes[1:] {
+		c.minX, c.minY = min(c.minX, node.x), min(c.minY, node.y)
+		maxX, maxY = max(maxX, node.x), max(maxY, node.y)
+	}
+
+	// about one tree per cell when the trees are spread evenly
+	side := max(maxX-c.minX, maxY-c.minY) + 1
+	c.bucket = max(1, int(math.Ceil(float64(side)/math.Sqrt(float64(len(nodes)-1)))))
+	c.columns = (maxX-c.minX)/c.bucket + 1
+	c.cells = make([][]int, c.columns*((maxY-c.minY)/c.bucket+1))
+	for i := 1; i < len(nodes); i++ {
+		cell := c.cell(nodes[i].x, nodes[i].y)
+		c.cells[cell] = append(c.cells[cell], i)
+	}
+	return c
+}
+
+func (c canopyIndex) cell(x, y int) int {
+	return (y-c.minY)/c.bucket*c.columns + (x-c.minX)/c.bucket
+}
+
+// tallestBetween returns the highest altitude over the trees the track from node i to node j crosses, 0 when there is none.
+func (c canopyIndex) tallestBetween(i, j int) int {
+	if c.cells == nil {
+		return 0
+	}
+
+	// plot (x,y) covers [x-1,x]x[y-1,y], the track goes from the center of a plot to the center of the other
+	ax, ay := float64(c.nodes[i].x)-0.5, float64(c.nodes[i].y)-0.5
+	bx, by := float64(c.nodes[j].x)-0.5, float64(c.nodes[j].y)-0.5
+	return c.tallestAlong(ax, ay, bx, by, i, j)
+}
+
+// tallestAlong returns the highest altitude over the trees the track from a to b crosses, but nodes i and j.
+func (c canopyIndex) tallestAlong(ax, ay, bx, by float64, i, j int) int {
+	if c.cells == nil {
+		return 0
+	}
+
+	// the part of the track over the grid of cells, in cell units
+	originX, originY := float64(c.minX-1), float64(c.minY-1)
+	rows := len(c.cells) / c.columns
+	t0, t1, ok := clipSegment(ax, ay, bx, by, originX, originY, originX+float64(c.columns*c.bucket), originY+float64(rows*c.bucket))
+	if !ok {
+		return 0
+	}
+	size := float64(c.bucket)
+	px, py := (ax+(bx-ax)*t0-originX)/size, (ay+(by-ay)*t0-originY)/size
+	qx, qy := (ax+(bx-ax)*t1-originX)/size, (ay+(by-ay)*t1-originY)/size
+
+	tallest := 0
+	visit := func(column, row int) {
+		if column < 0 || column >= c.columns || row < 0 || row >= rows {
+			return
+		}
+		for _, k := range c.cells[row*c.columns+column] {
+			if k != i && k != j && c.nodes[k].altitude > tallest && crossesPlot(ax, ay, bx, by, c.nodes[k].x, c.nodes[k].y) {
+				tallest = c.nodes[k].altitude
+			}
+		}
+	}
+
+	// walk the cells the track goes through, one step along x or y at a time
+	column, row := int(px), int(py)
+	lastColumn, lastRow := int(qx), int(qy)
+	stepX, nextX, deltaX := gridStep(px, qx)
+	stepY, nextY, deltaY := gridStep(py, qy)
+	for steps := abs(lastColumn-column) + abs(lastRow-row); ; steps-- {
+		visit(column, row)
+		if steps == 0 {
+			break
+		}
+		if nextX < nextY {
+			column += stepX
+			nextX += deltaX
+		} else {
+			row += stepY
+			nextY += deltaY
+		}
+	}
+	return tallest
+}
+
+// gridStep returns the direction of a walk from p to q over unit cells, the fraction of the walk where it first
+// leaves the cell of p and the fraction it takes to cross a cell.
+func gridStep(p, q float64) (int, float64, float64) {
+	d := q - p
+	switch {
+	case d > 0:
+		return 1, (math.Floor(p) + 1 - p) / d, 1 / d
+	case d < 0:
+		return -1, (p - math.Floor(p)) / -d, 1 / -d
+	default:
+		return 0, math.Inf(1), math.Inf(1)
+	}
+}
+
+// clipSegment returns the fractions of the segment from a to b where it enters and leaves the box, ok is false
+// when it misses the box.
+func clipSegment(ax, ay, bx, by, minX, minY, maxX, maxY float64) (float64, float64, bool) {
+	t0, t1 := 0.0, 1.0
+	for _, axis := range [][4]float64{{ax, bx, minX, maxX}, {ay, by, minY, maxY}} {
+		a, b, lo, hi := axis[0], axis[1], axis[2], axis[3]
+		d := b - a
+		if d == 0 {
+			if a < lo || a > hi {
+				return 0, 0, false
+			}
+			continue
+		}
+		enter, leave := (lo-a)/d, (hi-a)/d
+		if enter > leave {
+			enter, leave = leave, enter
+		}
+		t0, t1 = max(t0, enter), min(t1, leave)
+		if t0 > t1 {
+			return 0, 0, false
+		}
+	}
+	return t0, t1, true
+}
+
+// crossesPlot reports whether the segment from a to b goes over plot (x,y), touching its corner or its side is not crossing it.
+func crossesPlot(ax, ay, bx, by float64, x, y int) bool {
+	const margin = 1e-9
+	t0, t1, ok := clipSegment(ax, ay, bx, by, float64(x-1)+margin, float64(y-1)+margin, float64(x)-margin, float64(y)-margin)
+	return ok && t1-t0 > margin
+}
+
+/*
+inspectionCosts returns the distance flown between every two nodes, as a matrix in a flat slice. The drone flies
+straight at the highest altitude among the two nodes, the trees and the height obstacles it crosses and the minimum
+cruise altitude above the highest ground it crosses when it crosses any plot, climbing after the first node and
+descending before the second one. It
+takes the detour around the no-fly plots of the grid when the straight track crosses any.
+*/
+func inspectionCosts(nodes []inspectionNode, model flightModel, grid flightGrid) ([]float64, error) {
+	n := len(nodes)
+	index := newCanopyIndex(append(nodes[:n:n], grid.climbed...))
+	costs := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		for j := i + 1; j < n; j++ {
+			dx, dy := nodes[j].x-nodes[i].x, nodes[j].y-nodes[i].y
+			horizontal := model.plotSize * math.Hypot(float64(dx), float64(dy))
+			cruise := max(nodes[i].altitude, nodes[j].altitude)
+			d, err := grid.legAround([2]int{nodes[i].x, nodes[i].y}, [2]int{nodes[j].x, nodes[j].y})
+			if err != nil {
+				return nil, err
+			}
+			if d != nil {
+				horizontal, cruise = d.Horizontal, max(cruise, d.Altitude)
+			} else {
+				cruise = max(cruise, index.tallestBetween(i, j))
+				if max(abs(dx), abs(dy)) > 1 {
+					cruise = max(cruise, model.terrain.highestAlong([2]int{nodes[i].x, nodes[i].y}, [2]int{nodes[j].x, nodes[j].y})+model.altitude(nil))
+				}
+			}
+			cost := horizontal + float64(2*cruise-nodes[i].altitude-nodes[j].altitude)
+			costs[i*n+j] = cost
+			costs[j*n+i] = cost
+		}
+	}
+	return costs, nil
+}
+
+// inspectionLanding returns the distance to descend to the start/end altitude over every node, the route ends with it.
+func inspectionLanding(nodes []inspectionNode, model flightModel) []float64 {
+	landing := make([]float64, len(nodes))
+	for i, node := range nodes {
+		landing[i] = float64(abs(node.altitude - model.ground(node.x, node.y) - model.startEndAltitude))
+	}
+	return landing
+}
+
+/*
+planInspectionRoute returns a route through the nodes starting at node 0 and landing over its last node, built by
+going to the nearest node left and shortened with 2-opt moves until none shortens it, optimized is false when the
+deadline came first.
+*/
+func planInspectionRoute(costs []float64, landing []float64, deadline time.Time) ([]int, bool) {
+	n := len(landing)
+	if n == 0 {
+		return nil, true
+	}
+
+	route := make([]int, 0, n)
+	visited := make([]bool, n)
+	route = append(route, 0)
+	visited[0] = true
+	for len(route) < n {
+		from := route[len(route)-1]
+		nearest := -1
+		for to := 1; to < n; to++ {
+			if !visited[to] && (nearest < 0 || costs[from*n+to] < costs[from*n+nearest]) {
+				nearest = to
+			}
+		}
+		route = append(route, nearest)
+		visited[nearest] = true
+	}
+
+	// the distance flown after the node at position j of the route, to the next node or down to land
+	after := func(node, j int) float64 {
+		if j == n-1 {
+			return landing[node]
+		}
+		return costs[node*n+route[j+1]]
+	}
+
+	// reversing route[i..j] replaces the edge before i and the one after j, node 0 stays first
+	const epsilon = 1e-9
+	for improved := true; improved; {
+		improved = false
+		for i := 1; i < n-1; i++ {
+			if time.Now().After(deadline) {
+				return route, false
+			}
+			before := route[i-1]
+			for j := i + 1; j < n; j++ {
+				delta := costs[before*n+route[j]] + after(route[i], j) - costs[before*n+route[i]] - after(route[j], j)
+				if delta < -epsilon {
+					for a, b := i, j; a < b; a, b = a+1, b-1 {
+						route[a], route[b] = route[b], route[a]
+					}
+					improved = true
+				}
+			}
+		}
+	}
+	return route, true
+}
+
+// routeDistance returns the distance of a route rounded to the meter, landing included.
+func routeDistance(route []int, costs []float64, landing []float64) int {
+	n := len(landing)
+	if len(route) < 2 {
+		// no tree to inspect, the drone does not take off
+		return 0
+	}
+
+	distance := landing[route[len(route)-1]]
+	for i := 1; i < len(route); i++ {
+		distance += costs[route[i-1]*n+route[i]]
+	}
+	return int(math.Round(distance))
+}
+
+// inspectionNodes returns the home plot of an estate, the first plot of its traversal out of the no-fly zones,
+// followed by its trees.
+func inspectionNodes(estate repository.EstateEntity, plots []repository.PlotEntity, model flightModel, grid flightGrid) []inspectionNode {
+	trav := newTraversal(estate)
+	order := 1
+	for x, y := trav.plot(order); grid.noFly[[2]int{x, y}] && order < trav.plotCount(); x, y = trav.plot(order) {
+		order++
+	}
+	x, y := trav.plot(order)
+	nodes := make([]inspectionNode, 0, len(plots)+1)
+	nodes = append(nodes, inspectionNode{x: x, y: y, altitude: model.ground(x, y) + model.startEndAltitude})
+	for i := range plots {
+		x, y := int(plots[i].X), int(plots[i].Y)
+		nodes = append(nodes, inspectionNode{x: x, y: y, altitude: model.ground(x, y) + model.altitude(&plots[i])})
+	}
+	return nodes
+}
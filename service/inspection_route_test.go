@@ -0,0 +1,109 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanopyIndex_TallestBetween(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for _, size := range []int{3, 20, 5000} {
+		nodes := []inspectionNode{{x: 1, y: 1}}
+		for i := 0; i < 60; i++ {
+			nodes = append(nodes, inspectionNode{x: rng.Intn(size) + 1, y: rng.Intn(size) + 1, altitude: rng.Intn(30) + 1})
+		}
+		index := newCanopyIndex(nodes)
+
+		// the grid walk finds the same canopy as looking at every tree
+		for i := range nodes {
+			for j := range nodes {
+				ax, ay := float64(nodes[i].x)-0.5, float64(nodes[i].y)-0.5
+				bx, by := float64(nodes[j].x)-0.5, float64(nodes[j].y)-0.5
+				expected := 0
+				for k := 1; k < len(nodes); k++ {
+					if k != i && k != j && crossesPlot(ax, ay, bx, by, nodes[k].x, nodes[k].y) {
+						expected = max(expected, nodes[k].altitude)
+					}
+				}
+				require.Equal(t, expected, index.tallestBetween(i, j), "size %d from %v to %v", size, nodes[i], nodes[j])
+			}
+		}
+	}
+}
+
+func TestInspectionCosts(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 2}
+	nodes := []inspectionNode{
+		{x: 1, y: 1, altitude: 0},
+		{x: 2, y: 2, altitude: 5},
+		{x: 4, y: 2, altitude: 3},
+		{x: 3, y: 2, altitude: 20},
+		{x: 1, y: 4, altitude: 1},
+	}
+	costs, _ := inspectionCosts(nodes, model, flightGrid{})
+	n := len(nodes)
+
+	// the diagonal step crosses no plot, it only climbs to the tree
+	assert.InDelta(t, 14.142+5, costs[0*n+1], 0.001)
+	// the tree of plot (3,2) is between the trees of plots (2,2) and (4,2)
+	assert.InDelta(t, 20+15+17, costs[1*n+2], 0.001)
+	assert.Equal(t, costs[1*n+2], costs[2*n+1])
+	// the track to plot (4,2) also crosses the tree of plot (3,2)
+	assert.InDelta(t, 31.623+40-3, costs[0*n+2], 0.001)
+	// the drone crosses empty plots at the minimum cruise altitude
+	assert.InDelta(t, 30+4-1, costs[0*n+4], 0.001)
+}
+
+func TestPlanInspectionRoute(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+
+	t.Run("Removes The Backtrack", func(t *testing.T) {
+		// from plot 5 nearest neighbor goes to plot 6, back to plot 3 and over again to plot 10
+		nodes := []inspectionNode{{x: 5, y: 1}, {x: 6, y: 1, altitude: 1}, {x: 3, y: 1, altitude: 1}, {x: 10, y: 1, altitude: 1}}
+		costs, _ := inspectionCosts(nodes, model, flightGrid{})
+		landing := inspectionLanding(nodes, model)
+
+		route, optimized := planInspectionRoute(costs, landing, time.Now().Add(time.Second))
+		assert.True(t, optimized)
+		assert.Equal(t, []int{0, 2, 1, 3}, route)
+		assert.Equal(t, 90+2, routeDistance(route, costs, landing))
+	})
+
+	t.Run("No Tree", func(t *testing.T) {
+		nodes := []inspectionNode{{x: 1, y: 1}}
+		costs, _ := inspectionCosts(nodes, model, flightGrid{})
+		landing := inspectionLanding(nodes, model)
+
+		route, optimized := planInspectionRoute(costs, landing, time.Now().Add(time.Second))
+		assert.True(t, optimized)
+		assert.Equal(t, []int{0}, route)
+		assert.Equal(t, 0, routeDistance(route, costs, landing))
+	})
+
+	t.Run("Thousand Trees Within The Time Budget", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(1))
+		nodes := []inspectionNode{{x: 1, y: 1}}
+		for i := 0; i < 1000; i++ {
+			nodes = append(nodes, inspectionNode{x: rng.Intn(50000) + 1, y: rng.Intn(50000) + 1, altitude: rng.Intn(30) + 1})
+		}
+
+		start := time.Now()
+		costs, _ := inspectionCosts(nodes, model, flightGrid{})
+		landing := inspectionLanding(nodes, model)
+		route, _ := planInspectionRoute(costs, landing, time.Now().Add(inspectionTimeBudget))
+		assert.Less(t, time.Since(start), 2*inspectionTimeBudget)
+
+		// every tree is visited once, from the home plot
+		require.Len(t, route, len(nodes))
+		assert.Equal(t, 0, route[0])
+		seen := make(map[int]bool)
+		for _, node := range route {
+			seen[node] = true
+		}
+		assert.Len(t, seen, len(nodes))
+	})
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
@@ -10,8 +11,59 @@ import (
 )
 
 type ServiceInterface interface {
-	PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, error)
+	PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, int, error)
 	AddTreeToEstate(ctx echo.Context, req generated.TreeRequest, id uuid.UUID) (generated.TreeResponse, int, error)
 	GetEstateStats(ctx context.Context, id uuid.UUID) (generated.EstateStatsResponse, error)
-	GetEstateDronePlan(ctx context.Context, id uuid.UUID, maxDistance *int) (generated.DronePlanResponse, error)
+	GetEstateDronePlan(ctx context.Context, id uuid.UUID, params generated.GetEstateIdDronePlanParams) (generated.DronePlanResponse, int, error)
+	GetEstateFlightModeComparison(ctx context.Context, id uuid.UUID) (generated.FlightModeComparison, int, error)
+	GetEstateInspectionRoute(ctx context.Context, estateId uuid.UUID) (generated.InspectionRoute, int, error)
+	PlanEstateFleet(ctx context.Context, estateId uuid.UUID, req generated.FleetRequest) (generated.FleetPlan, int, error)
+	PlanEstatePlanting(ctx context.Context, estateId uuid.UUID, req generated.PlantingRequest) (generated.PlantingPlan, int, error)
+	PostDroneProfile(ctx context.Context, req generated.DroneProfileRequest) (generated.DroneProfileResponse, int, error)
+	GetDroneProfile(ctx context.Context, id uuid.UUID) (generated.DroneProfile, int, error)
+	PostSpecies(ctx context.Context, req generated.SpeciesRequest) (generated.SpeciesResponse, int, error)
+	GetSpecies(ctx context.Context, id uuid.UUID) (generated.Species, int, error)
+	GetSpeciesCatalog(ctx context.Context) (generated.SpeciesList, int, error)
+	AddChargingPad(ctx context.Context, estateId uuid.UUID, req generated.ChargingPadRequest) (generated.ChargingPadResponse, int, error)
+	GetChargingPads(ctx context.Context, estateId uuid.UUID) (generated.ChargingPadList, int, error)
+	AddObstacle(ctx context.Context, estateId uuid.UUID, req generated.ObstacleRequest) (generated.ObstacleResponse, int, error)
+	GetObstacles(ctx context.Context, estateId uuid.UUID) (generated.ObstacleList, int, error)
+	AddWorkOrder(ctx context.Context, estateId uuid.UUID, req generated.WorkOrderRequest) (generated.WorkOrder, int, error)
+	GetWorkOrders(ctx context.Context, estateId uuid.UUID, params generated.GetWorkOrdersParams) (generated.WorkOrderList, int, error)
+	GetWorkOrder(ctx context.Context, estateId uuid.UUID, orderId uuid.UUID) (generated.WorkOrder, int, error)
+	SetWorkOrderStatus(ctx context.Context, estateId uuid.UUID, orderId uuid.UUID, req generated.WorkOrderStatusRequest) (generated.WorkOrder, int, error)
+	AddSensor(ctx context.Context, estateId uuid.UUID, req generated.SensorRequest) (generated.Sensor, int, error)
+	GetSensors(ctx context.Context, estateId uuid.UUID) (generated.SensorList, int, error)
+	AddSensorReadings(ctx context.Context, estateId uuid.UUID, req generated.SensorReadingBatch) (generated.SensorReadingBatchResponse, int, error)
+	GetSensorLatest(ctx context.Context, estateId uuid.UUID, params generated.GetSensorLatestParams) (generated.SensorLatest, int, error)
+	GetSensorStats(ctx context.Context, estateId uuid.UUID, params generated.GetSensorStatsParams) (generated.SensorStatsReport, int, error)
+	GetSensorSeries(ctx context.Context, estateId uuid.UUID, params generated.GetSensorSeriesParams) (generated.SensorSeries, int, error)
+	GetEstateStatsAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (generated.EstateStatsResponse, error)
+	GetEstateExtendedStats(ctx context.Context, id uuid.UUID, asOf *time.Time, percentiles []float64) (generated.EstateStatsResponse, int, error)
+	GetEstateRegionStats(ctx context.Context, id uuid.UUID, params generated.GetEstateIdStatsParams) (generated.EstateStatsResponse, int, error)
+	GetEstateMapPng(ctx context.Context, id uuid.UUID, params generated.GetEstateMapPngParams) ([]byte, int, error)
+	GetEstateMapAscii(ctx context.Context, id uuid.UUID, params generated.GetEstateMapAsciiParams) ([]byte, int, error)
+	GetEstateMapKml(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmlParams) ([]byte, int, error)
+	GetEstateMapKmz(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmzParams) ([]byte, int, error)
+	AddTreeMeasurement(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID, req generated.TreeMeasurementRequest) (generated.TreeMeasurementResponse, int, error)
+	GetTreeGrowth(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID) (generated.TreeGrowthResponse, int, error)
+	AddTreeInspection(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID, req generated.TreeInspectionRequest) (generated.TreeInspectionResponse, int, error)
+	GetTreeInspections(ctx context.Context, estateId uuid.UUID, treeId uuid.UUID) (generated.TreeInspectionList, int, error)
+	AddHarvest(ctx context.Context, estateId uuid.UUID, req generated.HarvestRequest) (generated.HarvestResponse, int, error)
+	GetEstateYield(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldParams) (generated.YieldReport, int, error)
+	GetEstateYieldCsv(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldCsvParams) ([]byte, int, error)
+	GetEstateTrees(ctx context.Context, estateId uuid.UUID, params generated.GetEstateTreesParams) (generated.TreeList, int, error)
+	GetEstateForecast(ctx context.Context, estateId uuid.UUID, params generated.GetEstateForecastParams) (generated.EstateForecast, int, error)
+	SetEstateGeoReference(ctx context.Context, estateId uuid.UUID, req generated.GeoReference) (generated.GeoReference, int, error)
+	SetEstateFlightSettings(ctx context.Context, estateId uuid.UUID, req generated.FlightSettings) (generated.FlightSettingsResponse, int, error)
+	SetEstateElevation(ctx context.Context, estateId uuid.UUID, grid []byte, params generated.SetEstateElevationParams) (generated.ElevationResponse, int, error)
+	AddFlightLog(ctx context.Context, estateId uuid.UUID, log []byte, params generated.AddFlightLogParams) (generated.FlightLogReport, int, error)
+	GetFlightLog(ctx context.Context, estateId uuid.UUID, logId uuid.UUID) (generated.FlightLogReport, int, error)
+	SetEstateTraversal(ctx context.Context, estateId uuid.UUID, req generated.Traversal) (generated.TraversalResponse, int, error)
+	LocateEstatePlot(ctx context.Context, estateId uuid.UUID, params generated.LocateEstatePlotParams) (generated.PlotLocation, int, error)
+	GetEstateBoundaryGeoJson(ctx context.Context, estateId uuid.UUID) (generated.EstateBoundaryFeature, int, error)
+	GetEstateTreesGeoJson(ctx context.Context, estateId uuid.UUID) (generated.TreeFeatureCollection, int, error)
+	GetEstateDronePathGeoJson(ctx context.Context, estateId uuid.UUID) (generated.DronePathFeature, int, error)
+	GetEstateMissionPlan(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionPlanParams) (MissionFile, int, error)
+	GetEstateMissionWaypoints(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionWaypointsParams) (MissionFile, int, error)
 }
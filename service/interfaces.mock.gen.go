@@ -8,10 +8,11 @@ import (
 	context "context"
 	reflect "reflect"
 	generated "spgo/generated"
+	time "time"
 
 	gomock "github.com/golang/mock/gomock"
 	uuid "github.com/google/uuid"
-	echo "github.com/labstack/echo/v4"
+	v4 "github.com/labstack/echo/v4"
 )
 
 // MockServiceInterface is a mock of ServiceInterface interface.
@@ -37,8 +38,136 @@ func (m *MockServiceInterface) EXPECT() *MockServiceInterfaceMockRecorder {
 	return m.recorder
 }
 
+// AddChargingPad mocks base method.
+func (m *MockServiceInterface) AddChargingPad(ctx context.Context, estateId uuid.UUID, req generated.ChargingPadRequest) (generated.ChargingPadResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddChargingPad", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.ChargingPadResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddChargingPad indicates an expected call of AddChargingPad.
+func (mr *MockServiceInterfaceMockRecorder) AddChargingPad(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddChargingPad", reflect.TypeOf((*MockServiceInterface)(nil).AddChargingPad), ctx, estateId, req)
+}
+
+// AddFlightLog mocks base method.
+func (m *MockServiceInterface) AddFlightLog(ctx context.Context, estateId uuid.UUID, log []byte, params generated.AddFlightLogParams) (generated.FlightLogReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddFlightLog", ctx, estateId, log, params)
+	ret0, _ := ret[0].(generated.FlightLogReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddFlightLog indicates an expected call of AddFlightLog.
+func (mr *MockServiceInterfaceMockRecorder) AddFlightLog(ctx, estateId, log, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddFlightLog", reflect.TypeOf((*MockServiceInterface)(nil).AddFlightLog), ctx, estateId, log, params)
+}
+
+// AddHarvest mocks base method.
+func (m *MockServiceInterface) AddHarvest(ctx context.Context, estateId uuid.UUID, req generated.HarvestRequest) (generated.HarvestResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddHarvest", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.HarvestResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddHarvest indicates an expected call of AddHarvest.
+func (mr *MockServiceInterfaceMockRecorder) AddHarvest(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddHarvest", reflect.TypeOf((*MockServiceInterface)(nil).AddHarvest), ctx, estateId, req)
+}
+
+// AddObstacle mocks base method.
+func (m *MockServiceInterface) AddObstacle(ctx context.Context, estateId uuid.UUID, req generated.ObstacleRequest) (generated.ObstacleResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddObstacle", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.ObstacleResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddObstacle indicates an expected call of AddObstacle.
+func (mr *MockServiceInterfaceMockRecorder) AddObstacle(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddObstacle", reflect.TypeOf((*MockServiceInterface)(nil).AddObstacle), ctx, estateId, req)
+}
+
+// AddSensor mocks base method.
+func (m *MockServiceInterface) AddSensor(ctx context.Context, estateId uuid.UUID, req generated.SensorRequest) (generated.Sensor, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSensor", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.Sensor)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddSensor indicates an expected call of AddSensor.
+func (mr *MockServiceInterfaceMockRecorder) AddSensor(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSensor", reflect.TypeOf((*MockServiceInterface)(nil).AddSensor), ctx, estateId, req)
+}
+
+// AddSensorReadings mocks base method.
+func (m *MockServiceInterface) AddSensorReadings(ctx context.Context, estateId uuid.UUID, req generated.SensorReadingBatch) (generated.SensorReadingBatchResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddSensorReadings", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.SensorReadingBatchResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddSensorReadings indicates an expected call of AddSensorReadings.
+func (mr *MockServiceInterfaceMockRecorder) AddSensorReadings(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddSensorReadings", reflect.TypeOf((*MockServiceInterface)(nil).AddSensorReadings), ctx, estateId, req)
+}
+
+// AddTreeInspection mocks base method.
+func (m *MockServiceInterface) AddTreeInspection(ctx context.Context, estateId, treeId uuid.UUID, req generated.TreeInspectionRequest) (generated.TreeInspectionResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTreeInspection", ctx, estateId, treeId, req)
+	ret0, _ := ret[0].(generated.TreeInspectionResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddTreeInspection indicates an expected call of AddTreeInspection.
+func (mr *MockServiceInterfaceMockRecorder) AddTreeInspection(ctx, estateId, treeId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTreeInspection", reflect.TypeOf((*MockServiceInterface)(nil).AddTreeInspection), ctx, estateId, treeId, req)
+}
+
+// AddTreeMeasurement mocks base method.
+func (m *MockServiceInterface) AddTreeMeasurement(ctx context.Context, estateId, treeId uuid.UUID, req generated.TreeMeasurementRequest) (generated.TreeMeasurementResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddTreeMeasurement", ctx, estateId, treeId, req)
+	ret0, _ := ret[0].(generated.TreeMeasurementResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddTreeMeasurement indicates an expected call of AddTreeMeasurement.
+func (mr *MockServiceInterfaceMockRecorder) AddTreeMeasurement(ctx, estateId, treeId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTreeMeasurement", reflect.TypeOf((*MockServiceInterface)(nil).AddTreeMeasurement), ctx, estateId, treeId, req)
+}
+
 // AddTreeToEstate mocks base method.
-func (m *MockServiceInterface) AddTreeToEstate(ctx echo.Context, req generated.TreeRequest, id uuid.UUID) (generated.TreeResponse, int, error) {
+func (m *MockServiceInterface) AddTreeToEstate(ctx v4.Context, req generated.TreeRequest, id uuid.UUID) (generated.TreeResponse, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "AddTreeToEstate", ctx, req, id)
 	ret0, _ := ret[0].(generated.TreeResponse)
@@ -53,19 +182,276 @@ func (mr *MockServiceInterfaceMockRecorder) AddTreeToEstate(ctx, req, id interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddTreeToEstate", reflect.TypeOf((*MockServiceInterface)(nil).AddTreeToEstate), ctx, req, id)
 }
 
+// AddWorkOrder mocks base method.
+func (m *MockServiceInterface) AddWorkOrder(ctx context.Context, estateId uuid.UUID, req generated.WorkOrderRequest) (generated.WorkOrder, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddWorkOrder", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.WorkOrder)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// AddWorkOrder indicates an expected call of AddWorkOrder.
+func (mr *MockServiceInterfaceMockRecorder) AddWorkOrder(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddWorkOrder", reflect.TypeOf((*MockServiceInterface)(nil).AddWorkOrder), ctx, estateId, req)
+}
+
+// GetChargingPads mocks base method.
+func (m *MockServiceInterface) GetChargingPads(ctx context.Context, estateId uuid.UUID) (generated.ChargingPadList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetChargingPads", ctx, estateId)
+	ret0, _ := ret[0].(generated.ChargingPadList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetChargingPads indicates an expected call of GetChargingPads.
+func (mr *MockServiceInterfaceMockRecorder) GetChargingPads(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetChargingPads", reflect.TypeOf((*MockServiceInterface)(nil).GetChargingPads), ctx, estateId)
+}
+
+// GetDroneProfile mocks base method.
+func (m *MockServiceInterface) GetDroneProfile(ctx context.Context, id uuid.UUID) (generated.DroneProfile, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDroneProfile", ctx, id)
+	ret0, _ := ret[0].(generated.DroneProfile)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDroneProfile indicates an expected call of GetDroneProfile.
+func (mr *MockServiceInterfaceMockRecorder) GetDroneProfile(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDroneProfile", reflect.TypeOf((*MockServiceInterface)(nil).GetDroneProfile), ctx, id)
+}
+
+// GetEstateBoundaryGeoJson mocks base method.
+func (m *MockServiceInterface) GetEstateBoundaryGeoJson(ctx context.Context, estateId uuid.UUID) (generated.EstateBoundaryFeature, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateBoundaryGeoJson", ctx, estateId)
+	ret0, _ := ret[0].(generated.EstateBoundaryFeature)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateBoundaryGeoJson indicates an expected call of GetEstateBoundaryGeoJson.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateBoundaryGeoJson(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateBoundaryGeoJson", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateBoundaryGeoJson), ctx, estateId)
+}
+
+// GetEstateDronePathGeoJson mocks base method.
+func (m *MockServiceInterface) GetEstateDronePathGeoJson(ctx context.Context, estateId uuid.UUID) (generated.DronePathFeature, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateDronePathGeoJson", ctx, estateId)
+	ret0, _ := ret[0].(generated.DronePathFeature)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateDronePathGeoJson indicates an expected call of GetEstateDronePathGeoJson.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateDronePathGeoJson(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateDronePathGeoJson", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateDronePathGeoJson), ctx, estateId)
+}
+
 // GetEstateDronePlan mocks base method.
-func (m *MockServiceInterface) GetEstateDronePlan(ctx context.Context, id uuid.UUID, maxDistance *int) (generated.DronePlanResponse, error) {
+func (m *MockServiceInterface) GetEstateDronePlan(ctx context.Context, id uuid.UUID, params generated.GetEstateIdDronePlanParams) (generated.DronePlanResponse, int, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetEstateDronePlan", ctx, id, maxDistance)
+	ret := m.ctrl.Call(m, "GetEstateDronePlan", ctx, id, params)
 	ret0, _ := ret[0].(generated.DronePlanResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // GetEstateDronePlan indicates an expected call of GetEstateDronePlan.
-func (mr *MockServiceInterfaceMockRecorder) GetEstateDronePlan(ctx, id, maxDistance interface{}) *gomock.Call {
+func (mr *MockServiceInterfaceMockRecorder) GetEstateDronePlan(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateDronePlan", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateDronePlan), ctx, id, params)
+}
+
+// GetEstateExtendedStats mocks base method.
+func (m *MockServiceInterface) GetEstateExtendedStats(ctx context.Context, id uuid.UUID, asOf *time.Time, percentiles []float64) (generated.EstateStatsResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateExtendedStats", ctx, id, asOf, percentiles)
+	ret0, _ := ret[0].(generated.EstateStatsResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateExtendedStats indicates an expected call of GetEstateExtendedStats.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateExtendedStats(ctx, id, asOf, percentiles interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateExtendedStats", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateExtendedStats), ctx, id, asOf, percentiles)
+}
+
+// GetEstateFlightModeComparison mocks base method.
+func (m *MockServiceInterface) GetEstateFlightModeComparison(ctx context.Context, id uuid.UUID) (generated.FlightModeComparison, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateFlightModeComparison", ctx, id)
+	ret0, _ := ret[0].(generated.FlightModeComparison)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateFlightModeComparison indicates an expected call of GetEstateFlightModeComparison.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateFlightModeComparison(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateFlightModeComparison", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateFlightModeComparison), ctx, id)
+}
+
+// GetEstateForecast mocks base method.
+func (m *MockServiceInterface) GetEstateForecast(ctx context.Context, estateId uuid.UUID, params generated.GetEstateForecastParams) (generated.EstateForecast, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateForecast", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.EstateForecast)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateForecast indicates an expected call of GetEstateForecast.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateForecast(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateForecast", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateForecast), ctx, estateId, params)
+}
+
+// GetEstateInspectionRoute mocks base method.
+func (m *MockServiceInterface) GetEstateInspectionRoute(ctx context.Context, estateId uuid.UUID) (generated.InspectionRoute, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateInspectionRoute", ctx, estateId)
+	ret0, _ := ret[0].(generated.InspectionRoute)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateInspectionRoute indicates an expected call of GetEstateInspectionRoute.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateInspectionRoute(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateInspectionRoute", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateInspectionRoute), ctx, estateId)
+}
+
+// GetEstateMapAscii mocks base method.
+func (m *MockServiceInterface) GetEstateMapAscii(ctx context.Context, id uuid.UUID, params generated.GetEstateMapAsciiParams) ([]byte, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMapAscii", ctx, id, params)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMapAscii indicates an expected call of GetEstateMapAscii.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMapAscii(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMapAscii", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMapAscii), ctx, id, params)
+}
+
+// GetEstateMapKml mocks base method.
+func (m *MockServiceInterface) GetEstateMapKml(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmlParams) ([]byte, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMapKml", ctx, id, params)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMapKml indicates an expected call of GetEstateMapKml.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMapKml(ctx, id, params interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateDronePlan", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateDronePlan), ctx, id, maxDistance)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMapKml", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMapKml), ctx, id, params)
+}
+
+// GetEstateMapKmz mocks base method.
+func (m *MockServiceInterface) GetEstateMapKmz(ctx context.Context, id uuid.UUID, params generated.GetEstateMapKmzParams) ([]byte, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMapKmz", ctx, id, params)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMapKmz indicates an expected call of GetEstateMapKmz.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMapKmz(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMapKmz", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMapKmz), ctx, id, params)
+}
+
+// GetEstateMapPng mocks base method.
+func (m *MockServiceInterface) GetEstateMapPng(ctx context.Context, id uuid.UUID, params generated.GetEstateMapPngParams) ([]byte, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMapPng", ctx, id, params)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMapPng indicates an expected call of GetEstateMapPng.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMapPng(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMapPng", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMapPng), ctx, id, params)
+}
+
+// GetEstateMissionPlan mocks base method.
+func (m *MockServiceInterface) GetEstateMissionPlan(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionPlanParams) (MissionFile, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMissionPlan", ctx, id, params)
+	ret0, _ := ret[0].(MissionFile)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMissionPlan indicates an expected call of GetEstateMissionPlan.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMissionPlan(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMissionPlan", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMissionPlan), ctx, id, params)
+}
+
+// GetEstateMissionWaypoints mocks base method.
+func (m *MockServiceInterface) GetEstateMissionWaypoints(ctx context.Context, id uuid.UUID, params generated.GetEstateMissionWaypointsParams) (MissionFile, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateMissionWaypoints", ctx, id, params)
+	ret0, _ := ret[0].(MissionFile)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateMissionWaypoints indicates an expected call of GetEstateMissionWaypoints.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateMissionWaypoints(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateMissionWaypoints", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateMissionWaypoints), ctx, id, params)
+}
+
+// GetEstateRegionStats mocks base method.
+func (m *MockServiceInterface) GetEstateRegionStats(ctx context.Context, id uuid.UUID, params generated.GetEstateIdStatsParams) (generated.EstateStatsResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateRegionStats", ctx, id, params)
+	ret0, _ := ret[0].(generated.EstateStatsResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateRegionStats indicates an expected call of GetEstateRegionStats.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateRegionStats(ctx, id, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateRegionStats", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateRegionStats), ctx, id, params)
 }
 
 // GetEstateStats mocks base method.
@@ -83,13 +469,349 @@ func (mr *MockServiceInterfaceMockRecorder) GetEstateStats(ctx, id interface{})
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateStats", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateStats), ctx, id)
 }
 
+// GetEstateStatsAsOf mocks base method.
+func (m *MockServiceInterface) GetEstateStatsAsOf(ctx context.Context, id uuid.UUID, asOf time.Time) (generated.EstateStatsResponse, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateStatsAsOf", ctx, id, asOf)
+	ret0, _ := ret[0].(generated.EstateStatsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEstateStatsAsOf indicates an expected call of GetEstateStatsAsOf.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateStatsAsOf(ctx, id, asOf interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateStatsAsOf", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateStatsAsOf), ctx, id, asOf)
+}
+
+// GetEstateTrees mocks base method.
+func (m *MockServiceInterface) GetEstateTrees(ctx context.Context, estateId uuid.UUID, params generated.GetEstateTreesParams) (generated.TreeList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateTrees", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.TreeList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateTrees indicates an expected call of GetEstateTrees.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateTrees(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateTrees", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateTrees), ctx, estateId, params)
+}
+
+// GetEstateTreesGeoJson mocks base method.
+func (m *MockServiceInterface) GetEstateTreesGeoJson(ctx context.Context, estateId uuid.UUID) (generated.TreeFeatureCollection, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateTreesGeoJson", ctx, estateId)
+	ret0, _ := ret[0].(generated.TreeFeatureCollection)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateTreesGeoJson indicates an expected call of GetEstateTreesGeoJson.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateTreesGeoJson(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateTreesGeoJson", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateTreesGeoJson), ctx, estateId)
+}
+
+// GetEstateYield mocks base method.
+func (m *MockServiceInterface) GetEstateYield(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldParams) (generated.YieldReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateYield", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.YieldReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateYield indicates an expected call of GetEstateYield.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateYield(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateYield", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateYield), ctx, estateId, params)
+}
+
+// GetEstateYieldCsv mocks base method.
+func (m *MockServiceInterface) GetEstateYieldCsv(ctx context.Context, estateId uuid.UUID, params generated.GetEstateYieldCsvParams) ([]byte, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetEstateYieldCsv", ctx, estateId, params)
+	ret0, _ := ret[0].([]byte)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetEstateYieldCsv indicates an expected call of GetEstateYieldCsv.
+func (mr *MockServiceInterfaceMockRecorder) GetEstateYieldCsv(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEstateYieldCsv", reflect.TypeOf((*MockServiceInterface)(nil).GetEstateYieldCsv), ctx, estateId, params)
+}
+
+// GetFlightLog mocks base method.
+func (m *MockServiceInterface) GetFlightLog(ctx context.Context, estateId, logId uuid.UUID) (generated.FlightLogReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFlightLog", ctx, estateId, logId)
+	ret0, _ := ret[0].(generated.FlightLogReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetFlightLog indicates an expected call of GetFlightLog.
+func (mr *MockServiceInterfaceMockRecorder) GetFlightLog(ctx, estateId, logId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFlightLog", reflect.TypeOf((*MockServiceInterface)(nil).GetFlightLog), ctx, estateId, logId)
+}
+
+// GetObstacles mocks base method.
+func (m *MockServiceInterface) GetObstacles(ctx context.Context, estateId uuid.UUID) (generated.ObstacleList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetObstacles", ctx, estateId)
+	ret0, _ := ret[0].(generated.ObstacleList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetObstacles indicates an expected call of GetObstacles.
+func (mr *MockServiceInterfaceMockRecorder) GetObstacles(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetObstacles", reflect.TypeOf((*MockServiceInterface)(nil).GetObstacles), ctx, estateId)
+}
+
+// GetSensorLatest mocks base method.
+func (m *MockServiceInterface) GetSensorLatest(ctx context.Context, estateId uuid.UUID, params generated.GetSensorLatestParams) (generated.SensorLatest, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorLatest", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.SensorLatest)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSensorLatest indicates an expected call of GetSensorLatest.
+func (mr *MockServiceInterfaceMockRecorder) GetSensorLatest(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorLatest", reflect.TypeOf((*MockServiceInterface)(nil).GetSensorLatest), ctx, estateId, params)
+}
+
+// GetSensorSeries mocks base method.
+func (m *MockServiceInterface) GetSensorSeries(ctx context.Context, estateId uuid.UUID, params generated.GetSensorSeriesParams) (generated.SensorSeries, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorSeries", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.SensorSeries)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSensorSeries indicates an expected call of GetSensorSeries.
+func (mr *MockServiceInterfaceMockRecorder) GetSensorSeries(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorSeries", reflect.TypeOf((*MockServiceInterface)(nil).GetSensorSeries), ctx, estateId, params)
+}
+
+// GetSensorStats mocks base method.
+func (m *MockServiceInterface) GetSensorStats(ctx context.Context, estateId uuid.UUID, params generated.GetSensorStatsParams) (generated.SensorStatsReport, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensorStats", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.SensorStatsReport)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSensorStats indicates an expected call of GetSensorStats.
+func (mr *MockServiceInterfaceMockRecorder) GetSensorStats(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensorStats", reflect.TypeOf((*MockServiceInterface)(nil).GetSensorStats), ctx, estateId, params)
+}
+
+// GetSensors mocks base method.
+func (m *MockServiceInterface) GetSensors(ctx context.Context, estateId uuid.UUID) (generated.SensorList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSensors", ctx, estateId)
+	ret0, _ := ret[0].(generated.SensorList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSensors indicates an expected call of GetSensors.
+func (mr *MockServiceInterfaceMockRecorder) GetSensors(ctx, estateId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSensors", reflect.TypeOf((*MockServiceInterface)(nil).GetSensors), ctx, estateId)
+}
+
+// GetSpecies mocks base method.
+func (m *MockServiceInterface) GetSpecies(ctx context.Context, id uuid.UUID) (generated.Species, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpecies", ctx, id)
+	ret0, _ := ret[0].(generated.Species)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSpecies indicates an expected call of GetSpecies.
+func (mr *MockServiceInterfaceMockRecorder) GetSpecies(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpecies", reflect.TypeOf((*MockServiceInterface)(nil).GetSpecies), ctx, id)
+}
+
+// GetSpeciesCatalog mocks base method.
+func (m *MockServiceInterface) GetSpeciesCatalog(ctx context.Context) (generated.SpeciesList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSpeciesCatalog", ctx)
+	ret0, _ := ret[0].(generated.SpeciesList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetSpeciesCatalog indicates an expected call of GetSpeciesCatalog.
+func (mr *MockServiceInterfaceMockRecorder) GetSpeciesCatalog(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSpeciesCatalog", reflect.TypeOf((*MockServiceInterface)(nil).GetSpeciesCatalog), ctx)
+}
+
+// GetTreeGrowth mocks base method.
+func (m *MockServiceInterface) GetTreeGrowth(ctx context.Context, estateId, treeId uuid.UUID) (generated.TreeGrowthResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeGrowth", ctx, estateId, treeId)
+	ret0, _ := ret[0].(generated.TreeGrowthResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTreeGrowth indicates an expected call of GetTreeGrowth.
+func (mr *MockServiceInterfaceMockRecorder) GetTreeGrowth(ctx, estateId, treeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeGrowth", reflect.TypeOf((*MockServiceInterface)(nil).GetTreeGrowth), ctx, estateId, treeId)
+}
+
+// GetTreeInspections mocks base method.
+func (m *MockServiceInterface) GetTreeInspections(ctx context.Context, estateId, treeId uuid.UUID) (generated.TreeInspectionList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetTreeInspections", ctx, estateId, treeId)
+	ret0, _ := ret[0].(generated.TreeInspectionList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetTreeInspections indicates an expected call of GetTreeInspections.
+func (mr *MockServiceInterfaceMockRecorder) GetTreeInspections(ctx, estateId, treeId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTreeInspections", reflect.TypeOf((*MockServiceInterface)(nil).GetTreeInspections), ctx, estateId, treeId)
+}
+
+// GetWorkOrder mocks base method.
+func (m *MockServiceInterface) GetWorkOrder(ctx context.Context, estateId, orderId uuid.UUID) (generated.WorkOrder, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkOrder", ctx, estateId, orderId)
+	ret0, _ := ret[0].(generated.WorkOrder)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkOrder indicates an expected call of GetWorkOrder.
+func (mr *MockServiceInterfaceMockRecorder) GetWorkOrder(ctx, estateId, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkOrder", reflect.TypeOf((*MockServiceInterface)(nil).GetWorkOrder), ctx, estateId, orderId)
+}
+
+// GetWorkOrders mocks base method.
+func (m *MockServiceInterface) GetWorkOrders(ctx context.Context, estateId uuid.UUID, params generated.GetWorkOrdersParams) (generated.WorkOrderList, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetWorkOrders", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.WorkOrderList)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetWorkOrders indicates an expected call of GetWorkOrders.
+func (mr *MockServiceInterfaceMockRecorder) GetWorkOrders(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetWorkOrders", reflect.TypeOf((*MockServiceInterface)(nil).GetWorkOrders), ctx, estateId, params)
+}
+
+// LocateEstatePlot mocks base method.
+func (m *MockServiceInterface) LocateEstatePlot(ctx context.Context, estateId uuid.UUID, params generated.LocateEstatePlotParams) (generated.PlotLocation, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LocateEstatePlot", ctx, estateId, params)
+	ret0, _ := ret[0].(generated.PlotLocation)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// LocateEstatePlot indicates an expected call of LocateEstatePlot.
+func (mr *MockServiceInterfaceMockRecorder) LocateEstatePlot(ctx, estateId, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LocateEstatePlot", reflect.TypeOf((*MockServiceInterface)(nil).LocateEstatePlot), ctx, estateId, params)
+}
+
+// PlanEstateFleet mocks base method.
+func (m *MockServiceInterface) PlanEstateFleet(ctx context.Context, estateId uuid.UUID, req generated.FleetRequest) (generated.FleetPlan, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanEstateFleet", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.FleetPlan)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PlanEstateFleet indicates an expected call of PlanEstateFleet.
+func (mr *MockServiceInterfaceMockRecorder) PlanEstateFleet(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanEstateFleet", reflect.TypeOf((*MockServiceInterface)(nil).PlanEstateFleet), ctx, estateId, req)
+}
+
+// PlanEstatePlanting mocks base method.
+func (m *MockServiceInterface) PlanEstatePlanting(ctx context.Context, estateId uuid.UUID, req generated.PlantingRequest) (generated.PlantingPlan, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlanEstatePlanting", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.PlantingPlan)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PlanEstatePlanting indicates an expected call of PlanEstatePlanting.
+func (mr *MockServiceInterfaceMockRecorder) PlanEstatePlanting(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlanEstatePlanting", reflect.TypeOf((*MockServiceInterface)(nil).PlanEstatePlanting), ctx, estateId, req)
+}
+
+// PostDroneProfile mocks base method.
+func (m *MockServiceInterface) PostDroneProfile(ctx context.Context, req generated.DroneProfileRequest) (generated.DroneProfileResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostDroneProfile", ctx, req)
+	ret0, _ := ret[0].(generated.DroneProfileResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PostDroneProfile indicates an expected call of PostDroneProfile.
+func (mr *MockServiceInterfaceMockRecorder) PostDroneProfile(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostDroneProfile", reflect.TypeOf((*MockServiceInterface)(nil).PostDroneProfile), ctx, req)
+}
+
 // PostEstate mocks base method.
-func (m *MockServiceInterface) PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, error) {
+func (m *MockServiceInterface) PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, int, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "PostEstate", ctx, req)
 	ret0, _ := ret[0].(generated.EstateResponse)
-	ret1, _ := ret[1].(error)
-	return ret0, ret1
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
 }
 
 // PostEstate indicates an expected call of PostEstate.
@@ -97,3 +819,99 @@ func (mr *MockServiceInterfaceMockRecorder) PostEstate(ctx, req interface{}) *go
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostEstate", reflect.TypeOf((*MockServiceInterface)(nil).PostEstate), ctx, req)
 }
+
+// PostSpecies mocks base method.
+func (m *MockServiceInterface) PostSpecies(ctx context.Context, req generated.SpeciesRequest) (generated.SpeciesResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PostSpecies", ctx, req)
+	ret0, _ := ret[0].(generated.SpeciesResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// PostSpecies indicates an expected call of PostSpecies.
+func (mr *MockServiceInterfaceMockRecorder) PostSpecies(ctx, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PostSpecies", reflect.TypeOf((*MockServiceInterface)(nil).PostSpecies), ctx, req)
+}
+
+// SetEstateElevation mocks base method.
+func (m *MockServiceInterface) SetEstateElevation(ctx context.Context, estateId uuid.UUID, grid []byte, params generated.SetEstateElevationParams) (generated.ElevationResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEstateElevation", ctx, estateId, grid, params)
+	ret0, _ := ret[0].(generated.ElevationResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetEstateElevation indicates an expected call of SetEstateElevation.
+func (mr *MockServiceInterfaceMockRecorder) SetEstateElevation(ctx, estateId, grid, params interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEstateElevation", reflect.TypeOf((*MockServiceInterface)(nil).SetEstateElevation), ctx, estateId, grid, params)
+}
+
+// SetEstateFlightSettings mocks base method.
+func (m *MockServiceInterface) SetEstateFlightSettings(ctx context.Context, estateId uuid.UUID, req generated.FlightSettings) (generated.FlightSettingsResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEstateFlightSettings", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.FlightSettingsResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetEstateFlightSettings indicates an expected call of SetEstateFlightSettings.
+func (mr *MockServiceInterfaceMockRecorder) SetEstateFlightSettings(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEstateFlightSettings", reflect.TypeOf((*MockServiceInterface)(nil).SetEstateFlightSettings), ctx, estateId, req)
+}
+
+// SetEstateGeoReference mocks base method.
+func (m *MockServiceInterface) SetEstateGeoReference(ctx context.Context, estateId uuid.UUID, req generated.GeoReference) (generated.GeoReference, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEstateGeoReference", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.GeoReference)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetEstateGeoReference indicates an expected call of SetEstateGeoReference.
+func (mr *MockServiceInterfaceMockRecorder) SetEstateGeoReference(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEstateGeoReference", reflect.TypeOf((*MockServiceInterface)(nil).SetEstateGeoReference), ctx, estateId, req)
+}
+
+// SetEstateTraversal mocks base method.
+func (m *MockServiceInterface) SetEstateTraversal(ctx context.Context, estateId uuid.UUID, req generated.Traversal) (generated.TraversalResponse, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetEstateTraversal", ctx, estateId, req)
+	ret0, _ := ret[0].(generated.TraversalResponse)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetEstateTraversal indicates an expected call of SetEstateTraversal.
+func (mr *MockServiceInterfaceMockRecorder) SetEstateTraversal(ctx, estateId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetEstateTraversal", reflect.TypeOf((*MockServiceInterface)(nil).SetEstateTraversal), ctx, estateId, req)
+}
+
+// SetWorkOrderStatus mocks base method.
+func (m *MockServiceInterface) SetWorkOrderStatus(ctx context.Context, estateId, orderId uuid.UUID, req generated.WorkOrderStatusRequest) (generated.WorkOrder, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetWorkOrderStatus", ctx, estateId, orderId, req)
+	ret0, _ := ret[0].(generated.WorkOrder)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// SetWorkOrderStatus indicates an expected call of SetWorkOrderStatus.
+func (mr *MockServiceInterfaceMockRecorder) SetWorkOrderStatus(ctx, estateId, orderId, req interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetWorkOrderStatus", reflect.TypeOf((*MockServiceInterface)(nil).SetWorkOrderStatus), ctx, estateId, orderId, req)
+}
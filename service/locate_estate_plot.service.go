@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+)
+
+func (s *Service) LocateEstatePlot(ctx context.Context, estateId uuid.UUID, params generated.LocateEstatePlotParams) (generated.PlotLocation, int, error) {
+	if params.Latitude < -90 || params.Latitude > 90 {
+		return generated.PlotLocation{}, http.StatusBadRequest, errors.New("latitude must be between -90 and 90")
+	}
+	if params.Longitude < -180 || params.Longitude > 180 {
+		return generated.PlotLocation{}, http.StatusBadRequest, errors.New("longitude must be between -180 and 180")
+	}
+
+	estate, geo, httpStatus, err := s.loadGeoReference(ctx, estateId)
+	if err != nil {
+		return generated.PlotLocation{}, httpStatus, err
+	}
+
+	px, py := geo.toGrid(params.Latitude, params.Longitude)
+	x := int(math.Floor(px)) + 1
+	y := int(math.Floor(py)) + 1
+	if !inEstate(estate, x, y) {
+		return generated.PlotLocation{}, http.StatusBadRequest, errors.New("position is outside the estate")
+	}
+
+	lat, lon := geo.plotCenter(x, y)
+	return generated.PlotLocation{
+		X:         &x,
+		Y:         &y,
+		Latitude:  &lat,
+		Longitude: &lon,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,114 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_LocateEstatePlot(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	anchor := 0.0
+	// plot centers of a 10 meter grid anchored on the equator at the prime meridian, x east and y north
+	lonPerPlot := 0.00008983152841195214
+	latPerPlot := 0.00009043694770492493
+	mockEstate := repository.EstateEntity{
+		ID:              mockEstateID,
+		Length:          5,
+		Width:           3,
+		AnchorLatitude:  &anchor,
+		AnchorLongitude: &anchor,
+		PlotSize:        10,
+	}
+
+	tests := []struct {
+		name           string
+		params         generated.LocateEstatePlotParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedX      int
+		expectedY      int
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:   "Plot Found",
+			params: generated.LocateEstatePlotParams{Latitude: 1.2 * latPerPlot, Longitude: 3.9 * lonPerPlot},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedX:      4,
+			expectedY:      2,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Outside The Estate",
+			params: generated.LocateEstatePlotParams{Latitude: 1.2 * latPerPlot, Longitude: 5.1 * lonPerPlot},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("position is outside the estate"),
+		},
+		{
+			name:           "Latitude Out Of Range",
+			params:         generated.LocateEstatePlotParams{Latitude: 91},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("latitude must be between -90 and 90"),
+		},
+		{
+			name:   "Not Geo-Referenced",
+			params: generated.LocateEstatePlotParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 3}, nil)
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErr:    errors.New("estate is not geo-referenced"),
+		},
+		{
+			name:   "Estate Not Found",
+			params: generated.LocateEstatePlotParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.LocateEstatePlot(mockContext, mockEstateID, tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr != nil {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expectedX, *resp.X)
+			assert.Equal(t, tt.expectedY, *resp.Y)
+			assert.InDelta(t, (float64(tt.expectedY)-0.5)*latPerPlot, *resp.Latitude, 1e-9)
+			assert.InDelta(t, (float64(tt.expectedX)-0.5)*lonPerPlot, *resp.Longitude, 1e-9)
+		})
+	}
+}
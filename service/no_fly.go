@@ -0,0 +1,328 @@
+package service
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"spgo/repository"
+)
+
+// maxDetourSearch bounds the plots a detour search settles before it gives up.
+const maxDetourSearch = 1 << 20
+
+// detourSteps are the moves of a detour from a plot to its neighbours, along the axes first.
+var detourSteps = [8][2]int{{1, 0}, {0, 1}, {-1, 0}, {0, -1}, {1, 1}, {-1, 1}, {-1, -1}, {1, -1}}
+
+/*
+detour is the way around the no-fly zones between two plots: the plot centers where it turns, the meters flown
+across and the altitude cruised, the highest over the plots it crosses.
+*/
+type detour struct {
+	Turns      [][2]int
+	Horizontal float64
+	Altitude   int
+}
+
+// cost prices the detour between a plot flown at altitude from and a plot flown at altitude to.
+func (d detour) cost(meter sortieMeter, from, to int) float64 {
+	return meter(d.Horizontal, 0, 0) + verticalCost(meter, from, d.Altitude) + verticalCost(meter, d.Altitude, to)
+}
+
+// errNoFlyEstate is returned when no plot of an estate is out of the no-fly zones.
+var errNoFlyEstate = errors.New("every plot of the estate is in a no-fly zone")
+
+// unreachablePlotError is returned when no detour around the no-fly zones reaches a plot.
+type unreachablePlotError struct {
+	x, y int
+}
+
+func (e unreachablePlotError) Error() string {
+	return fmt.Sprintf("plot (%d,%d) cannot be reached around the no-fly zones", e.x, e.y)
+}
+
+/*
+flightGrid is an estate as the drone crosses it around its no-fly zones. A detour goes from plot to plot, along the
+axes or diagonally between two plots that are not in a no-fly zone, and cruises above the trees and the height
+obstacles of the plots it crosses like the flight model does.
+*/
+type flightGrid struct {
+	length, width int
+	model         flightModel
+	noFly         map[[2]int]bool
+	altitudes     map[[2]int]int
+	// noFlyIndex finds the no-fly plots crossed by a straight track, its first node is not a plot
+	noFlyIndex canopyIndex
+	// climbed are the plots under a height obstacle, with the altitude flown over them
+	climbed []inspectionNode
+}
+
+// newFlightGrid builds the grid of an estate, plots are its trees with its height obstacles.
+func newFlightGrid(estate repository.EstateEntity, obstacles obstacleMap, plots []repository.PlotEntity, model flightModel) flightGrid {
+	g := flightGrid{length: estate.Length, width: estate.Width, model: model, noFly: obstacles.noFly}
+	if len(obstacles.noFly) > 0 || len(estate.ShapeMask) > 0 {
+		g.altitudes = make(map[[2]int]int, len(plots))
+		for i := range plots {
+			x, y := int(plots[i].X), int(plots[i].Y)
+			g.altitudes[[2]int{x, y}] = model.ground(x, y) + model.altitude(&plots[i])
+		}
+	}
+	if len(obstacles.noFly) > 0 {
+		nodes := make([]inspectionNode, 0, len(obstacles.noFly)+1)
+		nodes = append(nodes, inspectionNode{})
+		for plot := range obstacles.noFly {
+			nodes = append(nodes, inspectionNode{x: plot[0], y: plot[1], altitude: 1})
+		}
+		g.noFlyIndex = newCanopyIndex(nodes)
+	}
+
+	for plot, height := range obstacles.heights {
+		if !obstacles.noFly[plot] {
+			g.climbed = append(g.climbed, inspectionNode{x: plot[0], y: plot[1], altitude: model.ground(plot[0], plot[1]) + model.altitude(&repository.PlotEntity{TreeHeight: height})})
+		}
+	}
+	return g
+}
+
+// altitude returns the altitude flown over plot (x,y).
+func (g flightGrid) altitude(plot [2]int) int {
+	if altitude, ok := g.altitudes[plot]; ok {
+		return altitude
+	}
+	return g.model.ground(plot[0], plot[1]) + g.model.altitude(nil)
+}
+
+// cruise returns the lowest altitude a detour between two plots cruises at, above the ground of both.
+func (g flightGrid) cruise(from, to [2]int) int {
+	return max(g.model.ground(from[0], from[1]), g.model.ground(to[0], to[1])) + g.model.altitude(nil)
+}
+
+// crossesNoFly reports whether the straight track between the centers of two plots crosses a no-fly plot.
+func (g flightGrid) crossesNoFly(from, to [2]int) bool {
+	if len(g.noFly) == 0 {
+		return false
+	}
+	return g.noFlyIndex.tallestAlong(float64(from[0])-0.5, float64(from[1])-0.5, float64(to[0])-0.5, float64(to[1])-0.5, 0, 0) > 0
+}
+
+// legAround returns the detour between two plots when the straight track between them crosses a no-fly plot, nil when it does not.
+func (g flightGrid) legAround(from, to [2]int) (*detour, error) {
+	if !g.crossesNoFly(from, to) {
+		return nil, nil
+	}
+	d, err := g.detour(from, to)
+	if err != nil {
+		return nil, err
+	}
+	return &d, nil
+}
+
+/*
+detour returns the shortest way from a plot to another around the no-fly zones, searched with A* under the octile
+distance. A diagonal move never cuts the corner of a no-fly plot.
+*/
+func (g flightGrid) detour(from, to [2]int) (detour, error) {
+	if len(g.noFly) == 0 {
+		return g.openDetour(from, to), nil
+	}
+
+	estimate := func(plot [2]int) float64 {
+		dx, dy := abs(plot[0]-to[0]), abs(plot[1]-to[1])
+		return float64(max(dx, dy)-min(dx, dy)) + math.Sqrt2*float64(min(dx, dy))
+	}
+	free := func(x, y int) bool {
+		return x >= 1 && y >= 1 && x <= g.length && y <= g.width && !g.noFly[[2]int{x, y}]
+	}
+
+	costs := map[[2]int]float64{from: 0}
+	parents := map[[2]int][2]int{}
+	settled := map[[2]int]bool{}
+	open := &detourQueue{{plot: from, estimate: estimate(from)}}
+	for open.Len() > 0 && len(settled) < maxDetourSearch {
+		current := heap.Pop(open).(detourNode).plot
+		if settled[current] {
+			continue
+		}
+		if current == to {
+			return g.tracedDetour(from, to, parents, costs[to]), nil
+		}
+		settled[current] = true
+
+		for _, step := range detourSteps {
+			x, y := current[0]+step[0], current[1]+step[1]
+			if !free(x, y) {
+				continue
+			}
+			length := 1.0
+			if step[0] != 0 && step[1] != 0 {
+				if !free(current[0]+step[0], current[1]) || !free(current[0], current[1]+step[1]) {
+					continue
+				}
+				length = math.Sqrt2
+			}
+			next := [2]int{x, y}
+			cost := costs[current] + length
+			if known, ok := costs[next]; ok && known <= cost {
+				continue
+			}
+			costs[next], parents[next] = cost, current
+			heap.Push(open, detourNode{plot: next, estimate: cost + estimate(next)})
+		}
+	}
+	return detour{}, unreachablePlotError{x: to[0], y: to[1]}
+}
+
+// openDetour returns the shortest way between two plots when nothing is in the way: diagonally, then along an axis.
+func (g flightGrid) openDetour(from, to [2]int) detour {
+	dx, dy := abs(to[0]-from[0]), abs(to[1]-from[1])
+	step := [2]int{sign(to[0] - from[0]), sign(to[1] - from[1])}
+	d := detour{Horizontal: (float64(max(dx, dy)-min(dx, dy)) + math.Sqrt2*float64(min(dx, dy))) * g.model.plotSize, Altitude: g.cruise(from, to)}
+	for plot := from; plot != to; {
+		next := [2]int{sign(to[0] - plot[0]), sign(to[1] - plot[1])}
+		if plot != from && next != step {
+			d.Turns = append(d.Turns, plot)
+		}
+		step = next
+		plot = [2]int{plot[0] + step[0], plot[1] + step[1]}
+		if plot != to {
+			d.Altitude = max(d.Altitude, g.altitude(plot))
+		}
+	}
+	return d
+}
+
+func sign(v int) int {
+	switch {
+	case v > 0:
+		return 1
+	case v < 0:
+		return -1
+	}
+	return 0
+}
+
+// tracedDetour walks a detour found by the search back from its end.
+func (g flightGrid) tracedDetour(from, to [2]int, parents map[[2]int][2]int, cost float64) detour {
+	d := detour{Horizontal: cost * g.model.plotSize, Altitude: g.cruise(from, to)}
+	var turns [][2]int
+	plot := to
+	for plot != from {
+		parent := parents[plot]
+		if parent != from {
+			d.Altitude = max(d.Altitude, g.altitude(parent))
+			grandparent := parents[parent]
+			if parent[0]-grandparent[0] != plot[0]-parent[0] || parent[1]-grandparent[1] != plot[1]-parent[1] {
+				turns = append(turns, parent)
+			}
+		}
+		plot = parent
+	}
+	for i, j := 0, len(turns)-1; i < j; i, j = i+1, j-1 {
+		turns[i], turns[j] = turns[j], turns[i]
+	}
+	d.Turns = turns
+	return d
+}
+
+type detourNode struct {
+	plot     [2]int
+	estimate float64
+}
+
+// detourQueue is the open set of a detour search, the plot with the lowest estimate first.
+type detourQueue []detourNode
+
+func (q detourQueue) Len() int           { return len(q) }
+func (q detourQueue) Less(i, j int) bool { return q[i].estimate < q[j].estimate }
+func (q detourQueue) Swap(i, j int)      { q[i], q[j] = q[j], q[i] }
+
+func (q *detourQueue) Push(node any) {
+	*q = append(*q, node.(detourNode))
+}
+
+func (q *detourQueue) Pop() any {
+	old := *q
+	node := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return node
+}
+
+/*
+cutRuns takes the no-fly plots out of the runs of a traversal and cuts them at its breaks. The drone goes on from
+the last plot it flew before no-fly plots or a break to the next one of the traversal by a detour, kept on the run
+the detour leads to.
+*/
+func (g flightGrid) cutRuns(runs []flightRun, trav traversal) ([]flightRun, error) {
+	breaks := trav.breaks()
+	if len(g.noFly) == 0 && len(breaks) == 0 {
+		return runs, nil
+	}
+
+	orders := make([]int, 0, len(g.noFly))
+	for plot := range g.noFly {
+		orders = append(orders, trav.order(plot[0], plot[1]))
+	}
+	sort.Ints(orders)
+
+	cut := make([]flightRun, 0, len(runs)+len(orders)+len(breaks))
+	last, k, b := 0, 0, 0
+	for _, run := range runs {
+		for from := run.From; from <= run.To; {
+			for k < len(orders) && orders[k] < from {
+				k++
+			}
+			if k < len(orders) && orders[k] == from {
+				from++
+				continue
+			}
+
+			to := run.To
+			if k < len(orders) {
+				to = min(to, orders[k]-1)
+			}
+			for b < len(breaks) && breaks[b] < from {
+				b++
+			}
+			if b < len(breaks) {
+				to = min(to, breaks[b])
+			}
+			piece := flightRun{From: from, To: to, Altitude: run.Altitude, Ground: run.Ground}
+			if last > 0 && (from > last+1 || b > 0 && breaks[b-1] == last) {
+				fromX, fromY := trav.plot(last)
+				toX, toY := trav.plot(from)
+				d, err := g.detour([2]int{fromX, fromY}, [2]int{toX, toY})
+				if err != nil {
+					return nil, err
+				}
+				piece.Detour = &d
+			}
+			cut = append(cut, piece)
+			last, from = to, to+1
+		}
+	}
+
+	if len(cut) == 0 {
+		return nil, errNoFlyEstate
+	}
+	return cut, nil
+}
+
+// flownPlots returns the first and the last plot flown among the plots from..to of the traversal and how many are flown.
+func flownPlots(runs []flightRun, from, to int) (int, int, int) {
+	first, last, count := 0, 0, 0
+	start := sort.Search(len(runs), func(i int) bool { return runs[i].To >= from })
+	for _, run := range runs[start:] {
+		if run.From > to {
+			break
+		}
+		runFrom, runTo := max(run.From, from), min(run.To, to)
+		if first == 0 {
+			first = runFrom
+		}
+		last = runTo
+		count += runTo - runFrom + 1
+	}
+	return first, last, count
+}
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/repository"
+)
+
+// noFlyGrid returns the grid of an estate with no-fly plots, plots are its trees.
+func noFlyGrid(estate repository.EstateEntity, model flightModel, plots []repository.PlotEntity, noFly ...[2]int) flightGrid {
+	obstacles := newObstacleMap(nil)
+	for _, plot := range noFly {
+		obstacles.noFly[plot] = true
+	}
+	return newFlightGrid(estate, obstacles, plots, model)
+}
+
+func TestFlightGrid_Detour(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+	estate := repository.EstateEntity{Length: 5, Width: 3}
+
+	t.Run("Goes Around Without Cutting Corners", func(t *testing.T) {
+		// a tree of 5 meters on plot (2,2), next to the no-fly plot (3,1)
+		plots := []repository.PlotEntity{{X: 2, Y: 2, TreeHeight: 5}}
+		grid := noFlyGrid(estate, model, plots, [2]int{3, 1})
+
+		d, err := grid.detour([2]int{2, 1}, [2]int{4, 1})
+		require.NoError(t, err)
+		assert.Equal(t, detour{Turns: [][2]int{{2, 2}, {4, 2}}, Horizontal: 40, Altitude: 6}, d)
+	})
+
+	t.Run("Straight Track Clear Of The No-Fly Plots", func(t *testing.T) {
+		grid := noFlyGrid(estate, model, nil, [2]int{3, 1})
+
+		d, err := grid.legAround([2]int{1, 3}, [2]int{5, 2})
+		require.NoError(t, err)
+		assert.Nil(t, d)
+
+		d, err = grid.legAround([2]int{1, 1}, [2]int{5, 1})
+		require.NoError(t, err)
+		assert.InDelta(t, 20+20*math.Sqrt2, d.Horizontal, 1e-9)
+	})
+
+	t.Run("Plot Walled In", func(t *testing.T) {
+		grid := noFlyGrid(estate, model, nil, [2]int{4, 1}, [2]int{4, 2}, [2]int{5, 2})
+
+		_, err := grid.detour([2]int{1, 1}, [2]int{5, 1})
+		assert.Equal(t, unreachablePlotError{x: 5, y: 1}, err)
+	})
+}
+
+func TestFlightGrid_CutRuns(t *testing.T) {
+	model := flightModel{plotSize: 10, clearance: 1}
+	estate := repository.EstateEntity{Length: 5, Width: 3}
+	trav := newTraversal(estate)
+	grid := noFlyGrid(estate, model, nil, [2]int{3, 1})
+
+	runs, err := grid.cutRuns(flightRuns(trav.plotCount(), nil, model), trav)
+	require.NoError(t, err)
+	assert.Equal(t, []flightRun{
+		{From: 1, To: 2},
+		{From: 4, To: 15, Detour: &detour{Turns: [][2]int{{2, 2}, {4, 2}}, Horizontal: 40}},
+	}, runs)
+
+	// plot 2 to plot 4 is 20 meters over plot 3, the detour is 40
+	assert.Equal(t, 170, model.runsDistance(runs))
+	plots := []repository.PlotEntity{{OrderNumber: 2}, {OrderNumber: 4}, {OrderNumber: 15}}
+	assert.Equal(t, []int{20, 60, 170}, model.runDistances(runs, plots))
+
+	first, last, count := flownPlots(runs, 3, 5)
+	assert.Equal(t, []int{4, 5, 2}, []int{first, last, count})
+
+	t.Run("Sorties Take The Detour", func(t *testing.T) {
+		maxDistance := 60
+		sorties, err := splitSorties(runs, model, &maxDistance)
+		require.NoError(t, err)
+		// plot 1 to plot 5 is 10 meters, the detour and 10 meters more
+		assert.Equal(t, []missionSortie{{From: 1, To: 5}, {From: 6, To: 12}, {From: 13, To: 15}}, sorties)
+
+		horizontal, _, _ := sortieLegs(runs, missionSortie{From: 1, To: 5}, model)
+		assert.Equal(t, 60.0, horizontal)
+	})
+
+	t.Run("Rest Plot Counts The Detour", func(t *testing.T) {
+		assert.Equal(t, 2, model.restPlot(runs, 49))
+		assert.Equal(t, 4, model.restPlot(runs, 60))
+	})
+
+	t.Run("Every Plot In A No-Fly Zone", func(t *testing.T) {
+		grid := noFlyGrid(repository.EstateEntity{Length: 2, Width: 1}, model, nil, [2]int{1, 1}, [2]int{2, 1})
+
+		_, err := grid.cutRuns(flightRuns(2, nil, model), newTraversal(repository.EstateEntity{Length: 2, Width: 1}))
+		assert.Equal(t, errNoFlyEstate, err)
+	})
+}
+
+func TestFlightModel_RunDistances(t *testing.T) {
+	// without no-fly plots the distances along the runs are the incremental ones
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		estate := repository.EstateEntity{Length: rng.Intn(10) + 1, Width: rng.Intn(10) + 1}
+		trav := newTraversal(estate)
+		model := flightModel{plotSize: float64(rng.Intn(20) + 1), clearance: rng.Intn(3), minCruiseAltitude: rng.Intn(10), startEndAltitude: rng.Intn(5)}
+
+		var plots []repository.PlotEntity
+		for x := 1; x <= estate.Length; x++ {
+			for y := 1; y <= estate.Width; y++ {
+				if rng.Intn(3) == 0 {
+					plots = append(plots, repository.PlotEntity{X: uint16(x), Y: uint16(y), TreeHeight: rng.Intn(30) + 1})
+				}
+			}
+		}
+		trav.orderPlots(plots)
+
+		runs := flightRuns(trav.plotCount(), plots, model)
+		distances := model.runDistances(runs, plots)
+		var behind *repository.PlotEntity
+		for j := range plots {
+			plots[j].Distance = model.plotDistance(plots[j], behind)
+			require.Equal(t, plots[j].Distance, distances[j], "estate %d", i)
+			behind = &plots[j]
+		}
+		require.Equal(t, model.totalDistance(trav.plotCount(), plots), model.runsDistance(runs), "estate %d", i)
+	}
+}
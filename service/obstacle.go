@@ -0,0 +1,231 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxBlockedPlots bounds the plots under the obstacles of an estate, the no-fly plots are looked up on every detour.
+const maxBlockedPlots = 10000
+
+// obstacle shapes as stored, a rectangle is a single area and a list of plots an area per plot.
+const (
+	obstacleShapeRectangle = "rectangle"
+	obstacleShapePlots     = "plots"
+)
+
+// obstacleMap holds the plots of an estate under an obstacle: the height of the tallest obstacle over a plot and the no-fly plots.
+type obstacleMap struct {
+	heights map[[2]int]int
+	noFly   map[[2]int]bool
+}
+
+func newObstacleMap(obstacles []repository.ObstacleEntity) obstacleMap {
+	m := obstacleMap{heights: map[[2]int]int{}, noFly: map[[2]int]bool{}}
+	for _, obstacle := range obstacles {
+		m.add(obstacle)
+	}
+	return m
+}
+
+// add puts the plots of an obstacle in the map.
+func (m obstacleMap) add(obstacle repository.ObstacleEntity) {
+	for _, area := range obstacle.Areas {
+		for x := int(area.MinX); x <= int(area.MaxX); x++ {
+			for y := int(area.MinY); y <= int(area.MaxY); y++ {
+				if obstacle.NoFly {
+					m.noFly[[2]int{x, y}] = true
+				} else if obstacle.Height != nil {
+					m.heights[[2]int{x, y}] = max(m.heights[[2]int{x, y}], *obstacle.Height)
+				}
+			}
+		}
+	}
+}
+
+// blocked reports whether plot (x,y) is under an obstacle.
+func (m obstacleMap) blocked(x, y int) bool {
+	_, climbed := m.heights[[2]int{x, y}]
+	return climbed || m.noFly[[2]int{x, y}]
+}
+
+// count returns the number of plots under an obstacle.
+func (m obstacleMap) count() int {
+	count := 0
+	m.each(func(int, int) { count++ })
+	return count
+}
+
+// each calls visit with every plot under an obstacle.
+func (m obstacleMap) each(visit func(x, y int)) {
+	for plot := range m.noFly {
+		visit(plot[0], plot[1])
+	}
+	for plot := range m.heights {
+		if !m.noFly[plot] {
+			visit(plot[0], plot[1])
+		}
+	}
+}
+
+// countIn returns the number of plots under an obstacle in a region.
+func (m obstacleMap) countIn(region repository.PlotRegion) int {
+	count := 0
+	m.each(func(x, y int) {
+		if x >= region.MinX && x <= region.MaxX && y >= region.MinY && y <= region.MaxY {
+			count++
+		}
+	})
+	return count
+}
+
+// countByTile returns the number of plots under an obstacle in a region by tile of tileWidth by tileLength plots.
+func (m obstacleMap) countByTile(region repository.PlotRegion, tileWidth int, tileLength int) map[[2]int]int {
+	counts := map[[2]int]int{}
+	m.each(func(x, y int) {
+		if x >= region.MinX && x <= region.MaxX && y >= region.MinY && y <= region.MaxY {
+			counts[[2]int{(x - 1) / tileWidth, (y - 1) / tileLength}]++
+		}
+	})
+	return counts
+}
+
+/*
+withHeights returns the trees by order number in the traversal with a tree on every plot under a height obstacle,
+so the drone climbs over the obstacle like over a tree. The no-fly plots are never flown over, they are left out.
+*/
+func (m obstacleMap) withHeights(plots []repository.PlotEntity, trav traversal) []repository.PlotEntity {
+	if len(m.heights) == 0 {
+		return plots
+	}
+
+	merged := make([]repository.PlotEntity, 0, len(plots)+len(m.heights))
+	merged = append(merged, plots...)
+	for plot, height := range m.heights {
+		if !m.noFly[plot] {
+			merged = append(merged, repository.PlotEntity{X: uint16(plot[0]), Y: uint16(plot[1]), OrderNumber: trav.order(plot[0], plot[1]), TreeHeight: height})
+		}
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		return merged[i].OrderNumber < merged[j].OrderNumber
+	})
+	return merged
+}
+
+// loadObstacles returns the obstacles of an estate, they are not loaded when no plot of the estate is blocked.
+func (s *Service) loadObstacles(ctx context.Context, estate repository.EstateEntity) (obstacleMap, error) {
+	if estate.BlockedPlots == 0 {
+		return newObstacleMap(nil), nil
+	}
+
+	obstacles, err := s.Repository.GetObstacles(ctx, estate.ID)
+	if err != nil {
+		return obstacleMap{}, err
+	}
+	return newObstacleMap(obstacles), nil
+}
+
+/*
+withObstacles returns the trees of an estate the drone flies over by order number in the traversal with its height
+obstacles, and the grid the drone detours around its no-fly zones on. The model of the grid flies over the terrain of
+the estate.
+*/
+func (s *Service) withObstacles(ctx context.Context, estate repository.EstateEntity, trav traversal, plots []repository.PlotEntity, model flightModel) ([]repository.PlotEntity, flightGrid, error) {
+	obstacles, err := s.loadObstacles(ctx, estate)
+	if err != nil {
+		return nil, flightGrid{}, err
+	}
+	if model.terrain, err = s.loadTerrain(ctx, estate, model); err != nil {
+		return nil, flightGrid{}, err
+	}
+	plots = obstacles.withHeights(flownTrees(estate, plots), trav)
+	return plots, newFlightGrid(estate, obstacles, plots, model), nil
+}
+
+// obstacleRuns returns the runs of a flight mode over the trees of an estate with its obstacles and its terrain, cut around its no-fly zones and at the gaps of its shape.
+func (s *Service) obstacleRuns(ctx context.Context, estate repository.EstateEntity, trav traversal, mode generated.FlightMode, plots []repository.PlotEntity) ([]flightRun, int, error) {
+	plots, grid, err := s.withObstacles(ctx, estate, trav, plots, newFlightModel(estate))
+	if err != nil {
+		return nil, http.StatusInternalServerError, err
+	}
+
+	runs, err := grid.cutRuns(altitudeRuns(mode, trav, plots, grid.model), trav)
+	if err != nil {
+		return nil, http.StatusBadRequest, err
+	}
+	return runs, http.StatusOK, nil
+}
+
+// obstacleAreas returns the areas of an obstacle request within an estate with the shape they were given in.
+func obstacleAreas(req generated.ObstacleRequest, estate repository.EstateEntity) ([]repository.ObstacleAreaEntity, string, error) {
+	if (req.Rectangle == nil) == (req.Plots == nil) {
+		return nil, "", errors.New("an obstacle must have either a rectangle or plots")
+	}
+
+	// obstacles stand within the shape of the estate, so every plot under them has an order number
+	shape := estateShapeOf(estate)
+	if req.Rectangle != nil {
+		r := *req.Rectangle
+		if r.MinX > r.MaxX || r.MinY > r.MaxY {
+			return nil, "", errors.New("min_x and min_y of the rectangle cannot be greater than max_x and max_y")
+		}
+		region := repository.PlotRegion{MinX: r.MinX, MinY: r.MinY, MaxX: r.MaxX, MaxY: r.MaxY}
+		if r.MinX < 1 || r.MinY < 1 || r.MaxX > estate.Length || r.MaxY > estate.Width || shape != nil && shape.countIn(region) != plotCount(region) {
+			return nil, "", errors.New("rectangle is out of range")
+		}
+		if (r.MaxX-r.MinX+1)*(r.MaxY-r.MinY+1) > maxBlockedPlots {
+			return nil, "", fmt.Errorf("an estate cannot have more than %d plots under obstacles", maxBlockedPlots)
+		}
+		return []repository.ObstacleAreaEntity{{MinX: uint16(r.MinX), MinY: uint16(r.MinY), MaxX: uint16(r.MaxX), MaxY: uint16(r.MaxY)}}, obstacleShapeRectangle, nil
+	}
+
+	plots := *req.Plots
+	if len(plots) == 0 {
+		return nil, "", errors.New("plots of an obstacle cannot be empty")
+	}
+	if len(plots) > maxBlockedPlots {
+		return nil, "", fmt.Errorf("an estate cannot have more than %d plots under obstacles", maxBlockedPlots)
+	}
+	areas := make([]repository.ObstacleAreaEntity, 0, len(plots))
+	seen := make(map[[2]int]bool, len(plots))
+	for _, plot := range plots {
+		if plot.X == nil || plot.Y == nil {
+			return nil, "", errors.New("every plot of an obstacle must have x and y")
+		}
+		x, y := *plot.X, *plot.Y
+		if x < 1 || y < 1 || x > estate.Length || y > estate.Width || shape != nil && !shape.contains(x, y) {
+			return nil, "", fmt.Errorf("plot (%d,%d) is out of range", x, y)
+		}
+		if seen[[2]int{x, y}] {
+			continue
+		}
+		seen[[2]int{x, y}] = true
+		areas = append(areas, repository.ObstacleAreaEntity{MinX: uint16(x), MinY: uint16(y), MaxX: uint16(x), MaxY: uint16(y)})
+	}
+	return areas, obstacleShapePlots, nil
+}
+
+// obstacleResponse returns an obstacle in the shape it was given in.
+func obstacleResponse(obstacle repository.ObstacleEntity) generated.Obstacle {
+	noFly := obstacle.NoFly
+	resp := generated.Obstacle{Id: &obstacle.ID, Height: obstacle.Height, NoFly: &noFly}
+	if obstacle.Shape == obstacleShapeRectangle && len(obstacle.Areas) == 1 {
+		area := obstacle.Areas[0]
+		resp.Rectangle = &generated.PlotRectangle{MinX: int(area.MinX), MinY: int(area.MinY), MaxX: int(area.MaxX), MaxY: int(area.MaxY)}
+		return resp
+	}
+
+	plots := make([]generated.PlotPosition, len(obstacle.Areas))
+	for i, area := range obstacle.Areas {
+		x, y := int(area.MinX), int(area.MinY)
+		plots[i] = generated.PlotPosition{X: &x, Y: &y}
+	}
+	resp.Plots = &plots
+	return resp
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+)
+
+func (s *Service) PlanEstateFleet(ctx context.Context, estateId uuid.UUID, req generated.FleetRequest) (generated.FleetPlan, int, error) {
+	mode, err := parseFlightMode(req.FlightMode)
+	if err != nil {
+		return generated.FleetPlan{}, http.StatusBadRequest, err
+	}
+	if len(req.Drones) < 1 || len(req.Drones) > maxFleetDrones {
+		return generated.FleetPlan{}, http.StatusBadRequest, fmt.Errorf("drones must list between 1 and %d drones", maxFleetDrones)
+	}
+	drones := make([]int, len(req.Drones))
+	for i, drone := range req.Drones {
+		if drone.MaxDistance < 1 {
+			return generated.FleetPlan{}, http.StatusBadRequest, fmt.Errorf("max_distance of drone %d must be at least 1", i+1)
+		}
+		drones[i] = drone.MaxDistance
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FleetPlan{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.FleetPlan{}, http.StatusInternalServerError, err
+	}
+
+	plots, err := s.Repository.GetPlots(ctx, estateId)
+	if err != nil {
+		return generated.FleetPlan{}, http.StatusInternalServerError, err
+	}
+
+	trav := newTraversal(estate)
+	model := newFlightModel(estate)
+	runs, httpStatus, err := s.obstacleRuns(ctx, estate, trav, mode, plots)
+	if err != nil {
+		return generated.FleetPlan{}, httpStatus, err
+	}
+	legs, err := planFleet(runs, trav.plotCount(), drones, model)
+	if err != nil {
+		return generated.FleetPlan{}, http.StatusBadRequest, err
+	}
+
+	makespan := 0
+	assignments := make([]generated.FleetAssignment, len(legs))
+	for i, leg := range legs {
+		drone := i + 1
+		// the no-fly plots of the segment are not swept
+		first, last, plotCount := flownPlots(runs, leg.From, leg.To)
+		distance := int(math.Round(leg.Distance))
+		makespan = max(makespan, distance)
+		assignments[i] = generated.FleetAssignment{Drone: &drone, Plots: &plotCount, Distance: &distance, Landings: &legs[i].Landings}
+		if plotCount > 0 {
+			startX, startY := trav.plot(first)
+			endX, endY := trav.plot(last)
+			assignments[i].Start = &generated.PlotPosition{X: &startX, Y: &startY}
+			assignments[i].End = &generated.PlotPosition{X: &endX, Y: &endY}
+		}
+	}
+
+	return generated.FleetPlan{FlightMode: &mode, Makespan: &makespan, Drones: &assignments}, http.StatusOK, nil
+}
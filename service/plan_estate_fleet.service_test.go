@@ -0,0 +1,129 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_PlanEstateFleet(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	terrainFollowing := generated.TerrainFollowing
+	invalidMode := generated.FlightMode("hovering")
+
+	// a row of 10 empty plots, every drone flies 10 meters from a plot center to the next
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 10, Width: 1, PlotSize: 10, Clearance: 1}
+	twoDrones := []generated.FleetDrone{{MaxDistance: 1000}, {MaxDistance: 1000}}
+	position := func(x, y int) *generated.PlotPosition {
+		return &generated.PlotPosition{X: &x, Y: &y}
+	}
+
+	tests := []struct {
+		name           string
+		request        generated.FleetRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		expectedResp   generated.FleetPlan
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Balanced Segments",
+			request: generated.FleetRequest{Drones: twoDrones},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+			},
+			expectedResp: generated.FleetPlan{
+				FlightMode: &terrainFollowing,
+				Makespan:   &[]int{40}[0],
+				Drones: &[]generated.FleetAssignment{
+					{Drone: &[]int{1}[0], Start: position(1, 1), End: position(5, 1), Plots: &[]int{5}[0], Distance: &[]int{40}[0], Landings: &[]int{1}[0]},
+					{Drone: &[]int{2}[0], Start: position(6, 1), End: position(10, 1), Plots: &[]int{5}[0], Distance: &[]int{40}[0], Landings: &[]int{1}[0]},
+				},
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "No Drone",
+			request:        generated.FleetRequest{Drones: []generated.FleetDrone{}},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("drones must list between 1 and 100 drones"),
+		},
+		{
+			name:           "Invalid Max Distance",
+			request:        generated.FleetRequest{Drones: []generated.FleetDrone{{MaxDistance: 1000}, {MaxDistance: -5}}},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_distance of drone 2 must be at least 1"),
+		},
+		{
+			name:           "Invalid Flight Mode",
+			request:        generated.FleetRequest{Drones: twoDrones, FlightMode: &invalidMode},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("flight_mode must be one of terrain_following, fixed_estate_max or fixed_row_max"),
+		},
+		{
+			name:    "Battery Too Short",
+			request: generated.FleetRequest{Drones: []generated.FleetDrone{{MaxDistance: 20}}},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{{X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10}}, nil)
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("the drones cannot sweep the whole estate, their max_distance is too short"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.FleetRequest{Drones: twoDrones},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:    "Repository Error",
+			request: generated.FleetRequest{Drones: twoDrones},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo})
+			resp, status, err := svc.PlanEstateFleet(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/util"
+)
+
+// maxPlantingSpacing bounds the spacing between the saplings and the trees.
+const maxPlantingSpacing = 10
+
+// maxEstateTrees is the most trees an estate holds, the tree_count check of the estate table.
+const maxEstateTrees = 1000
+
+func (s *Service) PlanEstatePlanting(ctx context.Context, estateId uuid.UUID, req generated.PlantingRequest) (generated.PlantingPlan, int, error) {
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	spacing := 1
+	if req.MinSpacing != nil {
+		spacing = *req.MinSpacing
+	}
+	if spacing < 1 || spacing > maxPlantingSpacing {
+		err = fmt.Errorf("min_spacing must be between 1 and %d", maxPlantingSpacing)
+		return generated.PlantingPlan{}, http.StatusBadRequest, err
+	}
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.PlantingPlan{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.PlantingPlan{}, http.StatusInternalServerError, err
+	}
+
+	var avoidRows []int
+	if req.AvoidRows != nil {
+		avoidRows = *req.AvoidRows
+	}
+	for _, row := range avoidRows {
+		if row < 1 || row > estate.Width {
+			err = errors.New("avoid_rows must be within the width of the estate")
+			return generated.PlantingPlan{}, http.StatusBadRequest, err
+		}
+	}
+
+	// the saplings are capped by their species like any planted tree
+	status, err := s.checkTreeHeight(nCtx, req.SpeciesId, req.Height)
+	if err != nil {
+		return generated.PlantingPlan{}, status, err
+	}
+
+	plots, err := s.Repository.GetPlots(nCtx, estateId)
+	if err != nil {
+		return generated.PlantingPlan{}, http.StatusInternalServerError, err
+	}
+	obstacles, err := s.loadObstacles(nCtx, estate)
+	if err != nil {
+		return generated.PlantingPlan{}, http.StatusInternalServerError, err
+	}
+	model := newFlightModel(estate)
+	if model.terrain, err = s.loadTerrain(nCtx, estate, model); err != nil {
+		return generated.PlantingPlan{}, http.StatusInternalServerError, err
+	}
+
+	trav := newTraversal(estate)
+	sapling := repository.PlotEntity{EstateId: estate.ID, TreeHeight: req.Height, SpeciesId: req.SpeciesId}
+	planner := newPlantingPlanner(trav, model, obstacles, plots, obstacles.withHeights(flownTrees(estate, plots), trav), sapling, spacing, avoidRows)
+	orders, added := planner.pick(req.Count)
+
+	saplings := make([]repository.PlotEntity, len(orders))
+	picked := make(map[int]int, len(orders))
+	for i, order := range orders {
+		x, y := trav.plot(order)
+		saplings[i] = sapling
+		saplings[i].X, saplings[i].Y, saplings[i].OrderNumber = uint16(x), uint16(y), order
+		picked[order] = i
+	}
+
+	// the saplings are flown with the trees, over the terrain and around the obstacles like the drone plan
+	planted := append(append([]repository.PlotEntity{}, plots...), saplings...)
+	sort.Slice(planted, func(i, j int) bool {
+		return planted[i].OrderNumber < planted[j].OrderNumber
+	})
+	flown := obstacles.withHeights(flownTrees(estate, planted), trav)
+	grid := newFlightGrid(estate, obstacles, flown, model)
+	runs, err := grid.cutRuns(altitudeRuns(generated.TerrainFollowing, trav, flown, grid.model), trav)
+	if err != nil {
+		return generated.PlantingPlan{}, http.StatusBadRequest, err
+	}
+	distance := model.runsDistance(runs)
+	for i, plotDistance := range model.runDistances(runs, planted) {
+		if j, ok := picked[planted[i].OrderNumber]; ok {
+			saplings[j].Distance = plotDistance
+		}
+	}
+	addedDistance := distance - estate.TotalDistance
+
+	suggestions := make([]generated.PlantingSuggestion, len(saplings))
+	for i := range saplings {
+		x, y := int(saplings[i].X), int(saplings[i].Y)
+		suggestions[i] = generated.PlantingSuggestion{X: &x, Y: &y, AddedDistance: &added[i]}
+	}
+
+	committed := req.Commit != nil && *req.Commit && len(saplings) > 0
+	resp := generated.PlantingPlan{
+		Plots:         &suggestions,
+		AddedDistance: &addedDistance,
+		Distance:      &distance,
+		Committed:     &committed,
+	}
+	if !committed {
+		return resp, http.StatusOK, nil
+	}
+	if estate.TreeCount+len(saplings) > maxEstateTrees {
+		err = fmt.Errorf("an estate cannot hold more than %d trees, it has %d", maxEstateTrees, estate.TreeCount)
+		return generated.PlantingPlan{}, http.StatusBadRequest, err
+	}
+
+	ids, err := s.plantSaplings(nCtx, &estate, saplings)
+	if err != nil {
+		return generated.PlantingPlan{}, http.StatusInternalServerError, err
+	}
+	for i := range suggestions {
+		suggestions[i].Id = &ids[i]
+	}
+	return resp, http.StatusCreated, nil
+}
+
+// plantSaplings inserts the saplings of a planting with their first measurement, and refreshes the distances and the stats of the estate.
+func (s *Service) plantSaplings(ctx context.Context, estate *repository.EstateEntity, saplings []repository.PlotEntity) ([]uuid.UUID, error) {
+	ids, err := s.Repository.PostPlots(ctx, saplings)
+	if err != nil {
+		return nil, err
+	}
+
+	// the planted height is the first measurement of the growth history of every sapling
+	measuredAt := time.Now()
+	measurements := make([]repository.TreeMeasurementEntity, len(saplings))
+	for i := range saplings {
+		measurements[i] = repository.TreeMeasurementEntity{
+			PlotId:     ids[i],
+			EstateId:   estate.ID,
+			Height:     saplings[i].TreeHeight,
+			MeasuredAt: measuredAt,
+		}
+	}
+	if err = s.Repository.PostTreeMeasurements(ctx, measurements); err != nil {
+		return nil, err
+	}
+
+	// the trees after the saplings are flown at a new distance
+	if err = s.recomputeFlightDistances(ctx, estate); err != nil {
+		return nil, err
+	}
+	estate.TreeCount += len(saplings)
+	return ids, s.saveTreeHeightStats(ctx, *estate)
+}
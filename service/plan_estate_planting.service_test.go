@@ -0,0 +1,219 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_PlanEstatePlanting(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockSpeciesID := uuid.New()
+	mockSaplingID := uuid.New()
+	mockContext := context.TODO()
+	commit := true
+	spacing := 2
+
+	// a row of 5 plots with a tree of 10 meters in the middle
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 1, TotalDistance: 72, PlotSize: 10, Clearance: 1, TreeCount: 1}
+	mockTree := repository.PlotEntity{ID: uuid.New(), EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 10, Distance: 41}
+
+	tests := []struct {
+		name           string
+		request        generated.PlantingRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.PlantingPlan
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Suggests The Plots Adding The Least",
+			request: generated.PlantingRequest{Count: 2, Height: 4},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{mockTree}, nil)
+				mock.ExpectCommit()
+			},
+			// the drone climbs to the tree anyway, the saplings before it are flown on the way up
+			expectedResp: generated.PlantingPlan{
+				Plots: &[]generated.PlantingSuggestion{
+					{X: &[]int{2}[0], Y: &[]int{1}[0], AddedDistance: &[]int{0}[0]},
+					{X: &[]int{1}[0], Y: &[]int{1}[0], AddedDistance: &[]int{0}[0]},
+				},
+				AddedDistance: &[]int{0}[0],
+				Distance:      &[]int{72}[0],
+				Committed:     &[]bool{false}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Plants The Saplings",
+			request: generated.PlantingRequest{Count: 1, Height: 4, SpeciesId: &mockSpeciesID, MinSpacing: &spacing, Commit: &commit},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetSpecies(gomock.Any(), mockSpeciesID).Return(repository.SpeciesEntity{ID: mockSpeciesID, MaxHeight: 20}, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{mockTree}, nil)
+				// the sapling is inserted at its distance, the drone climbs 5 meters over it and crosses its plot
+				sapling := repository.PlotEntity{EstateId: mockEstateID, X: 1, Y: 1, OrderNumber: 1, TreeHeight: 4, Distance: 15, SpeciesId: &mockSpeciesID}
+				mockRepo.EXPECT().PostPlots(gomock.Any(), []repository.PlotEntity{sapling}).Return([]uuid.UUID{mockSaplingID}, nil)
+				mockRepo.EXPECT().PostTreeMeasurements(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, measurements []repository.TreeMeasurementEntity) error {
+					require.Len(t, measurements, 1)
+					require.Equal(t, []any{mockSaplingID, 4}, []any{measurements[0].PlotId, measurements[0].Height})
+					return nil
+				})
+				sapling.ID = mockSaplingID
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{sapling, mockTree}, nil)
+				// the drone comes down from the sapling before it climbs to the tree
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, []any{mockTree.ID, 51}, []any{plot.ID, plot.Distance})
+					return &plot.ID, nil
+				})
+				mockRepo.EXPECT().GetTreeHeightStats(gomock.Any(), mockEstateID).Return(repository.TreeHeightStats{Count: 2, Min: 4, Max: 10, Median: 7}, nil)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{2, 82, 4, 10, 7}, []int{estate.TreeCount, estate.TotalDistance, estate.TreeMinHeight, estate.TreeMaxHeight, estate.TreeMedianHeight})
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.PlantingPlan{
+				Plots: &[]generated.PlantingSuggestion{
+					{Id: &mockSaplingID, X: &[]int{1}[0], Y: &[]int{1}[0], AddedDistance: &[]int{10}[0]},
+				},
+				AddedDistance: &[]int{10}[0],
+				Distance:      &[]int{82}[0],
+				Committed:     &commit,
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:    "No Free Plot",
+			request: generated.PlantingRequest{Count: 1, Height: 4, AvoidRows: &[]int{1}, Commit: &commit},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{mockTree}, nil)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.PlantingPlan{
+				Plots:         &[]generated.PlantingSuggestion{},
+				AddedDistance: &[]int{0}[0],
+				Distance:      &[]int{72}[0],
+				Committed:     &[]bool{false}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Too Many Trees",
+			request: generated.PlantingRequest{Count: 2, Height: 4, Commit: &commit},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				estate := mockEstate
+				estate.TreeCount = 999
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(estate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{mockTree}, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("an estate cannot hold more than 1000 trees, it has 999"),
+		},
+		{
+			name:    "Min Spacing Out Of Range",
+			request: generated.PlantingRequest{Count: 1, Height: 4, MinSpacing: &[]int{11}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("min_spacing must be between 1 and 10"),
+		},
+		{
+			name:    "Avoided Row Out Of The Estate",
+			request: generated.PlantingRequest{Count: 1, Height: 4, AvoidRows: &[]int{2}},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("avoid_rows must be within the width of the estate"),
+		},
+		{
+			name:    "Height Exceeds Without Species",
+			request: generated.PlantingRequest{Count: 1, Height: 31},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("height must be between 1 and 30 for a tree without species"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.PlantingRequest{Count: 1, Height: 4},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:    "Repository Error",
+			request: generated.PlantingRequest{Count: 1, Height: 4, Commit: &commit},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{mockTree}, nil)
+				mockRepo.EXPECT().PostPlots(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.PlanEstatePlanting(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
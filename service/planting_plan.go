@@ -0,0 +1,205 @@
+package service
+
+import (
+	"container/heap"
+	"sort"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+/*
+plantingPlanner picks the plots saplings are planted on. runs are the altitudes the drone flies at over the plots by
+order number above the lowest plot of the estate, planted the altitudes over the plots picked so far and occupied
+the plots closer than the spacing to a tree or a sapling. The plots are never looked at one by one: but the first
+and the last one, the plots of a run lie between two plots flown at the altitude of the run, so a sapling on any of
+them adds the same distance.
+*/
+type plantingPlanner struct {
+	trav      traversal
+	model     flightModel
+	obstacles obstacleMap
+	sapling   repository.PlotEntity
+	spacing   int
+	plotCount int
+	runs      []flightRun
+	planted   map[int]int
+	occupied  map[[2]int]bool
+	avoided   map[int]bool
+}
+
+/*
+newPlantingPlanner builds the planner of an estate, trees are its trees and flown its trees with its height
+obstacles, by order number. A plot is free when it holds no tree, is out of the obstacles and the avoided rows, and
+has no tree closer than spacing plots along x and y.
+*/
+func newPlantingPlanner(trav traversal, model flightModel, obstacles obstacleMap, trees []repository.PlotEntity, flown []repository.PlotEntity, sapling repository.PlotEntity, spacing int, avoidRows []int) plantingPlanner {
+	p := plantingPlanner{
+		trav:      trav,
+		model:     model,
+		obstacles: obstacles,
+		sapling:   sapling,
+		spacing:   spacing,
+		plotCount: trav.plotCount(),
+		runs:      altitudeRuns(generated.TerrainFollowing, trav, flown, model),
+		planted:   map[int]int{},
+		occupied:  map[[2]int]bool{},
+		avoided:   make(map[int]bool, len(avoidRows)),
+	}
+	for _, row := range avoidRows {
+		p.avoided[row] = true
+	}
+	for _, tree := range trees {
+		p.occupy(int(tree.X), int(tree.Y))
+	}
+	return p
+}
+
+// occupy takes the plots closer than the spacing to a tree on plot (x,y) out of the free plots.
+func (p plantingPlanner) occupy(x, y int) {
+	for dy := 1 - p.spacing; dy < p.spacing; dy++ {
+		for dx := 1 - p.spacing; dx < p.spacing; dx++ {
+			if x+dx >= 1 && x+dx <= p.trav.length && y+dy >= 1 && y+dy <= p.trav.width {
+				p.occupied[[2]int{x + dx, y + dy}] = true
+			}
+		}
+	}
+}
+
+/*
+nextFree returns the order number of the first free plot from an order number up to another, past the last one when
+there is none. A leg along an avoided row is skipped at once.
+*/
+func (p plantingPlanner) nextFree(order, last int) int {
+	for ; order <= last; order++ {
+		x, y := p.trav.plot(order)
+		if p.avoided[y] {
+			if end := p.trav.legEnd(order); end > order {
+				if _, nextY := p.trav.plot(order + 1); nextY == y {
+					order = min(end, last)
+				}
+			}
+			continue
+		}
+		if !p.occupied[[2]int{x, y}] && !p.obstacles.blocked(x, y) {
+			return order
+		}
+	}
+	return order
+}
+
+// altitude returns the altitude over the plot at an order number, the drone is at the start/end altitude before the
+// first plot and after the last one.
+func (p plantingPlanner) altitude(order int) int {
+	switch {
+	case order < 1:
+		return p.model.ground(p.trav.plot(1)) + p.model.startEndAltitude
+	case order > p.plotCount:
+		return p.model.ground(p.trav.plot(p.plotCount)) + p.model.startEndAltitude
+	}
+	if altitude, ok := p.planted[order]; ok {
+		return altitude
+	}
+	return p.runs[sort.Search(len(p.runs), func(i int) bool { return p.runs[i].To >= order })].Altitude
+}
+
+// added returns the distance a sapling on the plot at an order number adds, from the altitudes over its neighbours.
+func (p plantingPlanner) added(order int) int {
+	x, y := p.trav.plot(order)
+	previous, next := p.altitude(order-1), p.altitude(order+1)
+	sapling := p.model.ground(x, y) + p.model.altitude(&p.sapling)
+	return climb(previous, sapling, next) - climb(previous, p.altitude(order), next)
+}
+
+// candidate returns the plot at an order number as a candidate on its own.
+func (p plantingPlanner) candidate(order int) plantingCandidate {
+	return plantingCandidate{order: order, last: order, added: p.added(order)}
+}
+
+/*
+pick returns the order numbers of up to count plots for the saplings and the distance each adds. The plots are picked
+one by one, each time the free plot that adds the least once the plots picked before it are planted, the first in
+the traversal on a tie. The first and the last plot of every run are candidates on their own and the plots between
+them a single candidate from the first free one. A sapling only changes what its neighbours in the traversal add,
+they are pushed again on their own with what they add now and their stale entries are skipped when they come out.
+*/
+func (p plantingPlanner) pick(count int) ([]int, []int) {
+	queue := make(plantingQueue, 0, 3*len(p.runs))
+	for _, run := range p.runs {
+		queue = append(queue, p.candidate(run.From))
+		if run.To > run.From+1 {
+			queue = append(queue, plantingCandidate{order: run.From + 1, last: run.To - 1, added: p.added(run.From + 1)})
+		}
+		if run.To > run.From {
+			queue = append(queue, p.candidate(run.To))
+		}
+	}
+	heap.Init(&queue)
+
+	var orders, added []int
+	for len(orders) < count && queue.Len() > 0 {
+		candidate := heap.Pop(&queue).(plantingCandidate)
+		order := p.nextFree(candidate.order, candidate.last)
+		if order > candidate.last {
+			continue
+		}
+		if order != candidate.order {
+			// the plots before it were taken, the candidate comes back from its first free plot
+			heap.Push(&queue, plantingCandidate{order: order, last: candidate.last, added: candidate.added})
+			continue
+		}
+		if p.added(order) != candidate.added {
+			// a sapling next to it changed what it adds, it was pushed again on its own
+			if order < candidate.last {
+				heap.Push(&queue, plantingCandidate{order: order + 1, last: candidate.last, added: candidate.added})
+			}
+			continue
+		}
+
+		x, y := p.trav.plot(order)
+		p.planted[order] = p.model.ground(x, y) + p.model.altitude(&p.sapling)
+		p.occupy(x, y)
+		orders = append(orders, order)
+		added = append(added, candidate.added)
+
+		if order+2 <= candidate.last {
+			heap.Push(&queue, plantingCandidate{order: order + 2, last: candidate.last, added: candidate.added})
+		}
+		for _, neighbour := range []int{order - 1, order + 1} {
+			if neighbour >= 1 && neighbour <= p.plotCount && p.nextFree(neighbour, neighbour) == neighbour {
+				heap.Push(&queue, p.candidate(neighbour))
+			}
+		}
+	}
+	return orders, added
+}
+
+// plantingCandidate is a free plot, or the plots from order up to last that all add the same distance.
+type plantingCandidate struct {
+	order int
+	last  int
+	added int
+}
+
+// plantingQueue is the free plots of a planting, the one that adds the least first and the first in the traversal on a tie.
+type plantingQueue []plantingCandidate
+
+func (q plantingQueue) Len() int { return len(q) }
+func (q plantingQueue) Less(i, j int) bool {
+	if q[i].added != q[j].added {
+		return q[i].added < q[j].added
+	}
+	return q[i].order < q[j].order
+}
+func (q plantingQueue) Swap(i, j int) { q[i], q[j] = q[j], q[i] }
+
+func (q *plantingQueue) Push(candidate any) {
+	*q = append(*q, candidate.(plantingCandidate))
+}
+
+func (q *plantingQueue) Pop() any {
+	old := *q
+	candidate := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return candidate
+}
@@ -0,0 +1,205 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestPlantingPlanner_Pick(t *testing.T) {
+	// a row of 5 plots with a tree of 10 meters in the middle, flown 11 meters up from the ground
+	row := repository.EstateEntity{Length: 5, Width: 1, PlotSize: 10, Clearance: 1}
+	trees := []repository.PlotEntity{{X: 3, Y: 1, OrderNumber: 3, TreeHeight: 10}}
+	sapling := repository.PlotEntity{TreeHeight: 4}
+
+	tests := []struct {
+		name           string
+		estate         repository.EstateEntity
+		obstacles      []repository.ObstacleEntity
+		spacing        int
+		avoidRows      []int
+		count          int
+		expectedOrders []int
+		expectedAdded  []int
+	}{
+		{
+			// the drone climbs to the tree anyway, a sapling next to it or next to a sapling climbs along
+			name:           "Next To The Tree",
+			estate:         row,
+			spacing:        1,
+			count:          4,
+			expectedOrders: []int{2, 1, 4, 5},
+			expectedAdded:  []int{0, 0, 0, 0},
+		},
+		{
+			name:           "Spaced From The Tree",
+			estate:         row,
+			spacing:        2,
+			count:          3,
+			expectedOrders: []int{1, 5},
+			expectedAdded:  []int{10, 10},
+		},
+		{
+			// the second row goes back from x 5, the first one is avoided
+			name:           "Avoided Row",
+			estate:         repository.EstateEntity{Length: 5, Width: 2, PlotSize: 10, Clearance: 1},
+			spacing:        2,
+			avoidRows:      []int{1},
+			count:          2,
+			expectedOrders: []int{6, 10},
+			expectedAdded:  []int{10, 10},
+		},
+		{
+			// the plot under the obstacle is not free, the drone climbs over it from the first plot anyway
+			name:   "Under An Obstacle",
+			estate: row,
+			obstacles: []repository.ObstacleEntity{
+				{Height: &[]int{20}[0], Areas: []repository.ObstacleAreaEntity{{MinX: 2, MinY: 1, MaxX: 2, MaxY: 1}}},
+			},
+			spacing:        1,
+			count:          1,
+			expectedOrders: []int{1},
+			expectedAdded:  []int{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			trav := newTraversal(tt.estate)
+			obstacles := newObstacleMap(tt.obstacles)
+			planner := newPlantingPlanner(trav, newFlightModel(tt.estate), obstacles, trees, obstacles.withHeights(trees, trav), sapling, tt.spacing, tt.avoidRows)
+
+			orders, added := planner.pick(tt.count)
+			assert.Equal(t, tt.expectedOrders, orders)
+			assert.Equal(t, tt.expectedAdded, added)
+		})
+	}
+}
+
+func TestPlantingPlanner_PickMatchesEveryPlot(t *testing.T) {
+	rng := rand.New(rand.NewSource(45))
+	sapling := repository.PlotEntity{TreeHeight: 4}
+
+	// everyPlot picks the plots one by one from what every plot adds once the plots picked before it are planted
+	everyPlot := func(trav traversal, model flightModel, obstacles obstacleMap, trees, flown []repository.PlotEntity, spacing int, avoidRows []int, count int) ([]int, []int) {
+		plotCount := trav.plotCount()
+		altitudes := make([]int, plotCount+2)
+		altitudes[0] = model.ground(trav.plot(1)) + model.startEndAltitude
+		altitudes[plotCount+1] = model.ground(trav.plot(plotCount)) + model.startEndAltitude
+		for _, run := range altitudeRuns(generated.TerrainFollowing, trav, flown, model) {
+			for order := run.From; order <= run.To; order++ {
+				altitudes[order] = run.Altitude
+			}
+		}
+		planted := append([]repository.PlotEntity{}, trees...)
+		spaced := func(x, y int) bool {
+			for _, tree := range planted {
+				if abs(int(tree.X)-x) < spacing && abs(int(tree.Y)-y) < spacing {
+					return false
+				}
+			}
+			return true
+		}
+		avoided := func(y int) bool {
+			for _, row := range avoidRows {
+				if row == y {
+					return true
+				}
+			}
+			return false
+		}
+
+		var orders, added []int
+		for len(orders) < count {
+			best, bestAdded := 0, 0
+			for order := 1; order <= plotCount; order++ {
+				x, y := trav.plot(order)
+				if avoided(y) || obstacles.blocked(x, y) || !spaced(x, y) {
+					continue
+				}
+				previous, next := altitudes[order-1], altitudes[order+1]
+				a := climb(previous, model.ground(x, y)+model.altitude(&sapling), next) - climb(previous, altitudes[order], next)
+				if best == 0 || a < bestAdded {
+					best, bestAdded = order, a
+				}
+			}
+			if best == 0 {
+				break
+			}
+			x, y := trav.plot(best)
+			altitudes[best] = model.ground(x, y) + model.altitude(&sapling)
+			planted = append(planted, repository.PlotEntity{X: uint16(x), Y: uint16(y)})
+			orders = append(orders, best)
+			added = append(added, bestAdded)
+		}
+		return orders, added
+	}
+
+	estates := []repository.EstateEntity{
+		{Length: 23, Width: 17, TraversalPattern: string(generated.RowSerpentine)},
+		{Length: 23, Width: 17, TraversalPattern: string(generated.ColumnSerpentine), TraversalCorner: string(generated.XMaxYMax)},
+		{Length: 23, Width: 17, TraversalPattern: string(generated.Spiral), TraversalCorner: string(generated.XMinYMax)},
+	}
+	for _, estate := range estates {
+		for _, rough := range []bool{false, true} {
+			model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 3, startEndAltitude: 5}
+			if rough {
+				g := elevationGrid{columns: 9, rows: 7, cellSize: float64(estate.Length) * 10 / 8, elevations: make([]float64, 63)}
+				for i := range g.elevations {
+					g.elevations[i] = float64(rng.Intn(10))
+				}
+				model.terrain = newTerrain(estate, g, 10)
+			}
+			trav := newTraversal(estate)
+
+			obstacles := newObstacleMap([]repository.ObstacleEntity{
+				{Height: &[]int{8}[0], Areas: []repository.ObstacleAreaEntity{{MinX: 4, MinY: 3, MaxX: 6, MaxY: 5}}},
+				{Areas: []repository.ObstacleAreaEntity{{MinX: 15, MinY: 10, MaxX: 16, MaxY: 12}}},
+			})
+			var plots []repository.PlotEntity
+			for i := 0; i < 12; i++ {
+				plot := repository.PlotEntity{X: uint16(1 + rng.Intn(estate.Length)), Y: uint16(1 + rng.Intn(estate.Width)), TreeHeight: 1 + rng.Intn(10)}
+				// no tree stands under an obstacle
+				if !obstacles.blocked(int(plot.X), int(plot.Y)) {
+					plots = append(plots, plot)
+				}
+			}
+			trav.orderPlots(plots)
+			trees := plots[:0]
+			for i := range plots {
+				if i == 0 || plots[i].OrderNumber != plots[i-1].OrderNumber {
+					trees = append(trees, plots[i])
+				}
+			}
+			flown := obstacles.withHeights(trees, trav)
+
+			for _, spacing := range []int{1, 2, 3} {
+				avoidRows := []int{2, 9}
+				expectedOrders, expectedAdded := everyPlot(trav, model, obstacles, trees, flown, spacing, avoidRows, 60)
+				orders, added := newPlantingPlanner(trav, model, obstacles, trees, flown, sapling, spacing, avoidRows).pick(60)
+				require.Equal(t, expectedOrders, orders, "%s rough %t spacing %d", estate.TraversalPattern, rough, spacing)
+				require.Equal(t, expectedAdded, added, "%s rough %t spacing %d", estate.TraversalPattern, rough, spacing)
+			}
+		}
+	}
+}
+
+func TestPlantingPlanner_PickLargeEstate(t *testing.T) {
+	// the planner never looks at the plots one by one, the largest estate is planned at once
+	estate := repository.EstateEntity{Length: 50000, Width: 50000, PlotSize: 10, Clearance: 1}
+	trees := []repository.PlotEntity{{X: 3, Y: 1, OrderNumber: 3, TreeHeight: 10}}
+	trav := newTraversal(estate)
+	obstacles := newObstacleMap(nil)
+	planner := newPlantingPlanner(trav, newFlightModel(estate), obstacles, trees, obstacles.withHeights(trees, trav), repository.PlotEntity{TreeHeight: 4}, 2, nil)
+
+	orders, added := planner.pick(1000)
+	require.Len(t, orders, 1000)
+	// the saplings are spaced along the first row, each climbs from the cruise altitude
+	assert.Equal(t, []int{1, 5, 7}, orders[:3])
+	assert.Equal(t, []int{10, 10, 10}, added[:3])
+}
@@ -0,0 +1,49 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func (s *Service) PostDroneProfile(ctx context.Context, req generated.DroneProfileRequest) (generated.DroneProfileResponse, int, error) {
+	if len(req.Name) > 100 {
+		return generated.DroneProfileResponse{}, http.StatusBadRequest, errors.New("name must be at most 100 characters")
+	}
+	for _, field := range []struct {
+		name  string
+		value float64
+	}{
+		{"horizontal_speed", req.HorizontalSpeed},
+		{"climb_speed", req.ClimbSpeed},
+		{"descent_speed", req.DescentSpeed},
+		{"horizontal_power", req.HorizontalPower},
+		{"climb_power", req.ClimbPower},
+		{"descent_power", req.DescentPower},
+		{"battery_capacity", req.BatteryCapacity},
+	} {
+		if field.value <= 0 {
+			return generated.DroneProfileResponse{}, http.StatusBadRequest, fmt.Errorf("%s must be greater than 0", field.name)
+		}
+	}
+
+	id, err := s.Repository.PostDroneProfile(ctx, repository.DroneProfileEntity{
+		Name:            req.Name,
+		HorizontalSpeed: req.HorizontalSpeed,
+		ClimbSpeed:      req.ClimbSpeed,
+		DescentSpeed:    req.DescentSpeed,
+		HorizontalPower: req.HorizontalPower,
+		ClimbPower:      req.ClimbPower,
+		DescentPower:    req.DescentPower,
+		BatteryCapacity: req.BatteryCapacity,
+	})
+	if err != nil {
+		return generated.DroneProfileResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.DroneProfileResponse{Id: id}, http.StatusCreated, nil
+}
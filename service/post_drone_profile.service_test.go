@@ -0,0 +1,116 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_PostDroneProfile(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockUUID := uuid.New()
+	mockRequest := func() generated.DroneProfileRequest {
+		return generated.DroneProfileRequest{
+			Name:            "quadcopter",
+			HorizontalSpeed: 10,
+			ClimbSpeed:      2,
+			DescentSpeed:    5,
+			HorizontalPower: 100,
+			ClimbPower:      360,
+			DescentPower:    36,
+			BatteryCapacity: 50,
+		}
+	}
+	negativeClimbPower := mockRequest()
+	negativeClimbPower.ClimbPower = -1
+	longName := mockRequest()
+	longName.Name = strings.Repeat("a", 101)
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.DroneProfileRequest
+		expectedResp   generated.DroneProfileResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Post",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().PostDroneProfile(gomock.Any(), repository.DroneProfileEntity{
+					Name:            "quadcopter",
+					HorizontalSpeed: 10,
+					ClimbSpeed:      2,
+					DescentSpeed:    5,
+					HorizontalPower: 100,
+					ClimbPower:      360,
+					DescentPower:    36,
+					BatteryCapacity: 50,
+				}).Return(&mockUUID, nil)
+			},
+			request:        mockRequest(),
+			expectedResp:   generated.DroneProfileResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Power Not Positive",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        negativeClimbPower,
+			expectedResp:   generated.DroneProfileResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("climb_power must be greater than 0"),
+		},
+		{
+			name:           "Name Too Long",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        longName,
+			expectedResp:   generated.DroneProfileResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("name must be at most 100 characters"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().PostDroneProfile(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+			},
+			request:        mockRequest(),
+			expectedResp:   generated.DroneProfileResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.PostDroneProfile(mockContext, tt.request)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
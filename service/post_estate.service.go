@@ -2,27 +2,45 @@ package service
 
 import (
 	"context"
+	"net/http"
 
 	"spgo/generated"
 	"spgo/repository"
 )
 
-func (s *Service) PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, error) {
+func (s *Service) PostEstate(ctx context.Context, req generated.EstateRequest) (generated.EstateResponse, int, error) {
 	resp := generated.EstateResponse{}
-	var err error
 
-	// Total distance when there is no tree would be 10 each plot
-	// totalDistance should be width * length * 10
+	shape, err := newEstateShape(req)
+	if err != nil {
+		return generated.EstateResponse{}, http.StatusBadRequest, err
+	}
+
+	// a new estate has the default flight settings, with no tree the drone crosses every plot at the cruise altitude
 	estate := repository.EstateEntity{
-		Width:         req.Width,
-		Length:        req.Length,
-		TotalDistance: req.Width * req.Length * 10,
+		Width:     req.Width,
+		Length:    req.Length,
+		PlotSize:  defaultPlotSize,
+		Clearance: defaultClearance,
+	}
+	model := newFlightModel(estate)
+	if shape == nil {
+		estate.TotalDistance = model.totalDistance(req.Width*req.Length, nil)
+	} else {
+		// the drone only crosses the plots of the shape, going across its gaps from one part of it to the next
+		estate.ShapeMask = shape.encode()
+		trav := newTraversal(estate)
+		runs, err := newFlightGrid(estate, newObstacleMap(nil), nil, model).cutRuns(flightRuns(trav.plotCount(), nil, model), trav)
+		if err != nil {
+			return generated.EstateResponse{}, http.StatusInternalServerError, err
+		}
+		estate.TotalDistance = model.runsDistance(runs)
 	}
 
 	resp.Id, err = s.Repository.PostEstate(ctx, estate)
 	if err != nil {
-		return generated.EstateResponse{}, err
+		return generated.EstateResponse{}, http.StatusInternalServerError, err
 	}
 
-	return resp, nil
+	return resp, http.StatusCreated, nil
 }
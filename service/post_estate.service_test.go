@@ -3,11 +3,13 @@ package service_test
 import (
 	"context"
 	"errors"
+	"net/http"
 	"testing"
 
 	"github.com/golang/mock/gomock"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	"spgo/generated"
 	"spgo/repository"
@@ -24,13 +26,17 @@ func TestService_PostEstate(t *testing.T) {
 		Length: 10,
 	}
 	mockUUID := uuid.New()
+	position := func(x, y int) generated.PlotPosition {
+		return generated.PlotPosition{X: &x, Y: &y}
+	}
 
 	tests := []struct {
-		name         string
-		prepareMocks func(mockRepo *repository.MockRepositoryInterface)
-		request      generated.EstateRequest
-		expectedResp generated.EstateResponse
-		expectedErr  error
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.EstateRequest
+		expectedResp   generated.EstateResponse
+		expectedStatus int
+		expectedErr    error
 	}{
 		{
 			name: "Successful Post",
@@ -41,16 +47,42 @@ func TestService_PostEstate(t *testing.T) {
 			expectedResp: generated.EstateResponse{
 				Id: &mockUUID,
 			},
-			expectedErr: nil,
+			expectedStatus: http.StatusCreated,
+			expectedErr:    nil,
+		},
+		{
+			name: "Plots Outside The Shape Are Skipped",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().PostEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					// 5 plots of 10 meters, the drone goes diagonally from plot (3,1) to plot (2,2), 4 meters more
+					require.NotEmpty(t, estate.ShapeMask)
+					require.Equal(t, 54, estate.TotalDistance)
+					return &mockUUID, nil
+				})
+			},
+			request: generated.EstateRequest{Width: 2, Length: 3, ExcludedPlots: &[]generated.PlotPosition{position(3, 2)}},
+			expectedResp: generated.EstateResponse{
+				Id: &mockUUID,
+			},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Boundary Out Of Range",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.EstateRequest{Width: 5, Length: 10, Boundary: &[]generated.PlotPosition{position(1, 1), position(11, 1), position(1, 5)}},
+			expectedResp:   generated.EstateResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("boundary vertex (11,1) is out of range"),
 		},
 		{
 			name: "Repository Error",
 			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
 				mockRepo.EXPECT().PostEstate(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
 			},
-			request:      mockRequest,
-			expectedResp: generated.EstateResponse{},
-			expectedErr:  errors.New("repository error"),
+			request:        mockRequest,
+			expectedResp:   generated.EstateResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
 		},
 	}
 
@@ -63,9 +95,10 @@ func TestService_PostEstate(t *testing.T) {
 				Repository: mockRepo,
 			})
 
-			resp, err := svs.PostEstate(mockContext, tt.request)
+			resp, status, err := svs.PostEstate(mockContext, tt.request)
 
 			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
 			if tt.expectedErr == nil {
 				assert.NoError(t, err)
 			} else {
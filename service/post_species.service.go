@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxSpeciesHeight bounds the max height of a species, above the tallest trees ever measured.
+const maxSpeciesHeight = 150
+
+func (s *Service) PostSpecies(ctx context.Context, req generated.SpeciesRequest) (generated.SpeciesResponse, int, error) {
+	if req.Name == "" || len(req.Name) > 100 {
+		return generated.SpeciesResponse{}, http.StatusBadRequest, errors.New("name must be between 1 and 100 characters")
+	}
+	if req.MaxHeight < 1 || req.MaxHeight > maxSpeciesHeight {
+		return generated.SpeciesResponse{}, http.StatusBadRequest, fmt.Errorf("max_height must be between 1 and %d", maxSpeciesHeight)
+	}
+	if req.GrowthRate != nil && *req.GrowthRate < 0 {
+		return generated.SpeciesResponse{}, http.StatusBadRequest, errors.New("growth_rate cannot be negative")
+	}
+	if req.HarvestCycle != nil && (*req.HarvestCycle < 1 || *req.HarvestCycle > 1200) {
+		return generated.SpeciesResponse{}, http.StatusBadRequest, errors.New("harvest_cycle must be between 1 and 1200 months")
+	}
+
+	id, err := s.Repository.PostSpecies(ctx, repository.SpeciesEntity{
+		Name:         req.Name,
+		MaxHeight:    req.MaxHeight,
+		GrowthRate:   req.GrowthRate,
+		HarvestCycle: req.HarvestCycle,
+	})
+	if err != nil {
+		return generated.SpeciesResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.SpeciesResponse{Id: id}, http.StatusCreated, nil
+}
@@ -0,0 +1,115 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_PostSpecies(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockContext := context.TODO()
+	mockUUID := uuid.New()
+	growthRate := 0.6
+	harvestCycle := 1
+	negativeGrowthRate := -0.1
+	noHarvestCycle := 0
+
+	tests := []struct {
+		name           string
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface)
+		request        generated.SpeciesRequest
+		expectedResp   generated.SpeciesResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Successful Post",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().PostSpecies(gomock.Any(), repository.SpeciesEntity{
+					Name:         "Oil palm",
+					MaxHeight:    20,
+					GrowthRate:   &growthRate,
+					HarvestCycle: &harvestCycle,
+				}).Return(&mockUUID, nil)
+			},
+			request:        generated.SpeciesRequest{Name: "Oil palm", MaxHeight: 20, GrowthRate: &growthRate, HarvestCycle: &harvestCycle},
+			expectedResp:   generated.SpeciesResponse{Id: &mockUUID},
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "Max Height Out Of Range",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SpeciesRequest{Name: "Giant sequoia", MaxHeight: 151},
+			expectedResp:   generated.SpeciesResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("max_height must be between 1 and 150"),
+		},
+		{
+			name:           "Negative Growth Rate",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SpeciesRequest{Name: "Oil palm", MaxHeight: 20, GrowthRate: &negativeGrowthRate},
+			expectedResp:   generated.SpeciesResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("growth_rate cannot be negative"),
+		},
+		{
+			name:           "Harvest Cycle Out Of Range",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SpeciesRequest{Name: "Oil palm", MaxHeight: 20, HarvestCycle: &noHarvestCycle},
+			expectedResp:   generated.SpeciesResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("harvest_cycle must be between 1 and 1200 months"),
+		},
+		{
+			name:           "Empty Name",
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface) {},
+			request:        generated.SpeciesRequest{MaxHeight: 20},
+			expectedResp:   generated.SpeciesResponse{},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("name must be between 1 and 100 characters"),
+		},
+		{
+			name: "Repository Error",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface) {
+				mockRepo.EXPECT().PostSpecies(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+			},
+			request:        generated.SpeciesRequest{Name: "Oil palm", MaxHeight: 20},
+			expectedResp:   generated.SpeciesResponse{},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo)
+
+			svs := service.NewService(service.NewServiceOptions{
+				Repository: mockRepo,
+			})
+
+			resp, status, err := svs.PostSpecies(mockContext, tt.request)
+
+			assert.Equal(t, tt.expectedResp, resp)
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+		})
+	}
+}
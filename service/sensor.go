@@ -0,0 +1,93 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"slices"
+	"time"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxSensors bounds the sensors of an estate, maxSensorName the length of their name, maxSensorReadings the readings
+// of a batch and maxSensorBuckets the buckets of a series.
+const (
+	maxSensors        = 10000
+	maxSensorName     = 100
+	maxSensorReadings = 10000
+	maxSensorBuckets  = 10000
+)
+
+// sensorClockSkew is how far ahead of the server the clock of a sensor may run, sensorWindow the time the readings
+// are aggregated over by default.
+const (
+	sensorClockSkew = 5 * time.Minute
+	sensorWindow    = 24 * time.Hour
+)
+
+var sensorKinds = []generated.SensorKind{generated.SoilMoisture, generated.Temperature}
+
+// sensorRanges are the values a sensor of a kind reads, soil moisture in percent and temperature in degrees Celsius
+// as far as the probes measure.
+var sensorRanges = map[generated.SensorKind][2]float64{
+	generated.SoilMoisture: {0, 100},
+	generated.Temperature:  {-40, 85},
+}
+
+// sensorBucketSizes are the sizes of the buckets a series may be aggregated in.
+var sensorBucketSizes = map[generated.SensorBucketSize]time.Duration{
+	generated.SensorBucketSizeHour: time.Hour,
+	generated.SensorBucketSizeDay:  24 * time.Hour,
+	generated.SensorBucketSizeWeek: 7 * 24 * time.Hour,
+}
+
+func checkSensorKind(kind generated.SensorKind) error {
+	if !slices.Contains(sensorKinds, kind) {
+		return fmt.Errorf("kind must be one of %s, %s", generated.SoilMoisture, generated.Temperature)
+	}
+	return nil
+}
+
+// sensorFilter validates the kind and the time window of the readings aggregated, the readings of the sensorWindow
+// up to now by default.
+func sensorFilter(kind *generated.SensorKind, from, to *time.Time) (repository.SensorFilter, error) {
+	var filter repository.SensorFilter
+	if kind != nil {
+		if err := checkSensorKind(*kind); err != nil {
+			return repository.SensorFilter{}, err
+		}
+		filter.Kind = (*string)(kind)
+	}
+
+	filter.To = time.Now()
+	if to != nil {
+		filter.To = *to
+	}
+	filter.From = filter.To.Add(-sensorWindow)
+	if from != nil {
+		filter.From = *from
+	}
+	if !filter.From.Before(filter.To) {
+		return repository.SensorFilter{}, errors.New("from must be before to")
+	}
+	return filter, nil
+}
+
+// sensorBucketCount returns the number of buckets of a size a series over the window of the filter spans at most.
+func sensorBucketCount(filter repository.SensorFilter, size time.Duration) int {
+	return int(math.Ceil(float64(filter.To.Sub(filter.From))/float64(size))) + 1
+}
+
+func sensorResponse(sensor repository.SensorEntity) generated.Sensor {
+	kind := generated.SensorKind(sensor.Kind)
+	x, y := int(sensor.X), int(sensor.Y)
+	return generated.Sensor{
+		Id:   &sensor.ID,
+		Kind: &kind,
+		X:    &x,
+		Y:    &y,
+		Name: sensor.Name,
+	}
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/util"
+)
+
+func (s *Service) SetEstateElevation(ctx context.Context, estateId uuid.UUID, grid []byte, params generated.SetEstateElevationParams) (generated.ElevationResponse, int, error) {
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.ElevationResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.ElevationResponse{}, http.StatusInternalServerError, err
+	}
+
+	model := newFlightModel(estate)
+	g, err := parseElevationGrid(grid, params.CellSize, model.plotSize)
+	if err != nil {
+		return generated.ElevationResponse{}, http.StatusBadRequest, err
+	}
+	if !g.covers(estate.Length, estate.Width, model.plotSize) {
+		err = fmt.Errorf("elevation grid of %gx%g meters does not cover the estate of %gx%g meters",
+			float64(g.columns)*g.cellSize, float64(g.rows)*g.cellSize, float64(estate.Length)*model.plotSize, float64(estate.Width)*model.plotSize)
+		return generated.ElevationResponse{}, http.StatusBadRequest, err
+	}
+
+	err = s.Repository.SaveEstateElevation(nCtx, g.entity(estate))
+	if err != nil {
+		return generated.ElevationResponse{}, http.StatusInternalServerError, err
+	}
+
+	// every altitude is above the ground under its plot, so every distance is recomputed over the terrain
+	estate.Relief = g.relief()
+	err = s.recomputeFlightDistances(nCtx, &estate)
+	if err != nil {
+		return generated.ElevationResponse{}, http.StatusInternalServerError, err
+	}
+
+	_, err = s.Repository.SaveEstate(nCtx, estate)
+	if err != nil {
+		return generated.ElevationResponse{}, http.StatusInternalServerError, err
+	}
+
+	t := newTerrain(estate, g, model.plotSize)
+	return generated.ElevationResponse{
+		Lowest:   &t.lowest,
+		Highest:  &t.highest,
+		Relief:   &estate.Relief,
+		Distance: &estate.TotalDistance,
+	}, http.StatusOK, nil
+}
@@ -0,0 +1,210 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_SetEstateElevation(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	cellSize := 5.0
+	largeCellSize := 1000.0
+	elevations := make([]string, 21)
+	for i := range elevations {
+		elevations[i] = strconv.Itoa(100 + i)
+	}
+	slope := strings.Join(elevations, ",")
+
+	// a row of 3 plots with a tree of 4 meters on the last one, flown with the default settings on flat ground
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 1, TotalDistance: 40, PlotSize: 10, Clearance: 1}
+	mockPlots := func() []repository.PlotEntity {
+		return []repository.PlotEntity{
+			{EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 4, Distance: 35},
+		}
+	}
+
+	// the elevation grid is kept as uploaded and read back when the distances are recomputed over it
+	expectSavedElevation := func(mockRepo *repository.MockRepositoryInterface, columns, rows int, size float64) {
+		mockRepo.EXPECT().SaveEstateElevation(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, entity repository.EstateElevationEntity) error {
+			require.Equal(t, mockEstateID, entity.EstateId)
+			require.Equal(t, []any{columns, rows, size}, []any{entity.Columns, entity.Rows, entity.CellSize})
+			mockRepo.EXPECT().GetEstateElevation(gomock.Any(), mockEstateID).Return(entity, nil).AnyTimes()
+			return nil
+		})
+	}
+	expectSavedEstate := func(mockRepo *repository.MockRepositoryInterface, relief, total int) {
+		mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+			require.Equal(t, relief, estate.Relief)
+			require.Equal(t, total, estate.TotalDistance)
+			return &estate.ID, nil
+		})
+	}
+
+	tests := []struct {
+		name           string
+		grid           string
+		params         generated.SetEstateElevationParams
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.ElevationResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Flies Over A Valley",
+			grid: "130, 120, 130\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				expectSavedElevation(mockRepo, 3, 1, 10)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				// the drone takes off 10 meters up, goes down into the valley and climbs 15 meters over the tree
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, 55, plot.Distance)
+					return &plot.ID, nil
+				})
+				expectSavedEstate(mockRepo, 10, 60)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.ElevationResponse{
+				Lowest:   &[]float64{120}[0],
+				Highest:  &[]float64{130}[0],
+				Relief:   &[]int{10}[0],
+				Distance: &[]int{60}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "ASCII Raster On Flat Ground",
+			grid:   "ncols 6\nnrows 2\nxllcorner 0\nyllcorner 0\ncellsize 5\nNODATA_value -9999\n7 7 7 7 7 7\n7 7 7 7 7 7\n",
+			params: generated.SetEstateElevationParams{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				expectSavedElevation(mockRepo, 6, 2, 5)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				expectSavedEstate(mockRepo, 0, 40)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.ElevationResponse{
+				Lowest:   &[]float64{7}[0],
+				Highest:  &[]float64{7}[0],
+				Relief:   &[]int{0}[0],
+				Distance: &[]int{40}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "Grid Does Not Cover The Estate",
+			grid:   "0,0,0,0\n0,0,0,0\n",
+			params: generated.SetEstateElevationParams{CellSize: &cellSize},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("elevation grid of 20x10 meters does not cover the estate of 30x10 meters"),
+		},
+		{
+			name: "Invalid Grid",
+			grid: "130,120\n130\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("every row of the elevation grid must have the same number of values"),
+		},
+		{
+			// 2048x1024 plots are sampled in squares of 2x2 plots, over a slope rising 1 meter every 1000 meters along x
+			name:   "Large Estate Sampled In Squares",
+			grid:   strings.Repeat(slope+"\n", 11),
+			params: generated.SetEstateElevationParams{CellSize: &largeCellSize},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{ID: mockEstateID, Length: 2048, Width: 1024, PlotSize: 10}, nil)
+				expectSavedElevation(mockRepo, 21, 11, 1000)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, nil)
+				// every row climbs or descends the 20 meters of the slope
+				expectSavedEstate(mockRepo, 20, 2048*1024*10+1024*20)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.ElevationResponse{
+				Lowest:   &[]float64{100}[0],
+				Highest:  &[]float64{119.97}[0],
+				Relief:   &[]int{20}[0],
+				Distance: &[]int{2048*1024*10 + 1024*20}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Estate Not Found",
+			grid: "0\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name: "Repository Error",
+			grid: "130,120,130\n",
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().SaveEstateElevation(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.SetEstateElevation(mockContext, mockEstateID, []byte(tt.grid), tt.params)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/util"
+)
+
+func (s *Service) SetEstateFlightSettings(ctx context.Context, estateId uuid.UUID, req generated.FlightSettings) (generated.FlightSettingsResponse, int, error) {
+	if req.PlotSize != nil && (*req.PlotSize < 1 || *req.PlotSize > 100) {
+		return generated.FlightSettingsResponse{}, http.StatusBadRequest, errors.New("plot_size must be between 1 and 100")
+	}
+	if req.Clearance != nil && (*req.Clearance < 0 || *req.Clearance > 100) {
+		return generated.FlightSettingsResponse{}, http.StatusBadRequest, errors.New("clearance must be between 0 and 100")
+	}
+	if req.MinCruiseAltitude != nil && (*req.MinCruiseAltitude < 0 || *req.MinCruiseAltitude > 500) {
+		return generated.FlightSettingsResponse{}, http.StatusBadRequest, errors.New("min_cruise_altitude must be between 0 and 500")
+	}
+	if req.StartEndAltitude != nil && (*req.StartEndAltitude < 0 || *req.StartEndAltitude > 500) {
+		return generated.FlightSettingsResponse{}, http.StatusBadRequest, errors.New("start_end_altitude must be between 0 and 500")
+	}
+
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.FlightSettingsResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.FlightSettingsResponse{}, http.StatusInternalServerError, err
+	}
+
+	// the settings left out keep their current value, a plot size set with the geo-reference included
+	estate.PlotSize = newFlightModel(estate).plotSize
+	if req.PlotSize != nil {
+		estate.PlotSize = *req.PlotSize
+	}
+	if req.Clearance != nil {
+		estate.Clearance = *req.Clearance
+	}
+	if req.MinCruiseAltitude != nil {
+		estate.MinCruiseAltitude = *req.MinCruiseAltitude
+	}
+	if req.StartEndAltitude != nil {
+		estate.StartEndAltitude = *req.StartEndAltitude
+	}
+	if req.ExcludeDeadTrees != nil {
+		estate.ExcludeDeadTrees = *req.ExcludeDeadTrees
+	}
+
+	// every tree distance depends on the settings, so they are all recomputed rather than adjusted
+	err = s.recomputeFlightDistances(nCtx, &estate)
+	if err != nil {
+		return generated.FlightSettingsResponse{}, http.StatusInternalServerError, err
+	}
+
+	_, err = s.Repository.SaveEstate(nCtx, estate)
+	if err != nil {
+		return generated.FlightSettingsResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.FlightSettingsResponse{
+		PlotSize:          &estate.PlotSize,
+		Clearance:         &estate.Clearance,
+		MinCruiseAltitude: &estate.MinCruiseAltitude,
+		StartEndAltitude:  &estate.StartEndAltitude,
+		ExcludeDeadTrees:  &estate.ExcludeDeadTrees,
+		Distance:          &estate.TotalDistance,
+	}, http.StatusOK, nil
+}
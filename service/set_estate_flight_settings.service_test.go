@@ -0,0 +1,245 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_SetEstateFlightSettings(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	plotSize := 10.0
+	clearance := 2
+	minCruiseAltitude := 15
+	startEndAltitude := 3
+	defaultClearance := 1
+	zero := 0
+	outOfRange := 1000
+
+	// a row of 5 plots with trees of 10, 20 and 10 meters on plots 2, 3 and 4, flown with the default settings
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 1, TotalDistance: 92, PlotSize: 10, Clearance: 1}
+	mockPlots := func() []repository.PlotEntity {
+		return []repository.PlotEntity{
+			{EstateId: mockEstateID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, Distance: 31},
+			{EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 20, Distance: 51},
+			{EstateId: mockEstateID, X: 4, Y: 1, OrderNumber: 4, TreeHeight: 10, Distance: 71},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		request        generated.FlightSettings
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.FlightSettingsResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Recomputes Every Distance",
+			request: generated.FlightSettings{Clearance: &clearance, MinCruiseAltitude: &minCruiseAltitude, StartEndAltitude: &startEndAltitude},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				var distances []int
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					distances = append(distances, plot.Distance)
+					return &plot.ID, nil
+				}).Times(3)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{32, 49, 66}, distances)
+					require.Equal(t, 88, estate.TotalDistance)
+					require.Equal(t, clearance, estate.Clearance)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.FlightSettingsResponse{
+				PlotSize:          &plotSize,
+				Clearance:         &clearance,
+				MinCruiseAltitude: &minCruiseAltitude,
+				StartEndAltitude:  &startEndAltitude,
+				ExcludeDeadTrees:  &[]bool{false}[0],
+				Distance:          &[]int{88}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Empty Request Keeps The Settings",
+			request: generated.FlightSettings{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).Return(&mockEstateID, nil)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.FlightSettingsResponse{
+				PlotSize:          &plotSize,
+				Clearance:         &defaultClearance,
+				MinCruiseAltitude: &zero,
+				StartEndAltitude:  &zero,
+				ExcludeDeadTrees:  &[]bool{false}[0],
+				Distance:          &[]int{92}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Partial Update Keeps The Other Settings",
+			request: generated.FlightSettings{Clearance: &clearance},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				// plots of 5 meters set with the geo-reference, flown at 15 meters at least
+				estate := mockEstate
+				estate.PlotSize = 5
+				estate.MinCruiseAltitude = minCruiseAltitude
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(estate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					return &plot.ID, nil
+				}).AnyTimes()
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, 5.0, estate.PlotSize)
+					require.Equal(t, minCruiseAltitude, estate.MinCruiseAltitude)
+					require.Equal(t, clearance, estate.Clearance)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.FlightSettingsResponse{
+				PlotSize:          &[]float64{5}[0],
+				Clearance:         &clearance,
+				MinCruiseAltitude: &minCruiseAltitude,
+				StartEndAltitude:  &zero,
+				ExcludeDeadTrees:  &[]bool{false}[0],
+				Distance:          &[]int{69}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Excludes The Dead Trees",
+			request: generated.FlightSettings{ExcludeDeadTrees: &[]bool{true}[0]},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				// the tree of 20 meters is dead, the drone comes down to the ground over its plot
+				plots := mockPlots()
+				plots[1].HealthStatus = &[]string{"dead"}[0]
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(plots, nil)
+				var distances []int
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					distances = append(distances, plot.Distance)
+					return &plot.ID, nil
+				}).Times(2)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{52, 73}, distances)
+					require.True(t, estate.ExcludeDeadTrees)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.FlightSettingsResponse{
+				PlotSize:          &plotSize,
+				Clearance:         &defaultClearance,
+				MinCruiseAltitude: &zero,
+				StartEndAltitude:  &zero,
+				ExcludeDeadTrees:  &[]bool{true}[0],
+				Distance:          &[]int{94}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Plot Size Out Of Range",
+			request:        generated.FlightSettings{PlotSize: &[]float64{0.5}[0]},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot_size must be between 1 and 100"),
+		},
+		{
+			name:           "Clearance Out Of Range",
+			request:        generated.FlightSettings{Clearance: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("clearance must be between 0 and 100"),
+		},
+		{
+			name:           "Min Cruise Altitude Out Of Range",
+			request:        generated.FlightSettings{MinCruiseAltitude: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("min_cruise_altitude must be between 0 and 500"),
+		},
+		{
+			name:           "Start End Altitude Out Of Range",
+			request:        generated.FlightSettings{StartEndAltitude: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("start_end_altitude must be between 0 and 500"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.FlightSettings{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:    "Repository Error",
+			request: generated.FlightSettings{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(nil, errors.New("repository error"))
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.SetEstateFlightSettings(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
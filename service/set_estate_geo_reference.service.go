@@ -0,0 +1,99 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/util"
+)
+
+func (s *Service) SetEstateGeoReference(ctx context.Context, estateId uuid.UUID, req generated.GeoReference) (generated.GeoReference, int, error) {
+	if req.Latitude == nil || req.Longitude == nil {
+		return generated.GeoReference{}, http.StatusBadRequest, errors.New("latitude and longitude are required")
+	}
+	if *req.Latitude < -90 || *req.Latitude > 90 {
+		return generated.GeoReference{}, http.StatusBadRequest, errors.New("latitude must be between -90 and 90")
+	}
+	if *req.Longitude < -180 || *req.Longitude > 180 {
+		return generated.GeoReference{}, http.StatusBadRequest, errors.New("longitude must be between -180 and 180")
+	}
+
+	bearing := 0.0
+	if req.Bearing != nil {
+		bearing = *req.Bearing
+	}
+	if bearing < 0 || bearing >= 360 {
+		return generated.GeoReference{}, http.StatusBadRequest, errors.New("bearing must be at least 0 and less than 360")
+	}
+
+	if req.PlotSize != nil && (*req.PlotSize < 1 || *req.PlotSize > 100) {
+		return generated.GeoReference{}, http.StatusBadRequest, errors.New("plot_size must be between 1 and 100")
+	}
+
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.GeoReference{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.GeoReference{}, http.StatusInternalServerError, err
+	}
+
+	// the plot size is also a flight setting, it is kept when the request leaves it out
+	plotSize := newFlightModel(estate).plotSize
+	if req.PlotSize != nil {
+		plotSize = *req.PlotSize
+	}
+	resized := plotSize != estate.PlotSize
+
+	estate.AnchorLatitude = req.Latitude
+	estate.AnchorLongitude = req.Longitude
+	estate.Bearing = bearing
+	estate.PlotSize = plotSize
+
+	err = s.Repository.UpdateEstateGeoReference(nCtx, repository.EstateEntity{
+		ID:              estate.ID,
+		AnchorLatitude:  estate.AnchorLatitude,
+		AnchorLongitude: estate.AnchorLongitude,
+		Bearing:         estate.Bearing,
+		PlotSize:        estate.PlotSize,
+	})
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.GeoReference{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.GeoReference{}, http.StatusInternalServerError, err
+	}
+
+	if resized {
+		if err = s.recomputeFlightDistances(nCtx, &estate); err != nil {
+			return generated.GeoReference{}, http.StatusInternalServerError, err
+		}
+		if _, err = s.Repository.SaveEstate(nCtx, estate); err != nil {
+			return generated.GeoReference{}, http.StatusInternalServerError, err
+		}
+	}
+
+	return generated.GeoReference{
+		Latitude:  estate.AnchorLatitude,
+		Longitude: estate.AnchorLongitude,
+		Bearing:   &estate.Bearing,
+		PlotSize:  &estate.PlotSize,
+	}, http.StatusOK, nil
+}
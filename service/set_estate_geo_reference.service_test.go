@@ -0,0 +1,172 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_SetEstateGeoReference(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	latitude := -6.2
+	longitude := 106.8
+	bearing := 15.0
+	plotSize := 12.5
+	outOfRange := 400.0
+	defaultBearing := 0.0
+	defaultPlotSize := 10.0
+
+	// 3x1 estate with a tree on plot (2,1)
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 1, TotalDistance: 52, PlotSize: 10, Clearance: 1}
+	mockPlots := []repository.PlotEntity{{EstateId: mockEstateID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, Distance: 31}}
+
+	tests := []struct {
+		name           string
+		request        generated.GeoReference
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.GeoReference
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Successful Update Recomputes The Distances",
+			request: generated.GeoReference{Latitude: &latitude, Longitude: &longitude, Bearing: &bearing, PlotSize: &plotSize},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().UpdateEstateGeoReference(gomock.Any(), repository.EstateEntity{
+					ID:              mockEstateID,
+					AnchorLatitude:  &latitude,
+					AnchorLongitude: &longitude,
+					Bearing:         bearing,
+					PlotSize:        plotSize,
+				}).Return(nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots, nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, 36, plot.Distance)
+					return &plot.ID, nil
+				})
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, 60, estate.TotalDistance)
+					require.Equal(t, plotSize, estate.PlotSize)
+					return &estate.ID, nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.GeoReference{Latitude: &latitude, Longitude: &longitude, Bearing: &bearing, PlotSize: &plotSize},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Defaults Bearing And Keeps Plot Size",
+			request: generated.GeoReference{Latitude: &latitude, Longitude: &longitude},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().UpdateEstateGeoReference(gomock.Any(), gomock.Any()).Return(nil)
+				mock.ExpectCommit()
+			},
+			expectedResp:   generated.GeoReference{Latitude: &latitude, Longitude: &longitude, Bearing: &defaultBearing, PlotSize: &defaultPlotSize},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing Anchor",
+			request:        generated.GeoReference{Latitude: &latitude},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("latitude and longitude are required"),
+		},
+		{
+			name:           "Latitude Out Of Range",
+			request:        generated.GeoReference{Latitude: &outOfRange, Longitude: &longitude},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("latitude must be between -90 and 90"),
+		},
+		{
+			name:           "Longitude Out Of Range",
+			request:        generated.GeoReference{Latitude: &latitude, Longitude: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("longitude must be between -180 and 180"),
+		},
+		{
+			name:           "Bearing Out Of Range",
+			request:        generated.GeoReference{Latitude: &latitude, Longitude: &longitude, Bearing: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("bearing must be at least 0 and less than 360"),
+		},
+		{
+			name:           "Plot Size Out Of Range",
+			request:        generated.GeoReference{Latitude: &latitude, Longitude: &longitude, PlotSize: &outOfRange},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("plot_size must be between 1 and 100"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.GeoReference{Latitude: &latitude, Longitude: &longitude},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:    "Repository Error",
+			request: generated.GeoReference{Latitude: &latitude, Longitude: &longitude},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().UpdateEstateGeoReference(gomock.Any(), gomock.Any()).Return(errors.New("repository error"))
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.SetEstateGeoReference(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
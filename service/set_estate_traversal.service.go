@@ -0,0 +1,63 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/util"
+)
+
+func (s *Service) SetEstateTraversal(ctx context.Context, estateId uuid.UUID, req generated.Traversal) (generated.TraversalResponse, int, error) {
+	trav, err := traversal{pattern: generated.RowSerpentine, corner: generated.XMinYMin}.withOverride(req.Pattern, req.StartCorner)
+	if err != nil {
+		return generated.TraversalResponse{}, http.StatusBadRequest, err
+	}
+
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	estate, err := s.Repository.GetEstate(nCtx, estateId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.TraversalResponse{}, http.StatusNotFound, errors.New("estate not found")
+		}
+		return generated.TraversalResponse{}, http.StatusInternalServerError, err
+	}
+
+	// a spiral has no rings to go around on an estate that is not a rectangle
+	if _, err = newTraversal(estate).withOverride(&trav.pattern, &trav.corner); err != nil {
+		return generated.TraversalResponse{}, http.StatusBadRequest, err
+	}
+
+	estate.TraversalPattern = string(trav.pattern)
+	estate.TraversalCorner = string(trav.corner)
+
+	// the order number and the distance of every tree follow the traversal, so they are all recomputed
+	err = s.recomputeFlightDistances(nCtx, &estate)
+	if err != nil {
+		return generated.TraversalResponse{}, http.StatusInternalServerError, err
+	}
+
+	_, err = s.Repository.SaveEstate(nCtx, estate)
+	if err != nil {
+		return generated.TraversalResponse{}, http.StatusInternalServerError, err
+	}
+
+	return generated.TraversalResponse{
+		Pattern:     &trav.pattern,
+		StartCorner: &trav.corner,
+		Distance:    &estate.TotalDistance,
+	}, http.StatusOK, nil
+}
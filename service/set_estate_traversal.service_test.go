@@ -0,0 +1,223 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_SetEstateTraversal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockContext := context.TODO()
+	rowSerpentine := generated.RowSerpentine
+	columnSerpentine := generated.ColumnSerpentine
+	spiral := generated.Spiral
+	xMinYMin := generated.XMinYMin
+	xMaxYMax := generated.XMaxYMax
+	invalidPattern := generated.TraversalPattern("zigzag")
+	invalidCorner := generated.TraversalCorner("center")
+
+	// 3 x 2 estate with 4 meter trees on plots (1,1) and (3,2), flown in row serpentine with the default settings
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 3, Width: 2, TotalDistance: 80, PlotSize: 10, Clearance: 1}
+	mockPlots := func() []repository.PlotEntity {
+		return []repository.PlotEntity{
+			{EstateId: mockEstateID, X: 1, Y: 1, OrderNumber: 1, TreeHeight: 4, Distance: 15},
+			{EstateId: mockEstateID, X: 3, Y: 2, OrderNumber: 4, TreeHeight: 4, Distance: 55},
+		}
+	}
+
+	// the same estate without plot (2,2), its mask holds rows of plots 1 to 3 and 1, 3
+	mockShapedEstate := mockEstate
+	mockShapedEstate.ShapeMask = []byte{1, 1, 0, 2, 1, 2, 0, 0, 1, 0}
+
+	type savedPlot struct {
+		x, y        uint16
+		orderNumber int
+		distance    int
+	}
+	expectSavedPlots := func(mockRepo *repository.MockRepositoryInterface, expected []savedPlot, pattern generated.TraversalPattern, corner generated.TraversalCorner, total int) {
+		var saved []savedPlot
+		mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+			saved = append(saved, savedPlot{plot.X, plot.Y, plot.OrderNumber, plot.Distance})
+			return &plot.ID, nil
+		}).Times(len(expected))
+		mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+			require.Equal(t, expected, saved)
+			require.Equal(t, string(pattern), estate.TraversalPattern)
+			require.Equal(t, string(corner), estate.TraversalCorner)
+			require.Equal(t, total, estate.TotalDistance)
+			return &estate.ID, nil
+		})
+	}
+
+	tests := []struct {
+		name           string
+		request        generated.Traversal
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   generated.TraversalResponse
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name:    "Column Serpentine",
+			request: generated.Traversal{Pattern: &columnSerpentine},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				// (3,2) ends the traversal, the tree on the first plot keeps its order number and distance
+				expectSavedPlots(mockRepo, []savedPlot{{3, 2, 6, 75}}, columnSerpentine, xMinYMin, 80)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.TraversalResponse{
+				Pattern:     &columnSerpentine,
+				StartCorner: &xMinYMin,
+				Distance:    &[]int{80}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Spiral From The Far Corner",
+			request: generated.Traversal{Pattern: &spiral, StartCorner: &xMaxYMax},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				// the two trees swap their order numbers
+				expectSavedPlots(mockRepo, []savedPlot{{3, 2, 1, 15}, {1, 1, 4, 55}}, spiral, xMaxYMax, 80)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.TraversalResponse{
+				Pattern:     &spiral,
+				StartCorner: &xMaxYMax,
+				Distance:    &[]int{80}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Defaults Keep The Order",
+			request: generated.Traversal{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				expectSavedPlots(mockRepo, nil, rowSerpentine, xMinYMin, 80)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.TraversalResponse{
+				Pattern:     &rowSerpentine,
+				StartCorner: &xMinYMin,
+				Distance:    &[]int{80}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid Pattern",
+			request:        generated.Traversal{Pattern: &invalidPattern},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("traversal_pattern must be one of row_serpentine, column_serpentine or spiral"),
+		},
+		{
+			name:           "Invalid Start Corner",
+			request:        generated.Traversal{StartCorner: &invalidCorner},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("start_corner must be one of x_min_y_min, x_max_y_min, x_min_y_max or x_max_y_max"),
+		},
+		{
+			name:    "Column Serpentine Over A Shape",
+			request: generated.Traversal{Pattern: &columnSerpentine},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockShapedEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				// the drone goes diagonally from plot (1,2) to plot (2,1) and climbs over the tree on plot (3,2) last
+				expectSavedPlots(mockRepo, []savedPlot{{3, 2, 5, 69}}, columnSerpentine, xMinYMin, 74)
+				mock.ExpectCommit()
+			},
+			expectedResp: generated.TraversalResponse{
+				Pattern:     &columnSerpentine,
+				StartCorner: &xMinYMin,
+				Distance:    &[]int{74}[0],
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:    "Spiral Over A Shape",
+			request: generated.Traversal{Pattern: &spiral},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockShapedEstate, nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("traversal_pattern spiral cannot be used on an estate that is not a rectangle"),
+		},
+		{
+			name:    "Estate Not Found",
+			request: generated.Traversal{},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(repository.EstateEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("estate not found"),
+		},
+		{
+			name:    "Repository Error",
+			request: generated.Traversal{Pattern: &columnSerpentine},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(mockPlots(), nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).Return(nil, errors.New("repository error"))
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusInternalServerError,
+			expectedErr:    errors.New("repository error"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.SetEstateTraversal(mockContext, mockEstateID, tt.request)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			assert.Equal(t, tt.expectedResp, resp)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/util"
+)
+
+func (s *Service) SetWorkOrderStatus(ctx context.Context, estateId uuid.UUID, orderId uuid.UUID, req generated.WorkOrderStatusRequest) (generated.WorkOrder, int, error) {
+	if err := checkWorkOrderStatus("status", req.Status); err != nil {
+		return generated.WorkOrder{}, http.StatusBadRequest, err
+	}
+	if req.Status == generated.Assigned {
+		if req.Assignee == nil {
+			return generated.WorkOrder{}, http.StatusBadRequest, errors.New("an assigned work order must have an assignee")
+		}
+		if err := checkWorkOrderAssignee(*req.Assignee); err != nil {
+			return generated.WorkOrder{}, http.StatusBadRequest, err
+		}
+	} else if req.Assignee != nil {
+		return generated.WorkOrder{}, http.StatusBadRequest, errors.New("assignee is only given when the status is assigned")
+	}
+
+	var err error
+	tx := s.Db.WithContext(ctx).Begin()
+
+	nCtx := util.NewTxContext(ctx, tx)
+	defer func() {
+		if p := recover(); p != nil {
+			tx.Rollback()
+		}
+		util.HandleTransaction(tx, err)
+	}()
+
+	order, err := s.Repository.GetWorkOrder(nCtx, estateId, orderId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.WorkOrder{}, http.StatusNotFound, errors.New("work order not found")
+		}
+		return generated.WorkOrder{}, http.StatusInternalServerError, err
+	}
+
+	from := order.Status
+	if !slices.Contains(workOrderTransitions[generated.WorkOrderStatus(from)], req.Status) {
+		err = fmt.Errorf("work order cannot move from %s to %s", from, req.Status)
+		return generated.WorkOrder{}, http.StatusConflict, err
+	}
+
+	now := time.Now()
+	order.Status = string(req.Status)
+	order.UpdatedAt = now
+	switch req.Status {
+	case generated.Assigned:
+		order.Assignee = req.Assignee
+	case generated.Open:
+		order.Assignee = nil
+	case generated.Completed:
+		order.CompletedAt = &now
+	}
+
+	var removed, planted []uuid.UUID
+	kind := generated.WorkOrderKind(order.Kind)
+	if req.Status == generated.Completed && (kind == generated.Remove || kind == generated.Replant) {
+		if removed, planted, err = s.applyWorkOrder(nCtx, order); err != nil {
+			return generated.WorkOrder{}, http.StatusInternalServerError, err
+		}
+	}
+
+	// the status is only written while the order is still where it was read, a concurrent move rolls this one back
+	if err = s.Repository.UpdateWorkOrderStatus(nCtx, order, from); err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return generated.WorkOrder{}, http.StatusConflict, errors.New("work order was moved concurrently")
+		}
+		return generated.WorkOrder{}, http.StatusInternalServerError, err
+	}
+
+	resp := workOrderResponse(order)
+	if req.Status == generated.Completed && (kind == generated.Remove || kind == generated.Replant) {
+		resp.RemovedTreeIds = &removed
+	}
+	if req.Status == generated.Completed && kind == generated.Replant {
+		resp.PlantedTreeIds = &planted
+	}
+	return resp, http.StatusOK, nil
+}
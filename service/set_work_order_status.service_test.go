@@ -0,0 +1,257 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/golang/mock/gomock"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+	"spgo/service"
+)
+
+func TestService_SetWorkOrderStatus(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockEstateID := uuid.New()
+	mockOrderID := uuid.New()
+	mockTreeID := uuid.New()
+	mockSaplingID := uuid.New()
+	mockContext := context.TODO()
+	assignee := "Budi"
+
+	// a row of 5 plots with trees of 10, 20 and 10 meters on plots 2, 3 and 4
+	mockEstate := repository.EstateEntity{ID: mockEstateID, Length: 5, Width: 1, TotalDistance: 92, PlotSize: 10, Clearance: 1, TreeCount: 3}
+	mockPlots := func() []repository.PlotEntity {
+		return []repository.PlotEntity{
+			{EstateId: mockEstateID, X: 2, Y: 1, OrderNumber: 2, TreeHeight: 10, Distance: 31},
+			{ID: mockTreeID, EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 20, Distance: 51},
+			{EstateId: mockEstateID, X: 4, Y: 1, OrderNumber: 4, TreeHeight: 10, Distance: 71},
+		}
+	}
+	// an order on plot 3 and the empty plot 5
+	mockOrder := func(kind generated.WorkOrderKind, status generated.WorkOrderStatus) repository.WorkOrderEntity {
+		return repository.WorkOrderEntity{
+			ID:       mockOrderID,
+			EstateId: mockEstateID,
+			Kind:     string(kind),
+			Status:   string(status),
+			Shape:    "plots",
+			Assignee: &assignee,
+			Areas:    []repository.WorkOrderAreaEntity{{MinX: 3, MinY: 1, MaxX: 3, MaxY: 1}, {MinX: 5, MinY: 1, MaxX: 5, MaxY: 1}},
+		}
+	}
+
+	tests := []struct {
+		name           string
+		req            generated.WorkOrderStatusRequest
+		prepareMocks   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock)
+		expectedResp   func(resp generated.WorkOrder)
+		expectedStatus int
+		expectedErr    error
+	}{
+		{
+			name: "Assign An Open Order",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Assigned, Assignee: &assignee},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				order := mockOrder(generated.Spray, generated.Open)
+				order.Assignee = nil
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(order, nil)
+				mockRepo.EXPECT().UpdateWorkOrderStatus(gomock.Any(), gomock.Any(), "open").DoAndReturn(func(ctx context.Context, entity repository.WorkOrderEntity, from string) error {
+					require.Equal(t, []any{"assigned", &assignee}, []any{entity.Status, entity.Assignee})
+					return nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, generated.Assigned, *resp.Status)
+				assert.Equal(t, assignee, *resp.Assignee)
+				assert.Nil(t, resp.RemovedTreeIds)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Open An Assigned Order Again",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Open},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(mockOrder(generated.Prune, generated.Assigned), nil)
+				mockRepo.EXPECT().UpdateWorkOrderStatus(gomock.Any(), gomock.Any(), "assigned").DoAndReturn(func(ctx context.Context, entity repository.WorkOrderEntity, from string) error {
+					require.Nil(t, entity.Assignee)
+					return nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, generated.Open, *resp.Status)
+				assert.Nil(t, resp.Assignee)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Complete A Remove Order",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Completed},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(mockOrder(generated.Remove, generated.InProgress), nil)
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				plots := mockPlots()
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(plots, nil)
+				// only the tree of plot 3 stands on the plots of the order
+				mockRepo.EXPECT().DeletePlots(gomock.Any(), mockEstateID, []uuid.UUID{mockTreeID}, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{plots[0], plots[2]}, nil)
+				// the drone comes down over the removed tree before climbing over the tree of plot 4
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, plot repository.PlotEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{4, 73}, []int{int(plot.X), plot.Distance})
+					return &plot.ID, nil
+				})
+				mockRepo.EXPECT().GetTreeHeightStats(gomock.Any(), mockEstateID).Return(repository.TreeHeightStats{Count: 2, Min: 10, Max: 10, Median: 10}, nil)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{2, 94, 10}, []int{estate.TreeCount, estate.TotalDistance, estate.TreeMaxHeight})
+					return &estate.ID, nil
+				})
+				mockRepo.EXPECT().UpdateWorkOrderStatus(gomock.Any(), gomock.Any(), "in_progress").DoAndReturn(func(ctx context.Context, entity repository.WorkOrderEntity, from string) error {
+					require.Equal(t, "completed", entity.Status)
+					require.NotNil(t, entity.CompletedAt)
+					return nil
+				})
+				mock.ExpectCommit()
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, generated.Completed, *resp.Status)
+				assert.NotNil(t, resp.CompletedAt)
+				assert.Equal(t, []uuid.UUID{mockTreeID}, *resp.RemovedTreeIds)
+				assert.Nil(t, resp.PlantedTreeIds)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Complete A Replant Order",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Completed},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				order := mockOrder(generated.Replant, generated.InProgress)
+				order.Height = &[]int{2}[0]
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(order, nil)
+				mockRepo.EXPECT().GetEstate(gomock.Any(), mockEstateID).Return(mockEstate, nil)
+				plots := mockPlots()
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return(plots, nil)
+				mockRepo.EXPECT().DeletePlots(gomock.Any(), mockEstateID, []uuid.UUID{mockTreeID}, gomock.Any()).Return(nil)
+				mockRepo.EXPECT().PostPlots(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, saplings []repository.PlotEntity) ([]uuid.UUID, error) {
+					require.Len(t, saplings, 1)
+					require.Equal(t, []int{3, 1, 3, 2}, []int{int(saplings[0].X), int(saplings[0].Y), saplings[0].OrderNumber, saplings[0].TreeHeight})
+					return []uuid.UUID{mockSaplingID}, nil
+				})
+				mockRepo.EXPECT().PostTreeMeasurements(gomock.Any(), gomock.Any()).Return(nil)
+				sapling := repository.PlotEntity{ID: mockSaplingID, EstateId: mockEstateID, X: 3, Y: 1, OrderNumber: 3, TreeHeight: 2}
+				mockRepo.EXPECT().GetPlots(gomock.Any(), mockEstateID).Return([]repository.PlotEntity{plots[0], sapling, plots[2]}, nil)
+				mockRepo.EXPECT().SavePlot(gomock.Any(), gomock.Any()).Return(&mockSaplingID, nil).AnyTimes()
+				mockRepo.EXPECT().GetTreeHeightStats(gomock.Any(), mockEstateID).Return(repository.TreeHeightStats{Count: 3, Min: 2, Max: 10, Median: 10}, nil)
+				mockRepo.EXPECT().SaveEstate(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, estate repository.EstateEntity) (*uuid.UUID, error) {
+					require.Equal(t, []int{3, 2}, []int{estate.TreeCount, estate.TreeMinHeight})
+					return &estate.ID, nil
+				})
+				mockRepo.EXPECT().UpdateWorkOrderStatus(gomock.Any(), gomock.Any(), "in_progress").Return(nil)
+				mock.ExpectCommit()
+			},
+			expectedResp: func(resp generated.WorkOrder) {
+				assert.Equal(t, []uuid.UUID{mockTreeID}, *resp.RemovedTreeIds)
+				assert.Equal(t, []uuid.UUID{mockSaplingID}, *resp.PlantedTreeIds)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Completed Is Final",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Cancelled},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(mockOrder(generated.Prune, generated.Completed), nil)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErr:    errors.New("work order cannot move from completed to cancelled"),
+		},
+		{
+			name: "Moved Concurrently",
+			req:  generated.WorkOrderStatusRequest{Status: generated.InProgress},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(mockOrder(generated.Prune, generated.Assigned), nil)
+				mockRepo.EXPECT().UpdateWorkOrderStatus(gomock.Any(), gomock.Any(), "assigned").Return(gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusConflict,
+			expectedErr:    errors.New("work order was moved concurrently"),
+		},
+		{
+			name:           "Assigned Without Assignee",
+			req:            generated.WorkOrderStatusRequest{Status: generated.Assigned},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("an assigned work order must have an assignee"),
+		},
+		{
+			name:           "Assignee When Started",
+			req:            generated.WorkOrderStatusRequest{Status: generated.InProgress, Assignee: &assignee},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("assignee is only given when the status is assigned"),
+		},
+		{
+			name:           "Unknown Status",
+			req:            generated.WorkOrderStatusRequest{Status: "done"},
+			prepareMocks:   func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {},
+			expectedStatus: http.StatusBadRequest,
+			expectedErr:    errors.New("status must be one of open, assigned, in_progress, completed, cancelled"),
+		},
+		{
+			name: "Work Order Not Found",
+			req:  generated.WorkOrderStatusRequest{Status: generated.Cancelled},
+			prepareMocks: func(mockRepo *repository.MockRepositoryInterface, mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mockRepo.EXPECT().GetWorkOrder(gomock.Any(), mockEstateID, mockOrderID).Return(repository.WorkOrderEntity{}, gorm.ErrRecordNotFound)
+				mock.ExpectRollback()
+			},
+			expectedStatus: http.StatusNotFound,
+			expectedErr:    errors.New("work order not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+
+			gdb, err := gorm.Open(postgres.New(postgres.Config{Conn: db}), &gorm.Config{})
+			require.NoError(t, err)
+
+			mockRepo := repository.NewMockRepositoryInterface(ctrl)
+			tt.prepareMocks(mockRepo, mock)
+
+			svc := service.NewService(service.NewServiceOptions{Repository: mockRepo, Db: gdb})
+			resp, status, err := svc.SetWorkOrderStatus(mockContext, mockEstateID, mockOrderID, tt.req)
+
+			assert.Equal(t, tt.expectedStatus, status)
+			if tt.expectedErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.EqualError(t, err, tt.expectedErr.Error())
+			}
+			if tt.expectedResp != nil {
+				tt.expectedResp(resp)
+			}
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
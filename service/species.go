@@ -0,0 +1,80 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// defaultMaxTreeHeight caps the height of a tree without species.
+const defaultMaxTreeHeight = 30
+
+// maxTreeHeight returns the height a tree of a species cannot exceed, speciesId is nil for a tree without species.
+func (s *Service) maxTreeHeight(ctx context.Context, speciesId *uuid.UUID) (int, int, error) {
+	if speciesId == nil {
+		return defaultMaxTreeHeight, http.StatusOK, nil
+	}
+
+	species, err := s.Repository.GetSpecies(ctx, *speciesId)
+	if err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return 0, http.StatusBadRequest, errors.New("species not found")
+		}
+		return 0, http.StatusInternalServerError, err
+	}
+	return species.MaxHeight, http.StatusOK, nil
+}
+
+// checkTreeHeight validates the height of a tree against the max height of its species.
+func (s *Service) checkTreeHeight(ctx context.Context, speciesId *uuid.UUID, height int) (int, error) {
+	limit, status, err := s.maxTreeHeight(ctx, speciesId)
+	if err != nil {
+		return status, err
+	}
+	if height < 1 || height > limit {
+		if speciesId == nil {
+			return http.StatusBadRequest, fmt.Errorf("height must be between 1 and %d for a tree without species", limit)
+		}
+		return http.StatusBadRequest, fmt.Errorf("height must be between 1 and %d, the max height of the species", limit)
+	}
+	return http.StatusOK, nil
+}
+
+// speciesStats returns the stats per species of the trees selected by the filter.
+func (s *Service) speciesStats(ctx context.Context, estateID uuid.UUID, filter repository.TreeHeightFilter) (*[]generated.SpeciesStats, error) {
+	stats, err := s.Repository.GetTreeHeightStatsBySpecies(ctx, estateID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	species := make([]generated.SpeciesStats, len(stats))
+	for i := range stats {
+		median := int(stats[i].Median)
+		species[i] = generated.SpeciesStats{
+			SpeciesId: stats[i].SpeciesId,
+			Name:      stats[i].Name,
+			Count:     &stats[i].Count,
+			Max:       &stats[i].Max,
+			Min:       &stats[i].Min,
+			Median:    &median,
+		}
+	}
+	return &species, nil
+}
+
+func speciesOf(entity repository.SpeciesEntity) generated.Species {
+	return generated.Species{
+		Id:           &entity.ID,
+		Name:         &entity.Name,
+		MaxHeight:    &entity.MaxHeight,
+		GrowthRate:   entity.GrowthRate,
+		HarvestCycle: entity.HarvestCycle,
+	}
+}
@@ -0,0 +1,395 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"spgo/repository"
+)
+
+// maxElevationCells bounds the cells of an elevation grid, every plot is interpolated from it on every flight plan.
+const maxElevationCells = 1 << 20
+
+/*
+maxTerrainSamples bounds the grounds the terrain of an estate holds. The ground of an estate of more plots is sampled
+in squares of plots, every plot of a square is flown over the ground under the center of the square.
+*/
+const maxTerrainSamples = 1 << 20
+
+// elevations are in meters above sea level, from the shore of the Dead Sea to above the highest estates.
+const (
+	minElevation = -500
+	maxElevation = 9000
+)
+
+// terrainBlock is the side in samples of the squares a track over the terrain looks at before their samples.
+const terrainBlock = 16
+
+/*
+elevationGrid is an elevation model as uploaded: the elevation in meters at the center of square cells of cellSize
+meters, row by row from y 1 up. The lower left corner of the grid is the origin of the estate.
+*/
+type elevationGrid struct {
+	columns, rows int
+	cellSize      float64
+	elevations    []float64
+}
+
+/*
+parseElevationGrid reads an elevation grid from an ESRI ASCII raster, told by its ncols header, or from CSV rows of
+elevations. Both are written from the highest y down like the exported canopy map. The CSV grid has no header, its
+cells are cellSize meters, the plot size when it is nil.
+*/
+func parseElevationGrid(content []byte, cellSize *float64, plotSize float64) (elevationGrid, error) {
+	fields := bytes.Fields(content)
+	if len(fields) == 0 {
+		return elevationGrid{}, errors.New("elevation grid cannot be empty")
+	}
+	if strings.EqualFold(string(fields[0]), "ncols") {
+		if cellSize != nil {
+			return elevationGrid{}, errors.New("cell_size cannot be given with an ASCII raster, its header holds the cell size")
+		}
+		return parseAsciiRaster(fields)
+	}
+
+	size := plotSize
+	if cellSize != nil {
+		size = *cellSize
+	}
+	if size <= 0 || size > 1000 {
+		return elevationGrid{}, errors.New("cell_size must be greater than 0 and at most 1000")
+	}
+	return parseElevationCsv(content, size)
+}
+
+func parseAsciiRaster(fields [][]byte) (elevationGrid, error) {
+	g := elevationGrid{}
+	var noData *float64
+	i := 0
+	for ; i+1 < len(fields); i += 2 {
+		key := strings.ToLower(string(fields[i]))
+		if key == "" || key[0] < 'a' || key[0] > 'z' {
+			break
+		}
+		value, err := strconv.ParseFloat(string(fields[i+1]), 64)
+		if err != nil {
+			return elevationGrid{}, fmt.Errorf("ASCII raster header %s must be a number", key)
+		}
+		switch key {
+		case "ncols":
+			g.columns = int(value)
+		case "nrows":
+			g.rows = int(value)
+		case "cellsize":
+			g.cellSize = value
+		case "nodata_value":
+			noData = &value
+		case "xllcorner", "yllcorner", "xllcenter", "yllcenter":
+			// the grid is laid on the estate from its origin, its own coordinates are not used
+		default:
+			return elevationGrid{}, fmt.Errorf("ASCII raster header %s is not supported", key)
+		}
+	}
+
+	if g.columns < 1 || g.rows < 1 {
+		return elevationGrid{}, errors.New("ASCII raster ncols and nrows must be at least 1")
+	}
+	if g.cellSize <= 0 || g.cellSize > 1000 {
+		return elevationGrid{}, errors.New("ASCII raster cellsize must be greater than 0 and at most 1000")
+	}
+	if g.columns*g.rows > maxElevationCells {
+		return elevationGrid{}, fmt.Errorf("an elevation grid cannot have more than %d cells", maxElevationCells)
+	}
+	if len(fields)-i != g.columns*g.rows {
+		return elevationGrid{}, fmt.Errorf("ASCII raster must hold %d values, %d by %d", g.columns*g.rows, g.columns, g.rows)
+	}
+
+	g.elevations = make([]float64, g.columns*g.rows)
+	for k, field := range fields[i:] {
+		// the raster starts with its top row
+		column, row := k%g.columns, g.rows-1-k/g.columns
+		if value, err := strconv.ParseFloat(string(field), 64); err == nil && noData != nil && value == *noData {
+			return elevationGrid{}, fmt.Errorf("elevation grid cell (%d,%d) has no data", column+1, row+1)
+		}
+		elevation, err := parseElevation(string(field))
+		if err != nil {
+			return elevationGrid{}, err
+		}
+		g.elevations[row*g.columns+column] = elevation
+	}
+	return g, nil
+}
+
+func parseElevationCsv(content []byte, cellSize float64) (elevationGrid, error) {
+	r := csv.NewReader(bytes.NewReader(content))
+	r.TrimLeadingSpace = true
+	r.ReuseRecord = true
+
+	// the rows are read from the top down, they are flipped once they are all read
+	var top [][]float64
+	g := elevationGrid{cellSize: cellSize}
+	for {
+		record, err := r.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if errors.Is(err, csv.ErrFieldCount) {
+			return elevationGrid{}, errors.New("every row of the elevation grid must have the same number of values")
+		}
+		if err != nil {
+			return elevationGrid{}, err
+		}
+		if g.columns = len(record); g.columns*(len(top)+1) > maxElevationCells {
+			return elevationGrid{}, fmt.Errorf("an elevation grid cannot have more than %d cells", maxElevationCells)
+		}
+
+		row := make([]float64, len(record))
+		for column, field := range record {
+			if row[column], err = parseElevation(strings.TrimSpace(field)); err != nil {
+				return elevationGrid{}, err
+			}
+		}
+		top = append(top, row)
+	}
+
+	g.rows = len(top)
+	g.elevations = make([]float64, 0, g.columns*g.rows)
+	for row := g.rows - 1; row >= 0; row-- {
+		g.elevations = append(g.elevations, top[row]...)
+	}
+	return g, nil
+}
+
+func parseElevation(field string) (float64, error) {
+	elevation, err := strconv.ParseFloat(field, 64)
+	if err != nil || math.IsNaN(elevation) {
+		return 0, fmt.Errorf("elevation grid value %q is not a number", field)
+	}
+	if elevation < minElevation || elevation > maxElevation {
+		return 0, fmt.Errorf("elevation %g is out of range, it must be between %d and %d meters", elevation, minElevation, maxElevation)
+	}
+	return elevation, nil
+}
+
+// covers reports whether the grid reaches over every plot of an estate of plots of plotSize meters.
+func (g elevationGrid) covers(length, width int, plotSize float64) bool {
+	const epsilon = 1e-9
+	return float64(g.columns)*g.cellSize >= float64(length)*plotSize-epsilon && float64(g.rows)*g.cellSize >= float64(width)*plotSize-epsilon
+}
+
+// relief returns the height in meters of the highest cell above the lowest one.
+func (g elevationGrid) relief() int {
+	lowest, highest := math.Inf(1), math.Inf(-1)
+	for _, elevation := range g.elevations {
+		lowest, highest = min(lowest, elevation), max(highest, elevation)
+	}
+	return int(math.Round(highest - lowest))
+}
+
+/*
+at returns the elevation at a point in meters from the origin, interpolated between the centers of the four cells
+around it. Past the centers of the cells on the edges of the grid the elevation is the one of the nearest edge.
+*/
+func (g elevationGrid) at(px, py float64) float64 {
+	u := min(max(px/g.cellSize-0.5, 0), float64(g.columns-1))
+	v := min(max(py/g.cellSize-0.5, 0), float64(g.rows-1))
+	c0, r0 := int(u), int(v)
+	c1, r1 := min(c0+1, g.columns-1), min(r0+1, g.rows-1)
+	fu, fv := u-float64(c0), v-float64(r0)
+
+	e := g.elevations
+	bottom := e[r0*g.columns+c0]*(1-fu) + e[r0*g.columns+c1]*fu
+	top := e[r1*g.columns+c0]*(1-fu) + e[r1*g.columns+c1]*fu
+	return bottom*(1-fv) + top*fv
+}
+
+// entity returns the grid as stored, the elevations as little endian float32.
+func (g elevationGrid) entity(estate repository.EstateEntity) repository.EstateElevationEntity {
+	elevations := make([]byte, 4*len(g.elevations))
+	for i, elevation := range g.elevations {
+		binary.LittleEndian.PutUint32(elevations[4*i:], math.Float32bits(float32(elevation)))
+	}
+	return repository.EstateElevationEntity{EstateId: estate.ID, Columns: g.columns, Rows: g.rows, CellSize: g.cellSize, Elevations: elevations}
+}
+
+func elevationGridOf(entity repository.EstateElevationEntity) (elevationGrid, error) {
+	if entity.Columns < 1 || entity.Rows < 1 || len(entity.Elevations) != 4*entity.Columns*entity.Rows {
+		return elevationGrid{}, errors.New("elevation grid of the estate is corrupt")
+	}
+	g := elevationGrid{columns: entity.Columns, rows: entity.Rows, cellSize: entity.CellSize, elevations: make([]float64, entity.Columns*entity.Rows)}
+	for i := range g.elevations {
+		g.elevations[i] = float64(math.Float32frombits(binary.LittleEndian.Uint32(entity.Elevations[4*i:])))
+	}
+	return g, nil
+}
+
+/*
+terrain is the ground of an estate under the center of every plot, in meters above its lowest plot and rounded to
+the meter like the flight altitudes. The altitudes of a flight over it are above the lowest plot too. On an estate of
+more than maxTerrainSamples plots the ground is sampled in squares of step x step plots, the plots are then the
+squares of plots.
+*/
+type terrain struct {
+	length, width int
+	// step is the side in plots of the squares the ground is sampled in, columns and rows count them
+	step          int
+	columns, rows int
+	grounds       []int32
+	// lowest and highest are the elevations of the lowest and the highest plot
+	lowest, highest float64
+	// blocks holds the highest ground of every square of terrainBlock samples
+	blocks       []int32
+	blockColumns int
+}
+
+// terrainStep returns the side in plots of the squares the ground of an estate is sampled in, 1 while it has at most
+// maxTerrainSamples plots.
+func terrainStep(length, width int) int {
+	step := 1
+	for ((length+step-1)/step)*((width+step-1)/step) > maxTerrainSamples {
+		step++
+	}
+	return step
+}
+
+// newTerrain interpolates the ground of every plot, or square of plots, of an estate from its elevation grid.
+func newTerrain(estate repository.EstateEntity, g elevationGrid, plotSize float64) *terrain {
+	t := &terrain{length: estate.Length, width: estate.Width, step: terrainStep(estate.Length, estate.Width)}
+	t.columns, t.rows = (t.length+t.step-1)/t.step, (t.width+t.step-1)/t.step
+	t.grounds = make([]int32, t.columns*t.rows)
+
+	elevations := make([]float64, len(t.grounds))
+	t.lowest, t.highest = math.Inf(1), math.Inf(-1)
+	for row := 0; row < t.rows; row++ {
+		// the center of the plots of the square, the last squares are cut by the edges of the estate
+		py := float64(row*t.step+min((row+1)*t.step, t.width)) / 2 * plotSize
+		for column := 0; column < t.columns; column++ {
+			px := float64(column*t.step+min((column+1)*t.step, t.length)) / 2 * plotSize
+			elevation := g.at(px, py)
+			elevations[row*t.columns+column] = elevation
+			t.lowest, t.highest = min(t.lowest, elevation), max(t.highest, elevation)
+		}
+	}
+
+	t.blockColumns = (t.columns-1)/terrainBlock + 1
+	t.blocks = make([]int32, t.blockColumns*((t.rows-1)/terrainBlock+1))
+	for i, elevation := range elevations {
+		ground := int32(math.Round(elevation - t.lowest))
+		t.grounds[i] = ground
+		block := i/t.columns/terrainBlock*t.blockColumns + i%t.columns/terrainBlock
+		t.blocks[block] = max(t.blocks[block], ground)
+	}
+	return t
+}
+
+// ground returns the ground under plot (x,y), 0 without terrain.
+func (t *terrain) ground(x, y int) int {
+	if t == nil || x < 1 || y < 1 || x > t.length || y > t.width {
+		return 0
+	}
+	return int(t.grounds[(y-1)/t.step*t.columns+(x-1)/t.step])
+}
+
+// sampled returns how many plots on from plot (x,y) along the direction (dx,dy) share its ground, as they lie in the
+// square of plots it is sampled in.
+func (t *terrain) sampled(x, y, dx, dy int) int {
+	switch {
+	case dx > 0:
+		return min((x-1)/t.step*t.step+t.step, t.length) - x
+	case dx < 0:
+		return (x - 1) % t.step
+	case dy > 0:
+		return min((y-1)/t.step*t.step+t.step, t.width) - y
+	case dy < 0:
+		return (y - 1) % t.step
+	default:
+		return 0
+	}
+}
+
+// relief returns the ground under the highest plot, 0 without terrain.
+func (t *terrain) relief() int {
+	if t == nil {
+		return 0
+	}
+	relief := int32(0)
+	for _, ground := range t.blocks {
+		relief = max(relief, ground)
+	}
+	return int(relief)
+}
+
+/*
+highestAlong returns the highest ground under the straight track between the centers of two plots, both plots
+included. The track walks the squares of samples it goes through and only looks at the samples of a square that is
+higher than the highest ground found so far.
+*/
+func (t *terrain) highestAlong(from, to [2]int) int {
+	if t == nil {
+		return 0
+	}
+
+	// sample (column,row) covers [column,column+1]x[row,row+1], in square units the track goes from p to q
+	step := float64(t.step)
+	ax, ay := (float64(from[0])-0.5)/step, (float64(from[1])-0.5)/step
+	bx, by := (float64(to[0])-0.5)/step, (float64(to[1])-0.5)/step
+	highest := max(t.ground(from[0], from[1]), t.ground(to[0], to[1]))
+	px, py, qx, qy := ax/terrainBlock, ay/terrainBlock, bx/terrainBlock, by/terrainBlock
+
+	visit := func(column, row int) {
+		if int(t.blocks[row*t.blockColumns+column]) <= highest {
+			return
+		}
+		for y := row * terrainBlock; y < min((row+1)*terrainBlock, t.rows); y++ {
+			for x := column * terrainBlock; x < min((column+1)*terrainBlock, t.columns); x++ {
+				if ground := int(t.grounds[y*t.columns+x]); ground > highest && crossesPlot(ax, ay, bx, by, x+1, y+1) {
+					highest = ground
+				}
+			}
+		}
+	}
+
+	column, row := int(px), int(py)
+	lastColumn, lastRow := int(qx), int(qy)
+	stepX, nextX, deltaX := gridStep(px, qx)
+	stepY, nextY, deltaY := gridStep(py, qy)
+	for steps := abs(lastColumn-column) + abs(lastRow-row); ; steps-- {
+		visit(column, row)
+		if steps == 0 {
+			break
+		}
+		if nextX < nextY {
+			column += stepX
+			nextX += deltaX
+		} else {
+			row += stepY
+			nextY += deltaY
+		}
+	}
+	return highest
+}
+
+// loadTerrain returns the terrain of an estate, nil when its ground is flat.
+func (s *Service) loadTerrain(ctx context.Context, estate repository.EstateEntity, model flightModel) (*terrain, error) {
+	if estate.Relief == 0 {
+		return nil, nil
+	}
+
+	entity, err := s.Repository.GetEstateElevation(ctx, estate.ID)
+	if err != nil {
+		return nil, err
+	}
+	g, err := elevationGridOf(entity)
+	if err != nil {
+		return nil, err
+	}
+	return newTerrain(estate, g, model.plotSize), nil
+}
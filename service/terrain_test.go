@@ -0,0 +1,259 @@
+package service
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestParseElevationGrid(t *testing.T) {
+	cellSize := 5.0
+
+	t.Run("CSV Rows From The Top Down", func(t *testing.T) {
+		g, err := parseElevationGrid([]byte("0, 10\n20, 30\n"), nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, elevationGrid{columns: 2, rows: 2, cellSize: 10, elevations: []float64{20, 30, 0, 10}}, g)
+	})
+
+	t.Run("ASCII Raster", func(t *testing.T) {
+		raster := "ncols 2\nnrows 2\nxllcorner 1000\nyllcorner 2000\ncellsize 5\nNODATA_value -9999\n0 10\n20 30\n"
+		g, err := parseElevationGrid([]byte(raster), nil, 10)
+		require.NoError(t, err)
+		assert.Equal(t, elevationGrid{columns: 2, rows: 2, cellSize: 5, elevations: []float64{20, 30, 0, 10}}, g)
+	})
+
+	errorTests := []struct {
+		name     string
+		content  string
+		cellSize *float64
+		expected string
+	}{
+		{name: "Empty", content: " \n", expected: "elevation grid cannot be empty"},
+		{name: "Ragged Rows", content: "1,2\n3\n", expected: "every row of the elevation grid must have the same number of values"},
+		{name: "Not A Number", content: "1,abc\n", expected: `elevation grid value "abc" is not a number`},
+		{name: "Out Of Range", content: "9001\n", expected: "elevation 9001 is out of range, it must be between -500 and 9000 meters"},
+		{name: "Invalid Cell Size", content: "1\n", cellSize: &[]float64{0}[0], expected: "cell_size must be greater than 0 and at most 1000"},
+		{name: "Cell Size With A Raster", content: "ncols 1\nnrows 1\ncellsize 5\n1\n", cellSize: &cellSize, expected: "cell_size cannot be given with an ASCII raster, its header holds the cell size"},
+		{name: "Raster Cell Without Data", content: "ncols 2\nnrows 1\ncellsize 5\nnodata_value -9999\n1 -9999\n", expected: "elevation grid cell (2,1) has no data"},
+		{name: "Raster Too Short", content: "ncols 2\nnrows 2\ncellsize 5\n1 2 3\n", expected: "ASCII raster must hold 4 values, 2 by 2"},
+	}
+	for _, tt := range errorTests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseElevationGrid([]byte(tt.content), tt.cellSize, 10)
+			assert.EqualError(t, err, tt.expected)
+		})
+	}
+}
+
+func TestElevationGrid_At(t *testing.T) {
+	g := elevationGrid{columns: 2, rows: 1, cellSize: 10, elevations: []float64{0, 10}}
+
+	// between the cell centers the elevation is interpolated, past them it is the one of the edge
+	assert.Equal(t, 0.0, g.at(0, 5))
+	assert.Equal(t, 0.0, g.at(5, 5))
+	assert.Equal(t, 5.0, g.at(10, 0))
+	assert.Equal(t, 7.5, g.at(12.5, 9))
+	assert.Equal(t, 10.0, g.at(20, 5))
+
+	// plots of 5 meters at 2.5, 7.5, 12.5 and 17.5 meters
+	estate := repository.EstateEntity{Length: 4, Width: 1}
+	terrain := newTerrain(estate, g, 5)
+	assert.Equal(t, []int32{0, 3, 8, 10}, terrain.grounds)
+	assert.Equal(t, []float64{0, 10}, []float64{terrain.lowest, terrain.highest})
+	assert.Equal(t, 10, terrain.relief())
+
+	stored, err := elevationGridOf(g.entity(estate))
+	require.NoError(t, err)
+	assert.Equal(t, g, stored)
+}
+
+func TestTerrain_HighestAlong(t *testing.T) {
+	const length, width = 53, 37
+	rng := rand.New(rand.NewSource(42))
+	g := elevationGrid{columns: length, rows: width, cellSize: 1, elevations: make([]float64, length*width)}
+	for i := range g.elevations {
+		g.elevations[i] = float64(rng.Intn(100))
+	}
+	ground := newTerrain(repository.EstateEntity{Length: length, Width: width}, g, 1)
+
+	// every plot the track goes through is looked at
+	bruteForce := func(from, to [2]int) int {
+		highest := max(ground.ground(from[0], from[1]), ground.ground(to[0], to[1]))
+		ax, ay, bx, by := float64(from[0])-0.5, float64(from[1])-0.5, float64(to[0])-0.5, float64(to[1])-0.5
+		for y := 1; y <= width; y++ {
+			for x := 1; x <= length; x++ {
+				if crossesPlot(ax, ay, bx, by, x, y) {
+					highest = max(highest, ground.ground(x, y))
+				}
+			}
+		}
+		return highest
+	}
+
+	for i := 0; i < 500; i++ {
+		from := [2]int{1 + rng.Intn(length), 1 + rng.Intn(width)}
+		to := [2]int{1 + rng.Intn(length), 1 + rng.Intn(width)}
+		require.Equal(t, bruteForce(from, to), ground.highestAlong(from, to), "from %v to %v", from, to)
+	}
+
+	// on an estate sampled in squares of plots the track looks at the squares it goes through
+	const sampledLength, sampledWidth = 1100, 1000
+	g = elevationGrid{columns: 110, rows: 100, cellSize: 1, elevations: make([]float64, 110*100)}
+	for i := range g.elevations {
+		g.elevations[i] = float64(rng.Intn(100))
+	}
+	sampled := newTerrain(repository.EstateEntity{Length: sampledLength, Width: sampledWidth}, g, 0.1)
+	require.Equal(t, 2, sampled.step)
+	for i := 0; i < 50; i++ {
+		from := [2]int{1 + rng.Intn(sampledLength), 1 + rng.Intn(sampledWidth)}
+		to := [2]int{1 + rng.Intn(sampledLength), 1 + rng.Intn(sampledWidth)}
+		highest := max(sampled.ground(from[0], from[1]), sampled.ground(to[0], to[1]))
+		ax, ay, bx, by := float64(from[0])-0.5, float64(from[1])-0.5, float64(to[0])-0.5, float64(to[1])-0.5
+		for y := min(from[1], to[1]); y <= max(from[1], to[1]); y++ {
+			for x := min(from[0], to[0]); x <= max(from[0], to[0]); x++ {
+				if crossesPlot(ax, ay, bx, by, x, y) {
+					highest = max(highest, sampled.ground(x, y))
+				}
+			}
+		}
+		require.Equal(t, highest, sampled.highestAlong(from, to), "from %v to %v", from, to)
+	}
+
+	var flat *terrain
+	assert.Equal(t, 0, flat.highestAlong([2]int{1, 1}, [2]int{5, 5}))
+}
+
+func TestTerrainRuns(t *testing.T) {
+	// a row of 3 plots across a valley 10 meters deep
+	estate := repository.EstateEntity{Length: 3, Width: 1}
+	g := elevationGrid{columns: 3, rows: 1, cellSize: 10, elevations: []float64{130, 120, 130}}
+	model := flightModel{plotSize: 10, clearance: 1, terrain: newTerrain(estate, g, 10)}
+	trav := newTraversal(estate)
+
+	tests := []struct {
+		name     string
+		mode     generated.FlightMode
+		expected []flightRun
+		distance int
+	}{
+		{
+			name: "Terrain Following",
+			mode: generated.TerrainFollowing,
+			expected: []flightRun{
+				{From: 1, To: 1, Altitude: 10, Ground: 10},
+				{From: 2, To: 2, Altitude: 0, Ground: 0},
+				{From: 3, To: 3, Altitude: 10, Ground: 10},
+			},
+			// down into the valley and up out of it
+			distance: 50,
+		},
+		{
+			name: "Fixed Estate Max",
+			mode: generated.FixedEstateMax,
+			expected: []flightRun{
+				{From: 1, To: 1, Altitude: 10, Ground: 10},
+				{From: 2, To: 2, Altitude: 10, Ground: 0},
+				{From: 3, To: 3, Altitude: 10, Ground: 10},
+			},
+			distance: 30,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runs := altitudeRuns(tt.mode, trav, nil, model)
+			assert.Equal(t, tt.expected, runs)
+			assert.Equal(t, tt.distance, model.runsDistance(runs))
+		})
+	}
+}
+
+func TestTerrainStep(t *testing.T) {
+	// up to maxTerrainSamples plots the ground is sampled under every plot
+	assert.Equal(t, 1, terrainStep(1024, 1024))
+	assert.Equal(t, 1, terrainStep(1, maxTerrainSamples))
+	assert.Equal(t, 2, terrainStep(1025, 1024))
+	assert.Equal(t, 49, terrainStep(50000, 50000))
+}
+
+func TestTerrainRuns_SampledSquares(t *testing.T) {
+	rng := rand.New(rand.NewSource(7))
+
+	// the runs of the plots one by one, every plot looked at
+	bruteForce := func(mode generated.FlightMode, trav traversal, plots []repository.PlotEntity, model flightModel) []flightRun {
+		plotCount := trav.plotCount()
+		grounds := make([]int, plotCount+1)
+		altitudes := make([]int, plotCount+1)
+		trees := map[int]*repository.PlotEntity{}
+		for i := range plots {
+			trees[plots[i].OrderNumber] = &plots[i]
+		}
+		for order := 1; order <= plotCount; order++ {
+			grounds[order] = model.ground(trav.plot(order))
+			altitudes[order] = grounds[order] + model.altitude(trees[order])
+		}
+		for from := 1; from <= plotCount; {
+			to := plotCount
+			if mode == generated.FixedRowMax {
+				to = trav.legEnd(from)
+			}
+			highest := 0
+			for order := from; order <= to; order++ {
+				highest = max(highest, altitudes[order])
+			}
+			if mode != generated.TerrainFollowing {
+				for order := from; order <= to; order++ {
+					altitudes[order] = highest
+				}
+			}
+			from = to + 1
+		}
+		var runs []flightRun
+		for order := 1; order <= plotCount; order++ {
+			if last := len(runs) - 1; last >= 0 && runs[last].Altitude == altitudes[order] && runs[last].Ground == grounds[order] {
+				runs[last].To = order
+				continue
+			}
+			runs = append(runs, flightRun{From: order, To: order, Altitude: altitudes[order], Ground: grounds[order]})
+		}
+		return runs
+	}
+
+	estates := []repository.EstateEntity{
+		{Length: 23, Width: 17, TraversalPattern: string(generated.RowSerpentine)},
+		{Length: 23, Width: 17, TraversalPattern: string(generated.ColumnSerpentine), TraversalCorner: string(generated.XMaxYMax)},
+		{Length: 23, Width: 17, TraversalPattern: string(generated.Spiral), TraversalCorner: string(generated.XMinYMax)},
+		// more plots than samples, the ground is sampled in squares of 2x2 plots
+		{Length: 1100, Width: 1000, TraversalPattern: string(generated.RowSerpentine)},
+		{Length: 1100, Width: 1000, TraversalPattern: string(generated.Spiral), TraversalCorner: string(generated.XMaxYMin)},
+	}
+	for _, estate := range estates {
+		g := elevationGrid{columns: 9, rows: 7, cellSize: float64(estate.Length) * 10 / 8, elevations: make([]float64, 63)}
+		for i := range g.elevations {
+			g.elevations[i] = float64(rng.Intn(40))
+		}
+		model := flightModel{plotSize: 10, clearance: 1, minCruiseAltitude: 3, terrain: newTerrain(estate, g, 10)}
+		trav := newTraversal(estate)
+
+		var plots []repository.PlotEntity
+		for i := 0; i < 60; i++ {
+			plots = append(plots, repository.PlotEntity{X: uint16(1 + rng.Intn(estate.Length)), Y: uint16(1 + rng.Intn(estate.Width)), TreeHeight: 1 + rng.Intn(30)})
+		}
+		trav.orderPlots(plots)
+		trees := plots[:0]
+		for i := range plots {
+			if i == 0 || plots[i].OrderNumber != plots[i-1].OrderNumber {
+				trees = append(trees, plots[i])
+			}
+		}
+
+		for _, mode := range flightModes {
+			require.Equal(t, bruteForce(mode, trav, trees, model), altitudeRuns(mode, trav, trees, model), "%dx%d %s %s", estate.Length, estate.Width, estate.TraversalPattern, mode)
+		}
+	}
+}
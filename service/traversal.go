@@ -0,0 +1,392 @@
+package service
+
+import (
+	"errors"
+	"sort"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+/*
+traversal is the order the drone crosses the plots of an estate in, order numbers count from 1 at the start corner.
+Plots are handled in coordinates mirrored so the start corner is plot (1,1), where row_serpentine goes along x on
+odd rows and back on even rows, column_serpentine goes along y on odd columns and back on even columns, and spiral
+goes around the rings of the estate inward, first along x. On an estate that is not a rectangle the serpentines
+skip the plots outside its shape, so order numbers count the plots of the shape only.
+*/
+type traversal struct {
+	pattern generated.TraversalPattern
+	corner  generated.TraversalCorner
+	length  int
+	width   int
+	shape   *estateShape
+	passes  *shapePasses
+}
+
+func newTraversal(estate repository.EstateEntity) traversal {
+	t := traversal{
+		pattern: generated.TraversalPattern(estate.TraversalPattern),
+		corner:  generated.TraversalCorner(estate.TraversalCorner),
+		length:  estate.Length,
+		width:   estate.Width,
+		shape:   estateShapeOf(estate),
+	}
+	if t.pattern == "" {
+		t.pattern = generated.RowSerpentine
+	}
+	if t.corner == "" {
+		t.corner = generated.XMinYMin
+	}
+	t.passes = t.shapePasses()
+	return t
+}
+
+// withOverride returns the traversal with the pattern and the start corner of a plan request when they are given.
+func (t traversal) withOverride(pattern *generated.TraversalPattern, corner *generated.TraversalCorner) (traversal, error) {
+	if pattern != nil {
+		t.pattern = *pattern
+	}
+	if corner != nil {
+		t.corner = *corner
+	}
+	if err := t.validate(); err != nil {
+		return t, err
+	}
+	t.passes = t.shapePasses()
+	return t, nil
+}
+
+func (t traversal) validate() error {
+	switch t.pattern {
+	case generated.RowSerpentine, generated.ColumnSerpentine, generated.Spiral:
+	default:
+		return errors.New("traversal_pattern must be one of row_serpentine, column_serpentine or spiral")
+	}
+	switch t.corner {
+	case generated.XMinYMin, generated.XMaxYMin, generated.XMinYMax, generated.XMaxYMax:
+	default:
+		return errors.New("start_corner must be one of x_min_y_min, x_max_y_min, x_min_y_max or x_max_y_max")
+	}
+	if t.pattern == generated.Spiral && t.shape != nil {
+		return errSpiralShape
+	}
+	return nil
+}
+
+// errSpiralShape is returned for a spiral over an estate that is not a rectangle, it has no rings to go around.
+var errSpiralShape = errors.New("traversal_pattern spiral cannot be used on an estate that is not a rectangle")
+
+func (t traversal) plotCount() int {
+	if t.passes != nil {
+		return t.passes.upTo[len(t.passes.upTo)-1]
+	}
+	return t.length * t.width
+}
+
+// mirror maps a plot to the coordinates where the start corner is plot (1,1), it is its own inverse.
+func (t traversal) mirror(x, y int) (int, int) {
+	if t.corner == generated.XMaxYMin || t.corner == generated.XMaxYMax {
+		x = t.length - x + 1
+	}
+	if t.corner == generated.XMinYMax || t.corner == generated.XMaxYMax {
+		y = t.width - y + 1
+	}
+	return x, y
+}
+
+// order returns the order number of plot (x,y), 0 when it is outside the shape of the estate.
+func (t traversal) order(x, y int) int {
+	x, y = t.mirror(x, y)
+	switch {
+	case t.passes != nil && t.pattern == generated.ColumnSerpentine:
+		return t.passes.order(y, x)
+	case t.passes != nil:
+		return t.passes.order(x, y)
+	case t.pattern == generated.ColumnSerpentine:
+		return serpentineOrder(y, x, t.width)
+	case t.pattern == generated.Spiral:
+		return t.spiralOrder(x, y)
+	default:
+		return serpentineOrder(x, y, t.length)
+	}
+}
+
+// plot returns the plot at an order number.
+func (t traversal) plot(order int) (int, int) {
+	var x, y int
+	switch {
+	case t.passes != nil && t.pattern == generated.ColumnSerpentine:
+		y, x = t.passes.position(order)
+	case t.passes != nil:
+		x, y = t.passes.position(order)
+	case t.pattern == generated.ColumnSerpentine:
+		y, x = serpentinePosition(order, t.width)
+	case t.pattern == generated.Spiral:
+		x, y = t.spiralPlot(order)
+	default:
+		x, y = serpentinePosition(order, t.length)
+	}
+	return t.mirror(x, y)
+}
+
+// legEnd returns the order number of the last plot of the straight leg holding the plot at an order number.
+func (t traversal) legEnd(order int) int {
+	if t.passes != nil {
+		return t.passes.legEnd(order)
+	}
+	switch t.pattern {
+	case generated.ColumnSerpentine:
+		return (order + t.width - 1) / t.width * t.width
+	case generated.Spiral:
+		ring := t.spiralRing(order)
+		base := t.spiralBefore(ring)
+		for _, end := range t.spiralLegEnds(ring) {
+			if order <= base+end {
+				return base + end
+			}
+		}
+		return order
+	default:
+		return (order + t.length - 1) / t.length * t.length
+	}
+}
+
+// breaks returns the order numbers of the plots the traversal does not go on from to a plot next to them, ascending.
+func (t traversal) breaks() []int {
+	if t.passes == nil {
+		return nil
+	}
+	return t.passes.breaks()
+}
+
+// orderPlots sets the order number of every plot from the traversal and sorts them by it.
+func (t traversal) orderPlots(plots []repository.PlotEntity) {
+	for i := range plots {
+		plots[i].OrderNumber = t.order(int(plots[i].X), int(plots[i].Y))
+	}
+	sort.Slice(plots, func(i, j int) bool {
+		return plots[i].OrderNumber < plots[j].OrderNumber
+	})
+}
+
+// serpentineOrder returns the order number of the plot at a position along a pass, passes go forward when odd and back when even.
+func serpentineOrder(along, pass, passLength int) int {
+	if pass%2 == 1 {
+		return (pass-1)*passLength + along
+	}
+	return (pass-1)*passLength + passLength - along + 1
+}
+
+func serpentinePosition(order, passLength int) (int, int) {
+	pass := (order-1)/passLength + 1
+	offset := (order - 1) % passLength
+	if pass%2 == 1 {
+		return offset + 1, pass
+	}
+	return passLength - offset, pass
+}
+
+/*
+shapePasses are the passes of a serpentine over an estate shape, in the coordinates mirrored so the start corner is
+plot (1,1): the spans of plots along every pass and the number of plots in the passes up to each one, upTo[0] being 0.
+*/
+type shapePasses struct {
+	spans [][]plotSpan
+	upTo  []int
+}
+
+// shapePasses returns the passes of the traversal over the shape of its estate, nil for a rectangle or a spiral.
+func (t traversal) shapePasses() *shapePasses {
+	if t.shape == nil || t.pattern == generated.Spiral {
+		return nil
+	}
+
+	lines, alongLength := t.shape.rows, t.length
+	reverseLines := t.corner == generated.XMinYMax || t.corner == generated.XMaxYMax
+	reverseAlong := t.corner == generated.XMaxYMin || t.corner == generated.XMaxYMax
+	if t.pattern == generated.ColumnSerpentine {
+		lines, alongLength = t.shape.columns(), t.width
+		reverseLines, reverseAlong = reverseAlong, reverseLines
+	}
+
+	p := &shapePasses{spans: make([][]plotSpan, len(lines)), upTo: make([]int, len(lines)+1)}
+	for i := range lines {
+		line := lines[i]
+		if reverseLines {
+			line = lines[len(lines)-1-i]
+		}
+		spans := line
+		if reverseAlong {
+			spans = make([]plotSpan, len(line))
+			for j, span := range line {
+				spans[len(line)-1-j] = plotSpan{from: alongLength - span.to + 1, to: alongLength - span.from + 1}
+			}
+		}
+		p.spans[i] = spans
+		p.upTo[i+1] = p.upTo[i]
+		for _, span := range spans {
+			p.upTo[i+1] += span.to - span.from + 1
+		}
+	}
+	return p
+}
+
+// order returns the order number of the plot at a position along a pass, 0 when it is outside the shape.
+func (p *shapePasses) order(along, pass int) int {
+	if pass < 1 || pass >= len(p.upTo) {
+		return 0
+	}
+	before := 0
+	for _, span := range p.spans[pass-1] {
+		if along < span.from {
+			return 0
+		}
+		if along <= span.to {
+			return p.upTo[pass-1] + p.turned(pass, before+along-span.from+1)
+		}
+		before += span.to - span.from + 1
+	}
+	return 0
+}
+
+// turned maps the rank of a plot along a pass to its rank in the traversal and back, passes going back when even.
+func (p *shapePasses) turned(pass, rank int) int {
+	if pass%2 == 1 {
+		return rank
+	}
+	return p.upTo[pass] - p.upTo[pass-1] - rank + 1
+}
+
+// position returns the position along a pass and the pass of the plot at an order number.
+func (p *shapePasses) position(order int) (int, int) {
+	pass := sort.SearchInts(p.upTo, order)
+	rank := p.turned(pass, order-p.upTo[pass-1])
+	for _, span := range p.spans[pass-1] {
+		if rank <= span.to-span.from+1 {
+			return span.from + rank - 1, pass
+		}
+		rank -= span.to - span.from + 1
+	}
+	return 0, pass
+}
+
+// legEnd returns the order number of the last plot of the span holding the plot at an order number.
+func (p *shapePasses) legEnd(order int) int {
+	along, pass := p.position(order)
+	for _, span := range p.spans[pass-1] {
+		if along <= span.to {
+			if pass%2 == 1 {
+				return p.order(span.to, pass)
+			}
+			return p.order(span.from, pass)
+		}
+	}
+	return order
+}
+
+/*
+breaks returns the order numbers of the plots the serpentine does not go on from to a plot next to them: the end
+of a span followed by another one on the same pass, and the end of a pass when the next plot is not right beyond.
+*/
+func (p *shapePasses) breaks() []int {
+	var breaks []int
+	lastAlong, lastPass := 0, 0
+	for pass := 1; pass < len(p.upTo); pass++ {
+		spans := p.spans[pass-1]
+		if len(spans) == 0 {
+			continue
+		}
+		first, last := spans[0].from, spans[len(spans)-1].to
+		if pass%2 == 0 {
+			first, last = last, first
+		}
+		if lastPass > 0 && (pass != lastPass+1 || first != lastAlong) {
+			breaks = append(breaks, p.upTo[lastPass])
+		}
+
+		if pass%2 == 1 {
+			for i := 1; i < len(spans); i++ {
+				breaks = append(breaks, p.order(spans[i-1].to, pass))
+			}
+		} else {
+			for i := len(spans) - 1; i > 0; i-- {
+				breaks = append(breaks, p.order(spans[i].from, pass))
+			}
+		}
+		lastAlong, lastPass = last, pass
+	}
+	return breaks
+}
+
+/*
+The spiral goes around ring 0, the border of the estate, then ring 1 inside it and so on. Ring k starts at plot
+(k+1,k+1) and has 4 legs: along x to the far side, along y to the far side, back along x and back along y, a ring
+one plot wide or long is a single leg.
+*/
+
+// spiralBefore returns the number of plots in the rings outside ring k.
+func (t traversal) spiralBefore(k int) int {
+	return t.plotCount() - (t.length-2*k)*(t.width-2*k)
+}
+
+// spiralRing returns the ring of the plot at an order number.
+func (t traversal) spiralRing(order int) int {
+	// the last ring with fewer plots outside it than the order number
+	return sort.Search((min(t.length, t.width)+1)/2, func(k int) bool {
+		return t.spiralBefore(k) >= order
+	}) - 1
+}
+
+// spiralLegEnds returns the position in ring k of the last plot of each of its legs.
+func (t traversal) spiralLegEnds(k int) []int {
+	l := t.length - 2*k
+	w := t.width - 2*k
+	if w == 1 {
+		return []int{l}
+	}
+	if l == 1 {
+		return []int{w}
+	}
+	return []int{l, l + w - 1, 2*l + w - 2, 2*l + 2*w - 4}
+}
+
+func (t traversal) spiralOrder(x, y int) int {
+	k := min(x-1, y-1, t.length-x, t.width-y)
+	base := t.spiralBefore(k)
+	l := t.length - 2*k
+	w := t.width - 2*k
+
+	switch {
+	case l == 1:
+		return base + y - k
+	case y == k+1:
+		return base + x - k
+	case x == t.length-k:
+		return base + l + y - k - 1
+	case y == t.width-k:
+		return base + l + w - 1 + t.length - k - x
+	default:
+		return base + 2*l + w - 2 + t.width - k - y
+	}
+}
+
+func (t traversal) spiralPlot(order int) (int, int) {
+	k := t.spiralRing(order)
+	position := order - t.spiralBefore(k)
+	l := t.length - 2*k
+	w := t.width - 2*k
+
+	switch {
+	case l == 1:
+		return k + 1, k + position
+	case position <= l:
+		return k + position, k + 1
+	case position <= l+w-1:
+		return t.length - k, k + position - l + 1
+	case position <= 2*l+w-2:
+		return t.length - k - (position - l - w + 1), t.width - k
+	default:
+		return k + 1, t.width - k - (position - 2*l - w + 2)
+	}
+}
@@ -0,0 +1,83 @@
+package service
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+func TestTraversal(t *testing.T) {
+	patterns := []generated.TraversalPattern{generated.RowSerpentine, generated.ColumnSerpentine, generated.Spiral}
+	corners := []generated.TraversalCorner{generated.XMinYMin, generated.XMaxYMin, generated.XMinYMax, generated.XMaxYMax}
+	sizes := [][2]int{{1, 1}, {5, 1}, {1, 4}, {2, 2}, {4, 3}, {3, 6}, {7, 7}}
+
+	// every plot is visited once, the drone moves to a neighbour plot at every step and only turns at a leg end
+	for _, pattern := range patterns {
+		for _, corner := range corners {
+			for _, size := range sizes {
+				tr := traversal{pattern: pattern, corner: corner, length: size[0], width: size[1]}
+				t.Run(fmt.Sprintf("%s %s %dx%d", pattern, corner, size[0], size[1]), func(t *testing.T) {
+					// the traversal starts at the start corner
+					x, y := tr.mirror(tr.plot(1))
+					require.Equal(t, 1, x)
+					require.Equal(t, 1, y)
+
+					var previous [2]int
+					for order := 1; order <= tr.plotCount(); order++ {
+						x, y := tr.plot(order)
+						require.True(t, x >= 1 && x <= tr.length && y >= 1 && y <= tr.width, "plot (%d,%d) of order %d", x, y, order)
+						require.Equal(t, order, tr.order(x, y))
+
+						if order > 1 {
+							require.Equal(t, 1, abs(x-previous[0])+abs(y-previous[1]), "step to order %d", order)
+						}
+						end := tr.legEnd(order)
+						require.True(t, end >= order && end <= tr.plotCount())
+						ex, ey := tr.plot(end)
+						require.True(t, ex == x || ey == y, "leg from order %d to %d is not straight", order, end)
+						previous = [2]int{x, y}
+					}
+				})
+			}
+		}
+	}
+}
+
+func TestTraversal_Order(t *testing.T) {
+	tests := []struct {
+		name      string
+		traversal traversal
+		expected  [][]int
+	}{
+		{
+			name:      "Row Serpentine",
+			traversal: newTraversal(repository.EstateEntity{Length: 3, Width: 2}),
+			expected:  [][]int{{1, 2, 3}, {6, 5, 4}},
+		},
+		{
+			name:      "Column Serpentine From The Far Corner",
+			traversal: traversal{pattern: generated.ColumnSerpentine, corner: generated.XMaxYMax, length: 3, width: 2},
+			expected:  [][]int{{6, 3, 2}, {5, 4, 1}},
+		},
+		{
+			name:      "Spiral",
+			traversal: traversal{pattern: generated.Spiral, corner: generated.XMinYMin, length: 4, width: 3},
+			expected:  [][]int{{1, 2, 3, 4}, {10, 11, 12, 5}, {9, 8, 7, 6}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for y, row := range tt.expected {
+				for x, order := range row {
+					assert.Equal(t, order, tt.traversal.order(x+1, y+1), "plot (%d,%d)", x+1, y+1)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxInspectionNotes and maxInspectionImageRef bound the length of the notes and the image reference of an inspection.
+const (
+	maxInspectionNotes    = 2000
+	maxInspectionImageRef = 500
+)
+
+// healthStatuses are the health statuses a tree is found in by an inspection, from healthy to dead.
+var healthStatuses = []generated.HealthStatus{generated.Healthy, generated.Stressed, generated.Diseased, generated.Dead}
+
+// checkHealthStatus validates a health status against healthStatuses, field names it in the error.
+func checkHealthStatus(field string, status generated.HealthStatus) error {
+	for _, known := range healthStatuses {
+		if status == known {
+			return nil
+		}
+	}
+	return fmt.Errorf("%s must be one of %s, %s, %s, %s", field, generated.Healthy, generated.Stressed, generated.Diseased, generated.Dead)
+}
+
+// deadTree reports whether the latest inspection of a tree found it dead.
+func deadTree(plot repository.PlotEntity) bool {
+	return plot.HealthStatus != nil && *plot.HealthStatus == string(generated.Dead)
+}
+
+// flownTrees returns the trees the drone flies over, the dead trees are left out when the estate excludes them.
+func flownTrees(estate repository.EstateEntity, plots []repository.PlotEntity) []repository.PlotEntity {
+	if !estate.ExcludeDeadTrees {
+		return plots
+	}
+
+	flown := make([]repository.PlotEntity, 0, len(plots))
+	for _, plot := range plots {
+		if !deadTree(plot) {
+			flown = append(flown, plot)
+		}
+	}
+	return flown
+}
+
+/*
+updateTreeHealth replaces the denormalized health status of a tree. When its estate leaves the dead trees out of the
+flight model and the tree dies or comes back, the drone distances of the estate are recomputed.
+*/
+func (s *Service) updateTreeHealth(ctx context.Context, plot *repository.PlotEntity, status generated.HealthStatus) error {
+	if plot.HealthStatus != nil && *plot.HealthStatus == string(status) {
+		return nil
+	}
+
+	wasDead := deadTree(*plot)
+	health := string(status)
+	plot.HealthStatus = &health
+	if _, err := s.Repository.SavePlot(ctx, *plot); err != nil {
+		return err
+	}
+	if deadTree(*plot) == wasDead {
+		return nil
+	}
+
+	estate, err := s.Repository.GetEstate(ctx, plot.EstateId)
+	if err != nil {
+		return err
+	}
+	if !estate.ExcludeDeadTrees {
+		return nil
+	}
+	if err = s.recomputeFlightDistances(ctx, &estate); err != nil {
+		return err
+	}
+	_, err = s.Repository.SaveEstate(ctx, estate)
+	return err
+}
+
+// healthStats returns the stats per health status of the trees selected by the filter.
+func (s *Service) healthStats(ctx context.Context, estateID uuid.UUID, filter repository.TreeHeightFilter) (*[]generated.HealthStats, error) {
+	stats, err := s.Repository.GetTreeHeightStatsByHealth(ctx, estateID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	health := make([]generated.HealthStats, len(stats))
+	for i := range stats {
+		median := int(stats[i].Median)
+		health[i] = generated.HealthStats{
+			HealthStatus: (*generated.HealthStatus)(stats[i].HealthStatus),
+			Count:        &stats[i].Count,
+			Max:          &stats[i].Max,
+			Min:          &stats[i].Min,
+			Median:       &median,
+		}
+	}
+	return &health, nil
+}
@@ -0,0 +1,22 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"spgo/repository"
+)
+
+func TestFlownTrees(t *testing.T) {
+	dead, stressed := "dead", "stressed"
+	plots := []repository.PlotEntity{
+		{OrderNumber: 1, HealthStatus: &dead},
+		{OrderNumber: 2, HealthStatus: &stressed},
+		{OrderNumber: 3},
+	}
+
+	assert.Equal(t, plots, flownTrees(repository.EstateEntity{}, plots))
+	// the dead trees are left out, the trees never inspected are flown over
+	assert.Equal(t, plots[1:], flownTrees(repository.EstateEntity{ExcludeDeadTrees: true}, plots))
+}
@@ -7,3 +7,10 @@ type GetTestByIdInput struct {
 type GetTestByIdOutput struct {
 	Name string
 }
+
+// MissionFile is an exported mission of one sortie of the drone plan.
+type MissionFile struct {
+	Content     []byte
+	Sortie      int
+	SortieCount int
+}
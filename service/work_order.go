@@ -0,0 +1,221 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxWorkOrderPlots bounds the plots targeted by a work order, maxWorkOrderAssignee and maxWorkOrderNotes the length
+// of its assignee and its notes.
+const (
+	maxWorkOrderPlots    = 10000
+	maxWorkOrderAssignee = 100
+	maxWorkOrderNotes    = 2000
+)
+
+// work order shapes as stored, like the obstacles a rectangle is a single area and a list of plots an area per plot.
+// A tree is the area of its plot.
+const (
+	workOrderShapeTree      = "tree"
+	workOrderShapeRectangle = "rectangle"
+	workOrderShapePlots     = "plots"
+)
+
+var (
+	workOrderKinds    = []generated.WorkOrderKind{generated.Prune, generated.Spray, generated.Replant, generated.Remove}
+	workOrderStatuses = []generated.WorkOrderStatus{generated.Open, generated.Assigned, generated.InProgress, generated.Completed, generated.Cancelled}
+)
+
+// openWorkOrderStatuses are the statuses of the work orders still to be done, the ones listed by default.
+var openWorkOrderStatuses = []string{string(generated.Open), string(generated.Assigned), string(generated.InProgress)}
+
+// workOrderTransitions are the statuses a work order may move to from its status, completed and cancelled are final.
+// An assigned order moves to assigned again when it is reassigned.
+var workOrderTransitions = map[generated.WorkOrderStatus][]generated.WorkOrderStatus{
+	generated.Open:       {generated.Assigned, generated.Cancelled},
+	generated.Assigned:   {generated.Assigned, generated.Open, generated.InProgress, generated.Cancelled},
+	generated.InProgress: {generated.Completed, generated.Cancelled},
+}
+
+func checkWorkOrderKind(kind generated.WorkOrderKind) error {
+	if !slices.Contains(workOrderKinds, kind) {
+		return fmt.Errorf("kind must be one of %s, %s, %s, %s", generated.Prune, generated.Spray, generated.Replant, generated.Remove)
+	}
+	return nil
+}
+
+// checkWorkOrderStatus validates a work order status, field names it in the error.
+func checkWorkOrderStatus(field string, status generated.WorkOrderStatus) error {
+	if !slices.Contains(workOrderStatuses, status) {
+		return fmt.Errorf("%s must be one of %s, %s, %s, %s, %s", field, generated.Open, generated.Assigned, generated.InProgress, generated.Completed, generated.Cancelled)
+	}
+	return nil
+}
+
+func checkWorkOrderAssignee(assignee string) error {
+	if assignee == "" {
+		return errors.New("assignee cannot be empty")
+	}
+	if len(assignee) > maxWorkOrderAssignee {
+		return fmt.Errorf("assignee cannot be longer than %d characters", maxWorkOrderAssignee)
+	}
+	return nil
+}
+
+// workOrderAreas returns the areas of the plots targeted by a work order given as a rectangle or a list of plots.
+func workOrderAreas(req generated.WorkOrderRequest, estate repository.EstateEntity) ([]repository.WorkOrderAreaEntity, string, error) {
+	// the work orders stay within the shape of the estate, where the trees stand
+	shape := estateShapeOf(estate)
+	if req.Rectangle != nil {
+		r := *req.Rectangle
+		if r.MinX > r.MaxX || r.MinY > r.MaxY {
+			return nil, "", errors.New("min_x and min_y of the rectangle cannot be greater than max_x and max_y")
+		}
+		region := repository.PlotRegion{MinX: r.MinX, MinY: r.MinY, MaxX: r.MaxX, MaxY: r.MaxY}
+		if r.MinX < 1 || r.MinY < 1 || r.MaxX > estate.Length || r.MaxY > estate.Width || shape != nil && shape.countIn(region) != plotCount(region) {
+			return nil, "", errors.New("rectangle is out of range")
+		}
+		if plotCount(region) > maxWorkOrderPlots {
+			return nil, "", fmt.Errorf("a work order cannot target more than %d plots", maxWorkOrderPlots)
+		}
+		return []repository.WorkOrderAreaEntity{{MinX: uint16(r.MinX), MinY: uint16(r.MinY), MaxX: uint16(r.MaxX), MaxY: uint16(r.MaxY)}}, workOrderShapeRectangle, nil
+	}
+
+	plots := *req.Plots
+	if len(plots) == 0 {
+		return nil, "", errors.New("plots of a work order cannot be empty")
+	}
+	if len(plots) > maxWorkOrderPlots {
+		return nil, "", fmt.Errorf("a work order cannot target more than %d plots", maxWorkOrderPlots)
+	}
+	areas := make([]repository.WorkOrderAreaEntity, 0, len(plots))
+	seen := make(map[[2]int]bool, len(plots))
+	for _, plot := range plots {
+		if plot.X == nil || plot.Y == nil {
+			return nil, "", errors.New("every plot of a work order must have x and y")
+		}
+		x, y := *plot.X, *plot.Y
+		if !inEstate(estate, x, y) {
+			return nil, "", fmt.Errorf("plot (%d,%d) is out of range", x, y)
+		}
+		if seen[[2]int{x, y}] {
+			continue
+		}
+		seen[[2]int{x, y}] = true
+		areas = append(areas, repository.WorkOrderAreaEntity{MinX: uint16(x), MinY: uint16(y), MaxX: uint16(x), MaxY: uint16(y)})
+	}
+	return areas, workOrderShapePlots, nil
+}
+
+// workOrderTrees returns the trees targeted by a work order among the trees of its estate, a tree order only targets
+// its own tree and not another one planted on its plot since.
+func workOrderTrees(order repository.WorkOrderEntity, plots []repository.PlotEntity) []repository.PlotEntity {
+	var trees []repository.PlotEntity
+	for _, plot := range plots {
+		if order.Shape == workOrderShapeTree {
+			if order.TreeId != nil && plot.ID == *order.TreeId {
+				trees = append(trees, plot)
+			}
+			continue
+		}
+		for _, area := range order.Areas {
+			if plot.X >= area.MinX && plot.X <= area.MaxX && plot.Y >= area.MinY && plot.Y <= area.MaxY {
+				trees = append(trees, plot)
+				break
+			}
+		}
+	}
+	return trees
+}
+
+/*
+applyWorkOrder applies the tree mutation of a completed remove or replant order: the trees standing on its plots are
+removed, and replaced by saplings of the height and species of a replant order on the same plots. The distances and
+the tree stats of the estate are refreshed. It returns the trees removed and the saplings planted.
+*/
+func (s *Service) applyWorkOrder(ctx context.Context, order repository.WorkOrderEntity) ([]uuid.UUID, []uuid.UUID, error) {
+	estate, err := s.Repository.GetEstate(ctx, order.EstateId)
+	if err != nil {
+		return nil, nil, err
+	}
+	plots, err := s.Repository.GetPlots(ctx, estate.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	trees := workOrderTrees(order, plots)
+	if len(trees) == 0 {
+		return nil, nil, nil
+	}
+	removed := make([]uuid.UUID, len(trees))
+	for i := range trees {
+		removed[i] = trees[i].ID
+	}
+	if err = s.Repository.DeletePlots(ctx, estate.ID, removed, *order.CompletedAt); err != nil {
+		return nil, nil, err
+	}
+	estate.TreeCount -= len(trees)
+
+	if generated.WorkOrderKind(order.Kind) == generated.Remove {
+		// the drone no longer climbs over the removed trees
+		if err = s.recomputeFlightDistances(ctx, &estate); err != nil {
+			return nil, nil, err
+		}
+		return removed, nil, s.saveTreeHeightStats(ctx, estate)
+	}
+
+	saplings := make([]repository.PlotEntity, len(trees))
+	for i := range trees {
+		saplings[i] = repository.PlotEntity{
+			EstateId:    estate.ID,
+			X:           trees[i].X,
+			Y:           trees[i].Y,
+			OrderNumber: trees[i].OrderNumber,
+			TreeHeight:  *order.Height,
+			SpeciesId:   order.SpeciesId,
+		}
+	}
+	planted, err := s.plantSaplings(ctx, &estate, saplings)
+	if err != nil {
+		return nil, nil, err
+	}
+	return removed, planted, nil
+}
+
+func workOrderResponse(order repository.WorkOrderEntity) generated.WorkOrder {
+	kind := generated.WorkOrderKind(order.Kind)
+	status := generated.WorkOrderStatus(order.Status)
+	resp := generated.WorkOrder{
+		Id:          &order.ID,
+		Kind:        &kind,
+		Status:      &status,
+		TreeId:      order.TreeId,
+		Assignee:    order.Assignee,
+		Notes:       order.Notes,
+		Height:      order.Height,
+		SpeciesId:   order.SpeciesId,
+		CreatedAt:   &order.CreatedAt,
+		UpdatedAt:   &order.UpdatedAt,
+		CompletedAt: order.CompletedAt,
+	}
+	if order.Shape == workOrderShapeRectangle && len(order.Areas) == 1 {
+		area := order.Areas[0]
+		resp.Rectangle = &generated.PlotRectangle{MinX: int(area.MinX), MinY: int(area.MinY), MaxX: int(area.MaxX), MaxY: int(area.MaxY)}
+		return resp
+	}
+
+	plots := make([]generated.PlotPosition, len(order.Areas))
+	for i, area := range order.Areas {
+		x, y := int(area.MinX), int(area.MinY)
+		plots[i] = generated.PlotPosition{X: &x, Y: &y}
+	}
+	resp.Plots = &plots
+	return resp
+}
@@ -0,0 +1,162 @@
+package service
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+
+	"spgo/generated"
+	"spgo/repository"
+)
+
+// maxHarvestQuantity and maxHarvestGrade bound the kilograms and the grade length of a harvest.
+const (
+	maxHarvestQuantity = 10000
+	maxHarvestGrade    = 20
+)
+
+// yieldGroupings and yieldPeriods are the groups and the periods the harvests may be aggregated in.
+var (
+	yieldGroupings = []generated.YieldGrouping{generated.YieldGroupingEstate, generated.YieldGroupingTree, generated.YieldGroupingRow, generated.YieldGroupingSpecies}
+	yieldPeriods   = []generated.YieldPeriod{generated.YieldPeriodDay, generated.YieldPeriodWeek, generated.YieldPeriodMonth, generated.YieldPeriodYear}
+)
+
+// yieldFilter validates the yield query parameters, the harvests of the whole estate are aggregated when groupBy is nil.
+func yieldFilter(groupBy *generated.YieldGrouping, period *generated.YieldPeriod, from, to *time.Time, grade *string) (repository.YieldFilter, error) {
+	filter := repository.YieldFilter{GroupBy: string(generated.YieldGroupingEstate), From: from, To: to, Grade: grade}
+
+	if groupBy != nil {
+		if !slices.Contains(yieldGroupings, *groupBy) {
+			return repository.YieldFilter{}, fmt.Errorf("group_by must be one of %s, %s, %s, %s", generated.YieldGroupingEstate, generated.YieldGroupingTree, generated.YieldGroupingRow, generated.YieldGroupingSpecies)
+		}
+		filter.GroupBy = string(*groupBy)
+	}
+	if period != nil {
+		if !slices.Contains(yieldPeriods, *period) {
+			return repository.YieldFilter{}, fmt.Errorf("period must be one of %s, %s, %s, %s", generated.YieldPeriodDay, generated.YieldPeriodWeek, generated.YieldPeriodMonth, generated.YieldPeriodYear)
+		}
+		filter.Period = (*string)(period)
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return repository.YieldFilter{}, errors.New("from must be before to")
+	}
+	if grade != nil && len(*grade) > maxHarvestGrade {
+		return repository.YieldFilter{}, fmt.Errorf("grade cannot be longer than %d characters", maxHarvestGrade)
+	}
+
+	return filter, nil
+}
+
+// yieldGroup converts the aggregated yield of a group, only the fields of the grouping of the filter are set.
+func yieldGroup(filter repository.YieldFilter, stats repository.YieldStats) generated.YieldGroup {
+	group := generated.YieldGroup{
+		Period:   stats.Period,
+		Harvests: &stats.Harvests,
+		Trees:    &stats.Trees,
+		Quantity: &stats.Quantity,
+	}
+
+	switch generated.YieldGrouping(filter.GroupBy) {
+	case generated.YieldGroupingTree:
+		group.TreeId, group.X, group.Y = stats.TreeId, stats.X, stats.Y
+	case generated.YieldGroupingRow:
+		group.Row = stats.Y
+	case generated.YieldGroupingSpecies:
+		group.SpeciesId, group.SpeciesName = stats.SpeciesId, stats.Name
+	}
+
+	// the harvests of the removed trees have neither a tree nor a height to divide by
+	if stats.Trees > 0 {
+		perTree := stats.StandingQuantity / float64(stats.Trees)
+		group.QuantityPerTree = &perTree
+	}
+	if stats.TreeHeight > 0 {
+		perMeter := stats.StandingQuantity / float64(stats.TreeHeight)
+		group.YieldPerMeter = &perMeter
+	}
+
+	return group
+}
+
+// yieldCsv writes the groups of a yield report as CSV, the columns of the grouping and the period first.
+func yieldCsv(filter repository.YieldFilter, groups []generated.YieldGroup) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	var header []string
+	switch generated.YieldGrouping(filter.GroupBy) {
+	case generated.YieldGroupingTree:
+		header = append(header, "tree_id", "x", "y")
+	case generated.YieldGroupingRow:
+		header = append(header, "row")
+	case generated.YieldGroupingSpecies:
+		header = append(header, "species_id", "species_name")
+	}
+	if filter.Period != nil {
+		header = append(header, "period")
+	}
+	header = append(header, "harvests", "trees", "quantity", "quantity_per_tree", "yield_per_meter")
+	if err := w.Write(header); err != nil {
+		return nil, err
+	}
+
+	for _, group := range groups {
+		var record []string
+		switch generated.YieldGrouping(filter.GroupBy) {
+		case generated.YieldGroupingTree:
+			record = append(record, csvUUID(group.TreeId), csvInt(group.X), csvInt(group.Y))
+		case generated.YieldGroupingRow:
+			record = append(record, csvInt(group.Row))
+		case generated.YieldGroupingSpecies:
+			record = append(record, csvUUID(group.SpeciesId), csvString(group.SpeciesName))
+		}
+		if filter.Period != nil {
+			record = append(record, group.Period.UTC().Format(time.RFC3339))
+		}
+		record = append(record, csvInt(group.Harvests), csvInt(group.Trees), csvFloat(group.Quantity), csvFloat(group.QuantityPerTree), csvFloat(group.YieldPerMeter))
+		if err := w.Write(record); err != nil {
+			return nil, err
+		}
+	}
+
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// csvInt, csvFloat, csvString and csvUUID write an optional value as a CSV field, empty when it is nil.
+func csvInt(v *int) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.Itoa(*v)
+}
+
+func csvFloat(v *float64) string {
+	if v == nil {
+		return ""
+	}
+	return strconv.FormatFloat(*v, 'f', -1, 64)
+}
+
+func csvString(v *string) string {
+	if v == nil {
+		return ""
+	}
+	return *v
+}
+
+func csvUUID(v *uuid.UUID) string {
+	if v == nil {
+		return ""
+	}
+	return v.String()
+}
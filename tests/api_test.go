@@ -95,7 +95,9 @@ func getTestCases() []TestCase {
 			[]any{CreateTree, 20, 3, 1},
 			[]any{CreateTree, 10, 4, 1},
 			[]any{GetStats, 3, 10, 20, 10},
-			[]any{GetDronePlan, 0, 120}, // i change it to 120, previously 82. i dont know, is this intended to be changed or not, but after i calculate manually it should not 82
+			// 50 over the 5 plots, climbs 11, 10, 10 and descends 11 with the default flight settings. it was 120 while
+			// the total distance was adjusted tree by tree, which counted the climbs depending on the planting order.
+			[]any{GetDronePlan, 0, 92},
 		}),
 	}
 }